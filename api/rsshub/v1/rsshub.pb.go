@@ -0,0 +1,1897 @@
+// api/rsshub/v1/rsshub.proto
+//
+// gRPC API rsshub (rsshub serve, CLI_APP_GRPC_ADDR). Чтение доступно без ключа, изменяющие
+// вызовы требуют ключ API в метаданных "authorization: Bearer <ключ>" или "x-api-key", как и
+// REST API. Код Go генерируется командой buf generate (см. buf.gen.yaml)
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: rsshub/v1/rsshub.proto
+
+package rsshubv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Priority приоритет ленты
+type Priority int32
+
+const (
+	Priority_PRIORITY_UNSPECIFIED Priority = 0 // В CreateFeedRequest - normal
+	Priority_PRIORITY_LOW         Priority = 1
+	Priority_PRIORITY_NORMAL      Priority = 2
+	Priority_PRIORITY_HIGH        Priority = 3
+)
+
+// Enum value maps for Priority.
+var (
+	Priority_name = map[int32]string{
+		0: "PRIORITY_UNSPECIFIED",
+		1: "PRIORITY_LOW",
+		2: "PRIORITY_NORMAL",
+		3: "PRIORITY_HIGH",
+	}
+	Priority_value = map[string]int32{
+		"PRIORITY_UNSPECIFIED": 0,
+		"PRIORITY_LOW":         1,
+		"PRIORITY_NORMAL":      2,
+		"PRIORITY_HIGH":        3,
+	}
+)
+
+func (x Priority) Enum() *Priority {
+	p := new(Priority)
+	*p = x
+	return p
+}
+
+func (x Priority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Priority) Descriptor() protoreflect.EnumDescriptor {
+	return file_rsshub_v1_rsshub_proto_enumTypes[0].Descriptor()
+}
+
+func (Priority) Type() protoreflect.EnumType {
+	return &file_rsshub_v1_rsshub_proto_enumTypes[0]
+}
+
+func (x Priority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Priority.Descriptor instead.
+func (Priority) EnumDescriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{0}
+}
+
+// Mark изменение состояния статьи
+type Mark int32
+
+const (
+	Mark_MARK_UNSPECIFIED Mark = 0
+	Mark_MARK_READ        Mark = 1
+	Mark_MARK_UNREAD      Mark = 2
+	Mark_MARK_SAVED       Mark = 3
+	Mark_MARK_UNSAVED     Mark = 4
+)
+
+// Enum value maps for Mark.
+var (
+	Mark_name = map[int32]string{
+		0: "MARK_UNSPECIFIED",
+		1: "MARK_READ",
+		2: "MARK_UNREAD",
+		3: "MARK_SAVED",
+		4: "MARK_UNSAVED",
+	}
+	Mark_value = map[string]int32{
+		"MARK_UNSPECIFIED": 0,
+		"MARK_READ":        1,
+		"MARK_UNREAD":      2,
+		"MARK_SAVED":       3,
+		"MARK_UNSAVED":     4,
+	}
+)
+
+func (x Mark) Enum() *Mark {
+	p := new(Mark)
+	*p = x
+	return p
+}
+
+func (x Mark) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Mark) Descriptor() protoreflect.EnumDescriptor {
+	return file_rsshub_v1_rsshub_proto_enumTypes[1].Descriptor()
+}
+
+func (Mark) Type() protoreflect.EnumType {
+	return &file_rsshub_v1_rsshub_proto_enumTypes[1]
+}
+
+func (x Mark) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Mark.Descriptor instead.
+func (Mark) EnumDescriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{1}
+}
+
+// Feed лента. Заголовки, прокси и учетные данные не отдаются
+type Feed struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Url            string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Priority       Priority               `protobuf:"varint,4,opt,name=priority,proto3,enum=rsshub.v1.Priority" json:"priority,omitempty"`
+	Tags           []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	Folder         string                 `protobuf:"bytes,6,opt,name=folder,proto3" json:"folder,omitempty"` // Путь папки ленты, например "News/Tech"
+	Enabled        bool                   `protobuf:"varint,7,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	DisabledReason string                 `protobuf:"bytes,8,opt,name=disabled_reason,json=disabledReason,proto3" json:"disabled_reason,omitempty"`
+	Articles       int64                  `protobuf:"varint,9,opt,name=articles,proto3" json:"articles,omitempty"` // Всего статей (только в ListFeeds)
+	Unread         int64                  `protobuf:"varint,10,opt,name=unread,proto3" json:"unread,omitempty"`    // Непрочитанных статей (только в ListFeeds)
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Feed) Reset() {
+	*x = Feed{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed) ProtoMessage() {}
+
+func (x *Feed) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed.ProtoReflect.Descriptor instead.
+func (*Feed) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Feed) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Feed) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Feed) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Feed) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_UNSPECIFIED
+}
+
+func (x *Feed) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Feed) GetFolder() string {
+	if x != nil {
+		return x.Folder
+	}
+	return ""
+}
+
+func (x *Feed) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Feed) GetDisabledReason() string {
+	if x != nil {
+		return x.DisabledReason
+	}
+	return ""
+}
+
+func (x *Feed) GetArticles() int64 {
+	if x != nil {
+		return x.Articles
+	}
+	return 0
+}
+
+func (x *Feed) GetUnread() int64 {
+	if x != nil {
+		return x.Unread
+	}
+	return 0
+}
+
+func (x *Feed) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Feed) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListFeedsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeedsRequest) Reset() {
+	*x = ListFeedsRequest{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeedsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeedsRequest) ProtoMessage() {}
+
+func (x *ListFeedsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeedsRequest.ProtoReflect.Descriptor instead.
+func (*ListFeedsRequest) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{1}
+}
+
+type ListFeedsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Feeds         []*Feed                `protobuf:"bytes,1,rep,name=feeds,proto3" json:"feeds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeedsResponse) Reset() {
+	*x = ListFeedsResponse{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeedsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeedsResponse) ProtoMessage() {}
+
+func (x *ListFeedsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeedsResponse.ProtoReflect.Descriptor instead.
+func (*ListFeedsResponse) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListFeedsResponse) GetFeeds() []*Feed {
+	if x != nil {
+		return x.Feeds
+	}
+	return nil
+}
+
+type GetFeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFeedRequest) Reset() {
+	*x = GetFeedRequest{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeedRequest) ProtoMessage() {}
+
+func (x *GetFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeedRequest.ProtoReflect.Descriptor instead.
+func (*GetFeedRequest) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetFeedRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateFeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	Priority      Priority               `protobuf:"varint,4,opt,name=priority,proto3,enum=rsshub.v1.Priority" json:"priority,omitempty"`
+	Force         bool                   `protobuf:"varint,5,opt,name=force,proto3" json:"force,omitempty"` // Добавить ленту, даже если ее URL уже получается под другим именем
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFeedRequest) Reset() {
+	*x = CreateFeedRequest{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFeedRequest) ProtoMessage() {}
+
+func (x *CreateFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFeedRequest.ProtoReflect.Descriptor instead.
+func (*CreateFeedRequest) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateFeedRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateFeedRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateFeedRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateFeedRequest) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_UNSPECIFIED
+}
+
+func (x *CreateFeedRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type DeleteFeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFeedRequest) Reset() {
+	*x = DeleteFeedRequest{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFeedRequest) ProtoMessage() {}
+
+func (x *DeleteFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFeedRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFeedRequest) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteFeedRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteFeedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFeedResponse) Reset() {
+	*x = DeleteFeedResponse{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFeedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFeedResponse) ProtoMessage() {}
+
+func (x *DeleteFeedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFeedResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFeedResponse) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{6}
+}
+
+// MediaItem вложение Media RSS
+type MediaItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` // content или thumbnail
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`     // MIME тип, например video/mp4
+	Medium        string                 `protobuf:"bytes,4,opt,name=medium,proto3" json:"medium,omitempty"` // image, video, audio, document или executable
+	Width         int32                  `protobuf:"varint,5,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,6,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MediaItem) Reset() {
+	*x = MediaItem{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MediaItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MediaItem) ProtoMessage() {}
+
+func (x *MediaItem) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MediaItem.ProtoReflect.Descriptor instead.
+func (*MediaItem) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MediaItem) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *MediaItem) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *MediaItem) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *MediaItem) GetMedium() string {
+	if x != nil {
+		return x.Medium
+	}
+	return ""
+}
+
+func (x *MediaItem) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *MediaItem) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// Podcast метаданные эпизода подкаста
+type Podcast struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Author        string                 `protobuf:"bytes,1,opt,name=author,proto3" json:"author,omitempty"`
+	Duration      *durationpb.Duration   `protobuf:"bytes,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	Image         string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	Episode       int32                  `protobuf:"varint,4,opt,name=episode,proto3" json:"episode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Podcast) Reset() {
+	*x = Podcast{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Podcast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Podcast) ProtoMessage() {}
+
+func (x *Podcast) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Podcast.ProtoReflect.Descriptor instead.
+func (*Podcast) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Podcast) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Podcast) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *Podcast) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Podcast) GetEpisode() int32 {
+	if x != nil {
+		return x.Episode
+	}
+	return 0
+}
+
+// Article статья
+type Article struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seq           int64                  `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"` // Номер статьи: курсор StreamArticles и номер в MarkArticles
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Feed          string                 `protobuf:"bytes,3,opt,name=feed,proto3" json:"feed,omitempty"`
+	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`     // Теги ленты
+	Folder        string                 `protobuf:"bytes,5,opt,name=folder,proto3" json:"folder,omitempty"` // Папка ленты
+	Title         string                 `protobuf:"bytes,6,opt,name=title,proto3" json:"title,omitempty"`
+	Link          string                 `protobuf:"bytes,7,opt,name=link,proto3" json:"link,omitempty"`
+	PublishedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"`
+	Description   string                 `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
+	Language      string                 `protobuf:"bytes,10,opt,name=language,proto3" json:"language,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ReadAt        *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=read_at,json=readAt,proto3" json:"read_at,omitempty"`    // Не задано - не прочитана
+	SavedAt       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=saved_at,json=savedAt,proto3" json:"saved_at,omitempty"` // Не задано - не в избранном
+	Podcast       *Podcast               `protobuf:"bytes,14,opt,name=podcast,proto3" json:"podcast,omitempty"`
+	Media         []*MediaItem           `protobuf:"bytes,15,rep,name=media,proto3" json:"media,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Article) Reset() {
+	*x = Article{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Article) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Article) ProtoMessage() {}
+
+func (x *Article) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Article.ProtoReflect.Descriptor instead.
+func (*Article) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Article) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *Article) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Article) GetFeed() string {
+	if x != nil {
+		return x.Feed
+	}
+	return ""
+}
+
+func (x *Article) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Article) GetFolder() string {
+	if x != nil {
+		return x.Folder
+	}
+	return ""
+}
+
+func (x *Article) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Article) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *Article) GetPublishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishedAt
+	}
+	return nil
+}
+
+func (x *Article) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Article) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Article) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Article) GetReadAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReadAt
+	}
+	return nil
+}
+
+func (x *Article) GetSavedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SavedAt
+	}
+	return nil
+}
+
+func (x *Article) GetPodcast() *Podcast {
+	if x != nil {
+		return x.Podcast
+	}
+	return nil
+}
+
+func (x *Article) GetMedia() []*MediaItem {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+type ListArticlesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Feed          string                 `protobuf:"bytes,1,opt,name=feed,proto3" json:"feed,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // 0 - 20, не больше 500
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token предыдущей страницы
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArticlesRequest) Reset() {
+	*x = ListArticlesRequest{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArticlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArticlesRequest) ProtoMessage() {}
+
+func (x *ListArticlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArticlesRequest.ProtoReflect.Descriptor instead.
+func (*ListArticlesRequest) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListArticlesRequest) GetFeed() string {
+	if x != nil {
+		return x.Feed
+	}
+	return ""
+}
+
+func (x *ListArticlesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListArticlesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListArticlesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Articles      []*Article             `protobuf:"bytes,1,rep,name=articles,proto3" json:"articles,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Пусто - страница последняя
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArticlesResponse) Reset() {
+	*x = ListArticlesResponse{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArticlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArticlesResponse) ProtoMessage() {}
+
+func (x *ListArticlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArticlesResponse.ProtoReflect.Descriptor instead.
+func (*ListArticlesResponse) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListArticlesResponse) GetArticles() []*Article {
+	if x != nil {
+		return x.Articles
+	}
+	return nil
+}
+
+func (x *ListArticlesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type MarkArticlesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seqs          []int64                `protobuf:"varint,1,rep,packed,name=seqs,proto3" json:"seqs,omitempty"`
+	Mark          Mark                   `protobuf:"varint,2,opt,name=mark,proto3,enum=rsshub.v1.Mark" json:"mark,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkArticlesRequest) Reset() {
+	*x = MarkArticlesRequest{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkArticlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkArticlesRequest) ProtoMessage() {}
+
+func (x *MarkArticlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkArticlesRequest.ProtoReflect.Descriptor instead.
+func (*MarkArticlesRequest) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MarkArticlesRequest) GetSeqs() []int64 {
+	if x != nil {
+		return x.Seqs
+	}
+	return nil
+}
+
+func (x *MarkArticlesRequest) GetMark() Mark {
+	if x != nil {
+		return x.Mark
+	}
+	return Mark_MARK_UNSPECIFIED
+}
+
+type MarkArticlesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkArticlesResponse) Reset() {
+	*x = MarkArticlesResponse{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkArticlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkArticlesResponse) ProtoMessage() {}
+
+func (x *MarkArticlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkArticlesResponse.ProtoReflect.Descriptor instead.
+func (*MarkArticlesResponse) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{13}
+}
+
+type StreamArticlesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Feeds         []string               `protobuf:"bytes,1,rep,name=feeds,proto3" json:"feeds,omitempty"`                        // Только статьи этих лент
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`                          // Только статьи лент хотя бы с одним из этих тегов
+	AfterSeq      int64                  `protobuf:"varint,3,opt,name=after_seq,json=afterSeq,proto3" json:"after_seq,omitempty"` // Сначала - пропущенные статьи с номером больше after_seq (до 1000)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamArticlesRequest) Reset() {
+	*x = StreamArticlesRequest{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamArticlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamArticlesRequest) ProtoMessage() {}
+
+func (x *StreamArticlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamArticlesRequest.ProtoReflect.Descriptor instead.
+func (*StreamArticlesRequest) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StreamArticlesRequest) GetFeeds() []string {
+	if x != nil {
+		return x.Feeds
+	}
+	return nil
+}
+
+func (x *StreamArticlesRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *StreamArticlesRequest) GetAfterSeq() int64 {
+	if x != nil {
+		return x.AfterSeq
+	}
+	return 0
+}
+
+type GetStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{15}
+}
+
+// WorkerActivity чем занят воркер
+type WorkerActivity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Feed          string                 `protobuf:"bytes,2,opt,name=feed,proto3" json:"feed,omitempty"` // Пусто - воркер свободен
+	Since         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
+	Jobs          int32                  `protobuf:"varint,4,opt,name=jobs,proto3" json:"jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerActivity) Reset() {
+	*x = WorkerActivity{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerActivity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerActivity) ProtoMessage() {}
+
+func (x *WorkerActivity) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerActivity.ProtoReflect.Descriptor instead.
+func (*WorkerActivity) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *WorkerActivity) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WorkerActivity) GetFeed() string {
+	if x != nil {
+		return x.Feed
+	}
+	return ""
+}
+
+func (x *WorkerActivity) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *WorkerActivity) GetJobs() int32 {
+	if x != nil {
+		return x.Jobs
+	}
+	return 0
+}
+
+// AggregatorStatus состояние запущенного агрегатора
+type AggregatorStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	Interval      *durationpb.Duration   `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	Workers       int32                  `protobuf:"varint,4,opt,name=workers,proto3" json:"workers,omitempty"`
+	InFlight      int32                  `protobuf:"varint,5,opt,name=in_flight,json=inFlight,proto3" json:"in_flight,omitempty"`
+	Queued        int32                  `protobuf:"varint,6,opt,name=queued,proto3" json:"queued,omitempty"`
+	CycleRunning  bool                   `protobuf:"varint,7,opt,name=cycle_running,json=cycleRunning,proto3" json:"cycle_running,omitempty"`
+	Activity      []*WorkerActivity      `protobuf:"bytes,8,rep,name=activity,proto3" json:"activity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AggregatorStatus) Reset() {
+	*x = AggregatorStatus{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AggregatorStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregatorStatus) ProtoMessage() {}
+
+func (x *AggregatorStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregatorStatus.ProtoReflect.Descriptor instead.
+func (*AggregatorStatus) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AggregatorStatus) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *AggregatorStatus) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *AggregatorStatus) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *AggregatorStatus) GetWorkers() int32 {
+	if x != nil {
+		return x.Workers
+	}
+	return 0
+}
+
+func (x *AggregatorStatus) GetInFlight() int32 {
+	if x != nil {
+		return x.InFlight
+	}
+	return 0
+}
+
+func (x *AggregatorStatus) GetQueued() int32 {
+	if x != nil {
+		return x.Queued
+	}
+	return 0
+}
+
+func (x *AggregatorStatus) GetCycleRunning() bool {
+	if x != nil {
+		return x.CycleRunning
+	}
+	return false
+}
+
+func (x *AggregatorStatus) GetActivity() []*WorkerActivity {
+	if x != nil {
+		return x.Activity
+	}
+	return nil
+}
+
+type RefreshFeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Feed          string                 `protobuf:"bytes,1,opt,name=feed,proto3" json:"feed,omitempty"`
+	Force         bool                   `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"` // Получить ленту, даже если она только что получена
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshFeedRequest) Reset() {
+	*x = RefreshFeedRequest{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshFeedRequest) ProtoMessage() {}
+
+func (x *RefreshFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshFeedRequest.ProtoReflect.Descriptor instead.
+func (*RefreshFeedRequest) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RefreshFeedRequest) GetFeed() string {
+	if x != nil {
+		return x.Feed
+	}
+	return ""
+}
+
+func (x *RefreshFeedRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type RefreshFeedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NewArticles   int32                  `protobuf:"varint,1,opt,name=new_articles,json=newArticles,proto3" json:"new_articles,omitempty"`
+	Duplicates    int32                  `protobuf:"varint,2,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	Updated       int32                  `protobuf:"varint,3,opt,name=updated,proto3" json:"updated,omitempty"`
+	NotModified   bool                   `protobuf:"varint,4,opt,name=not_modified,json=notModified,proto3" json:"not_modified,omitempty"`
+	Skipped       bool                   `protobuf:"varint,5,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Bytes         int64                  `protobuf:"varint,6,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"` // Ошибка получения ленты
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshFeedResponse) Reset() {
+	*x = RefreshFeedResponse{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshFeedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshFeedResponse) ProtoMessage() {}
+
+func (x *RefreshFeedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshFeedResponse.ProtoReflect.Descriptor instead.
+func (*RefreshFeedResponse) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RefreshFeedResponse) GetNewArticles() int32 {
+	if x != nil {
+		return x.NewArticles
+	}
+	return 0
+}
+
+func (x *RefreshFeedResponse) GetDuplicates() int32 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *RefreshFeedResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *RefreshFeedResponse) GetNotModified() bool {
+	if x != nil {
+		return x.NotModified
+	}
+	return false
+}
+
+func (x *RefreshFeedResponse) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+func (x *RefreshFeedResponse) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *RefreshFeedResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type SetIntervalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Interval      *durationpb.Duration   `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIntervalRequest) Reset() {
+	*x = SetIntervalRequest{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIntervalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIntervalRequest) ProtoMessage() {}
+
+func (x *SetIntervalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIntervalRequest.ProtoReflect.Descriptor instead.
+func (*SetIntervalRequest) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SetIntervalRequest) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+type SetIntervalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIntervalResponse) Reset() {
+	*x = SetIntervalResponse{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIntervalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIntervalResponse) ProtoMessage() {}
+
+func (x *SetIntervalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIntervalResponse.ProtoReflect.Descriptor instead.
+func (*SetIntervalResponse) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{21}
+}
+
+type SetWorkersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workers       int32                  `protobuf:"varint,1,opt,name=workers,proto3" json:"workers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetWorkersRequest) Reset() {
+	*x = SetWorkersRequest{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetWorkersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWorkersRequest) ProtoMessage() {}
+
+func (x *SetWorkersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWorkersRequest.ProtoReflect.Descriptor instead.
+func (*SetWorkersRequest) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SetWorkersRequest) GetWorkers() int32 {
+	if x != nil {
+		return x.Workers
+	}
+	return 0
+}
+
+type SetWorkersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetWorkersResponse) Reset() {
+	*x = SetWorkersResponse{}
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetWorkersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWorkersResponse) ProtoMessage() {}
+
+func (x *SetWorkersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rsshub_v1_rsshub_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWorkersResponse.ProtoReflect.Descriptor instead.
+func (*SetWorkersResponse) Descriptor() ([]byte, []int) {
+	return file_rsshub_v1_rsshub_proto_rawDescGZIP(), []int{23}
+}
+
+var File_rsshub_v1_rsshub_proto protoreflect.FileDescriptor
+
+const file_rsshub_v1_rsshub_proto_rawDesc = "" +
+	"\n" +
+	"\x16rsshub/v1/rsshub.proto\x12\trsshub.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x86\x03\n" +
+	"\x04Feed\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12/\n" +
+	"\bpriority\x18\x04 \x01(\x0e2\x13.rsshub.v1.PriorityR\bpriority\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06folder\x18\x06 \x01(\tR\x06folder\x12\x18\n" +
+	"\aenabled\x18\a \x01(\bR\aenabled\x12'\n" +
+	"\x0fdisabled_reason\x18\b \x01(\tR\x0edisabledReason\x12\x1a\n" +
+	"\barticles\x18\t \x01(\x03R\barticles\x12\x16\n" +
+	"\x06unread\x18\n" +
+	" \x01(\x03R\x06unread\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x12\n" +
+	"\x10ListFeedsRequest\":\n" +
+	"\x11ListFeedsResponse\x12%\n" +
+	"\x05feeds\x18\x01 \x03(\v2\x0f.rsshub.v1.FeedR\x05feeds\"$\n" +
+	"\x0eGetFeedRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x94\x01\n" +
+	"\x11CreateFeedRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12/\n" +
+	"\bpriority\x18\x04 \x01(\x0e2\x13.rsshub.v1.PriorityR\bpriority\x12\x14\n" +
+	"\x05force\x18\x05 \x01(\bR\x05force\"'\n" +
+	"\x11DeleteFeedRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x14\n" +
+	"\x12DeleteFeedResponse\"\x8b\x01\n" +
+	"\tMediaItem\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x16\n" +
+	"\x06medium\x18\x04 \x01(\tR\x06medium\x12\x14\n" +
+	"\x05width\x18\x05 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x06 \x01(\x05R\x06height\"\x88\x01\n" +
+	"\aPodcast\x12\x16\n" +
+	"\x06author\x18\x01 \x01(\tR\x06author\x125\n" +
+	"\bduration\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12\x14\n" +
+	"\x05image\x18\x03 \x01(\tR\x05image\x12\x18\n" +
+	"\aepisode\x18\x04 \x01(\x05R\aepisode\"\x93\x04\n" +
+	"\aArticle\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x03R\x03seq\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x12\n" +
+	"\x04feed\x18\x03 \x01(\tR\x04feed\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06folder\x18\x05 \x01(\tR\x06folder\x12\x14\n" +
+	"\x05title\x18\x06 \x01(\tR\x05title\x12\x12\n" +
+	"\x04link\x18\a \x01(\tR\x04link\x12=\n" +
+	"\fpublished_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vpublishedAt\x12 \n" +
+	"\vdescription\x18\t \x01(\tR\vdescription\x12\x1a\n" +
+	"\blanguage\x18\n" +
+	" \x01(\tR\blanguage\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x123\n" +
+	"\aread_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x06readAt\x125\n" +
+	"\bsaved_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\asavedAt\x12,\n" +
+	"\apodcast\x18\x0e \x01(\v2\x12.rsshub.v1.PodcastR\apodcast\x12*\n" +
+	"\x05media\x18\x0f \x03(\v2\x14.rsshub.v1.MediaItemR\x05media\"e\n" +
+	"\x13ListArticlesRequest\x12\x12\n" +
+	"\x04feed\x18\x01 \x01(\tR\x04feed\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"n\n" +
+	"\x14ListArticlesResponse\x12.\n" +
+	"\barticles\x18\x01 \x03(\v2\x12.rsshub.v1.ArticleR\barticles\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"N\n" +
+	"\x13MarkArticlesRequest\x12\x12\n" +
+	"\x04seqs\x18\x01 \x03(\x03R\x04seqs\x12#\n" +
+	"\x04mark\x18\x02 \x01(\x0e2\x0f.rsshub.v1.MarkR\x04mark\"\x16\n" +
+	"\x14MarkArticlesResponse\"^\n" +
+	"\x15StreamArticlesRequest\x12\x14\n" +
+	"\x05feeds\x18\x01 \x03(\tR\x05feeds\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x12\x1b\n" +
+	"\tafter_seq\x18\x03 \x01(\x03R\bafterSeq\"\x12\n" +
+	"\x10GetStatusRequest\"z\n" +
+	"\x0eWorkerActivity\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04feed\x18\x02 \x01(\tR\x04feed\x120\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12\x12\n" +
+	"\x04jobs\x18\x04 \x01(\x05R\x04jobs\"\xcb\x02\n" +
+	"\x10AggregatorStatus\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x129\n" +
+	"\n" +
+	"started_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x125\n" +
+	"\binterval\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12\x18\n" +
+	"\aworkers\x18\x04 \x01(\x05R\aworkers\x12\x1b\n" +
+	"\tin_flight\x18\x05 \x01(\x05R\binFlight\x12\x16\n" +
+	"\x06queued\x18\x06 \x01(\x05R\x06queued\x12#\n" +
+	"\rcycle_running\x18\a \x01(\bR\fcycleRunning\x125\n" +
+	"\bactivity\x18\b \x03(\v2\x19.rsshub.v1.WorkerActivityR\bactivity\">\n" +
+	"\x12RefreshFeedRequest\x12\x12\n" +
+	"\x04feed\x18\x01 \x01(\tR\x04feed\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\"\xdb\x01\n" +
+	"\x13RefreshFeedResponse\x12!\n" +
+	"\fnew_articles\x18\x01 \x01(\x05R\vnewArticles\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x02 \x01(\x05R\n" +
+	"duplicates\x12\x18\n" +
+	"\aupdated\x18\x03 \x01(\x05R\aupdated\x12!\n" +
+	"\fnot_modified\x18\x04 \x01(\bR\vnotModified\x12\x18\n" +
+	"\askipped\x18\x05 \x01(\bR\askipped\x12\x14\n" +
+	"\x05bytes\x18\x06 \x01(\x03R\x05bytes\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"K\n" +
+	"\x12SetIntervalRequest\x125\n" +
+	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\"\x15\n" +
+	"\x13SetIntervalResponse\"-\n" +
+	"\x11SetWorkersRequest\x12\x18\n" +
+	"\aworkers\x18\x01 \x01(\x05R\aworkers\"\x14\n" +
+	"\x12SetWorkersResponse*^\n" +
+	"\bPriority\x12\x18\n" +
+	"\x14PRIORITY_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fPRIORITY_LOW\x10\x01\x12\x13\n" +
+	"\x0fPRIORITY_NORMAL\x10\x02\x12\x11\n" +
+	"\rPRIORITY_HIGH\x10\x03*^\n" +
+	"\x04Mark\x12\x14\n" +
+	"\x10MARK_UNSPECIFIED\x10\x00\x12\r\n" +
+	"\tMARK_READ\x10\x01\x12\x0f\n" +
+	"\vMARK_UNREAD\x10\x02\x12\x0e\n" +
+	"\n" +
+	"MARK_SAVED\x10\x03\x12\x10\n" +
+	"\fMARK_UNSAVED\x10\x042\x8e\x02\n" +
+	"\x05Feeds\x12F\n" +
+	"\tListFeeds\x12\x1b.rsshub.v1.ListFeedsRequest\x1a\x1c.rsshub.v1.ListFeedsResponse\x125\n" +
+	"\aGetFeed\x12\x19.rsshub.v1.GetFeedRequest\x1a\x0f.rsshub.v1.Feed\x12;\n" +
+	"\n" +
+	"CreateFeed\x12\x1c.rsshub.v1.CreateFeedRequest\x1a\x0f.rsshub.v1.Feed\x12I\n" +
+	"\n" +
+	"DeleteFeed\x12\x1c.rsshub.v1.DeleteFeedRequest\x1a\x1d.rsshub.v1.DeleteFeedResponse2\xf6\x01\n" +
+	"\bArticles\x12O\n" +
+	"\fListArticles\x12\x1e.rsshub.v1.ListArticlesRequest\x1a\x1f.rsshub.v1.ListArticlesResponse\x12O\n" +
+	"\fMarkArticles\x12\x1e.rsshub.v1.MarkArticlesRequest\x1a\x1f.rsshub.v1.MarkArticlesResponse\x12H\n" +
+	"\x0eStreamArticles\x12 .rsshub.v1.StreamArticlesRequest\x1a\x12.rsshub.v1.Article0\x012\xc1\x02\n" +
+	"\x11AggregatorControl\x12E\n" +
+	"\tGetStatus\x12\x1b.rsshub.v1.GetStatusRequest\x1a\x1b.rsshub.v1.AggregatorStatus\x12L\n" +
+	"\vRefreshFeed\x12\x1d.rsshub.v1.RefreshFeedRequest\x1a\x1e.rsshub.v1.RefreshFeedResponse\x12L\n" +
+	"\vSetInterval\x12\x1d.rsshub.v1.SetIntervalRequest\x1a\x1e.rsshub.v1.SetIntervalResponse\x12I\n" +
+	"\n" +
+	"SetWorkers\x12\x1c.rsshub.v1.SetWorkersRequest\x1a\x1d.rsshub.v1.SetWorkersResponseB\x1fZ\x1drsshub/api/rsshub/v1;rsshubv1b\x06proto3"
+
+var (
+	file_rsshub_v1_rsshub_proto_rawDescOnce sync.Once
+	file_rsshub_v1_rsshub_proto_rawDescData []byte
+)
+
+func file_rsshub_v1_rsshub_proto_rawDescGZIP() []byte {
+	file_rsshub_v1_rsshub_proto_rawDescOnce.Do(func() {
+		file_rsshub_v1_rsshub_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rsshub_v1_rsshub_proto_rawDesc), len(file_rsshub_v1_rsshub_proto_rawDesc)))
+	})
+	return file_rsshub_v1_rsshub_proto_rawDescData
+}
+
+var file_rsshub_v1_rsshub_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_rsshub_v1_rsshub_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_rsshub_v1_rsshub_proto_goTypes = []any{
+	(Priority)(0),                 // 0: rsshub.v1.Priority
+	(Mark)(0),                     // 1: rsshub.v1.Mark
+	(*Feed)(nil),                  // 2: rsshub.v1.Feed
+	(*ListFeedsRequest)(nil),      // 3: rsshub.v1.ListFeedsRequest
+	(*ListFeedsResponse)(nil),     // 4: rsshub.v1.ListFeedsResponse
+	(*GetFeedRequest)(nil),        // 5: rsshub.v1.GetFeedRequest
+	(*CreateFeedRequest)(nil),     // 6: rsshub.v1.CreateFeedRequest
+	(*DeleteFeedRequest)(nil),     // 7: rsshub.v1.DeleteFeedRequest
+	(*DeleteFeedResponse)(nil),    // 8: rsshub.v1.DeleteFeedResponse
+	(*MediaItem)(nil),             // 9: rsshub.v1.MediaItem
+	(*Podcast)(nil),               // 10: rsshub.v1.Podcast
+	(*Article)(nil),               // 11: rsshub.v1.Article
+	(*ListArticlesRequest)(nil),   // 12: rsshub.v1.ListArticlesRequest
+	(*ListArticlesResponse)(nil),  // 13: rsshub.v1.ListArticlesResponse
+	(*MarkArticlesRequest)(nil),   // 14: rsshub.v1.MarkArticlesRequest
+	(*MarkArticlesResponse)(nil),  // 15: rsshub.v1.MarkArticlesResponse
+	(*StreamArticlesRequest)(nil), // 16: rsshub.v1.StreamArticlesRequest
+	(*GetStatusRequest)(nil),      // 17: rsshub.v1.GetStatusRequest
+	(*WorkerActivity)(nil),        // 18: rsshub.v1.WorkerActivity
+	(*AggregatorStatus)(nil),      // 19: rsshub.v1.AggregatorStatus
+	(*RefreshFeedRequest)(nil),    // 20: rsshub.v1.RefreshFeedRequest
+	(*RefreshFeedResponse)(nil),   // 21: rsshub.v1.RefreshFeedResponse
+	(*SetIntervalRequest)(nil),    // 22: rsshub.v1.SetIntervalRequest
+	(*SetIntervalResponse)(nil),   // 23: rsshub.v1.SetIntervalResponse
+	(*SetWorkersRequest)(nil),     // 24: rsshub.v1.SetWorkersRequest
+	(*SetWorkersResponse)(nil),    // 25: rsshub.v1.SetWorkersResponse
+	(*timestamppb.Timestamp)(nil), // 26: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 27: google.protobuf.Duration
+}
+var file_rsshub_v1_rsshub_proto_depIdxs = []int32{
+	0,  // 0: rsshub.v1.Feed.priority:type_name -> rsshub.v1.Priority
+	26, // 1: rsshub.v1.Feed.created_at:type_name -> google.protobuf.Timestamp
+	26, // 2: rsshub.v1.Feed.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 3: rsshub.v1.ListFeedsResponse.feeds:type_name -> rsshub.v1.Feed
+	0,  // 4: rsshub.v1.CreateFeedRequest.priority:type_name -> rsshub.v1.Priority
+	27, // 5: rsshub.v1.Podcast.duration:type_name -> google.protobuf.Duration
+	26, // 6: rsshub.v1.Article.published_at:type_name -> google.protobuf.Timestamp
+	26, // 7: rsshub.v1.Article.created_at:type_name -> google.protobuf.Timestamp
+	26, // 8: rsshub.v1.Article.read_at:type_name -> google.protobuf.Timestamp
+	26, // 9: rsshub.v1.Article.saved_at:type_name -> google.protobuf.Timestamp
+	10, // 10: rsshub.v1.Article.podcast:type_name -> rsshub.v1.Podcast
+	9,  // 11: rsshub.v1.Article.media:type_name -> rsshub.v1.MediaItem
+	11, // 12: rsshub.v1.ListArticlesResponse.articles:type_name -> rsshub.v1.Article
+	1,  // 13: rsshub.v1.MarkArticlesRequest.mark:type_name -> rsshub.v1.Mark
+	26, // 14: rsshub.v1.WorkerActivity.since:type_name -> google.protobuf.Timestamp
+	26, // 15: rsshub.v1.AggregatorStatus.started_at:type_name -> google.protobuf.Timestamp
+	27, // 16: rsshub.v1.AggregatorStatus.interval:type_name -> google.protobuf.Duration
+	18, // 17: rsshub.v1.AggregatorStatus.activity:type_name -> rsshub.v1.WorkerActivity
+	27, // 18: rsshub.v1.SetIntervalRequest.interval:type_name -> google.protobuf.Duration
+	3,  // 19: rsshub.v1.Feeds.ListFeeds:input_type -> rsshub.v1.ListFeedsRequest
+	5,  // 20: rsshub.v1.Feeds.GetFeed:input_type -> rsshub.v1.GetFeedRequest
+	6,  // 21: rsshub.v1.Feeds.CreateFeed:input_type -> rsshub.v1.CreateFeedRequest
+	7,  // 22: rsshub.v1.Feeds.DeleteFeed:input_type -> rsshub.v1.DeleteFeedRequest
+	12, // 23: rsshub.v1.Articles.ListArticles:input_type -> rsshub.v1.ListArticlesRequest
+	14, // 24: rsshub.v1.Articles.MarkArticles:input_type -> rsshub.v1.MarkArticlesRequest
+	16, // 25: rsshub.v1.Articles.StreamArticles:input_type -> rsshub.v1.StreamArticlesRequest
+	17, // 26: rsshub.v1.AggregatorControl.GetStatus:input_type -> rsshub.v1.GetStatusRequest
+	20, // 27: rsshub.v1.AggregatorControl.RefreshFeed:input_type -> rsshub.v1.RefreshFeedRequest
+	22, // 28: rsshub.v1.AggregatorControl.SetInterval:input_type -> rsshub.v1.SetIntervalRequest
+	24, // 29: rsshub.v1.AggregatorControl.SetWorkers:input_type -> rsshub.v1.SetWorkersRequest
+	4,  // 30: rsshub.v1.Feeds.ListFeeds:output_type -> rsshub.v1.ListFeedsResponse
+	2,  // 31: rsshub.v1.Feeds.GetFeed:output_type -> rsshub.v1.Feed
+	2,  // 32: rsshub.v1.Feeds.CreateFeed:output_type -> rsshub.v1.Feed
+	8,  // 33: rsshub.v1.Feeds.DeleteFeed:output_type -> rsshub.v1.DeleteFeedResponse
+	13, // 34: rsshub.v1.Articles.ListArticles:output_type -> rsshub.v1.ListArticlesResponse
+	15, // 35: rsshub.v1.Articles.MarkArticles:output_type -> rsshub.v1.MarkArticlesResponse
+	11, // 36: rsshub.v1.Articles.StreamArticles:output_type -> rsshub.v1.Article
+	19, // 37: rsshub.v1.AggregatorControl.GetStatus:output_type -> rsshub.v1.AggregatorStatus
+	21, // 38: rsshub.v1.AggregatorControl.RefreshFeed:output_type -> rsshub.v1.RefreshFeedResponse
+	23, // 39: rsshub.v1.AggregatorControl.SetInterval:output_type -> rsshub.v1.SetIntervalResponse
+	25, // 40: rsshub.v1.AggregatorControl.SetWorkers:output_type -> rsshub.v1.SetWorkersResponse
+	30, // [30:41] is the sub-list for method output_type
+	19, // [19:30] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_rsshub_v1_rsshub_proto_init() }
+func file_rsshub_v1_rsshub_proto_init() {
+	if File_rsshub_v1_rsshub_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rsshub_v1_rsshub_proto_rawDesc), len(file_rsshub_v1_rsshub_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_rsshub_v1_rsshub_proto_goTypes,
+		DependencyIndexes: file_rsshub_v1_rsshub_proto_depIdxs,
+		EnumInfos:         file_rsshub_v1_rsshub_proto_enumTypes,
+		MessageInfos:      file_rsshub_v1_rsshub_proto_msgTypes,
+	}.Build()
+	File_rsshub_v1_rsshub_proto = out.File
+	file_rsshub_v1_rsshub_proto_goTypes = nil
+	file_rsshub_v1_rsshub_proto_depIdxs = nil
+}
@@ -0,0 +1,677 @@
+// api/rsshub/v1/rsshub.proto
+//
+// gRPC API rsshub (rsshub serve, CLI_APP_GRPC_ADDR). Чтение доступно без ключа, изменяющие
+// вызовы требуют ключ API в метаданных "authorization: Bearer <ключ>" или "x-api-key", как и
+// REST API. Код Go генерируется командой buf generate (см. buf.gen.yaml)
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: rsshub/v1/rsshub.proto
+
+package rsshubv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Feeds_ListFeeds_FullMethodName  = "/rsshub.v1.Feeds/ListFeeds"
+	Feeds_GetFeed_FullMethodName    = "/rsshub.v1.Feeds/GetFeed"
+	Feeds_CreateFeed_FullMethodName = "/rsshub.v1.Feeds/CreateFeed"
+	Feeds_DeleteFeed_FullMethodName = "/rsshub.v1.Feeds/DeleteFeed"
+)
+
+// FeedsClient is the client API for Feeds service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Feeds управление лентами
+type FeedsClient interface {
+	// ListFeeds возвращает все ленты вместе с количеством их статей
+	ListFeeds(ctx context.Context, in *ListFeedsRequest, opts ...grpc.CallOption) (*ListFeedsResponse, error)
+	// GetFeed возвращает ленту по имени (NOT_FOUND, если ее нет)
+	GetFeed(ctx context.Context, in *GetFeedRequest, opts ...grpc.CallOption) (*Feed, error)
+	// CreateFeed проверяет и добавляет ленту; URL, который уже получается под другим
+	// именем, отклоняется с ALREADY_EXISTS, если не задан force
+	CreateFeed(ctx context.Context, in *CreateFeedRequest, opts ...grpc.CallOption) (*Feed, error)
+	// DeleteFeed удаляет ленту вместе с ее статьями
+	DeleteFeed(ctx context.Context, in *DeleteFeedRequest, opts ...grpc.CallOption) (*DeleteFeedResponse, error)
+}
+
+type feedsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFeedsClient(cc grpc.ClientConnInterface) FeedsClient {
+	return &feedsClient{cc}
+}
+
+func (c *feedsClient) ListFeeds(ctx context.Context, in *ListFeedsRequest, opts ...grpc.CallOption) (*ListFeedsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFeedsResponse)
+	err := c.cc.Invoke(ctx, Feeds_ListFeeds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedsClient) GetFeed(ctx context.Context, in *GetFeedRequest, opts ...grpc.CallOption) (*Feed, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Feed)
+	err := c.cc.Invoke(ctx, Feeds_GetFeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedsClient) CreateFeed(ctx context.Context, in *CreateFeedRequest, opts ...grpc.CallOption) (*Feed, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Feed)
+	err := c.cc.Invoke(ctx, Feeds_CreateFeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedsClient) DeleteFeed(ctx context.Context, in *DeleteFeedRequest, opts ...grpc.CallOption) (*DeleteFeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteFeedResponse)
+	err := c.cc.Invoke(ctx, Feeds_DeleteFeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FeedsServer is the server API for Feeds service.
+// All implementations must embed UnimplementedFeedsServer
+// for forward compatibility.
+//
+// Feeds управление лентами
+type FeedsServer interface {
+	// ListFeeds возвращает все ленты вместе с количеством их статей
+	ListFeeds(context.Context, *ListFeedsRequest) (*ListFeedsResponse, error)
+	// GetFeed возвращает ленту по имени (NOT_FOUND, если ее нет)
+	GetFeed(context.Context, *GetFeedRequest) (*Feed, error)
+	// CreateFeed проверяет и добавляет ленту; URL, который уже получается под другим
+	// именем, отклоняется с ALREADY_EXISTS, если не задан force
+	CreateFeed(context.Context, *CreateFeedRequest) (*Feed, error)
+	// DeleteFeed удаляет ленту вместе с ее статьями
+	DeleteFeed(context.Context, *DeleteFeedRequest) (*DeleteFeedResponse, error)
+	mustEmbedUnimplementedFeedsServer()
+}
+
+// UnimplementedFeedsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFeedsServer struct{}
+
+func (UnimplementedFeedsServer) ListFeeds(context.Context, *ListFeedsRequest) (*ListFeedsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFeeds not implemented")
+}
+func (UnimplementedFeedsServer) GetFeed(context.Context, *GetFeedRequest) (*Feed, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeed not implemented")
+}
+func (UnimplementedFeedsServer) CreateFeed(context.Context, *CreateFeedRequest) (*Feed, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFeed not implemented")
+}
+func (UnimplementedFeedsServer) DeleteFeed(context.Context, *DeleteFeedRequest) (*DeleteFeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFeed not implemented")
+}
+func (UnimplementedFeedsServer) mustEmbedUnimplementedFeedsServer() {}
+func (UnimplementedFeedsServer) testEmbeddedByValue()               {}
+
+// UnsafeFeedsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FeedsServer will
+// result in compilation errors.
+type UnsafeFeedsServer interface {
+	mustEmbedUnimplementedFeedsServer()
+}
+
+func RegisterFeedsServer(s grpc.ServiceRegistrar, srv FeedsServer) {
+	// If the following call pancis, it indicates UnimplementedFeedsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Feeds_ServiceDesc, srv)
+}
+
+func _Feeds_ListFeeds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFeedsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedsServer).ListFeeds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Feeds_ListFeeds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedsServer).ListFeeds(ctx, req.(*ListFeedsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Feeds_GetFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedsServer).GetFeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Feeds_GetFeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedsServer).GetFeed(ctx, req.(*GetFeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Feeds_CreateFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedsServer).CreateFeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Feeds_CreateFeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedsServer).CreateFeed(ctx, req.(*CreateFeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Feeds_DeleteFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedsServer).DeleteFeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Feeds_DeleteFeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedsServer).DeleteFeed(ctx, req.(*DeleteFeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Feeds_ServiceDesc is the grpc.ServiceDesc for Feeds service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Feeds_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rsshub.v1.Feeds",
+	HandlerType: (*FeedsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListFeeds",
+			Handler:    _Feeds_ListFeeds_Handler,
+		},
+		{
+			MethodName: "GetFeed",
+			Handler:    _Feeds_GetFeed_Handler,
+		},
+		{
+			MethodName: "CreateFeed",
+			Handler:    _Feeds_CreateFeed_Handler,
+		},
+		{
+			MethodName: "DeleteFeed",
+			Handler:    _Feeds_DeleteFeed_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rsshub/v1/rsshub.proto",
+}
+
+const (
+	Articles_ListArticles_FullMethodName   = "/rsshub.v1.Articles/ListArticles"
+	Articles_MarkArticles_FullMethodName   = "/rsshub.v1.Articles/MarkArticles"
+	Articles_StreamArticles_FullMethodName = "/rsshub.v1.Articles/StreamArticles"
+)
+
+// ArticlesClient is the client API for Articles service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Articles статьи лент
+type ArticlesClient interface {
+	// ListArticles возвращает страницу статей ленты, новые первыми
+	ListArticles(ctx context.Context, in *ListArticlesRequest, opts ...grpc.CallOption) (*ListArticlesResponse, error)
+	// MarkArticles меняет состояние статей с перечисленными номерами
+	MarkArticles(ctx context.Context, in *MarkArticlesRequest, opts ...grpc.CallOption) (*MarkArticlesResponse, error)
+	// StreamArticles отправляет новые статьи по мере их сохранения, пока клиент не отменит вызов
+	StreamArticles(ctx context.Context, in *StreamArticlesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Article], error)
+}
+
+type articlesClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewArticlesClient(cc grpc.ClientConnInterface) ArticlesClient {
+	return &articlesClient{cc}
+}
+
+func (c *articlesClient) ListArticles(ctx context.Context, in *ListArticlesRequest, opts ...grpc.CallOption) (*ListArticlesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListArticlesResponse)
+	err := c.cc.Invoke(ctx, Articles_ListArticles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articlesClient) MarkArticles(ctx context.Context, in *MarkArticlesRequest, opts ...grpc.CallOption) (*MarkArticlesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkArticlesResponse)
+	err := c.cc.Invoke(ctx, Articles_MarkArticles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articlesClient) StreamArticles(ctx context.Context, in *StreamArticlesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Article], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Articles_ServiceDesc.Streams[0], Articles_StreamArticles_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamArticlesRequest, Article]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Articles_StreamArticlesClient = grpc.ServerStreamingClient[Article]
+
+// ArticlesServer is the server API for Articles service.
+// All implementations must embed UnimplementedArticlesServer
+// for forward compatibility.
+//
+// Articles статьи лент
+type ArticlesServer interface {
+	// ListArticles возвращает страницу статей ленты, новые первыми
+	ListArticles(context.Context, *ListArticlesRequest) (*ListArticlesResponse, error)
+	// MarkArticles меняет состояние статей с перечисленными номерами
+	MarkArticles(context.Context, *MarkArticlesRequest) (*MarkArticlesResponse, error)
+	// StreamArticles отправляет новые статьи по мере их сохранения, пока клиент не отменит вызов
+	StreamArticles(*StreamArticlesRequest, grpc.ServerStreamingServer[Article]) error
+	mustEmbedUnimplementedArticlesServer()
+}
+
+// UnimplementedArticlesServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedArticlesServer struct{}
+
+func (UnimplementedArticlesServer) ListArticles(context.Context, *ListArticlesRequest) (*ListArticlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArticles not implemented")
+}
+func (UnimplementedArticlesServer) MarkArticles(context.Context, *MarkArticlesRequest) (*MarkArticlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkArticles not implemented")
+}
+func (UnimplementedArticlesServer) StreamArticles(*StreamArticlesRequest, grpc.ServerStreamingServer[Article]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamArticles not implemented")
+}
+func (UnimplementedArticlesServer) mustEmbedUnimplementedArticlesServer() {}
+func (UnimplementedArticlesServer) testEmbeddedByValue()                  {}
+
+// UnsafeArticlesServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ArticlesServer will
+// result in compilation errors.
+type UnsafeArticlesServer interface {
+	mustEmbedUnimplementedArticlesServer()
+}
+
+func RegisterArticlesServer(s grpc.ServiceRegistrar, srv ArticlesServer) {
+	// If the following call pancis, it indicates UnimplementedArticlesServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Articles_ServiceDesc, srv)
+}
+
+func _Articles_ListArticles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArticlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticlesServer).ListArticles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Articles_ListArticles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticlesServer).ListArticles(ctx, req.(*ListArticlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Articles_MarkArticles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkArticlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticlesServer).MarkArticles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Articles_MarkArticles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticlesServer).MarkArticles(ctx, req.(*MarkArticlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Articles_StreamArticles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamArticlesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArticlesServer).StreamArticles(m, &grpc.GenericServerStream[StreamArticlesRequest, Article]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Articles_StreamArticlesServer = grpc.ServerStreamingServer[Article]
+
+// Articles_ServiceDesc is the grpc.ServiceDesc for Articles service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Articles_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rsshub.v1.Articles",
+	HandlerType: (*ArticlesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListArticles",
+			Handler:    _Articles_ListArticles_Handler,
+		},
+		{
+			MethodName: "MarkArticles",
+			Handler:    _Articles_MarkArticles_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamArticles",
+			Handler:       _Articles_StreamArticles_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "rsshub/v1/rsshub.proto",
+}
+
+const (
+	AggregatorControl_GetStatus_FullMethodName   = "/rsshub.v1.AggregatorControl/GetStatus"
+	AggregatorControl_RefreshFeed_FullMethodName = "/rsshub.v1.AggregatorControl/RefreshFeed"
+	AggregatorControl_SetInterval_FullMethodName = "/rsshub.v1.AggregatorControl/SetInterval"
+	AggregatorControl_SetWorkers_FullMethodName  = "/rsshub.v1.AggregatorControl/SetWorkers"
+)
+
+// AggregatorControlClient is the client API for AggregatorControl service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AggregatorControl управление фоновым процессом rsshub fetch
+type AggregatorControlClient interface {
+	// GetStatus возвращает состояние запущенного агрегатора (UNAVAILABLE, если он не запущен)
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*AggregatorStatus, error)
+	// RefreshFeed получает ленту сейчас через запущенный агрегатор, а если он не запущен - в
+	// процессе сервера
+	RefreshFeed(ctx context.Context, in *RefreshFeedRequest, opts ...grpc.CallOption) (*RefreshFeedResponse, error)
+	// SetInterval меняет интервал получения лент; запущенные агрегаторы применяют его сразу
+	SetInterval(ctx context.Context, in *SetIntervalRequest, opts ...grpc.CallOption) (*SetIntervalResponse, error)
+	// SetWorkers меняет количество воркеров; запущенные агрегаторы применяют его сразу
+	SetWorkers(ctx context.Context, in *SetWorkersRequest, opts ...grpc.CallOption) (*SetWorkersResponse, error)
+}
+
+type aggregatorControlClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAggregatorControlClient(cc grpc.ClientConnInterface) AggregatorControlClient {
+	return &aggregatorControlClient{cc}
+}
+
+func (c *aggregatorControlClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*AggregatorStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AggregatorStatus)
+	err := c.cc.Invoke(ctx, AggregatorControl_GetStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aggregatorControlClient) RefreshFeed(ctx context.Context, in *RefreshFeedRequest, opts ...grpc.CallOption) (*RefreshFeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefreshFeedResponse)
+	err := c.cc.Invoke(ctx, AggregatorControl_RefreshFeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aggregatorControlClient) SetInterval(ctx context.Context, in *SetIntervalRequest, opts ...grpc.CallOption) (*SetIntervalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetIntervalResponse)
+	err := c.cc.Invoke(ctx, AggregatorControl_SetInterval_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aggregatorControlClient) SetWorkers(ctx context.Context, in *SetWorkersRequest, opts ...grpc.CallOption) (*SetWorkersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetWorkersResponse)
+	err := c.cc.Invoke(ctx, AggregatorControl_SetWorkers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AggregatorControlServer is the server API for AggregatorControl service.
+// All implementations must embed UnimplementedAggregatorControlServer
+// for forward compatibility.
+//
+// AggregatorControl управление фоновым процессом rsshub fetch
+type AggregatorControlServer interface {
+	// GetStatus возвращает состояние запущенного агрегатора (UNAVAILABLE, если он не запущен)
+	GetStatus(context.Context, *GetStatusRequest) (*AggregatorStatus, error)
+	// RefreshFeed получает ленту сейчас через запущенный агрегатор, а если он не запущен - в
+	// процессе сервера
+	RefreshFeed(context.Context, *RefreshFeedRequest) (*RefreshFeedResponse, error)
+	// SetInterval меняет интервал получения лент; запущенные агрегаторы применяют его сразу
+	SetInterval(context.Context, *SetIntervalRequest) (*SetIntervalResponse, error)
+	// SetWorkers меняет количество воркеров; запущенные агрегаторы применяют его сразу
+	SetWorkers(context.Context, *SetWorkersRequest) (*SetWorkersResponse, error)
+	mustEmbedUnimplementedAggregatorControlServer()
+}
+
+// UnimplementedAggregatorControlServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAggregatorControlServer struct{}
+
+func (UnimplementedAggregatorControlServer) GetStatus(context.Context, *GetStatusRequest) (*AggregatorStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedAggregatorControlServer) RefreshFeed(context.Context, *RefreshFeedRequest) (*RefreshFeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshFeed not implemented")
+}
+func (UnimplementedAggregatorControlServer) SetInterval(context.Context, *SetIntervalRequest) (*SetIntervalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetInterval not implemented")
+}
+func (UnimplementedAggregatorControlServer) SetWorkers(context.Context, *SetWorkersRequest) (*SetWorkersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWorkers not implemented")
+}
+func (UnimplementedAggregatorControlServer) mustEmbedUnimplementedAggregatorControlServer() {}
+func (UnimplementedAggregatorControlServer) testEmbeddedByValue()                           {}
+
+// UnsafeAggregatorControlServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AggregatorControlServer will
+// result in compilation errors.
+type UnsafeAggregatorControlServer interface {
+	mustEmbedUnimplementedAggregatorControlServer()
+}
+
+func RegisterAggregatorControlServer(s grpc.ServiceRegistrar, srv AggregatorControlServer) {
+	// If the following call pancis, it indicates UnimplementedAggregatorControlServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AggregatorControl_ServiceDesc, srv)
+}
+
+func _AggregatorControl_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AggregatorControlServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AggregatorControl_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AggregatorControlServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AggregatorControl_RefreshFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshFeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AggregatorControlServer).RefreshFeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AggregatorControl_RefreshFeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AggregatorControlServer).RefreshFeed(ctx, req.(*RefreshFeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AggregatorControl_SetInterval_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetIntervalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AggregatorControlServer).SetInterval(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AggregatorControl_SetInterval_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AggregatorControlServer).SetInterval(ctx, req.(*SetIntervalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AggregatorControl_SetWorkers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWorkersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AggregatorControlServer).SetWorkers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AggregatorControl_SetWorkers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AggregatorControlServer).SetWorkers(ctx, req.(*SetWorkersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AggregatorControl_ServiceDesc is the grpc.ServiceDesc for AggregatorControl service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AggregatorControl_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rsshub.v1.AggregatorControl",
+	HandlerType: (*AggregatorControlServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStatus",
+			Handler:    _AggregatorControl_GetStatus_Handler,
+		},
+		{
+			MethodName: "RefreshFeed",
+			Handler:    _AggregatorControl_RefreshFeed_Handler,
+		},
+		{
+			MethodName: "SetInterval",
+			Handler:    _AggregatorControl_SetInterval_Handler,
+		},
+		{
+			MethodName: "SetWorkers",
+			Handler:    _AggregatorControl_SetWorkers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rsshub/v1/rsshub.proto",
+}
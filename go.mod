@@ -3,5 +3,15 @@ module rsshub
 go 1.24.2
 
 require (
-	github.com/lib/pq v1.10.9 // indirect
+	github.com/andybalholm/brotli v1.2.5
+	github.com/lib/pq v1.10.9
+	golang.org/x/net v0.41.0
+	google.golang.org/grpc v1.75.1
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	golang.org/x/sys v0.33.0 // indirect
+	golang.org/x/text v0.26.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250707201910-8d1bb00bc6a7 // indirect
 )
@@ -0,0 +1,248 @@
+// internal/adapter/archive/archive.go
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/config"
+	"rsshub/internal/platform/logger"
+)
+
+// Проверяем на этапе компиляции, что Archive реализует архив статей
+var _ port.ArticleArchive = (*Archive)(nil)
+
+// Format формат файлов архива
+type Format string
+
+const (
+	FormatMarkdown Format = "md"
+	FormatJSON     Format = "json"
+)
+
+// maxSlugLength максимальная длина части имени файла из заголовка статьи (в символах)
+const maxSlugLength = 60
+
+// unsafeName последовательность символов, недопустимых в имени файла архива
+var unsafeName = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// ParseFormat разбирает название формата архива
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "md", "markdown":
+		return FormatMarkdown, nil
+	case "json":
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("unknown archive format %q (expected md or json)", s)
+	}
+}
+
+// record статья в файле архива JSON
+type record struct {
+	ID          string    `json:"id"`
+	Feed        string    `json:"feed"`
+	Tags        []string  `json:"tags,omitempty"`
+	Title       string    `json:"title"`
+	Link        string    `json:"link"`
+	GUID        string    `json:"guid,omitempty"`
+	PublishedAt time.Time `json:"published_at"`
+	SavedAt     time.Time `json:"archived_at"`
+	Language    string    `json:"language,omitempty"`
+	Description string    `json:"description"`
+
+	Podcast *domain.PodcastInfo `json:"podcast,omitempty"` // Метаданные эпизода подкаста
+	Media   []domain.MediaItem  `json:"media,omitempty"`   // Вложения Media RSS
+}
+
+// Archive записывает каждую новую статью отдельным файлом в дерево каталогов по дате публикации:
+// <dir>/2006/01/02/<лента>-<заголовок>-<id>.md. Архив не зависит от PostgreSQL, по нему можно
+// искать обычными инструментами (grep, ripgrep) или публиковать его генератором сайтов
+type Archive struct {
+	dir    string
+	format Format
+}
+
+// New создает архив статей в каталоге cfg.Dir
+func New(cfg *config.ArchiveConfig) (*Archive, error) {
+	if cfg.Dir == "" {
+		return nil, fmt.Errorf("archive directory is not configured")
+	}
+	format, err := ParseFormat(cfg.Format)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	return &Archive{dir: cfg.Dir, format: format}, nil
+}
+
+// Archive записывает статьи в архив. Файл уже архивированной статьи перезаписывается,
+// поэтому повторная запись не создает дубликатов
+func (a *Archive) Archive(ctx context.Context, entries []*domain.DigestEntry) error {
+	written := 0
+	for _, e := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := a.write(e); err != nil {
+			return fmt.Errorf("failed to archive article '%s': %w", e.Article.Title, err)
+		}
+		written++
+	}
+
+	if written > 0 {
+		logger.Debug("Archived %d articles to %s", written, a.dir)
+	}
+	return nil
+}
+
+// write записывает файл статьи: сначала во временный файл, затем переименовывает его,
+// чтобы прерванная запись не оставила в архиве обрезанный файл
+func (a *Archive) write(e *domain.DigestEntry) error {
+	path := a.path(e)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	var data []byte
+	switch a.format {
+	case FormatJSON:
+		var err error
+		if data, err = encodeJSON(e); err != nil {
+			return err
+		}
+	default:
+		data = encodeMarkdown(e)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".archive-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	// Временный файл создается с правами 0600, а архив должны читать и другие программы
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// path возвращает путь файла статьи в дереве каталогов по дате публикации (по дате сохранения,
+// если у статьи нет даты публикации)
+func (a *Archive) path(e *domain.DigestEntry) string {
+	date := articleDate(e.Article)
+
+	name := safeName(e.FeedName, maxSlugLength)
+	if slug := safeName(e.Article.Title, maxSlugLength); slug != "" {
+		name += "-" + slug
+	}
+	id := e.Article.ID.String()
+	name += "-" + id[:min(8, len(id))] + "." + string(a.format)
+
+	return filepath.Join(a.dir, date.Format("2006"), date.Format("01"), date.Format("02"), name)
+}
+
+// articleDate дата, по которой статья раскладывается по каталогам
+func articleDate(article *domain.Article) time.Time {
+	if !article.PublishedAt.IsZero() {
+		return article.PublishedAt
+	}
+	if !article.CreatedAt.IsZero() {
+		return article.CreatedAt
+	}
+	return time.Now()
+}
+
+// safeName приводит строку к виду, пригодному для имени файла: буквы и цифры в нижнем
+// регистре, разделенные дефисами, не длиннее max символов
+func safeName(s string, max int) string {
+	name := strings.Trim(unsafeName.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if runes := []rune(name); len(runes) > max {
+		name = strings.TrimRight(string(runes[:max]), "-")
+	}
+	return name
+}
+
+// encodeJSON формирует файл статьи в формате JSON. HTML в описании не экранируется,
+// чтобы по файлам можно было искать текст как есть
+func encodeJSON(e *domain.DigestEntry) ([]byte, error) {
+	a := e.Article
+
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	err := enc.Encode(record{
+		ID:          a.ID.String(),
+		Feed:        e.FeedName,
+		Tags:        e.FeedTags,
+		Title:       a.Title,
+		Link:        a.Link,
+		GUID:        a.GUID,
+		PublishedAt: a.PublishedAt,
+		SavedAt:     time.Now(),
+		Language:    a.Language,
+		Description: a.Description,
+		Podcast:     a.Podcast,
+		Media:       a.Media,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// encodeMarkdown формирует файл статьи в формате Markdown с метаданными во front matter,
+// который понимают генераторы статических сайтов (Hugo, Jekyll)
+func encodeMarkdown(e *domain.DigestEntry) []byte {
+	a := e.Article
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "id: %s\n", a.ID)
+	fmt.Fprintf(&b, "title: %s\n", strconv.Quote(a.Title))
+	fmt.Fprintf(&b, "feed: %s\n", strconv.Quote(e.FeedName))
+	if len(e.FeedTags) > 0 {
+		tags := make([]string, 0, len(e.FeedTags))
+		for _, tag := range e.FeedTags {
+			tags = append(tags, strconv.Quote(tag))
+		}
+		fmt.Fprintf(&b, "tags: [%s]\n", strings.Join(tags, ", "))
+	}
+	fmt.Fprintf(&b, "link: %s\n", strconv.Quote(a.Link))
+	if !a.PublishedAt.IsZero() {
+		fmt.Fprintf(&b, "date: %s\n", a.PublishedAt.Format(time.RFC3339))
+	}
+	if a.Language != "" {
+		fmt.Fprintf(&b, "language: %s\n", a.Language)
+	}
+	b.WriteString("---\n\n")
+
+	title := strings.NewReplacer("[", `\[`, "]", `\]`).Replace(a.Title)
+	fmt.Fprintf(&b, "# [%s](%s)\n", title, a.Link)
+	if description := strings.TrimSpace(a.Description); description != "" {
+		fmt.Fprintf(&b, "\n%s\n", description)
+	}
+	return []byte(b.String())
+}
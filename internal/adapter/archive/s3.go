@@ -0,0 +1,217 @@
+// internal/adapter/archive/s3.go
+package archive
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"rsshub/internal/platform/config"
+)
+
+const (
+	// s3Service имя сервиса в подписи AWS Signature Version 4
+	s3Service = "s3"
+
+	// s3RequestTimeout таймаут одного запроса к хранилищу (вложения бывают большими)
+	s3RequestTimeout = 30 * time.Minute
+)
+
+// S3 загружает объекты в бакет S3 совместимого хранилища (AWS S3, MinIO, Ceph и др.).
+// Запросы подписываются AWS Signature Version 4
+type S3 struct {
+	client    *http.Client
+	endpoint  *url.URL
+	region    string
+	accessKey string
+	secretKey string
+	pathStyle bool
+
+	bucket string
+	prefix string // Префикс ключей объектов без завершающего слеша
+}
+
+// NewS3 создает клиент хранилища для адреса вида s3://bucket/prefix
+func NewS3(cfg *config.S3Config, target string) (*S3, error) {
+	bucket, prefix, err := ParseS3Target(target)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, fmt.Errorf("S3 credentials are not configured (CLI_APP_S3_ACCESS_KEY and CLI_APP_S3_SECRET_KEY)")
+	}
+
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || (endpoint.Scheme != "https" && endpoint.Scheme != "http") || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", cfg.Endpoint)
+	}
+	if cfg.Region == "" {
+		return nil, fmt.Errorf("S3 region is not configured")
+	}
+
+	return &S3{
+		client:    &http.Client{Timeout: s3RequestTimeout},
+		endpoint:  endpoint,
+		region:    cfg.Region,
+		accessKey: cfg.AccessKey,
+		secretKey: cfg.SecretKey,
+		pathStyle: cfg.PathStyle,
+		bucket:    bucket,
+		prefix:    prefix,
+	}, nil
+}
+
+// ParseS3Target разбирает адрес s3://bucket/prefix на бакет и префикс ключей
+func ParseS3Target(target string) (string, string, error) {
+	u, err := url.Parse(target)
+	if err != nil || u.Scheme != "s3" || u.Host == "" {
+		return "", "", fmt.Errorf("invalid S3 target %q (expected s3://bucket/prefix)", target)
+	}
+	return u.Host, strings.Trim(u.Path, "/"), nil
+}
+
+// String возвращает адрес хранилища в виде s3://bucket/prefix
+func (s *S3) String() string {
+	if s.prefix == "" {
+		return "s3://" + s.bucket
+	}
+	return "s3://" + s.bucket + "/" + s.prefix
+}
+
+// Put загружает объект с ключом key (относительно префикса). Тело читается дважды:
+// для подписи и для отправки, поэтому нужен io.ReadSeeker
+func (s *S3) Put(ctx context.Context, key, contentType string, body io.ReadSeeker) error {
+	hash := sha256.New()
+	size, err := io.Copy(hash, body)
+	if err != nil {
+		return fmt.Errorf("failed to read object %s: %w", key, err)
+	}
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read object %s: %w", key, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), io.NopCloser(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, hex.EncodeToString(hash.Sum(nil)), time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to upload %s: HTTP %d: %s", key, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// objectURL возвращает адрес объекта: https://endpoint/bucket/key при path-style адресации
+// (MinIO и большинство совместимых хранилищ) или https://bucket.endpoint/key
+func (s *S3) objectURL(key string) string {
+	fullKey := key
+	if s.prefix != "" {
+		fullKey = s.prefix + "/" + key
+	}
+
+	u := *s.endpoint
+	if s.pathStyle {
+		u.Path = path.Join("/", u.Path, s.bucket, fullKey)
+	} else {
+		u.Host = s.bucket + "." + u.Host
+		u.Path = path.Join("/", u.Path, fullKey)
+	}
+	u.RawPath = encodePath(u.Path)
+	return u.String()
+}
+
+// sign подписывает запрос по AWS Signature Version 4
+func (s *S3) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + s.region + "/" + s3Service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), day)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+// hmacSHA256 вычисляет HMAC-SHA256 данных data с ключом key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// encodePath кодирует путь объекта так, как этого требует подпись S3: все символы, кроме
+// букв, цифр, "-", "_", ".", "~" и разделителя "/", заменяются на %XX
+func encodePath(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' ||
+			c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,323 @@
+// internal/adapter/backup/backup.go
+package backup
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"rsshub/internal/core/domain"
+)
+
+// Version версия формата резервной копии JSON
+const Version = 1
+
+// Format формат выгрузки лент
+type Format string
+
+const (
+	FormatJSON Format = "json" // Все настройки лент и правила фильтров; читается обратно без потерь
+	FormatOPML Format = "opml" // Только подписки и папки, для других читалок
+)
+
+// ParseFormat разбирает название формата выгрузки лент
+func ParseFormat(s string) (Format, error) {
+	switch format := Format(strings.ToLower(strings.TrimSpace(s))); format {
+	case FormatJSON, FormatOPML:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown feeds format %q (expected json or opml)", s)
+	}
+}
+
+// document резервная копия JSON
+type document struct {
+	Version    int            `json:"version"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Feeds      []feedRecord   `json:"feeds"`
+	Filters    []filterRecord `json:"filters"`
+}
+
+// feedRecord лента в резервной копии. Длительности записываются строками ("30s", "6h"),
+// чтобы файл можно было править руками
+type feedRecord struct {
+	Name           string            `json:"name"`
+	URL            string            `json:"url"`
+	Enabled        bool              `json:"enabled"`
+	DisabledReason string            `json:"disabled_reason,omitempty"`
+	Priority       string            `json:"priority"`
+	Tags           []string          `json:"tags,omitempty"`
+	Folder         string            `json:"folder,omitempty"`
+	Languages      []string          `json:"languages,omitempty"`
+	Schedule       string            `json:"schedule,omitempty"`
+	Interval       string            `json:"interval,omitempty"`
+	Timeout        string            `json:"timeout,omitempty"`
+	ProxyURL       string            `json:"proxy_url,omitempty"`
+	TLSInsecure    bool              `json:"tls_insecure,omitempty"`
+	Headers        map[string]string `json:"headers,omitempty"`
+	Mirrors        []string          `json:"mirrors,omitempty"`
+	Auth           *authRecord       `json:"auth,omitempty"` // Только при выгрузке с учетными данными
+}
+
+// authRecord учетные данные ленты в резервной копии (открытым текстом)
+type authRecord struct {
+	Username    string `json:"username,omitempty"`
+	Password    string `json:"password,omitempty"`
+	BearerToken string `json:"bearer_token,omitempty"`
+}
+
+// filterRecord правило фильтра в резервной копии
+type filterRecord struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+	Action     string `json:"action"`
+}
+
+// Write записывает ленты и правила фильтров в w в указанном формате. Учетные данные лент
+// попадают в JSON, только если они заполнены в b (см. rsshub export --with-credentials)
+func Write(w io.Writer, format Format, b *domain.Backup) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, b)
+	case FormatOPML:
+		return writeOPML(w, b.Feeds)
+	default:
+		return fmt.Errorf("unknown feeds format %q", format)
+	}
+}
+
+// writeJSON записывает резервную копию JSON
+func writeJSON(w io.Writer, b *domain.Backup) error {
+	doc := document{
+		Version:    Version,
+		ExportedAt: time.Now().UTC().Truncate(time.Second),
+		Feeds:      make([]feedRecord, 0, len(b.Feeds)),
+		Filters:    make([]filterRecord, 0, len(b.Filters)),
+	}
+
+	for _, feed := range b.Feeds {
+		doc.Feeds = append(doc.Feeds, newFeedRecord(feed))
+	}
+	for _, rule := range b.Filters {
+		doc.Filters = append(doc.Filters, newFilterRecord(rule))
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// newFeedRecord создает запись ленты; причина отключения сохраняется только у отключенной ленты
+func newFeedRecord(feed *domain.Feed) feedRecord {
+	record := feedRecord{
+		Name:        feed.Name,
+		URL:         feed.URL,
+		Enabled:     feed.Enabled,
+		Priority:    feed.Priority.String(),
+		Tags:        feed.Tags,
+		Folder:      feed.Folder,
+		Languages:   feed.Languages,
+		Schedule:    feed.Schedule,
+		Interval:    formatDuration(feed.Interval),
+		Timeout:     formatDuration(feed.Timeout),
+		ProxyURL:    feed.ProxyURL,
+		TLSInsecure: feed.TLSInsecure,
+		Headers:     feed.Headers,
+		Mirrors:     feed.Mirrors,
+	}
+	if !feed.Enabled {
+		record.DisabledReason = feed.DisabledReason
+	}
+	if feed.Auth != nil {
+		record.Auth = &authRecord{Username: feed.Auth.Username, Password: feed.Auth.Password, BearerToken: feed.Auth.BearerToken}
+	}
+	return record
+}
+
+// newFilterRecord создает запись правила фильтра
+func newFilterRecord(rule *domain.FilterRule) filterRecord {
+	return filterRecord{Name: rule.Name, Expression: rule.Expression, Action: string(rule.Action)}
+}
+
+// formatDuration записывает длительность строкой; 0 - пустая строка (значение по умолчанию)
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
+
+// Read читает резервную копию JSON и проверяет ее: неизвестные поля, ленты без имени или URL,
+// неверные приоритеты, длительности, расписания и языки - ошибка с номером ленты или правила
+func Read(r io.Reader) (*domain.Backup, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+
+	var doc document
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid backup file: %w", err)
+	}
+	if doc.Version != Version {
+		return nil, fmt.Errorf("unsupported backup version %d (expected %d)", doc.Version, Version)
+	}
+
+	b := &domain.Backup{Feeds: make([]*domain.Feed, 0, len(doc.Feeds)), Filters: make([]*domain.FilterRule, 0, len(doc.Filters))}
+	for i, record := range doc.Feeds {
+		feed, err := record.feed()
+		if err != nil {
+			return nil, fmt.Errorf("feed #%d (%s): %w", i+1, record.Name, err)
+		}
+		b.Feeds = append(b.Feeds, feed)
+	}
+
+	for i, record := range doc.Filters {
+		rule, err := record.rule()
+		if err != nil {
+			return nil, fmt.Errorf("filter #%d (%s): %w", i+1, record.Name, err)
+		}
+		b.Filters = append(b.Filters, rule)
+	}
+	return b, nil
+}
+
+// rule проверяет запись и создает по ней правило фильтра
+func (r filterRecord) rule() (*domain.FilterRule, error) {
+	if r.Name == "" || r.Expression == "" {
+		return nil, fmt.Errorf("name and expression are required")
+	}
+	action, err := domain.ParseFilterAction(r.Action)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := domain.ParseExpression(r.Expression); err != nil {
+		return nil, fmt.Errorf("invalid expression: %w", err)
+	}
+	return &domain.FilterRule{Name: r.Name, Expression: r.Expression, Action: action}, nil
+}
+
+// feed проверяет запись и создает по ней ленту
+func (r feedRecord) feed() (*domain.Feed, error) {
+	if strings.TrimSpace(r.Name) == "" || strings.TrimSpace(r.URL) == "" {
+		return nil, fmt.Errorf("name and url are required")
+	}
+
+	priority, err := domain.ParseFeedPriority(r.Priority)
+	if err != nil {
+		return nil, err
+	}
+	languages, err := domain.ParseLanguages(strings.Join(r.Languages, ","))
+	if err != nil {
+		return nil, err
+	}
+	interval, err := parseDuration("interval", r.Interval, time.Second)
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := parseDuration("timeout", r.Timeout, time.Millisecond)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &domain.Feed{
+		Name:        r.Name,
+		URL:         r.URL,
+		Enabled:     r.Enabled,
+		Priority:    priority,
+		Tags:        domain.ParseTags(strings.Join(r.Tags, ",")),
+		Folder:      domain.ParseFolder(r.Folder),
+		Languages:   languages,
+		Interval:    interval,
+		Timeout:     timeout,
+		ProxyURL:    r.ProxyURL,
+		TLSInsecure: r.TLSInsecure,
+		Headers:     r.Headers,
+		Mirrors:     r.Mirrors,
+	}
+	if !r.Enabled {
+		feed.DisabledReason = r.DisabledReason
+	}
+	if r.Schedule != "" {
+		schedule, err := domain.ParseCron(r.Schedule)
+		if err != nil {
+			return nil, err
+		}
+		feed.Schedule = schedule.String()
+	}
+	if r.Auth != nil {
+		if r.Auth.BearerToken != "" && (r.Auth.Username != "" || r.Auth.Password != "") {
+			return nil, fmt.Errorf("auth must have either username/password or bearer_token, not both")
+		}
+		feed.Auth = &domain.FeedAuth{Username: r.Auth.Username, Password: r.Auth.Password, BearerToken: r.Auth.BearerToken}
+	}
+	return feed, nil
+}
+
+// parseDuration разбирает длительность записи; пустая строка - 0 (значение по умолчанию)
+func parseDuration(field, s string, min time.Duration) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < min {
+		return 0, fmt.Errorf("invalid %s: %s (expected a duration of at least %s)", field, s, min)
+	}
+	return d, nil
+}
+
+// opmlDocument документ OPML 2.0 с подписками
+type opmlDocument struct {
+	XMLName xml.Name       `xml:"opml"`
+	Version string         `xml:"version,attr"`
+	Title   string         `xml:"head>title"`
+	Body    []*opmlOutline `xml:"body>outline"`
+}
+
+// opmlOutline подписка (с xmlUrl) или папка с вложенными outline
+type opmlOutline struct {
+	Text     string         `xml:"text,attr"`
+	Title    string         `xml:"title,attr,omitempty"`
+	Type     string         `xml:"type,attr,omitempty"`
+	XMLURL   string         `xml:"xmlUrl,attr,omitempty"`
+	Outlines []*opmlOutline `xml:"outline"`
+}
+
+// writeOPML записывает подписки документом OPML; папки лент становятся вложенными outline,
+// как их читает rsshub import --from opml
+func writeOPML(w io.Writer, feeds []*domain.Feed) error {
+	doc := opmlDocument{Version: "2.0", Title: "rsshub subscriptions"}
+	for _, feed := range feeds {
+		outlines := &doc.Body
+		if feed.Folder != "" {
+			for _, level := range strings.Split(feed.Folder, domain.FolderSeparator) {
+				outlines = &folderOutline(outlines, level).Outlines
+			}
+		}
+		*outlines = append(*outlines, &opmlOutline{Text: feed.Name, Title: feed.Name, Type: "rss", XMLURL: feed.URL})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// folderOutline находит в outlines папку с именем name или добавляет ее
+func folderOutline(outlines *[]*opmlOutline, name string) *opmlOutline {
+	for _, outline := range *outlines {
+		if outline.XMLURL == "" && outline.Text == name {
+			return outline
+		}
+	}
+	folder := &opmlOutline{Text: name}
+	*outlines = append(*outlines, folder)
+	return folder
+}
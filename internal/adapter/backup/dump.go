@@ -0,0 +1,260 @@
+// internal/adapter/backup/dump.go
+package backup
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"rsshub/internal/core/domain"
+)
+
+// DumpFormat имя формата в заголовке дампа
+const DumpFormat = "rsshub-dump"
+
+// DumpVersion версия формата дампа
+const DumpVersion = 1
+
+// dumpHeader первая запись дампа
+type dumpHeader struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// dumpRecord запись дампа: заполнено ровно одно поле
+type dumpRecord struct {
+	Feed    *feedRecord    `json:"feed,omitempty"`
+	Filter  *filterRecord  `json:"filter,omitempty"`
+	Article *articleRecord `json:"article,omitempty"`
+}
+
+// articleRecord статья в дампе вместе с ее состоянием; лента указывается по имени
+type articleRecord struct {
+	Feed        string     `json:"feed"`
+	Title       string     `json:"title"`
+	Link        string     `json:"link"`
+	GUID        string     `json:"guid,omitempty"`
+	PublishedAt time.Time  `json:"published_at"`
+	Description string     `json:"description"`
+	Language    string     `json:"language,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ModifiedAt  *time.Time `json:"modified_at,omitempty"`
+	ReadAt      *time.Time `json:"read_at,omitempty"`
+	SavedAt     *time.Time `json:"saved_at,omitempty"`
+
+	Podcast *domain.PodcastInfo `json:"podcast,omitempty"`
+	Media   []domain.MediaItem  `json:"media,omitempty"`
+}
+
+// DumpWriter записывает полную резервную копию базы данных (rsshub backup): сжатый gzip поток
+// JSON записей - заголовок, ленты со всеми настройками и учетными данными, правила фильтров,
+// затем статьи. Формат не зависит от хранилища, поэтому дамп Postgres читается любым бэкендом
+type DumpWriter struct {
+	gz       *gzip.Writer
+	enc      *json.Encoder
+	articles bool // Статьи уже записывались: ленты и правила после них не принимаются
+}
+
+// NewDumpWriter начинает дамп в w и записывает его заголовок
+func NewDumpWriter(w io.Writer) (*DumpWriter, error) {
+	gz := gzip.NewWriter(w)
+	d := &DumpWriter{gz: gz, enc: json.NewEncoder(gz)}
+	header := dumpHeader{Format: DumpFormat, Version: DumpVersion, CreatedAt: time.Now().UTC().Truncate(time.Second)}
+	if err := d.enc.Encode(header); err != nil {
+		return nil, fmt.Errorf("failed to write dump header: %w", err)
+	}
+	return d, nil
+}
+
+// WriteBackup записывает ленты и правила фильтров; вызывается до WriteArticles
+func (d *DumpWriter) WriteBackup(b *domain.Backup) error {
+	if d.articles {
+		return fmt.Errorf("feeds must be written before articles")
+	}
+	for _, feed := range b.Feeds {
+		record := newFeedRecord(feed)
+		if err := d.enc.Encode(dumpRecord{Feed: &record}); err != nil {
+			return err
+		}
+	}
+	for _, rule := range b.Filters {
+		record := newFilterRecord(rule)
+		if err := d.enc.Encode(dumpRecord{Filter: &record}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteArticles записывает статьи ленты feedName
+func (d *DumpWriter) WriteArticles(feedName string, articles []*domain.Article) error {
+	d.articles = true
+	for _, a := range articles {
+		record := &articleRecord{
+			Feed:        feedName,
+			Title:       a.Title,
+			Link:        a.Link,
+			GUID:        a.GUID,
+			PublishedAt: a.PublishedAt,
+			Description: a.Description,
+			Language:    a.Language,
+			CreatedAt:   a.CreatedAt,
+			UpdatedAt:   a.UpdatedAt,
+			ModifiedAt:  a.ModifiedAt,
+			ReadAt:      a.ReadAt,
+			SavedAt:     a.SavedAt,
+			Podcast:     a.Podcast,
+			Media:       a.Media,
+		}
+		if err := d.enc.Encode(dumpRecord{Article: record}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close дописывает сжатый поток; w не закрывается
+func (d *DumpWriter) Close() error {
+	return d.gz.Close()
+}
+
+// DumpReader читает дамп rsshub backup: ленты и правила фильтров сразу (Backup), статьи - частями
+// (NextArticles), чтобы большой дамп не читался в память целиком
+type DumpReader struct {
+	dec     *json.Decoder
+	backup  *domain.Backup
+	pending *articleRecord // Первая статья, прочитанная вместе с лентами
+	record  int            // Номер последней прочитанной записи для сообщений об ошибках
+}
+
+// OpenDump проверяет заголовок дампа и читает его ленты и правила фильтров
+func OpenDump(r io.Reader) (*DumpReader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dump file: %w", err)
+	}
+	d := &DumpReader{dec: json.NewDecoder(gz), backup: &domain.Backup{}}
+	d.dec.DisallowUnknownFields()
+
+	var header dumpHeader
+	if err := d.dec.Decode(&header); err != nil {
+		return nil, fmt.Errorf("invalid dump file: %w", err)
+	}
+	if header.Format != DumpFormat {
+		return nil, fmt.Errorf("invalid dump file: not an rsshub backup")
+	}
+	if header.Version != DumpVersion {
+		return nil, fmt.Errorf("unsupported dump version %d (expected %d)", header.Version, DumpVersion)
+	}
+
+	for {
+		record, err := d.next()
+		if errors.Is(err, io.EOF) {
+			return d, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case record.Feed != nil:
+			feed, err := record.Feed.feed()
+			if err != nil {
+				return nil, fmt.Errorf("dump record #%d: feed %s: %w", d.record, record.Feed.Name, err)
+			}
+			d.backup.Feeds = append(d.backup.Feeds, feed)
+		case record.Filter != nil:
+			rule, err := record.Filter.rule()
+			if err != nil {
+				return nil, fmt.Errorf("dump record #%d: filter %s: %w", d.record, record.Filter.Name, err)
+			}
+			d.backup.Filters = append(d.backup.Filters, rule)
+		default:
+			d.pending = record.Article
+			return d, nil
+		}
+	}
+}
+
+// Backup возвращает ленты и правила фильтров дампа
+func (d *DumpReader) Backup() *domain.Backup {
+	return d.backup
+}
+
+// NextArticles возвращает до limit следующих статей дампа; после последней - io.EOF
+func (d *DumpReader) NextArticles(limit int) ([]*domain.DigestEntry, error) {
+	var entries []*domain.DigestEntry
+	for len(entries) < limit {
+		record := d.pending
+		d.pending = nil
+		if record == nil {
+			next, err := d.next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if next.Article == nil {
+				return nil, fmt.Errorf("dump record #%d: feeds and filters must precede articles", d.record)
+			}
+			record = next.Article
+		}
+		if record.Feed == "" || record.Link == "" {
+			return nil, fmt.Errorf("dump record #%d: article must have a feed and a link", d.record)
+		}
+		entries = append(entries, &domain.DigestEntry{FeedName: record.Feed, Article: record.article()})
+	}
+
+	if len(entries) == 0 {
+		return nil, io.EOF
+	}
+	return entries, nil
+}
+
+// next читает следующую запись; в записи должно быть заполнено ровно одно поле
+func (d *DumpReader) next() (*dumpRecord, error) {
+	var record dumpRecord
+	if err := d.dec.Decode(&record); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("invalid dump file after record #%d: %w", d.record, err)
+	}
+	d.record++
+
+	filled := 0
+	for _, ok := range []bool{record.Feed != nil, record.Filter != nil, record.Article != nil} {
+		if ok {
+			filled++
+		}
+	}
+	if filled != 1 {
+		return nil, fmt.Errorf("dump record #%d: expected exactly one of feed, filter or article", d.record)
+	}
+	return &record, nil
+}
+
+// article создает статью по записи; ID, ленту и ключ уникальности назначает восстановление
+func (r *articleRecord) article() *domain.Article {
+	return &domain.Article{
+		Title:       r.Title,
+		Link:        r.Link,
+		GUID:        r.GUID,
+		PublishedAt: r.PublishedAt,
+		Description: r.Description,
+		Language:    r.Language,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+		ModifiedAt:  r.ModifiedAt,
+		ReadAt:      r.ReadAt,
+		SavedAt:     r.SavedAt,
+		Podcast:     r.Podcast,
+		Media:       r.Media,
+	}
+}
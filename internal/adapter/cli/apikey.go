@@ -0,0 +1,105 @@
+// internal/adapter/cli/apikey.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/logger"
+)
+
+// handleAPIKey управляет ключами доступа к HTTP API: create, list, revoke
+func (c *CLI) handleAPIKey(ctx context.Context, args []string) error {
+	var name string
+	fs := newFlagSet()
+	fs.String("--name", &name)
+	action, err := fs.parseAction(args[2:])
+	if err != nil {
+		return err
+	}
+	if action == "" {
+		return usageErrorf("apikey requires an action: create, list or revoke")
+	}
+
+	switch action {
+	case "create", "revoke":
+		if name == "" {
+			return usageErrorf("--name is required")
+		}
+
+		if action == "create" {
+			return c.createAPIKey(ctx, name)
+		}
+		if err := c.db.RevokeAPIKey(ctx, name); err != nil {
+			return err
+		}
+		logger.Success("Revoked API key: %s", name)
+		return nil
+	case "list":
+		if name != "" {
+			return usageErrorf("--name is not supported by apikey list")
+		}
+		return c.listAPIKeys(ctx)
+	default:
+		return usageErrorf("unknown apikey action: %s (expected create, list or revoke)", action)
+	}
+}
+
+// createAPIKey генерирует и сохраняет ключ; сам ключ выводится один раз и больше нигде не хранится
+func (c *CLI) createAPIKey(ctx context.Context, name string) error {
+	key, secret, err := domain.NewAPIKey(name)
+	if err != nil {
+		return err
+	}
+
+	if err := c.db.CreateAPIKey(ctx, key); err != nil {
+		return err
+	}
+
+	logger.Success("Created API key %s; store it now, it will not be shown again", name)
+	fmt.Println(secret)
+	return nil
+}
+
+// listAPIKeys выводит таблицу ключей без самих ключей
+func (c *CLI) listAPIKeys(ctx context.Context) error {
+	keys, err := c.db.GetAPIKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get API keys: %w", err)
+	}
+
+	if len(keys) == 0 {
+		fmt.Println("No API keys found")
+		return nil
+	}
+
+	fmt.Println("# API Keys")
+	fmt.Println()
+
+	for i, key := range keys {
+		status := ""
+		if key.RevokedAt != nil {
+			status = " (revoked " + key.RevokedAt.Format("2006-01-02 15:04") + ")"
+		}
+		fmt.Printf("%d. Name: %s%s\n", i+1, key.Name, status)
+		fmt.Printf("   Key: %s...\n", key.Prefix)
+		fmt.Printf("   Created: %s\n", key.CreatedAt.Format("2006-01-02 15:04"))
+		fmt.Printf("   Last used: %s\n", formatOptionalTime(key.LastUsedAt))
+		if key.FeverHash == "" {
+			fmt.Println("   Fever: not available, recreate the key to use it with Fever clients")
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// formatOptionalTime форматирует необязательное время, "never" если его нет
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return "never"
+	}
+	return t.Format("2006-01-02 15:04")
+}
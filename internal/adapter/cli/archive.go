@@ -0,0 +1,267 @@
+// internal/adapter/cli/archive.go
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"rsshub/internal/adapter/archive"
+	"rsshub/internal/adapter/export"
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+)
+
+// ARCHIVE_LAST_KEY_PREFIX префикс настройки агрегатора со временем последней выгрузки в хранилище;
+// за ним следует адрес хранилища (и лента, если выгружалась одна)
+const ARCHIVE_LAST_KEY_PREFIX = "archive_last:"
+
+// archiveContentTypes MIME типы файлов выгрузки по формату
+var archiveContentTypes = map[export.Format]string{
+	export.FormatJSON:     "application/json",
+	export.FormatMarkdown: "text/markdown; charset=utf-8",
+	export.FormatCSV:      "text/csv; charset=utf-8",
+	export.FormatRSS:      "application/rss+xml",
+}
+
+// archiveExtensions расширения файлов выгрузки по формату
+var archiveExtensions = map[export.Format]string{
+	export.FormatJSON:     "json",
+	export.FormatMarkdown: "md",
+	export.FormatCSV:      "csv",
+	export.FormatRSS:      "xml",
+}
+
+// archiveStats итоги выгрузки в хранилище
+type archiveStats struct {
+	articles         int
+	exports          int
+	enclosures       int
+	failedEnclosures int
+}
+
+// handleArchive выгружает статьи, добавленные после прошлой выгрузки, и их вложения
+// в S3 совместимое хранилище (--to s3://bucket/prefix)
+func (c *CLI) handleArchive(ctx context.Context, args []string) error {
+	var target, feedName string
+	format := export.FormatJSON
+	full, withEnclosures := false, true
+
+	fs := newFlagSet()
+	fs.String("--to", &target)
+	fs.String("--feed-name", &feedName)
+	fs.Func("--format", func(value string) (err error) {
+		format, err = export.ParseFormat(value)
+		return err
+	})
+	fs.Bool("--full", &full)
+	fs.BoolFunc("--no-enclosures", func() { withEnclosures = false })
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	if target == "" {
+		return usageErrorf("--to is required (e.g. --to s3://bucket/rsshub)")
+	}
+	if _, _, err := archive.ParseS3Target(target); err != nil {
+		return usageError(err)
+	}
+	if feedName != "" {
+		if _, err := c.db.GetFeedByName(ctx, feedName); err != nil {
+			return err
+		}
+	}
+
+	store, err := archive.NewS3(&c.config.S3, target)
+	if err != nil {
+		return err
+	}
+
+	// Время прошлой выгрузки хранится отдельно для каждого хранилища и ленты
+	stateKey := ARCHIVE_LAST_KEY_PREFIX + store.String()
+	if feedName != "" {
+		stateKey += "#" + feedName
+	}
+	var since time.Time
+	if !full {
+		since = c.lastArchived(ctx, stateKey)
+	}
+	until := time.Now()
+
+	// Ctrl+C прерывает выгрузку; время выгрузки при этом не сохраняется, и следующий запуск начнется заново
+	entries, err := c.db.GetArticlesSince(ctx, since, 0)
+	if err != nil {
+		return err
+	}
+	byFeed := make(map[string][]*domain.Article)
+	for _, entry := range entries {
+		if !entry.Article.CreatedAt.Before(until) || (feedName != "" && entry.FeedName != feedName) {
+			continue
+		}
+		byFeed[entry.FeedName] = append(byFeed[entry.FeedName], entry.Article)
+	}
+
+	stats := &archiveStats{}
+	names := make([]string, 0, len(byFeed))
+	for name := range byFeed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := c.archiveFeed(ctx, store, name, byFeed[name], format, since, withEnclosures, stats); err != nil {
+			return err
+		}
+	}
+
+	if err := c.db.SetAggregatorSetting(ctx, stateKey, until.Format(time.RFC3339Nano)); err != nil {
+		return fmt.Errorf("failed to save archive time: %w", err)
+	}
+
+	if stats.articles == 0 {
+		logger.Info("No new articles to archive to %s", store)
+		return nil
+	}
+	logger.Success("Archived %d articles of %d feeds to %s (%d enclosures)", stats.articles, stats.exports, store, stats.enclosures)
+	if stats.failedEnclosures > 0 {
+		logger.Warn("%d enclosures could not be archived, see the log above", stats.failedEnclosures)
+	}
+	return nil
+}
+
+// lastArchived возвращает время прошлой выгрузки (нулевое, если выгрузки еще не было)
+func (c *CLI) lastArchived(ctx context.Context, stateKey string) time.Time {
+	value, err := c.db.GetAggregatorSetting(ctx, stateKey)
+	if err != nil || value == "" {
+		return time.Time{}
+	}
+	last, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		logger.Warn("Invalid %s setting %q: %v", stateKey, value, err)
+		return time.Time{}
+	}
+	return last
+}
+
+// archiveFeed выгружает статьи ленты одним файлом <лента>/articles-<начало периода>.<формат>
+// и загружает их вложения в <лента>/enclosures/<id статьи>/. Повторная выгрузка того же
+// периода перезаписывает файл, поэтому прерванную выгрузку можно просто повторить
+func (c *CLI) archiveFeed(ctx context.Context, store *archive.S3, feedName string, articles []*domain.Article,
+	format export.Format, since time.Time, withEnclosures bool, stats *archiveStats) error {
+	// Имя ленты может содержать "/", который в ключе объекта означает каталог
+	dir := strings.ReplaceAll(feedName, "/", "_")
+
+	period := "full"
+	if !since.IsZero() {
+		period = since.UTC().Format("20060102T150405.000Z")
+	}
+
+	var buf bytes.Buffer
+	if err := export.Write(&buf, format, feedName, articles); err != nil {
+		return fmt.Errorf("failed to export articles of feed %s: %w", feedName, err)
+	}
+	key := dir + "/articles-" + period + "." + archiveExtensions[format]
+	if err := store.Put(ctx, key, archiveContentTypes[format], bytes.NewReader(buf.Bytes())); err != nil {
+		return err
+	}
+	stats.articles += len(articles)
+	stats.exports++
+	logger.Info("Archived %d articles of feed %s to %s/%s", len(articles), feedName, store, key)
+
+	if !withEnclosures {
+		return nil
+	}
+	fetcher, ok := c.parser.(port.EnclosureFetcher)
+	if !ok {
+		return nil
+	}
+	feed, err := c.db.GetFeedByName(ctx, feedName)
+	if err != nil {
+		logger.Warn("Skipping enclosures of feed %s: %v", feedName, err)
+		return nil
+	}
+
+	for _, article := range articles {
+		for _, media := range article.Media {
+			if media.Kind != domain.MediaKindContent {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			key := dir + "/enclosures/" + article.ID.String() + "/" + enclosureName(media.URL)
+			if err := c.archiveEnclosure(ctx, fetcher, store, feed, media.URL, key); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				logger.Warn("Failed to archive enclosure %s of feed %s: %v", media.URL, feedName, err)
+				stats.failedEnclosures++
+				continue
+			}
+			stats.enclosures++
+		}
+	}
+	return nil
+}
+
+// archiveEnclosure загружает вложение во временный файл и выгружает его в хранилище
+func (c *CLI) archiveEnclosure(ctx context.Context, fetcher port.EnclosureFetcher, store *archive.S3,
+	feed *domain.Feed, rawURL, key string) error {
+	file, err := os.CreateTemp("", "rsshub-enclosure-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	contentType, err := fetcher.FetchEnclosure(ctx, feed, rawURL, file, int64(c.config.S3.MaxEnclosureSize))
+	if err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read temporary file: %w", err)
+	}
+	return store.Put(ctx, key, contentType, file)
+}
+
+// enclosureName имя файла вложения в хранилище по последнему сегменту пути его URL
+func enclosureName(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "enclosure"
+	}
+	base := path.Base(u.Path)
+	ext := path.Ext(base)
+	name := archiveName(strings.TrimSuffix(base, ext), "enclosure")
+	if ext = archiveName(ext, ""); ext != "" {
+		name += "." + ext
+	}
+	return name
+}
+
+// archiveName приводит строку к виду, безопасному для имени файла и ключа объекта: буквы, цифры,
+// "-" и "_"; пустой результат заменяется на fallback
+func archiveName(s, fallback string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "-"):
+			b.WriteByte('-')
+		}
+	}
+	if name := strings.Trim(b.String(), "-"); name != "" {
+		return name
+	}
+	return fallback
+}
@@ -0,0 +1,230 @@
+// internal/adapter/cli/audiodigest.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"rsshub/internal/adapter/export"
+	"rsshub/internal/adapter/tts"
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	aggregator "rsshub/internal/core/service"
+	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/utils"
+)
+
+const (
+	// DEFAULT_AUDIO_DIGEST_SINCE за какой период audio-digest берет статьи без --since
+	DEFAULT_AUDIO_DIGEST_SINCE = 24 * time.Hour
+
+	// MAX_AUDIO_DIGEST_EPISODES сколько последних эпизодов попадает в ленту подкаста
+	MAX_AUDIO_DIGEST_EPISODES = 100
+
+	// MAX_AUDIO_SUMMARY_LENGTH максимальная длина озвучиваемого описания статьи (в символах)
+	MAX_AUDIO_SUMMARY_LENGTH = 600
+
+	// AUDIO_DIGEST_FEED имя файла ленты подкаста в каталоге аудио-дайджеста
+	AUDIO_DIGEST_FEED = "feed.xml"
+)
+
+// handleAudioDigest озвучивает непрочитанные статьи за период по тегам, записывает MP3 файлы
+// и обновляет ленту подкаста с ними
+func (c *CLI) handleAudioDigest(ctx context.Context, args []string) error {
+	cfg := c.config.AudioDigest
+	since := DEFAULT_AUDIO_DIGEST_SINCE
+	perTag := cfg.ArticlesPerTag
+
+	fs := newFlagSet()
+	fs.PositiveDuration("--since", &since)
+	fs.PositiveInt("--per-tag", &perTag)
+	fs.String("--output", &cfg.Dir)
+	fs.String("--url", &cfg.PublicURL)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	if cfg.Dir == "" {
+		return usageErrorf("--output is required when CLI_APP_AUDIO_DIGEST_DIR is empty")
+	}
+
+	synthesizer, err := tts.New(&c.config.TTS)
+	if err != nil {
+		return err
+	}
+
+	digester, err := aggregator.NewDigester(c.db, nil, nil, aggregator.GroupByTag, 0)
+	if err != nil {
+		return err
+	}
+	digest, err := digester.BuildUnread(ctx, time.Now().Add(-since), perTag)
+	if err != nil {
+		return fmt.Errorf("failed to build digest: %w", err)
+	}
+	if digest.Total() == 0 {
+		logger.Info("No unread articles in the last %s, audio digest not generated", since)
+		return nil
+	}
+
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create audio digest directory: %w", err)
+	}
+
+	// Ctrl+C прерывает озвучивание; уже записанные эпизоды остаются в ленте
+	day := digest.To.In(c.location).Format("2006-01-02")
+	generated := 0
+	for _, group := range digest.Groups {
+		name := day + "-" + archiveName(group.Name, "tag")
+		title := fmt.Sprintf("RSSHub digest: %s, %s", group.Name, day)
+		if err := c.writeEpisode(ctx, synthesizer, cfg.Dir, name, audioScript(title, group)); err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			logger.Error("Failed to generate audio digest for %s: %v", group.Name, err)
+			continue
+		}
+		generated++
+		logger.Info("Generated audio digest for %s: %d articles", group.Name, len(group.Entries))
+	}
+
+	if err := writePodcastFeed(cfg.Dir, cfg.PublicURL); err != nil {
+		return err
+	}
+	if cfg.PublicURL == "" {
+		logger.Warn("CLI_APP_AUDIO_DIGEST_URL is not set: %s links episodes by file name", AUDIO_DIGEST_FEED)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if generated == 0 {
+		return fmt.Errorf("failed to generate audio digest")
+	}
+	logger.Success("Generated %d audio digest episodes in %s", generated, cfg.Dir)
+	return nil
+}
+
+// writeEpisode озвучивает текст эпизода в <name>.mp3 и сохраняет текст рядом в <name>.txt.
+// Аудио сначала пишется во временный файл, чтобы лента не ссылалась на недописанный эпизод
+func (c *CLI) writeEpisode(ctx context.Context, synthesizer port.SpeechSynthesizer, dir, name, script string) error {
+	tmp, err := os.CreateTemp(dir, ".episode-*")
+	if err != nil {
+		return fmt.Errorf("failed to create audio file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := synthesizer.Synthesize(ctx, script, tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write audio file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("failed to write audio file: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, name+".txt"), []byte(script), 0o644); err != nil {
+		return fmt.Errorf("failed to write transcript: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, name+".mp3")); err != nil {
+		return fmt.Errorf("failed to write audio file: %w", err)
+	}
+	return nil
+}
+
+// audioScript формирует текст эпизода: первая строка - заголовок, затем статьи с именем ленты
+// и кратким содержанием
+func audioScript(title string, group domain.DigestGroup) string {
+	var b strings.Builder
+	b.WriteString(title + ".\n\n")
+	if len(group.Entries) == 1 {
+		b.WriteString("One unread article.\n\n")
+	} else {
+		fmt.Fprintf(&b, "%d unread articles.\n\n", len(group.Entries))
+	}
+
+	for i, e := range group.Entries {
+		fmt.Fprintf(&b, "Article %d, from %s: %s.\n", i+1, e.FeedName, strings.TrimRight(strings.TrimSpace(e.Article.Title), ".!?"))
+		if summary := audioSummary(e.Article.Description); summary != "" {
+			b.WriteString(summary + "\n")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("That's all for " + group.Name + ".\n")
+	return b.String()
+}
+
+// audioSummary возвращает описание статьи без HTML разметки, обрезанное по границе предложения
+// или слова до MAX_AUDIO_SUMMARY_LENGTH
+func audioSummary(description string) string {
+	return truncateText(utils.StripHTML(description), MAX_AUDIO_SUMMARY_LENGTH)
+}
+
+// writePodcastFeed обновляет ленту подкаста по MP3 файлам каталога: заголовок эпизода - первая
+// строка его текста, описание - остальной текст
+func writePodcastFeed(dir, publicURL string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.mp3"))
+	if err != nil {
+		return fmt.Errorf("failed to list audio digest episodes: %w", err)
+	}
+
+	var episodes []export.Episode
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		name := strings.TrimSuffix(filepath.Base(file), ".mp3")
+
+		episode := export.Episode{
+			Title:       name,
+			URL:         url.PathEscape(filepath.Base(file)),
+			Size:        info.Size(),
+			ContentType: "audio/mpeg",
+			PublishedAt: info.ModTime(),
+		}
+		if publicURL != "" {
+			episode.URL = strings.TrimRight(publicURL, "/") + "/" + episode.URL
+		}
+		if script, err := os.ReadFile(filepath.Join(dir, name+".txt")); err == nil {
+			title, description, _ := strings.Cut(string(script), "\n")
+			episode.Title = strings.TrimSuffix(strings.TrimSpace(title), ".")
+			episode.Description = strings.TrimSpace(description)
+		}
+		episodes = append(episodes, episode)
+	}
+
+	sort.Slice(episodes, func(i, j int) bool { return episodes[i].PublishedAt.After(episodes[j].PublishedAt) })
+	if len(episodes) > MAX_AUDIO_DIGEST_EPISODES {
+		episodes = episodes[:MAX_AUDIO_DIGEST_EPISODES]
+	}
+
+	tmp, err := os.CreateTemp(dir, ".feed-*")
+	if err != nil {
+		return fmt.Errorf("failed to write podcast feed: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	err = export.WritePodcast(tmp, "RSSHub audio digest", publicURL,
+		"Daily audio digests of unread articles by tag, generated by RSSHub", episodes)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0o644)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write podcast feed: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, AUDIO_DIGEST_FEED)); err != nil {
+		return fmt.Errorf("failed to write podcast feed: %w", err)
+	}
+	return nil
+}
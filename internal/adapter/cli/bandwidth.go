@@ -0,0 +1,85 @@
+// internal/adapter/cli/bandwidth.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/config"
+	"rsshub/internal/platform/logger"
+)
+
+// bandwidthBudget разбирает лимиты трафика из конфигурации; некорректный лимит не применяется
+func bandwidthBudget(cfg *config.AggregatorConfig) domain.BandwidthBudget {
+	var budget domain.BandwidthBudget
+	limits := []struct {
+		env   string
+		value string
+		dst   *int64
+	}{
+		{"CLI_APP_BANDWIDTH_DAILY", cfg.BandwidthDaily, &budget.Daily},
+		{"CLI_APP_BANDWIDTH_MONTHLY", cfg.BandwidthMonth, &budget.Monthly},
+	}
+	for _, l := range limits {
+		if l.value == "" {
+			continue
+		}
+		size, err := domain.ParseByteSize(l.value)
+		if err != nil {
+			logger.Warn("Ignoring invalid %s: %v", l.env, err)
+			continue
+		}
+		*l.dst = size
+	}
+	return budget
+}
+
+// feedTraffic трафик лент за текущие сутки и месяц по именам лент
+type feedTraffic struct {
+	today, month map[string]int64
+}
+
+// loadFeedTraffic читает трафик лент за текущие сутки и месяц
+func (c *CLI) loadFeedTraffic(ctx context.Context, now time.Time) (*feedTraffic, error) {
+	day, month := domain.TrafficPeriods(now)
+	today, err := c.db.GetFeedTraffic(ctx, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed traffic: %w", err)
+	}
+	monthly, err := c.db.GetFeedTraffic(ctx, month)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed traffic: %w", err)
+	}
+	return &feedTraffic{today: today, month: monthly}, nil
+}
+
+// printTrafficTotal выводит общий трафик за сутки и месяц, включая удаленные ленты, и лимиты
+func (c *CLI) printTrafficTotal(ctx context.Context, now time.Time) error {
+	day, month := domain.TrafficPeriods(now)
+	today, err := c.db.GetTrafficSince(ctx, day)
+	if err != nil {
+		return fmt.Errorf("failed to get traffic: %w", err)
+	}
+	monthly, err := c.db.GetTrafficSince(ctx, month)
+	if err != nil {
+		return fmt.Errorf("failed to get traffic: %w", err)
+	}
+
+	budget := bandwidthBudget(&c.config.Aggregator)
+	fmt.Printf("Total traffic: %s today%s, %s this month%s\n",
+		domain.FormatBytes(today), budgetSuffix(budget.Daily), domain.FormatBytes(monthly), budgetSuffix(budget.Monthly))
+	if (budget.Daily > 0 && today >= budget.Daily) || (budget.Monthly > 0 && monthly >= budget.Monthly) {
+		fmt.Println("Bandwidth budget exceeded: fetching is paused until the next period")
+	}
+	return nil
+}
+
+// budgetSuffix возвращает " of N budget" для заданного лимита
+func budgetSuffix(limit int64) string {
+	if limit <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(" of %s budget", domain.FormatBytes(limit))
+}
@@ -0,0 +1,30 @@
+// internal/adapter/cli/browser.go
+package cli
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// openBrowser открывает URL в браузере по умолчанию текущей ОС
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+
+	// Не ждем завершения браузера, только освобождаем ресурсы процесса
+	go cmd.Wait()
+	return nil
+}
@@ -2,99 +2,276 @@
 package cli
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
-	"os/signal"
+	"path"
+	"slices"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
-	rss "rsshub/internal/adapter/fetcher/http"
+	"rsshub/internal/adapter/archive"
+	"rsshub/internal/adapter/export"
+	"rsshub/internal/adapter/notifier/email"
+	"rsshub/internal/adapter/storage/memory"
+	"rsshub/internal/core/domain"
 	"rsshub/internal/core/port"
 	aggregator "rsshub/internal/core/service"
 	"rsshub/internal/platform/config"
 	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/urlnorm"
+	"rsshub/internal/platform/utils"
 )
 
 const (
 	DB_LOCK_NAME = "rsshub_fetch_lock"
+
+	// BACKFILL_LOCK_PREFIX префикс блокировки импорта архива; к нему добавляется ID ленты
+	BACKFILL_LOCK_PREFIX = "rsshub_backfill_lock_"
+
+	// DEFAULT_PAGE_SIZE размер страницы списка лент при пагинации без --num
+	DEFAULT_PAGE_SIZE = 20
+
+	// INACTIVE_FEED_AGE лента без новых статей дольше этого срока помечается в статистике неактивной
+	INACTIVE_FEED_AGE = 30 * 24 * time.Hour
 )
 
 // CLI представляет интерфейс командной строки
 type CLI struct {
 	db              port.FeedArticleRepository
+	parser          port.Parser
 	aggregator      port.Aggregator
 	config          *config.Config
 	settingsManager *aggregator.AggregatorManager
+	location        *time.Location // Часовой пояс, в котором выводятся даты статей
+	noColor         bool           // --no-color: вывод без цвета даже в терминале
 }
 
 // New создает новый CLI
 func New(db port.FeedArticleRepository, parser port.Parser, cfg *config.Config) *CLI {
+	applyLogLevel(cfg)
+
 	// Создаем агрегатор с настройками по умолчанию
 	agg := aggregator.New(db, parser, cfg.Aggregator.DefaultInterval, cfg.Aggregator.DefaultWorkers)
+	configureAggregator(agg, cfg)
 
 	return &CLI{
 		db:              db,
+		parser:          parser,
 		aggregator:      agg,
 		config:          cfg,
 		settingsManager: aggregator.NewAggregatorManager(db),
+		location:        time.Local,
 	}
 }
 
-// Run запускает CLI и обрабатывает аргументы командной строки
-func (c *CLI) Run(args []string) error {
-	if len(args) < 2 {
-		c.showHelp()
-		return fmt.Errorf("no command provided")
+// configureAggregator применяет к агрегатору настройки конфигурации и получателей
+// уведомлений; вызывается при создании CLI и при перезагрузке конфигурации
+func configureAggregator(agg *aggregator.Aggregator, cfg *config.Config) {
+	// Тихие часы, в которые циклы получения пропускаются
+	if quietHours, err := aggregator.ParseQuietHours(cfg.Aggregator.QuietHours); err != nil {
+		logger.Warn("Ignoring invalid CLI_APP_QUIET_HOURS: %v", err)
+	} else {
+		agg.SetQuietHours(quietHours)
+	}
+
+	// Ключ, по которому определяются дубликаты статей
+	if dedupMode, err := domain.ParseDedupMode(cfg.Aggregator.DedupKey); err != nil {
+		logger.Warn("Ignoring invalid CLI_APP_DEDUP_KEY: %v", err)
+	} else {
+		agg.SetDedupMode(dedupMode)
+	}
+
+	// Обновлять ли сохраненные статьи, содержимое которых изменила лента
+	if conflictMode, err := domain.ParseConflictMode(cfg.Aggregator.ArticleConflict); err != nil {
+		logger.Warn("Ignoring invalid CLI_APP_ARTICLE_CONFLICT: %v", err)
+	} else {
+		agg.SetConflictMode(conflictMode)
+	}
+
+	// Сохранять ли статью, ссылка которой уже сохранена в другой ленте
+	if crossFeedDedup, err := domain.ParseCrossFeedDedup(cfg.Aggregator.CrossFeedDedup); err != nil {
+		logger.Warn("Ignoring invalid CLI_APP_CROSS_FEED_DEDUP: %v", err)
+	} else {
+		agg.SetCrossFeedDedup(crossFeedDedup)
+	}
+
+	// Зависшие задания воркеров отменяются, чтобы цикл не ждал их бесконечно
+	agg.SetMaxJobDuration(cfg.Aggregator.MaxJobDuration)
+
+	// Самые старые статьи лент сверх лимита удаляются после каждого цикла
+	if cfg.Aggregator.MaxArticles < 0 {
+		logger.Warn("Ignoring invalid CLI_APP_MAX_ARTICLES_PER_FEED: %d (expected 0 or more)", cfg.Aggregator.MaxArticles)
+	} else {
+		// Удаленные статьи, которые лента еще отдает, сохранялись бы в следующем цикле снова как новые
+		if cfg.Aggregator.MaxArticles > 0 && cfg.Aggregator.MaxArticles < cfg.Aggregator.MaxItemsPerFeed {
+			logger.Warn("CLI_APP_MAX_ARTICLES_PER_FEED (%d) is less than CLI_APP_MAX_ITEMS_PER_FEED (%d): trimmed articles still in a feed will be saved again as new",
+				cfg.Aggregator.MaxArticles, cfg.Aggregator.MaxItemsPerFeed)
+		}
+		agg.SetMaxArticlesPerFeed(cfg.Aggregator.MaxArticles)
+	}
+
+	// Настройки set-interval и set-workers перечитываются и без уведомления, если оно потерялось
+	agg.SetSettingsCheckInterval(cfg.Aggregator.SettingsCheck)
+
+	// Зеркала лент пробуются после нескольких неудач основного URL подряд
+	agg.SetMirrorAfterFailures(cfg.Aggregator.MirrorAfter)
+
+	// Иконки лент для клиентов API обновляются раз в CLI_APP_ICON_REFRESH
+	agg.SetIconRefresh(cfg.Aggregator.IconRefresh)
+
+	// Мертвые ленты (410 Gone или ошибки дольше CLI_APP_DEAD_FEED_AFTER) отключаются с уведомлением
+	agg.SetDeadFeedAfter(cfg.Aggregator.DeadFeedAfter)
+
+	// Лимиты трафика приостанавливают получение лент до начала следующего периода
+	agg.SetBandwidthBudget(bandwidthBudget(&cfg.Aggregator))
+
+	// Что делать с циклом, если предыдущий еще не завершен
+	if overlap, err := aggregator.ParseCycleOverlap(cfg.Aggregator.CycleOverlap); err != nil {
+		logger.Warn("Ignoring invalid CLI_APP_CYCLE_OVERLAP: %v", err)
+	} else {
+		agg.SetCycleOverlap(overlap)
+	}
+
+	// Ждать ли свободного воркера, когда очередь заданий заполнена
+	if queueFull, err := aggregator.ParseQueueFullMode(cfg.Aggregator.QueueFull); err != nil {
+		logger.Warn("Ignoring invalid CLI_APP_QUEUE_FULL: %v", err)
+	} else {
+		agg.SetQueueFullMode(queueFull)
 	}
 
-	command := args[1]
+	// Каналы уведомлений о новых статьях; статьи распределяются по ним правилами уведомлений
+	agg.SetNotifiers(newNotifiers(cfg))
+
+	// Архив, в который каждая новая статья записывается файлом
+	agg.SetArchive(newArchive(cfg))
+}
 
-	switch command {
-	case "fetch":
-		return c.handleFetch()
-	case "add":
-		return c.handleAdd(args)
-	case "set-interval":
-		return c.handleSetInterval(args)
-	case "set-workers":
-		return c.handleSetWorkers(args)
-	case "list":
-		return c.handleList(args)
-	case "delete":
-		return c.handleDelete(args)
-	case "articles":
-		return c.handleArticles(args)
-	case "--help", "-h", "help":
-		c.showHelp()
+// newArchive создает архив статей на диске, если задан CLI_APP_ARCHIVE_DIR
+func newArchive(cfg *config.Config) port.ArticleArchive {
+	if cfg.Archive.Dir == "" {
+		return nil
+	}
+	a, err := archive.New(&cfg.Archive)
+	if err != nil {
+		logger.Warn("Article archive disabled: %v", err)
 		return nil
-	default:
-		c.showHelp()
-		return fmt.Errorf("unknown command: %s", command)
 	}
+	return a
 }
 
-// handleFetch запускает фоновый процесс получения RSS лент с блокировкой через БД
-func (c *CLI) handleFetch() error {
-	// Пытаемся получить блокировку в базе данных
-	locked, err := c.db.TryLock(DB_LOCK_NAME)
+// applyLogLevel задает уровень логирования из CLI_APP_LOG_LEVEL
+func applyLogLevel(cfg *config.Config) {
+	level, err := logger.ParseLevel(cfg.LogLevel)
 	if err != nil {
-		return fmt.Errorf("failed to acquire database lock: %w", err)
+		logger.Warn("Ignoring invalid CLI_APP_LOG_LEVEL: %v", err)
+		return
 	}
+	logger.SetLevel(level)
+}
 
-	if !locked {
-		logger.Info("Another instance is already running")
-		return fmt.Errorf("another instance is already running")
+// SetTimezone задает часовой пояс вывода дат статей по имени IANA (например, Europe/Moscow или UTC);
+// пустое имя оставляет локальный часовой пояс
+func (c *CLI) SetTimezone(name string) error {
+	if name == "" {
+		return nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return usageErrorf("invalid --tz %q: %v", name, err)
 	}
+	c.location = location
+	return nil
+}
 
-	// Обеспечиваем освобождение блокировки при выходе
-	defer func() {
-		if err := c.db.ReleaseLock(DB_LOCK_NAME); err != nil {
-			logger.Error("Failed to release database lock: %v", err)
+// DisableColor отключает цветной вывод команд (--no-color)
+func (c *CLI) DisableColor() {
+	c.noColor = true
+}
+
+// renderer создает оформление вывода команды; --no-color отключает цвет при любом mode
+func (c *CLI) renderer(mode colorMode, descriptionLength int) *textRenderer {
+	if c.noColor {
+		mode = colorNever
+	}
+	return newTextRenderer(mode, descriptionLength)
+}
+
+// localTime переводит время в часовой пояс вывода
+func (c *CLI) localTime(t time.Time) time.Time {
+	return t.In(c.location)
+}
+
+// localArticles переводит даты публикации статей в часовой пояс вывода перед выгрузкой
+func (c *CLI) localArticles(articles []*domain.Article) []*domain.Article {
+	for _, article := range articles {
+		article.PublishedAt = c.localTime(article.PublishedAt)
+	}
+	return articles
+}
+
+// handleFetch запускает фоновый процесс получения RSS лент.
+// Несколько экземпляров делят ленты между собой через резервирование в БД;
+// с флагом --exclusive разрешен только один экземпляр (блокировка через БД).
+// С флагом --once выполняется один цикл и команда завершается (для cron)
+func (c *CLI) handleFetch(ctx context.Context, args []string) error {
+	exclusive, once, dryRun, followPermanent, backfill := false, false, false, true, false
+
+	fs := newFlagSet()
+	fs.Bool("--exclusive", &exclusive)
+	fs.Bool("--once", &once)
+	fs.Bool("--dry-run", &dryRun)
+	fs.BoolFunc("--no-follow-permanent", func() { followPermanent = false })
+	fs.Bool("--backfill", &backfill)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	// --backfill снимает ограничение на количество элементов ленты за цикл
+	maxItems := c.config.Aggregator.MaxItemsPerFeed
+	if backfill {
+		maxItems = 0
+	}
+
+	if dryRun {
+		if !once {
+			return usageErrorf("--dry-run requires --once")
 		}
-	}()
+		return c.fetchDryRun(ctx, maxItems)
+	}
+
+	c.aggregator.SetFollowPermanent(followPermanent)
+	c.aggregator.SetMaxItemsPerFeed(maxItems)
+
+	if exclusive {
+		// Пытаемся получить блокировку в базе данных
+		locked, err := c.db.TryLock(ctx, DB_LOCK_NAME)
+		if err != nil {
+			return fmt.Errorf("failed to acquire database lock: %w", err)
+		}
+
+		if !locked {
+			logger.Info("Another instance is already running")
+			return fmt.Errorf("another instance is already running")
+		}
+
+		// Обеспечиваем освобождение блокировки при выходе, в том числе после Ctrl+C:
+		// блокировка - строка в базе, и без отмены ctx ее удаление не выполнится
+		defer func() {
+			if err := c.db.ReleaseLock(context.WithoutCancel(ctx), DB_LOCK_NAME); err != nil {
+				logger.Error("Failed to release database lock: %v", err)
+			}
+		}()
+	}
+
+	if once {
+		return c.fetchOnce(ctx)
+	}
 
 	// Проверяем, не запущен ли уже процесс
 	if c.aggregator.IsRunning() {
@@ -102,269 +279,1646 @@ func (c *CLI) handleFetch() error {
 		return nil
 	}
 
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Email дайджест по расписанию, если он настроен
+	stopDigester, err := c.runDigester(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to configure email digest: %w", err)
+	}
+
 	// Запускаем агрегатор
-	ctx := context.Background()
 	if err := c.aggregator.Start(ctx); err != nil {
 		return fmt.Errorf("failed to start aggregator: %w", err)
 	}
 
+	// Сокет управления отдает состояние агрегатора команде status --live
+	stopControlSocket := c.startControlSocket()
+	defer stopControlSocket()
+
+	// По SIGHUP настройки перечитываются; флаги команды действуют до остановки процесса
+	go c.watchReload(ctx, func() {
+		if !backfill {
+			c.aggregator.SetMaxItemsPerFeed(c.config.Aggregator.MaxItemsPerFeed)
+		}
+
+		stopDigester()
+		restarted, err := c.runDigester(ctx)
+		if err != nil {
+			logger.Error("Email digest disabled: %v", err)
+			restarted = func() {}
+		}
+		stopDigester = restarted
+	})
+
 	// Ждем сигнала завершения (Ctrl+C)
-	c.waitForShutdown()
+	c.waitForShutdown(ctx)
 
 	return nil
 }
 
-// handleAdd добавляет новую RSS ленту
-func (c *CLI) handleAdd(args []string) error {
-	var name, url string
+// fetchOnce выполняет один цикл получения по всем устаревшим лентам.
+// Возвращает ошибку (ненулевой код выхода), если хотя бы одна лента не получена
+func (c *CLI) fetchOnce(ctx context.Context) error {
+	// Ctrl+C прерывает цикл: необработанные ленты отмечаются пропущенными
+	report, err := c.aggregator.RunOnce(ctx)
+	if err != nil {
+		return fmt.Errorf("fetch cycle failed: %w", err)
+	}
 
-	// Парсим аргументы
-	for i := 2; i < len(args); i++ {
-		switch args[i] {
-		case "--name":
-			if i+1 >= len(args) {
-				return fmt.Errorf("--name requires a value")
-			}
-			name = args[i+1]
-			i++
-		case "--url":
-			if i+1 >= len(args) {
-				return fmt.Errorf("--url requires a value")
-			}
-			url = args[i+1]
-			i++
+	for _, result := range report.Feeds {
+		if result.Err != nil {
+			logger.Error("Feed %s failed: %v", result.FeedName, result.Err)
 		}
 	}
 
-	if name == "" || url == "" {
-		return fmt.Errorf("both --name and --url are required")
+	if failed := report.Failed(); failed > 0 {
+		return fetchError(fmt.Errorf("%d of %d feeds failed", failed, len(report.Feeds)))
 	}
 
-	// Валидируем RSS URL
-	parser := rss.NewParser()
-	if err := parser.ValidateRSSURL(url); err != nil {
-		return fmt.Errorf("invalid RSS URL: %w", err)
+	logger.Success("Fetched %d feeds, %d new articles (%d duplicates, %d updated)",
+		len(report.Feeds), report.NewArticles(), report.Duplicates(), report.Updated())
+	return nil
+}
+
+// fetchDryRun выполняет один цикл получения без записи в БД и печатает, что было бы сделано
+func (c *CLI) fetchDryRun(ctx context.Context, maxItems int) error {
+	repo := memory.NewDryRun(c.db)
+	agg := aggregator.New(repo, c.parser, c.config.Aggregator.DefaultInterval, c.config.Aggregator.DefaultWorkers)
+	// URL перемещенных лент в режиме dry-run не меняются, только логируются
+	agg.SetFollowPermanent(false)
+	agg.SetMaxItemsPerFeed(maxItems)
+	agg.SetMaxJobDuration(c.config.Aggregator.MaxJobDuration)
+	agg.SetMirrorAfterFailures(c.config.Aggregator.MirrorAfter)
+	// Иконки в режиме dry-run не запрашиваются: их некуда сохранить
+	agg.SetIconRefresh(0)
+	if conflictMode, err := domain.ParseConflictMode(c.config.Aggregator.ArticleConflict); err == nil {
+		agg.SetConflictMode(conflictMode)
+	}
+	if crossFeedDedup, err := domain.ParseCrossFeedDedup(c.config.Aggregator.CrossFeedDedup); err == nil {
+		agg.SetCrossFeedDedup(crossFeedDedup)
 	}
 
-	// Создаем ленту в базе данных
-	feed, err := c.db.CreateFeed(name, url)
+	report, err := agg.RunOnce(ctx)
 	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "unique constraint") {
-			return fmt.Errorf("feed with name '%s' already exists", name)
+		return fmt.Errorf("dry run failed: %w", err)
+	}
+
+	fmt.Println("# Dry run: nothing was written to the database")
+	fmt.Println()
+
+	if len(report.Feeds) == 0 {
+		fmt.Println("No feeds would be updated")
+		return nil
+	}
+
+	for _, result := range report.Feeds {
+		switch {
+		case result.TimedOut:
+			fmt.Printf("- %s: would time out: %v\n", result.FeedName, result.Err)
+		case result.Err != nil:
+			fmt.Printf("- %s: would fail: %v\n", result.FeedName, result.Err)
+		case result.Skipped:
+			fmt.Printf("- %s: would be skipped\n", result.FeedName)
+		default:
+			fmt.Printf("- %s: would be updated, %d new articles (%d duplicates, %d changed)\n",
+				result.FeedName, result.NewArticles, result.Duplicates, result.Updated)
 		}
-		return fmt.Errorf("failed to create feed: %w", err)
 	}
 
-	logger.Success("Successfully added feed: %s (%s)", feed.Name, feed.URL)
+	fmt.Printf("\nTotal: %d feeds, %d new articles would be inserted\n",
+		len(report.Feeds), len(repo.PendingArticles()))
 	return nil
 }
 
-// handleSetInterval изменяет интервал получения лент и сохраняет в БД
-func (c *CLI) handleSetInterval(args []string) error {
-	if len(args) < 3 {
-		return fmt.Errorf("interval duration is required (e.g., '2m', '30s', '1h')")
-	}
+// handleAdd добавляет новую RSS ленту
+func (c *CLI) handleAdd(ctx context.Context, args []string) error {
+	feed := &domain.Feed{Priority: domain.PriorityNormal, Enabled: true}
+	var youtube, mastodon string
+	var mirrors []string
+	force, refresh := false, false
 
-	durationStr := args[2]
-	duration, err := time.ParseDuration(durationStr)
-	if err != nil {
-		return fmt.Errorf("invalid duration format: %s", durationStr)
+	// Парсим аргументы
+	fs := newFlagSet()
+	fs.String("--name", &feed.Name)
+	fs.String("--url", &feed.URL)
+	fs.String("--youtube", &youtube)
+	fs.String("--mastodon", &mastodon)
+	fs.Func("--mirror", func(value string) error {
+		mirrors = append(mirrors, value)
+		return nil
+	})
+	fs.String("--proxy", &feed.ProxyURL)
+	fs.Bool("--insecure", &feed.TLSInsecure)
+	fs.Bool("--force", &force)
+	fs.Bool("--refresh", &refresh)
+	fs.Func("--header", func(value string) error {
+		name, value, err := parseHeader(value)
+		if err != nil {
+			return err
+		}
+		if feed.Headers == nil {
+			feed.Headers = make(map[string]string)
+		}
+		feed.Headers[name] = value
+		return nil
+	})
+	fs.Func("--user-agent", func(value string) error {
+		if feed.Headers == nil {
+			feed.Headers = make(map[string]string)
+		}
+		feed.Headers["User-Agent"] = value
+		return nil
+	})
+	fs.Func("--priority", func(value string) (err error) {
+		feed.Priority, err = domain.ParseFeedPriority(value)
+		return err
+	})
+	fs.Func("--tags", func(value string) error {
+		feed.Tags = domain.ParseTags(value)
+		return nil
+	})
+	fs.Func("--folder", func(value string) error {
+		feed.Folder = domain.ParseFolder(value)
+		return nil
+	})
+	fs.Func("--lang", func(value string) (err error) {
+		feed.Languages, err = domain.ParseLanguages(value)
+		return err
+	})
+	fs.Func("--timeout", func(value string) (err error) {
+		feed.Timeout, err = parseFeedTimeout(value)
+		return err
+	})
+	fs.Func("--schedule", func(value string) (err error) {
+		feed.Schedule, feed.NextRunAt, err = parseFeedSchedule(value)
+		return err
+	})
+	fs.Func("--interval", func(value string) (err error) {
+		feed.Interval, err = parseFeedInterval(value)
+		return err
+	})
+	auth := func() *domain.FeedAuth {
+		if feed.Auth == nil {
+			feed.Auth = &domain.FeedAuth{}
+		}
+		return feed.Auth
 	}
-
-	if duration < time.Second {
-		return fmt.Errorf("interval must be at least 1 second")
+	fs.Func("--username", func(value string) error {
+		auth().Username = value
+		return nil
+	})
+	fs.Func("--password", func(value string) error {
+		auth().Password = value
+		return nil
+	})
+	fs.Func("--bearer-token", func(value string) error {
+		auth().BearerToken = value
+		return nil
+	})
+	if err := fs.parse(args[2:]); err != nil {
+		return err
 	}
 
-	// Используем менеджер настроек для динамического изменения
-	return c.settingsManager.SetInterval(duration)
-}
+	if feed.Auth != nil && feed.Auth.BearerToken != "" && (feed.Auth.Username != "" || feed.Auth.Password != "") {
+		return usageErrorf("use either --username/--password or --bearer-token, not both")
+	}
 
-// handleSetWorkers изменяет количество воркеров и сохраняет в БД
-func (c *CLI) handleSetWorkers(args []string) error {
-	if len(args) < 3 {
-		return fmt.Errorf("number of workers is required")
+	sources := 0
+	for _, source := range []string{feed.URL, youtube, mastodon} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return usageErrorf("use only one of --url, --youtube or --mastodon")
+	}
+	if feed.Name == "" || sources == 0 {
+		return usageErrorf("both --name and --url (or --youtube, --mastodon) are required")
 	}
 
-	count, err := strconv.Atoi(args[2])
+	// --youtube и --mastodon - то же, что сокращения youtube:<канал> и mastodon:<аккаунт>
+	switch {
+	case youtube != "":
+		feed.URL = "youtube:" + youtube
+	case mastodon != "":
+		feed.URL = "mastodon:" + mastodon
+	}
+	url, err := c.resolveFeedURL(ctx, feed.URL)
 	if err != nil {
-		return fmt.Errorf("invalid workers count: %s", args[2])
+		return err
+	}
+	feed.URL = urlnorm.Normalize(url)
+	if feed.Mirrors, err = c.resolveMirrors(ctx, feed.URL, mirrors); err != nil {
+		return err
 	}
 
-	if count <= 0 {
-		return fmt.Errorf("workers count must be positive")
+	// Имя не должно совпадать со смарт-лентой: обе открываются через --feed-name
+	if _, err := c.db.GetSmartFeedByName(ctx, feed.Name); err == nil {
+		return fmt.Errorf("%w: %s", domain.ErrDuplicateSmartFeed, feed.Name)
+	} else if !errors.Is(err, domain.ErrSmartFeedNotFound) {
+		return err
 	}
 
-	// Используем менеджер настроек для динамического изменения
-	return c.settingsManager.SetWorkers(count)
-}
+	// Та же лента под другим именем получалась бы дважды
+	if err := c.checkDuplicateURL(ctx, feed, force); err != nil {
+		return err
+	}
 
-// handleList показывает список RSS лент
-func (c *CLI) handleList(args []string) error {
-	var limit int
+	// Валидируем RSS URL
+	if err := c.parser.ValidateFeed(ctx, feed); err != nil {
+		return fetchError(fmt.Errorf("invalid RSS URL: %w", err))
+	}
 
-	// Парсим аргумент --num
-	for i := 2; i < len(args); i++ {
-		if args[i] == "--num" {
-			if i+1 >= len(args) {
-				return fmt.Errorf("--num requires a value")
-			}
-			var err error
-			limit, err = strconv.Atoi(args[i+1])
-			if err != nil {
-				return fmt.Errorf("invalid number: %s", args[i+1])
-			}
-			break
+	// Создаем ленту в базе данных
+	if err := c.db.CreateFeed(ctx, feed); err != nil {
+		if errors.Is(err, domain.ErrDuplicateFeed) {
+			return err
 		}
+		return fmt.Errorf("failed to create feed: %w", err)
 	}
 
-	// Получаем ленты из базы данных
-	feeds, err := c.db.GetAllFeeds(limit)
+	logger.Success("Successfully added feed: %s (%s)", feed.Name, feed.URL)
+
+	// С --refresh статьи новой ленты появляются сразу, а не в следующем цикле агрегатора
+	if !refresh {
+		return nil
+	}
+	result, err := c.refreshFeed(ctx, feed, false, false)
 	if err != nil {
-		return fmt.Errorf("failed to get feeds: %w", err)
+		return err
 	}
+	return printRefreshResult(feed, result)
+}
 
-	if len(feeds) == 0 {
-		fmt.Println("No RSS feeds found")
+// checkDuplicateURL проверяет, что URL ленты после нормализации (http и https, www., завершающий
+// слеш) не совпадает с URL другой ленты; force разрешает намеренные дубликаты
+func (c *CLI) checkDuplicateURL(ctx context.Context, feed *domain.Feed, force bool) error {
+	if force {
 		return nil
 	}
 
-	fmt.Println("# Available RSS Feeds")
-	fmt.Println()
+	existing, err := c.db.GetFeedByURL(ctx, feed.URL)
+	if errors.Is(err, domain.ErrFeedNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID == feed.ID {
+		return nil
+	}
+	return fmt.Errorf("%w: %s is already fetched as feed %s (use --force to keep a duplicate anyway)",
+		domain.ErrDuplicateFeedURL, feed.URL, existing.Name)
+}
 
-	for i, feed := range feeds {
-		fmt.Printf("%d. Name: %s\n", i+1, feed.Name)
-		fmt.Printf("   URL: %s\n", feed.URL)
-		fmt.Printf("   Added: %s\n", feed.CreatedAt.Format("2006-01-02 15:04"))
-		fmt.Println()
+// resolveFeedURL раскрывает сокращение URL ленты (reddit:, github:, youtube:, mastodon:), если парсер их поддерживает
+func (c *CLI) resolveFeedURL(ctx context.Context, raw string) (string, error) {
+	resolver, ok := c.parser.(port.FeedURLResolver)
+	if !ok {
+		return raw, nil
 	}
 
-	return nil
+	url, err := resolver.ResolveFeedURL(ctx, raw)
+	if err != nil {
+		return "", fetchError(err)
+	}
+	if url != raw {
+		logger.Info("Resolved %s to %s", raw, url)
+	}
+	return url, nil
 }
 
-// handleDelete удаляет RSS ленту
-func (c *CLI) handleDelete(args []string) error {
-	var name string
+// resolveMirrors раскрывает сокращения URL зеркал и проверяет, что это HTTP(S) адреса.
+// Пустые значения, повторы и совпадающие с основным URL зеркала пропускаются
+func (c *CLI) resolveMirrors(ctx context.Context, primary string, raw []string) ([]string, error) {
+	mirrors := make([]string, 0, len(raw))
+	seen := map[string]bool{primary: true}
+	for _, mirror := range raw {
+		mirror = strings.TrimSpace(mirror)
+		if mirror == "" {
+			continue
+		}
 
-	// Парсим аргумент --name
-	for i := 2; i < len(args); i++ {
-		if args[i] == "--name" {
-			if i+1 >= len(args) {
-				return fmt.Errorf("--name requires a value")
-			}
-			name = args[i+1]
-			break
+		url, err := c.resolveFeedURL(ctx, mirror)
+		if err != nil {
+			return nil, err
+		}
+		if lower := strings.ToLower(url); !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+			return nil, usageErrorf("invalid mirror URL: %s (expected http:// or https://)", mirror)
 		}
+		if seen[url] {
+			continue
+		}
+		seen[url] = true
+		mirrors = append(mirrors, url)
 	}
+	return mirrors, nil
+}
 
-	if name == "" {
-		return fmt.Errorf("--name is required")
+// parseFeedTimeout разбирает таймаут ленты; 0 означает глобальный CLI_APP_FETCH_TIMEOUT
+func parseFeedTimeout(s string) (time.Duration, error) {
+	if s == "0" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(s)
+	if err != nil || timeout < 0 {
+		return 0, fmt.Errorf("invalid timeout: %s (expected a duration like 10s or 2m, or 0 for the global timeout)", s)
 	}
+	if timeout > 0 && timeout < time.Millisecond {
+		return 0, fmt.Errorf("timeout must be at least 1ms: %s", s)
+	}
+	return timeout, nil
+}
 
-	// Удаляем ленту
-	if err := c.db.DeleteFeed(name); err != nil {
-		return fmt.Errorf("failed to delete feed: %w", err)
+// parseFeedInterval разбирает интервал получения ленты; 0 означает интервал агрегатора
+func parseFeedInterval(s string) (time.Duration, error) {
+	if s == "0" {
+		return 0, nil
 	}
+	interval, err := time.ParseDuration(s)
+	if err != nil || interval < time.Second {
+		return 0, fmt.Errorf("invalid interval: %s (expected a duration of at least 1s like 30m or 6h, or 0 for the aggregator interval)", s)
+	}
+	return interval, nil
+}
 
-	logger.Success("Successfully deleted feed: %s", name)
-	return nil
+// parseFeedSchedule разбирает расписание cron ленты и возвращает его вместе со временем первого
+// запуска; пустая строка - получать ленту в каждом цикле
+func parseFeedSchedule(s string) (string, *time.Time, error) {
+	if strings.TrimSpace(s) == "" {
+		return "", nil, nil
+	}
+	schedule, err := domain.ParseCron(s)
+	if err != nil {
+		return "", nil, err
+	}
+	next := schedule.Next(time.Now())
+	return schedule.String(), &next, nil
 }
 
-// handleArticles показывает последние статьи из указанной ленты
-func (c *CLI) handleArticles(args []string) error {
-	var feedName string
-	var limit int = 3 // По умолчанию
+// handleUpdate изменяет имя, URL, теги, папку, приоритет, таймаут, интервал, зеркала, языки
+// или расписание существующей ленты без потери статей
+func (c *CLI) handleUpdate(ctx context.Context, args []string) error {
+	var name, newName, url, tags, priority, timeout, interval, mirrors, languages, schedule, folder string
+	tagsSet, mirrorsSet, languagesSet, scheduleSet, folderSet, force := false, false, false, false, false, false
 
-	// Парсим аргументы
-	for i := 2; i < len(args); i++ {
-		switch args[i] {
-		case "--feed-name":
-			if i+1 >= len(args) {
-				return fmt.Errorf("--feed-name requires a value")
-			}
-			feedName = args[i+1]
-			i++
-		case "--num":
-			if i+1 >= len(args) {
-				return fmt.Errorf("--num requires a value")
-			}
-			var err error
-			limit, err = strconv.Atoi(args[i+1])
-			if err != nil {
-				return fmt.Errorf("invalid number: %s", args[i+1])
-			}
-			i++
-		}
+	// Пустое значение --tags, --mirrors, --lang, --schedule и --folder очищает поле, поэтому
+	// запоминаем, какие из них указаны
+	fs := newFlagSet()
+	fs.String("--name", &name)
+	fs.String("--new-name", &newName)
+	fs.String("--url", &url)
+	fs.String("--priority", &priority)
+	fs.String("--timeout", &timeout)
+	fs.String("--interval", &interval)
+	fs.Bool("--force", &force)
+	optional := func(flag string, value *string, set *bool) {
+		fs.Func(flag, func(v string) error {
+			*value, *set = v, true
+			return nil
+		})
+	}
+	optional("--tags", &tags, &tagsSet)
+	optional("--mirrors", &mirrors, &mirrorsSet)
+	optional("--lang", &languages, &languagesSet)
+	optional("--schedule", &schedule, &scheduleSet)
+	optional("--folder", &folder, &folderSet)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
 	}
 
-	if feedName == "" {
-		return fmt.Errorf("--feed-name is required")
+	if name == "" {
+		return usageErrorf("--name is required")
+	}
+	if newName == "" && url == "" && !tagsSet && priority == "" && timeout == "" && interval == "" &&
+		!mirrorsSet && !languagesSet && !scheduleSet && !folderSet {
+		return usageErrorf("nothing to update: specify --new-name, --url, --tags, --priority, --timeout, --interval, --mirrors, --lang, --schedule or --folder")
 	}
 
-	// Проверяем, существует ли лента
-	_, err := c.db.GetFeedByName(feedName)
+	feed, err := c.db.GetFeedByName(ctx, name)
 	if err != nil {
-		return fmt.Errorf("feed not found: %s", feedName)
+		return err
 	}
 
-	// Получаем статьи
-	articles, err := c.db.GetArticlesByFeedName(feedName, limit)
-	if err != nil {
-		return fmt.Errorf("failed to get articles: %w", err)
+	if newName != "" {
+		feed.Name = newName
+	}
+	if tagsSet {
+		feed.Tags = domain.ParseTags(tags)
+	}
+	if folderSet {
+		feed.Folder = domain.ParseFolder(folder)
+	}
+	if languagesSet {
+		if feed.Languages, err = domain.ParseLanguages(languages); err != nil {
+			return usageError(err)
+		}
+	}
+	if scheduleSet {
+		if feed.Schedule, feed.NextRunAt, err = parseFeedSchedule(schedule); err != nil {
+			return usageError(err)
+		}
+	}
+	if priority != "" {
+		if feed.Priority, err = domain.ParseFeedPriority(priority); err != nil {
+			return usageError(err)
+		}
+	}
+	if timeout != "" {
+		if feed.Timeout, err = parseFeedTimeout(timeout); err != nil {
+			return usageError(err)
+		}
+	}
+	if interval != "" {
+		if feed.Interval, err = parseFeedInterval(interval); err != nil {
+			return usageError(err)
+		}
+	}
+	if url != "" {
+		if url, err = c.resolveFeedURL(ctx, url); err != nil {
+			return err
+		}
+		url = urlnorm.Normalize(url)
+	}
+	if url != "" && url != feed.URL {
+		feed.URL = url
+		if err := c.checkDuplicateURL(ctx, feed, force); err != nil {
+			return err
+		}
+		// Новый URL должен быть валидной RSS лентой
+		if err := c.parser.ValidateFeed(ctx, feed); err != nil {
+			return fetchError(fmt.Errorf("invalid RSS URL: %w", err))
+		}
+	}
+	if mirrorsSet {
+		if feed.Mirrors, err = c.resolveMirrors(ctx, feed.URL, strings.Split(mirrors, ",")); err != nil {
+			return err
+		}
 	}
 
-	if len(articles) == 0 {
-		fmt.Printf("No articles found for feed: %s\n", feedName)
-		return nil
+	if err := c.db.UpdateFeed(ctx, feed); err != nil {
+		if errors.Is(err, domain.ErrDuplicateFeed) {
+			return err
+		}
+		return fmt.Errorf("failed to update feed: %w", err)
 	}
 
-	fmt.Printf("Feed: %s\n\n", feedName)
+	logger.Success("Successfully updated feed: %s (%s)", feed.Name, feed.URL)
+	return nil
+}
 
-	for i, article := range articles {
-		date := article.PublishedAt.Format("2006-01-02")
-		fmt.Printf("%d. [%s] %s\n", i+1, date, article.Title)
-		fmt.Printf("   %s\n\n", article.Link)
+// parseHeader разбирает заголовок в формате "Name: Value"
+func parseHeader(raw string) (string, string, error) {
+	name, value, ok := strings.Cut(raw, ":")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return "", "", fmt.Errorf("invalid header format: %s (expected \"Name: Value\")", raw)
 	}
+	return http.CanonicalHeaderKey(name), strings.TrimSpace(value), nil
+}
 
-	return nil
+// handleSetInterval изменяет интервал получения лент и сохраняет в БД
+func (c *CLI) handleSetInterval(ctx context.Context, args []string) error {
+	positional, err := newFlagSet().parseArgs(args[2:])
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("interval duration is required (e.g., '2m', '30s', '1h')")
+	}
+
+	durationStr := positional[0]
+	duration, err := time.ParseDuration(durationStr)
+	if err != nil {
+		return usageErrorf("invalid duration format: %s", durationStr)
+	}
+
+	if duration < time.Second {
+		return usageErrorf("interval must be at least 1 second")
+	}
+
+	// Используем менеджер настроек для динамического изменения
+	return c.settingsManager.SetInterval(ctx, duration)
 }
 
-// showHelp выводит справку по использованию CLI
-func (c *CLI) showHelp() {
-	fmt.Println(`Usage:
-  rsshub COMMAND [OPTIONS]
+// handleSetWorkers изменяет количество воркеров и сохраняет в БД
+func (c *CLI) handleSetWorkers(ctx context.Context, args []string) error {
+	positional, err := newFlagSet().parseArgs(args[2:])
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("number of workers is required")
+	}
+
+	count, err := strconv.Atoi(positional[0])
+	if err != nil {
+		return usageErrorf("invalid workers count: %s", positional[0])
+	}
 
-Common Commands:
-     add             add new RSS feed
-     set-interval    set RSS fetch interval (persisted in database)
-     set-workers     set number of workers (persisted in database)
-     list            list available RSS feeds
-     delete          delete RSS feed
-     articles        show latest articles
-     fetch           starts the background process that periodically fetches and processes RSS feeds using a worker pool
+	if count <= 0 {
+		return usageErrorf("workers count must be positive")
+	}
 
-Examples:
-     rsshub add --name "tech-crunch" --url "https://techcrunch.com/feed/"
-     rsshub list --num 5
-     rsshub delete --name "tech-crunch"
-     rsshub articles --feed-name "tech-crunch" --num 5
-     rsshub set-interval 2m
-     rsshub set-workers 5
-     rsshub fetch`)
+	// Используем менеджер настроек для динамического изменения
+	return c.settingsManager.SetWorkers(ctx, count)
 }
 
-// waitForShutdown ожидает сигнала завершения (Ctrl+C)
-func (c *CLI) waitForShutdown() {
-	// Создаем канал для получения сигналов ОС
-	sigChan := make(chan os.Signal, 1)
+// handleList показывает список RSS лент
+func (c *CLI) handleList(ctx context.Context, args []string) error {
+	var limit int
+	var paging pageArgs
+	var folder string
+	verbose, disabledOnly, foldersOnly := false, false, false
+
+	// Парсим аргументы --num, --page, --after, --verbose, --disabled, --folder и --folders
+	fs := newFlagSet()
+	fs.Bool("--verbose", &verbose)
+	fs.Bool("-v", &verbose)
+	fs.Bool("--disabled", &disabledOnly)
+	fs.Func("--folder", func(value string) error {
+		if folder = domain.ParseFolder(value); folder == "" {
+			return usageErrorf("invalid folder: %q", value)
+		}
+		return nil
+	})
+	fs.Bool("--folders", &foldersOnly)
+	fs.Int("--num", &limit)
+	paging.register(fs)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	if foldersOnly {
+		return c.listFolders(ctx)
+	}
 
-	// Регистрируемся на получение SIGINT (Ctrl+C) и SIGTERM
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	// Без пагинации показываем весь список (или первые --num лент); лента папки
+	// выбирается из всех лент, поэтому --folder не сочетается с пагинацией
+	var feeds []*domain.Feed
+	var err error
+	if folder != "" {
+		if paging.enabled() {
+			return usageErrorf("--folder cannot be combined with --page or --after")
+		}
+		feeds, err = c.folderFeeds(ctx, folder, limit)
+	} else if paging.enabled() {
+		if limit <= 0 {
+			limit = DEFAULT_PAGE_SIZE
+		}
+		feeds, err = c.feedsPage(ctx, &paging, limit)
+	} else {
+		feeds, err = c.db.GetAllFeeds(ctx, limit)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get feeds: %w", err)
+	}
+
+	if disabledOnly {
+		return c.listDisabledFeeds(feeds)
+	}
+
+	if len(feeds) == 0 && folder != "" {
+		fmt.Printf("No RSS feeds found in folder: %s\n", folder)
+		return nil
+	}
+	if len(feeds) == 0 {
+		fmt.Println("No RSS feeds found")
+		return nil
+	}
+
+	// Количество статей всех лент считается одним запросом, а не по запросу на ленту
+	counts, err := c.db.GetFeedArticleCounts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to count articles: %w", err)
+	}
+
+	fmt.Println("# Available RSS Feeds")
+	fmt.Println()
+
+	render := c.renderer(colorAuto, 0)
+	for i, feed := range feeds {
+		status := ""
+		if !feed.Enabled {
+			status = render.style(ansiYellow, " (disabled)")
+		}
+		fmt.Printf("%d. Name: %s%s\n", paging.offset(limit)+i+1, render.feedName(feed.Name), status)
+		fmt.Printf("   URL: %s\n", feed.URL)
+		if feed.Priority != domain.PriorityNormal {
+			fmt.Printf("   Priority: %s\n", feed.Priority)
+		}
+		if feed.Folder != "" {
+			fmt.Printf("   Folder: %s\n", feed.Folder)
+		}
+		if len(feed.Tags) > 0 {
+			fmt.Printf("   Tags: %s\n", strings.Join(feed.Tags, ", "))
+		}
+		if len(feed.Languages) > 0 {
+			fmt.Printf("   Languages: %s\n", strings.Join(feed.Languages, ", "))
+		}
+		if feed.Timeout > 0 {
+			fmt.Printf("   Timeout: %s\n", feed.Timeout)
+		}
+		if feed.Interval > 0 {
+			fmt.Printf("   Interval: %s\n", feed.Interval)
+		}
+		if feed.Schedule != "" && feed.NextRunAt != nil {
+			fmt.Printf("   Schedule: %s (next run %s)\n", feed.Schedule, feed.NextRunAt.Local().Format("2006-01-02 15:04"))
+		} else if feed.Schedule != "" {
+			fmt.Printf("   Schedule: %s\n", feed.Schedule)
+		}
+		if len(feed.Mirrors) > 0 {
+			fmt.Printf("   Mirrors: %s\n", strings.Join(feed.Mirrors, ", "))
+		}
+		if verbose {
+			fmt.Printf("   ID: %s\n", feed.ID)
+			fmt.Printf("   Status: %s\n", feedStatus(feed))
+			if feed.LastError != "" && feed.LastErrorAt != nil {
+				fmt.Printf("   Last error: %s (%s)\n", render.errorText(feed.LastError), render.date(feed.LastErrorAt.Format("2006-01-02 15:04")))
+			}
+		} else if feed.FetchFailures > 0 {
+			fmt.Printf("   Failures: %s\n", render.errorText(fmt.Sprintf("%d in a row", feed.FetchFailures)))
+		}
+		if feed.ActiveURL != "" {
+			fmt.Printf("   Fetched from mirror: %s\n", feed.ActiveURL)
+		}
+		fmt.Printf("   Articles: %d (%d unread)\n", counts[feed.ID].Total, counts[feed.ID].Unread)
+		fmt.Printf("   Added: %s\n", render.date(feed.CreatedAt.Format("2006-01-02 15:04")))
+		fmt.Println()
+	}
+
+	if paging.enabled() && len(feeds) == limit {
+		last := feeds[len(feeds)-1]
+		cursor := &domain.PageCursor{Time: last.CreatedAt, ID: last.ID}
+		fmt.Printf("Next page: rsshub list --num %d --after %s\n", limit, cursor.Encode())
+	}
+
+	return nil
+}
+
+// folderFeeds возвращает ленты папки и ее вложенных папок, не больше limit (0 - все)
+func (c *CLI) folderFeeds(ctx context.Context, folder string, limit int) ([]*domain.Feed, error) {
+	all, err := c.db.GetAllFeeds(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var feeds []*domain.Feed
+	for _, feed := range all {
+		if feed.Folder != "" && domain.InFolder(feed.Folder, folder) {
+			feeds = append(feeds, feed)
+		}
+	}
+	if limit > 0 && len(feeds) > limit {
+		feeds = feeds[:limit]
+	}
+	return feeds, nil
+}
+
+// listFolders выводит дерево папок с количеством лент в каждой папке вместе с вложенными
+func (c *CLI) listFolders(ctx context.Context) error {
+	folders, err := c.db.GetFolders(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get folders: %w", err)
+	}
+	feeds, err := c.db.GetAllFeeds(ctx, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get feeds: %w", err)
+	}
+
+	if len(folders) == 0 {
+		fmt.Println("No folders found")
+		return nil
+	}
+
+	// Папки сравниваются по уровням, чтобы вложенные шли сразу за родительской
+	// ("News/Tech" раньше "News Digest")
+	slices.SortFunc(folders, func(a, b string) int {
+		return slices.Compare(strings.Split(a, domain.FolderSeparator), strings.Split(b, domain.FolderSeparator))
+	})
+
+	fmt.Println("# Folders")
+	fmt.Println()
+
+	render := c.renderer(colorAuto, 0)
+	for _, path := range folders {
+		count := 0
+		for _, feed := range feeds {
+			if feed.Folder != "" && domain.InFolder(feed.Folder, path) {
+				count++
+			}
+		}
+		indent := strings.Repeat("  ", domain.FolderDepth(path))
+		fmt.Printf("%s%s (%d feeds)\n", indent, render.feedName(domain.FolderName(path)), count)
+	}
+	unfiled := 0
+	for _, feed := range feeds {
+		if feed.Folder == "" {
+			unfiled++
+		}
+	}
+
+	fmt.Printf("\nFeeds outside folders: %d\n", unfiled)
+	fmt.Println("Show the feeds of a folder with: rsshub list --folder <path>")
+	return nil
+}
+
+// listDisabledFeeds выводит отключенные ленты: вручную или агрегатором как мертвые, с причиной
+func (c *CLI) listDisabledFeeds(feeds []*domain.Feed) error {
+	var disabled []*domain.Feed
+	for _, feed := range feeds {
+		if !feed.Enabled {
+			disabled = append(disabled, feed)
+		}
+	}
+
+	if len(disabled) == 0 {
+		fmt.Println("No disabled feeds found")
+		return nil
+	}
+
+	fmt.Println("# Disabled RSS Feeds")
+	fmt.Println()
+
+	render := c.renderer(colorAuto, 0)
+	for i, feed := range disabled {
+		fmt.Printf("%d. Name: %s\n", i+1, render.feedName(feed.Name))
+		fmt.Printf("   URL: %s\n", feed.URL)
+		if feed.DisabledReason != "" && feed.DisabledAt != nil {
+			fmt.Printf("   Disabled automatically: %s (%s)\n", render.errorText(feed.DisabledReason),
+				render.date(c.localTime(*feed.DisabledAt).Format("2006-01-02 15:04")))
+		} else {
+			fmt.Println("   Disabled manually")
+		}
+		if feed.LastError != "" && feed.LastErrorAt != nil {
+			fmt.Printf("   Last error: %s (%s)\n", render.errorText(feed.LastError), render.date(feed.LastErrorAt.Format("2006-01-02 15:04")))
+		}
+		fmt.Println()
+	}
+
+	fmt.Println("Re-enable a feed with: rsshub enable --name <name>")
+	return nil
+}
+
+// feedStatus возвращает состояние получения ленты для list --verbose
+func feedStatus(feed *domain.Feed) string {
+	switch {
+	case !feed.Enabled && feed.DisabledReason != "":
+		return "disabled as dead: " + feed.DisabledReason
+	case !feed.Enabled:
+		return "disabled"
+	case feed.FetchFailures == 1:
+		return "failed 1 time"
+	case feed.FetchFailures > 1:
+		return fmt.Sprintf("failed %d times", feed.FetchFailures)
+	case feed.LastError != "":
+		return "failed" // Таймаут или паника при обработке: получение само по себе успешно
+	default:
+		return "OK"
+	}
+}
+
+// feedsPage получает страницу лент; страница --page N находится проходом по курсорам
+func (c *CLI) feedsPage(ctx context.Context, paging *pageArgs, limit int) ([]*domain.Feed, error) {
+	after := paging.after
+	for p := 1; ; p++ {
+		feeds, err := c.db.GetFeedsPage(ctx, after, limit)
+		if err != nil || p >= paging.page || len(feeds) < limit {
+			if p < paging.page {
+				return nil, err // Страницы с таким номером нет
+			}
+			return feeds, err
+		}
+		last := feeds[len(feeds)-1]
+		after = &domain.PageCursor{Time: last.CreatedAt, ID: last.ID}
+	}
+}
+
+// handleDelete удаляет RSS ленту по имени или все ленты с тегом либо подходящие под шаблон имени
+func (c *CLI) handleDelete(ctx context.Context, args []string) error {
+	var name, tag, pattern string
+	yes := false
+
+	// Парсим аргументы
+	fs := newFlagSet()
+	fs.String("--name", &name)
+	fs.Func("--tag", func(value string) error {
+		tag = strings.ToLower(strings.TrimSpace(value))
+		return nil
+	})
+	fs.String("--match", &pattern)
+	fs.Bool("--yes", &yes)
+	fs.Bool("-y", &yes)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	selectors := 0
+	for _, s := range []string{name, tag, pattern} {
+		if s != "" {
+			selectors++
+		}
+	}
+	if selectors != 1 {
+		return usageErrorf("exactly one of --name, --tag or --match is required")
+	}
+
+	if name != "" {
+		// Удаляем ленту
+		if err := c.db.DeleteFeed(ctx, name); err != nil {
+			return fmt.Errorf("failed to delete feed: %w", err)
+		}
+
+		logger.Success("Successfully deleted feed: %s", name)
+		return nil
+	}
+
+	return c.deleteMatchingFeeds(ctx, tag, pattern, yes)
+}
+
+// deleteMatchingFeeds удаляет все ленты с тегом tag или с именем, подходящим под шаблон
+// pattern (синтаксис path.Match: *, ?, [...]), после подтверждения пользователя
+func (c *CLI) deleteMatchingFeeds(ctx context.Context, tag, pattern string, yes bool) error {
+	if pattern != "" {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return usageErrorf("invalid pattern %q: %v", pattern, err)
+		}
+	}
+
+	feeds, err := c.db.GetAllFeeds(ctx, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get feeds: %w", err)
+	}
+
+	var names []string
+	for _, feed := range feeds {
+		if pattern != "" {
+			if matched, _ := path.Match(pattern, feed.Name); matched {
+				names = append(names, feed.Name)
+			}
+			continue
+		}
+		for _, t := range feed.Tags {
+			if t == tag {
+				names = append(names, feed.Name)
+				break
+			}
+		}
+	}
+
+	selector := "tag " + tag
+	if pattern != "" {
+		selector = "pattern " + pattern
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("%w: no feeds match %s", domain.ErrFeedNotFound, selector)
+	}
+
+	fmt.Printf("Feeds matching %s:\n", selector)
+	for _, name := range names {
+		fmt.Printf("  %s\n", name)
+	}
+
+	if !yes {
+		confirmed, err := confirm(fmt.Sprintf("Delete %d feeds and all their articles?", len(names)))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return fmt.Errorf("deletion cancelled")
+		}
+	}
+
+	if err := c.db.DeleteFeeds(ctx, names); err != nil {
+		return fmt.Errorf("failed to delete feeds: %w", err)
+	}
+
+	logger.Success("Successfully deleted %d feeds", len(names))
+	return nil
+}
+
+// confirm задает вопрос в терминале и возвращает true при ответе y или yes.
+// Без ответа (например, stdin закрыт) действие не подтверждается
+func confirm(question string) (bool, error) {
+	fmt.Printf("%s [y/N]: ", question)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read answer: %w", err)
+	}
+	if errors.Is(err, io.EOF) {
+		fmt.Println()
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// handleSetEnabled включает или отключает получение ленты, сохраняя ее статьи
+func (c *CLI) handleSetEnabled(ctx context.Context, args []string, enabled bool) error {
+	var name string
+
+	// Парсим аргумент --name
+	fs := newFlagSet()
+	fs.String("--name", &name)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	if name == "" {
+		return usageErrorf("--name is required")
+	}
+
+	feed, err := c.db.GetFeedByName(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	action := "disabled"
+	if enabled {
+		action = "enabled"
+	}
+
+	if feed.Enabled == enabled {
+		logger.Info("Feed %s is already %s", name, action)
+		return nil
+	}
+
+	feed.Enabled = enabled
+	if err := c.db.UpdateFeed(ctx, feed); err != nil {
+		return fmt.Errorf("failed to update feed: %w", err)
+	}
+
+	logger.Success("Feed %s %s", name, action)
+	return nil
+}
+
+// feedNameByRef возвращает имя ленты, указанной одним из флагов: по имени (--feed-name),
+// по ID (--feed-id) или по URL (--feed-url, сравнивается после нормализации). ID и URL
+// не меняются при переименовании ленты, поэтому на них удобнее опираться в скриптах
+func (c *CLI) feedNameByRef(ctx context.Context, name, id, url string) (string, error) {
+	given := 0
+	for _, ref := range []string{name, id, url} {
+		if ref != "" {
+			given++
+		}
+	}
+	switch {
+	case given == 0:
+		return "", usageErrorf("--feed-name is required (or --feed-id, --feed-url)")
+	case given > 1:
+		return "", usageErrorf("use only one of --feed-name, --feed-id or --feed-url")
+	case name != "":
+		return name, nil
+	}
+
+	var feed *domain.Feed
+	if id != "" {
+		feedID, err := utils.ParseUUID(id)
+		if err != nil {
+			return "", usageErrorf("invalid --feed-id: %s", id)
+		}
+		if feed, err = c.db.GetFeedByID(ctx, feedID); err != nil {
+			return "", err
+		}
+	} else {
+		var err error
+		if feed, err = c.db.GetFeedByURL(ctx, url); err != nil {
+			return "", err
+		}
+	}
+	return feed.Name, nil
+}
+
+// handleArticles показывает последние статьи из указанной ленты
+func (c *CLI) handleArticles(ctx context.Context, args []string) error {
+	var feedName, feedID, feedURL, lang string
+	var limit int = 3 // По умолчанию
+	var paging pageArgs
+	var showUpdated, jsonOutput, showDescription bool
+	descriptionLength := DEFAULT_DESCRIPTION_LENGTH
+	color := colorAuto
+
+	// Парсим аргументы
+	fs := newFlagSet()
+	fs.String("--feed-name", &feedName)
+	fs.String("--feed-id", &feedID)
+	fs.String("--feed-url", &feedURL)
+	fs.Int("--num", &limit)
+	paging.register(fs)
+	fs.Bool("--show-updated", &showUpdated)
+	fs.Bool("--show-description", &showDescription)
+	fs.Func("--description-length", func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return usageErrorf("invalid description length: %s", value)
+		}
+		descriptionLength, showDescription = n, true
+		return nil
+	})
+	fs.Func("--color", func(value string) (err error) {
+		color, err = parseColorMode(value)
+		return err
+	})
+	fs.Func("--lang", func(value string) error {
+		lang = strings.ToLower(value)
+		return nil
+	})
+	fs.Func("--output", func(value string) error {
+		switch value {
+		case "text":
+			jsonOutput = false
+		case "json":
+			jsonOutput = true
+		default:
+			return usageErrorf("invalid output format: %s (expected text or json)", value)
+		}
+		return nil
+	})
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	feedName, err := c.feedNameByRef(ctx, feedName, feedID, feedURL)
+	if err != nil {
+		return err
+	}
+	if limit <= 0 {
+		limit = 3
+	}
+	if lang != "" && showUpdated {
+		return usageErrorf("--lang cannot be combined with --show-updated")
+	}
+	langTerm := &domain.QueryTerm{Field: domain.QueryFieldLanguage, Value: lang}
+	render := c.renderer(color, descriptionLength)
+
+	// Проверяем, существует ли лента; если нет - это может быть смарт-лента
+	if _, err := c.db.GetFeedByName(ctx, feedName); err != nil {
+		smartFeed, query, err := c.findSmartFeed(ctx, feedName, err)
+		if err != nil {
+			return err
+		}
+		if showUpdated {
+			return usageErrorf("--show-updated is not supported for smart feeds")
+		}
+		if jsonOutput {
+			return usageErrorf("--output json is not supported for smart feeds")
+		}
+		if lang != "" {
+			query = &domain.QueryAnd{Left: query, Right: langTerm}
+		}
+		return c.showSmartFeedArticles(ctx, smartFeed, query, &paging, limit, lang, render, showDescription)
+	}
+
+	// Получаем статьи (с --show-updated - только измененные лентой после сохранения,
+	// с --lang - только на указанном языке, тем же запросом, что и смарт-ленты)
+	getPage := c.db.GetArticlesPage
+	switch {
+	case showUpdated:
+		getPage = c.db.GetUpdatedArticlesPage
+	case lang != "":
+		getPage = func(ctx context.Context, name string, after *domain.PageCursor, limit int) ([]*domain.Article, error) {
+			query := &domain.QueryAnd{Left: &domain.QueryTerm{Field: domain.QueryFieldFeed, Value: name}, Right: langTerm}
+			entries, err := c.db.GetSmartFeedArticles(ctx, query, after, limit)
+			if err != nil {
+				return nil, err
+			}
+			articles := make([]*domain.Article, len(entries))
+			for i, entry := range entries {
+				articles[i] = entry.Article
+			}
+			return articles, nil
+		}
+	}
+	articles, err := c.articlesPage(ctx, getPage, feedName, &paging, limit)
+	if err != nil {
+		return fmt.Errorf("failed to get articles: %w", err)
+	}
+
+	// JSON выводится всегда, даже пустой, чтобы его можно было разбирать скриптами
+	if jsonOutput {
+		return export.Write(os.Stdout, export.FormatJSON, feedName, c.localArticles(articles))
+	}
+
+	if len(articles) == 0 {
+		if showUpdated {
+			fmt.Printf("No updated articles found for feed: %s\n", feedName)
+			return nil
+		}
+		fmt.Printf("No articles found for feed: %s\n", feedName)
+		return nil
+	}
+
+	fmt.Printf("Feed: %s\n\n", feedName)
+
+	for i, article := range articles {
+		date := c.localTime(article.PublishedAt).Format("2006-01-02")
+		marker := ""
+		if article.ReadAt == nil {
+			marker = " *" // Непрочитанная статья
+		}
+		if article.ModifiedAt != nil {
+			marker += fmt.Sprintf(" (updated %s)", article.ModifiedAt.Format("2006-01-02 15:04"))
+		}
+		fmt.Println(render.articleLine(paging.offset(limit)+i+1, date, article.Title, marker))
+		fmt.Println(render.linkLine(article.Link, ""))
+		if showDescription {
+			for _, line := range render.descriptionLines(article.Description, "   ") {
+				fmt.Println(line)
+			}
+		}
+		fmt.Println()
+	}
+
+	// Полная страница - вероятно, есть следующая
+	if len(articles) == limit {
+		last := articles[len(articles)-1]
+		cursor := &domain.PageCursor{Time: last.PublishedAt, ID: last.ID}
+		flags := ""
+		if showUpdated {
+			flags = " --show-updated"
+		}
+		if lang != "" {
+			flags += " --lang " + lang
+		}
+		flags += descriptionFlags(showDescription, descriptionLength)
+		fmt.Printf("Next page: rsshub articles --feed-name %q --num %d%s --after %s\n", feedName, limit, flags, cursor.Encode())
+	}
+
+	return nil
+}
+
+// articlesPage получает страницу статей ленты через getPage; страница --page N находится
+// проходом по курсорам
+func (c *CLI) articlesPage(ctx context.Context, getPage func(context.Context, string, *domain.PageCursor, int) ([]*domain.Article, error),
+	feedName string, paging *pageArgs, limit int) ([]*domain.Article, error) {
+	after := paging.after
+	for p := 1; ; p++ {
+		articles, err := getPage(ctx, feedName, after, limit)
+		if err != nil || p >= paging.page || len(articles) < limit {
+			if p < paging.page {
+				return nil, err // Страницы с таким номером нет
+			}
+			return articles, err
+		}
+		last := articles[len(articles)-1]
+		after = &domain.PageCursor{Time: last.PublishedAt, ID: last.ID}
+	}
+}
+
+// pageArgs параметры пагинации: номер страницы (--page) или курсор (--after)
+type pageArgs struct {
+	page  int
+	after *domain.PageCursor
+}
+
+// register добавляет в набор флаги пагинации --page и --after
+func (p *pageArgs) register(fs *flagSet) {
+	fs.Func("--page", func(value string) error {
+		page, err := strconv.Atoi(value)
+		if err != nil || page <= 0 {
+			return usageErrorf("invalid page number: %s", value)
+		}
+		p.page = page
+		return p.check()
+	})
+	fs.Func("--after", func(value string) error {
+		cursor, err := domain.ParsePageCursor(value)
+		if err != nil {
+			return usageError(err)
+		}
+		p.after = cursor
+		return p.check()
+	})
+}
+
+// check проверяет, что указан только один способ выбора страницы
+func (p *pageArgs) check() error {
+	if p.page > 1 && p.after != nil {
+		return usageErrorf("--page and --after cannot be used together")
+	}
+	return nil
+}
+
+// enabled сообщает, запрошена ли пагинация
+func (p *pageArgs) enabled() bool {
+	return p.page > 0 || p.after != nil
+}
+
+// offset возвращает количество элементов на предыдущих страницах (для нумерации при --page)
+func (p *pageArgs) offset(limit int) int {
+	if p.page <= 1 {
+		return 0
+	}
+	return (p.page - 1) * limit
+}
+
+// handleOpen открывает N-ю последнюю статью ленты в браузере и отмечает ее прочитанной
+func (c *CLI) handleOpen(ctx context.Context, args []string) error {
+	var feedName string
+	index := 1 // По умолчанию самая свежая статья
+
+	// Парсим аргументы
+	fs := newFlagSet()
+	fs.String("--feed-name", &feedName)
+	fs.Func("--index", func(value string) (err error) {
+		index, err = strconv.Atoi(value)
+		if err != nil || index <= 0 {
+			return usageErrorf("invalid index: %s", value)
+		}
+		return nil
+	})
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	if feedName == "" {
+		return usageErrorf("--feed-name is required")
+	}
+
+	// Нумерация совпадает с выводом команды articles
+	articles, err := c.db.GetArticlesByFeedName(ctx, feedName, index)
+	if err != nil {
+		return fmt.Errorf("failed to get articles: %w", err)
+	}
+	if len(articles) < index {
+		return fmt.Errorf("feed %s has only %d articles", feedName, len(articles))
+	}
+
+	article := articles[index-1]
+	if err := openBrowser(article.Link); err != nil {
+		return err
+	}
+
+	if err := c.db.MarkArticleRead(ctx, article.ID); err != nil {
+		return err
+	}
+
+	logger.Success("Opened article: %s", article.Title)
+	return nil
+}
+
+// handleMigrate показывает статус миграций, применяет или откатывает их
+func (c *CLI) handleMigrate(ctx context.Context, args []string) error {
+	migrator, ok := c.db.(port.Migrator)
+	if !ok {
+		return fmt.Errorf("storage does not support migrations")
+	}
+
+	positional, err := newFlagSet().parseArgs(args[2:])
+	if err != nil {
+		return err
+	}
+	action := "status"
+	if len(positional) > 0 {
+		action = positional[0]
+	}
+	if max := map[string]int{"status": 1, "up": 1, "down": 2}[action]; max > 0 && len(positional) > max {
+		return usageErrorf("unexpected argument: %s", positional[max])
+	}
+
+	switch action {
+	case "status":
+		statuses, err := migrator.MigrationsStatus()
+		if err != nil {
+			return fmt.Errorf("failed to get migrations status: %w", err)
+		}
+		for _, m := range statuses {
+			state := "pending"
+			if m.AppliedAt != nil {
+				state = "applied " + m.AppliedAt.Format("2006-01-02 15:04")
+			}
+			fmt.Printf("%03d  %-40s %s\n", m.Version, m.Name, state)
+		}
+		return nil
+	case "up":
+		return migrator.RunMigrations()
+	case "down":
+		steps := 1
+		if len(positional) > 1 {
+			steps, err = strconv.Atoi(positional[1])
+			if err != nil || steps <= 0 {
+				return usageErrorf("invalid number of steps: %s", positional[1])
+			}
+		}
+		return migrator.RollbackMigrations(steps)
+	default:
+		return usageErrorf("unknown migrate action: %s (expected status, up or down)", action)
+	}
+}
+
+// handleBackfill импортирует исторические статьи ленты из ее архива (RFC 5005).
+// Может выполняться одновременно с fetch; параллельный импорт одной ленты запрещен блокировкой
+func (c *CLI) handleBackfill(ctx context.Context, args []string) error {
+	var feedName string
+	maxNew := 0
+
+	fs := newFlagSet()
+	fs.String("--feed-name", &feedName)
+	fs.Func("--max", func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return usageErrorf("invalid --max value: %s (expected a positive number)", value)
+		}
+		maxNew = n
+		return nil
+	})
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	if feedName == "" {
+		return usageErrorf("--feed-name is required")
+	}
+
+	feed, err := c.db.GetFeedByName(ctx, feedName)
+	if err != nil {
+		return err
+	}
+
+	lockName := BACKFILL_LOCK_PREFIX + feed.ID.String()
+	locked, err := c.db.TryLock(ctx, lockName)
+	if err != nil {
+		return fmt.Errorf("failed to acquire database lock: %w", err)
+	}
+	if !locked {
+		return fmt.Errorf("backfill of feed %s is already running", feed.Name)
+	}
+	// Прерванная догрузка тоже освобождает блокировку, иначе лента останется заблокированной
+	defer func() {
+		if err := c.db.ReleaseLock(context.WithoutCancel(ctx), lockName); err != nil {
+			logger.Error("Failed to release database lock: %v", err)
+		}
+	}()
+
+	report, err := c.aggregator.Backfill(ctx, feed, maxNew)
+	if err != nil && report.Pages == 0 {
+		return fetchError(fmt.Errorf("backfill of feed %s failed: %w", feed.Name, err))
+	}
+
+	fmt.Printf("Backfill of feed %s: %d new articles from %d pages (%d items, %d duplicates)\n",
+		feed.Name, report.NewArticles, report.Pages, report.Items, report.Duplicates)
+	if err != nil {
+		return fmt.Errorf("backfill interrupted: %w", err)
+	}
+	return nil
+}
+
+// handleStats показывает статистику публикаций и трафик по лентам
+func (c *CLI) handleStats(ctx context.Context, args []string) error {
+	var feedName string
+
+	fs := newFlagSet()
+	fs.String("--feed-name", &feedName)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	stats, err := c.db.GetFeedStats(ctx, feedName)
+	if err != nil {
+		return fmt.Errorf("failed to get stats: %w", err)
+	}
+
+	if len(stats) == 0 {
+		if feedName != "" {
+			return fmt.Errorf("%w: %s", domain.ErrFeedNotFound, feedName)
+		}
+		fmt.Println("No feeds found")
+		return nil
+	}
+
+	now := time.Now()
+	traffic, err := c.loadFeedTraffic(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range stats {
+		fmt.Printf("Feed: %s\n", s.FeedName)
+		fmt.Printf("   Articles:      %d (%d in last 24h, %d in last 7 days)\n", s.TotalArticles, s.LastDay, s.LastWeek)
+
+		if s.TotalArticles > 0 {
+			fmt.Printf("   Per day:       %.1f (%.1f per week)\n", s.PerDay(now), s.PerDay(now)*7)
+		}
+		if interval := s.AvgInterval(); interval > 0 {
+			fmt.Printf("   Avg interval:  %v\n", interval.Round(time.Minute))
+		}
+		if s.LastArticleAt != nil {
+			fmt.Printf("   Last article:  %s (%v ago)\n", s.LastArticleAt.Format("2006-01-02 15:04"),
+				now.Sub(*s.LastArticleAt).Round(time.Minute))
+		}
+
+		if growth, ok := s.WeeklyGrowth(); ok {
+			fmt.Printf("   Weekly trend:  %+.0f%% (%d vs %d the week before)\n", growth, s.LastWeek, s.PrevWeek)
+		} else if s.LastWeek > 0 {
+			fmt.Printf("   Weekly trend:  new activity (%d this week, none the week before)\n", s.LastWeek)
+		}
+
+		fmt.Printf("   Last fetched:  %s\n", s.LastFetchedAt.Format("2006-01-02 15:04"))
+		fmt.Printf("   Traffic:       %s today, %s this month\n",
+			domain.FormatBytes(traffic.today[s.FeedName]), domain.FormatBytes(traffic.month[s.FeedName]))
+
+		// Подсказка для удаления "мертвых" лент
+		if s.LastArticleAt == nil || now.Sub(*s.LastArticleAt) > INACTIVE_FEED_AGE {
+			fmt.Printf("   Inactive: no new articles for more than %d days\n", int(INACTIVE_FEED_AGE.Hours()/24))
+		}
+		fmt.Println()
+	}
+
+	if feedName == "" {
+		return c.printTrafficTotal(ctx, now)
+	}
+	return nil
+}
+
+// handleExportArticles выгружает статьи ленты в JSON, Markdown или CSV в файл или stdout
+func (c *CLI) handleExportArticles(ctx context.Context, args []string) error {
+	var feedName, output string
+	format := export.FormatJSON
+	filter := domain.ArticleFilter{}
+
+	fs := newFlagSet()
+	fs.String("--feed-name", &feedName)
+	fs.Func("--format", func(value string) (err error) {
+		format, err = export.ParseFormat(value)
+		return err
+	})
+	fs.Func("--since", func(value string) (err error) {
+		filter.Since, err = parseDate(value)
+		return err
+	})
+	fs.String("--output", &output)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	if feedName == "" {
+		return usageErrorf("--feed-name is required")
+	}
+
+	var articles []*domain.Article
+	if _, err := c.db.GetFeedByName(ctx, feedName); err != nil {
+		_, query, err := c.findSmartFeed(ctx, feedName, err)
+		if err != nil {
+			return err
+		}
+		if articles, err = c.smartFeedArticlesSince(ctx, query, filter.Since); err != nil {
+			return fmt.Errorf("failed to get articles: %w", err)
+		}
+	} else {
+		filter.FeedName = feedName
+		if articles, err = c.db.FindArticles(ctx, filter); err != nil {
+			return fmt.Errorf("failed to get articles: %w", err)
+		}
+	}
+
+	// Прерванная выгрузка не должна оставить файл с частью статей
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// По умолчанию выгрузка идет в stdout, чтобы ее можно было перенаправить
+	w := os.Stdout
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if err := export.Write(w, format, feedName, c.localArticles(articles)); err != nil {
+		return fmt.Errorf("failed to export articles: %w", err)
+	}
+
+	if output != "" {
+		logger.Success("Exported %d articles to %s", len(articles), output)
+	}
+	return nil
+}
+
+// parseDate разбирает дату в формате YYYY-MM-DD или RFC3339
+func parseDate(s string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, usageErrorf("invalid date: %s (expected YYYY-MM-DD)", s)
+	}
+	return t, nil
+}
+
+// handleDigest показывает дайджест новых статей или отправляет его по email (--send)
+func (c *CLI) handleDigest(ctx context.Context, args []string) error {
+	var since time.Duration
+	send := false
+
+	fs := newFlagSet()
+	fs.Func("--since", func(value string) (err error) {
+		since, err = time.ParseDuration(value)
+		if err != nil || since <= 0 {
+			return usageErrorf("invalid duration: %s", value)
+		}
+		return nil
+	})
+	fs.Bool("--send", &send)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	digester, err := c.newDigester(send)
+	if err != nil {
+		return err
+	}
+
+	// По умолчанию дайджест начинается с момента прошлой отправки
+	from := time.Now().Add(-24 * time.Hour)
+	if since > 0 {
+		from = time.Now().Add(-since)
+	} else if lastSent := digester.LastSent(ctx); !lastSent.IsZero() {
+		from = lastSent
+	}
+
+	if send {
+		// Прерванная команда не отправляет письмо
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		_, err := digester.Send(ctx, from)
+		return err
+	}
+
+	digest, err := digester.Build(ctx, from)
+	if err != nil {
+		return fmt.Errorf("failed to build digest: %w", err)
+	}
+
+	fmt.Printf("Digest since %s: %d new articles\n", from.Format("2006-01-02 15:04"), digest.Total())
+	for _, group := range digest.Groups {
+		fmt.Printf("\n== %s (%d) ==\n", group.Name, len(group.Entries))
+		for _, entry := range group.Entries {
+			fmt.Printf("- %s\n  %s\n", entry.Article.Title, entry.Article.Link)
+		}
+	}
+
+	return nil
+}
+
+// runDigester запускает отправку email дайджеста по расписанию, если оно настроено,
+// и возвращает функцию его остановки
+func (c *CLI) runDigester(ctx context.Context) (context.CancelFunc, error) {
+	if c.config.Digest.Schedule == "" {
+		return func() {}, nil
+	}
+
+	digester, err := c.newDigester(true)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	go digester.Run(ctx)
+	return cancel, nil
+}
+
+// newDigester собирает дайджест из конфигурации; withSender - письма будут отправляться через SMTP
+func (c *CLI) newDigester(withSender bool) (*aggregator.Digester, error) {
+	cfg := &c.config.Digest
+
+	var schedule *aggregator.DigestSchedule
+	if cfg.Schedule != "" {
+		var err error
+		if schedule, err = aggregator.ParseDigestSchedule(cfg.Schedule, cfg.At, cfg.Weekday); err != nil {
+			return nil, err
+		}
+	}
+
+	var sender port.DigestSender
+	if withSender {
+		emailSender, err := email.NewSender(&c.config.SMTP, cfg)
+		if err != nil {
+			return nil, err
+		}
+		sender = emailSender
+	}
+
+	return aggregator.NewDigester(c.db, sender, schedule, cfg.GroupBy, cfg.MaxArticles)
+}
 
+// waitForShutdown ожидает сигнала завершения (Ctrl+C), который отменяет контекст команды
+func (c *CLI) waitForShutdown(ctx context.Context) {
 	logger.Info("Press Ctrl+C to stop the aggregator...")
 
-	// Ожидаем сигнал
-	sig := <-sigChan
-	logger.Info("Received signal: %v", sig)
+	<-ctx.Done()
+	logger.Info("Received shutdown signal, stopping the aggregator...")
 
 	// Останавливаем агрегатор
 	if err := c.aggregator.Stop(); err != nil {
@@ -0,0 +1,126 @@
+// internal/adapter/cli/discover.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/logger"
+)
+
+// bookmarkHref ссылка закладки в HTML экспорте браузера (<A HREF="...">)
+var bookmarkHref = regexp.MustCompile(`(?i)<a\s[^>]*\bhref\s*=\s*"([^"]+)"`)
+
+// handleDiscover находит ленты сайтов из файла (--file), проверяет их и с --add добавляет новые
+func (c *CLI) handleDiscover(ctx context.Context, args []string) error {
+	var file, tags string
+	add := false
+
+	fs := newFlagSet()
+	fs.String("--file", &file)
+	fs.String("--tags", &tags)
+	fs.Bool("--add", &add)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	if file == "" {
+		return usageErrorf("--file is required")
+	}
+
+	sites, err := readSiteList(file)
+	if err != nil {
+		return err
+	}
+	if len(sites) == 0 {
+		return usageErrorf("no site URLs found in %s", file)
+	}
+
+	logger.Info("Discovering feeds of %d sites from %s", len(sites), file)
+	results, err := c.aggregator.Discover(ctx, sites, add, domain.ParseTags(tags))
+
+	valid, added, site := 0, 0, ""
+	for _, r := range results {
+		if r.SiteURL != site {
+			site = r.SiteURL
+			fmt.Printf("Site: %s\n", site)
+		}
+		switch {
+		case r.URL == "":
+			fmt.Printf("   ! %s\n", r.Error)
+		case r.Existing != "":
+			fmt.Printf("   = %s (already added as %s)\n", r.URL, r.Existing)
+		case r.Error != "":
+			fmt.Printf("   ! %s: %s\n", r.URL, r.Error)
+		default:
+			valid++
+			line := fmt.Sprintf("   + %s (%s, %d items)", r.URL, r.Title, r.Items)
+			if r.Added != "" {
+				added++
+				line += " - added as " + r.Added
+			}
+			fmt.Println(line)
+		}
+	}
+
+	fmt.Printf("Found %d new valid feeds on %d sites", valid, len(sites))
+	if add {
+		fmt.Printf(", %d added\n", added)
+	} else {
+		fmt.Println()
+		if valid > 0 {
+			fmt.Println("Run again with --add to add them")
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("feed discovery failed: %w", err)
+	}
+	return nil
+}
+
+// readSiteList читает адреса сайтов: по одному на строку (пустые строки и # комментарии
+// пропускаются, адреса без схемы получают https://) или ссылки из HTML экспорта закладок браузера
+func readSiteList(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read site list: %w", err)
+	}
+
+	var raw []string
+	if matches := bookmarkHref.FindAllStringSubmatch(string(data), -1); len(matches) > 0 {
+		for _, m := range matches {
+			raw = append(raw, m[1])
+		}
+	} else {
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			if !strings.Contains(line, "://") {
+				line = "https://" + line
+			}
+			raw = append(raw, line)
+		}
+	}
+
+	var sites []string
+	seen := make(map[string]bool)
+	for _, s := range raw {
+		u, err := url.Parse(s)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			logger.Warn("Skipping invalid site URL: %s", s)
+			continue
+		}
+		if !seen[u.String()] {
+			seen[u.String()] = true
+			sites = append(sites, u.String())
+		}
+	}
+	return sites, nil
+}
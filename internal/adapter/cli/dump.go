@@ -0,0 +1,155 @@
+// internal/adapter/cli/dump.go
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"rsshub/internal/adapter/backup"
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/logger"
+)
+
+// DUMP_BATCH_SIZE сколько статей backup читает из БД и restore сохраняет за один шаг
+const DUMP_BATCH_SIZE = 500
+
+// handleBackup записывает полную резервную копию базы данных: ленты со всеми настройками и
+// учетными данными, правила фильтров и статьи с их состоянием. Дамп не зависит от pg_dump и
+// хранилища; файл появляется только после успешной записи всего дампа
+func (c *CLI) handleBackup(ctx context.Context, args []string) error {
+	var out string
+
+	fs := newFlagSet()
+	fs.String("--out", &out)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+	if out == "" {
+		return usageErrorf("--out is required")
+	}
+
+	feeds, err := c.db.GetAllFeeds(ctx, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get feeds: %w", err)
+	}
+	filters, err := c.db.GetFilterRules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get filter rules: %w", err)
+	}
+
+	// Временный файл создается с правами 0600: в дампе учетные данные лент открытым текстом
+	tmp, err := os.CreateTemp(filepath.Dir(out), ".rsshub-backup-*")
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	dump, err := backup.NewDumpWriter(tmp)
+	if err != nil {
+		return err
+	}
+	if err := dump.WriteBackup(&domain.Backup{Feeds: feeds, Filters: filters}); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	articles := 0
+	for _, feed := range feeds {
+		var after *domain.PageCursor
+		for {
+			// Прерванная выгрузка не должна оставить файл с частью статей
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			page, err := c.db.GetArticlesPage(ctx, feed.Name, after, DUMP_BATCH_SIZE)
+			if err != nil {
+				return fmt.Errorf("failed to get articles of feed %s: %w", feed.Name, err)
+			}
+			if err := dump.WriteArticles(feed.Name, page); err != nil {
+				return fmt.Errorf("failed to write backup: %w", err)
+			}
+			articles += len(page)
+			if len(page) < DUMP_BATCH_SIZE {
+				break
+			}
+			last := page[len(page)-1]
+			after = &domain.PageCursor{Time: last.PublishedAt, ID: last.ID}
+		}
+	}
+
+	if err := dump.Close(); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), out); err != nil {
+		return fmt.Errorf("failed to save backup file: %w", err)
+	}
+
+	logger.Success("Backed up %d feeds, %d filter rules and %d articles to %s", len(feeds), len(filters), articles, out)
+	return nil
+}
+
+// handleRestore восстанавливает ленты, правила фильтров и статьи из дампа rsshub backup.
+// Ленты и правила, которые уже есть, пропускаются, а статьи, которые уже есть, не дублируются,
+// поэтому прерванное восстановление можно повторить
+func (c *CLI) handleRestore(ctx context.Context, args []string) error {
+	var in string
+
+	fs := newFlagSet()
+	fs.String("--in", &in)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+	if in == "" {
+		return usageErrorf("--in is required")
+	}
+
+	file, err := os.Open(in)
+	if err != nil {
+		return usageErrorf("failed to open backup file: %v", err)
+	}
+	defer file.Close()
+
+	dump, err := backup.OpenDump(file)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+
+	b := dump.Backup()
+	logger.Info("Restoring %d feeds and %d filter rules from %s", len(b.Feeds), len(b.Filters), in)
+	report, err := c.aggregator.Restore(ctx, b)
+	if err == nil {
+		err = c.restoreArticles(ctx, dump, report)
+	}
+
+	fmt.Printf("Restored %d feeds (%d already present), %d filter rules (%d already present)\n",
+		report.Feeds, report.ExistingFeeds, report.Filters, report.ExistingFilters)
+	fmt.Printf("Articles: %d restored, %d already present", report.Articles, report.ExistingArticles)
+	if report.SkippedArticles > 0 {
+		fmt.Printf(", %d skipped (their feed is not in the database)", report.SkippedArticles)
+	}
+	fmt.Println()
+	return err
+}
+
+// restoreArticles сохраняет статьи дампа частями по DUMP_BATCH_SIZE
+func (c *CLI) restoreArticles(ctx context.Context, dump *backup.DumpReader, report *domain.RestoreReport) error {
+	for {
+		entries, err := dump.NextArticles(DUMP_BATCH_SIZE)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := c.aggregator.RestoreArticles(ctx, entries, report); err != nil {
+			return err
+		}
+	}
+}
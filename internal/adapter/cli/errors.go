@@ -0,0 +1,151 @@
+// internal/adapter/cli/errors.go
+package cli
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/logger"
+)
+
+// Коды выхода программы. Значения стабильны, на них могут опираться скрипты
+const (
+	ExitOK            = 0
+	ExitFailure       = 1 // Прочие ошибки
+	ExitUsage         = 2 // Неверные аргументы командной строки
+	ExitNotFound      = 3 // Лента, смарт-лента, статья, правило уведомлений или ключ API не найдены
+	ExitDBUnavailable = 4 // База данных недоступна
+	ExitFetchFailed   = 5 // Не удалось получить или разобрать ленту
+)
+
+// errDBUnavailable отмечает ошибки подключения к базе данных
+var errDBUnavailable = errors.New("database unavailable")
+
+// exitError связывает ошибку с кодом выхода, не меняя ее текст
+type exitError struct {
+	err  error
+	code int
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+// usageErrorf создает ошибку неверного использования команды
+func usageErrorf(format string, args ...interface{}) error {
+	return &exitError{err: fmt.Errorf(format, args...), code: ExitUsage}
+}
+
+// usageError отмечает ошибку разбора аргумента как ошибку использования
+func usageError(err error) error {
+	return &exitError{err: err, code: ExitUsage}
+}
+
+// fetchError отмечает ошибку получения ленты
+func fetchError(err error) error {
+	return &exitError{err: err, code: ExitFetchFailed}
+}
+
+// DatabaseUnavailable отмечает ошибку подключения к базе данных (код выхода 4)
+func DatabaseUnavailable(err error) error {
+	return fmt.Errorf("%w: %v", errDBUnavailable, err)
+}
+
+// ExitCode возвращает код выхода для ошибки команды
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+
+	var netErr *net.OpError
+	switch {
+	case errors.Is(err, domain.ErrFeedNotFound), errors.Is(err, domain.ErrSmartFeedNotFound),
+		errors.Is(err, domain.ErrAPIKeyNotFound), errors.Is(err, domain.ErrArticleNotFound),
+		errors.Is(err, domain.ErrRuleNotFound):
+		return ExitNotFound
+	case errors.Is(err, errDBUnavailable), errors.Is(err, driver.ErrBadConn), errors.As(err, &netErr):
+		return ExitDBUnavailable
+	default:
+		return ExitFailure
+	}
+}
+
+// exitKinds названия кодов выхода для машиночитаемого вывода ошибок
+var exitKinds = map[int]string{
+	ExitFailure:       "error",
+	ExitUsage:         "usage",
+	ExitNotFound:      "not_found",
+	ExitDBUnavailable: "db_unavailable",
+	ExitFetchFailed:   "fetch_failed",
+}
+
+// Options глобальные флаги, которые можно указать в любом месте командной строки
+type Options struct {
+	Quiet      bool   // --quiet: не выводить логи и сообщения об ошибках, только код выхода
+	JSONErrors bool   // --json-errors: выводить ошибку одной JSON строкой в stderr
+	Timezone   string // --tz: часовой пояс вывода дат статей (пусто - локальный)
+	NoColor    bool   // --no-color: не выделять вывод цветом даже в терминале
+	ConfigFile string // --config: файл конфигурации вместо CLI_APP_CONFIG_FILE
+}
+
+// ParseOptions извлекает глобальные флаги и возвращает оставшиеся аргументы
+func ParseOptions(args []string) (Options, []string) {
+	var opts Options
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case i > 0 && arg == "--quiet":
+			opts.Quiet = true
+		case i > 0 && arg == "--json-errors":
+			opts.JSONErrors = true
+		case i > 0 && arg == "--no-color":
+			opts.NoColor = true
+		case i > 0 && arg == "--tz" && i+1 < len(args):
+			opts.Timezone = args[i+1]
+			i++
+		case i > 0 && strings.HasPrefix(arg, "--tz="):
+			opts.Timezone = strings.TrimPrefix(arg, "--tz=")
+		case i > 0 && arg == "--config" && i+1 < len(args):
+			opts.ConfigFile = args[i+1]
+			i++
+		case i > 0 && strings.HasPrefix(arg, "--config="):
+			opts.ConfigFile = strings.TrimPrefix(arg, "--config=")
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return opts, rest
+}
+
+// ReportError выводит ошибку в выбранном формате и возвращает код выхода
+func ReportError(err error, opts Options) int {
+	code := ExitCode(err)
+	if code == ExitOK {
+		return code
+	}
+
+	switch {
+	case opts.JSONErrors:
+		payload, _ := json.Marshal(struct {
+			Error string `json:"error"`
+			Code  int    `json:"code"`
+			Kind  string `json:"kind"`
+		}{Error: err.Error(), Code: code, Kind: exitKinds[code]})
+		fmt.Fprintln(os.Stderr, string(payload))
+	case opts.Quiet:
+	default:
+		logger.Error("Command failed: %v", err)
+	}
+
+	return code
+}
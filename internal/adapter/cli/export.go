@@ -0,0 +1,79 @@
+// internal/adapter/cli/export.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"rsshub/internal/adapter/backup"
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/logger"
+)
+
+// handleExport выгружает ленты в JSON со всеми настройками и правилами фильтров (читается
+// обратно через import --format json) или в OPML для других читалок
+func (c *CLI) handleExport(ctx context.Context, args []string) error {
+	var output string
+	format := backup.FormatJSON
+	withCredentials := false
+
+	fs := newFlagSet()
+	fs.Func("--format", func(value string) (err error) {
+		format, err = backup.ParseFormat(value)
+		return err
+	})
+	fs.String("--output", &output)
+	fs.Bool("--with-credentials", &withCredentials)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	if withCredentials && format != backup.FormatJSON {
+		return usageErrorf("--with-credentials is supported only for --format json")
+	}
+
+	feeds, err := c.db.GetAllFeeds(ctx, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get feeds: %w", err)
+	}
+	filters, err := c.db.GetFilterRules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get filter rules: %w", err)
+	}
+	// Учетные данные выгружаются открытым текстом, поэтому только по явному запросу
+	if !withCredentials {
+		for _, feed := range feeds {
+			feed.Auth = nil
+		}
+	}
+
+	// Прерванная выгрузка не должна оставить файл с частью лент
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	w := os.Stdout
+	if output != "" {
+		// Файл с учетными данными доступен только владельцу
+		file, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if err := backup.Write(w, format, &domain.Backup{Feeds: feeds, Filters: filters}); err != nil {
+		return fmt.Errorf("failed to export feeds: %w", err)
+	}
+
+	if output != "" {
+		if format == backup.FormatJSON {
+			logger.Success("Exported %d feeds and %d filter rules to %s", len(feeds), len(filters), output)
+		} else {
+			logger.Success("Exported %d feeds to %s", len(feeds), output)
+		}
+	}
+	return nil
+}
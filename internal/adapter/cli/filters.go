@@ -0,0 +1,139 @@
+// internal/adapter/cli/filters.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/logger"
+)
+
+// handleFilter управляет правилами фильтров элементов лент: add, list, delete и test
+func (c *CLI) handleFilter(ctx context.Context, args []string) error {
+	var name, expr string
+	filterAction := domain.FilterDrop
+	since := DEFAULT_RULE_TEST_SINCE
+
+	fs := newFlagSet()
+	fs.String("--name", &name)
+	fs.String("--expr", &expr)
+	fs.Func("--action", func(value string) error {
+		parsed, err := domain.ParseFilterAction(value)
+		if err != nil {
+			return usageErrorf("%v", err)
+		}
+		filterAction = parsed
+		return nil
+	})
+	fs.PositiveDuration("--since", &since)
+	action, err := fs.parseAction(args[2:])
+	if err != nil {
+		return err
+	}
+	if action == "" {
+		return usageErrorf("filter requires an action: add, list, delete or test")
+	}
+
+	switch action {
+	case "add":
+		if name == "" || expr == "" {
+			return usageErrorf("--name and --expr are required")
+		}
+		if _, err := domain.ParseExpression(expr); err != nil {
+			return usageErrorf("invalid expression: %v", err)
+		}
+		rule := &domain.FilterRule{Name: name, Expression: expr, Action: filterAction}
+		if err := c.db.CreateFilterRule(ctx, rule); err != nil {
+			return err
+		}
+		logger.Success("Successfully added filter rule: %s (%s %s)", rule.Name, rule.Action, rule.Expression)
+		return nil
+	case "list":
+		return c.listFilters(ctx)
+	case "delete":
+		if name == "" {
+			return usageErrorf("--name is required")
+		}
+		if err := c.db.DeleteFilterRule(ctx, name); err != nil {
+			return err
+		}
+		logger.Success("Deleted filter rule: %s", name)
+		return nil
+	case "test":
+		if name == "" {
+			return usageErrorf("--name is required")
+		}
+		return c.testFilter(ctx, name, since)
+	default:
+		return usageErrorf("unknown filter action: %s (expected add, list, delete or test)", action)
+	}
+}
+
+// listFilters выводит правила фильтров
+func (c *CLI) listFilters(ctx context.Context) error {
+	rules, err := c.db.GetFilterRules(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(rules) == 0 {
+		fmt.Println("No filter rules found: every feed item is saved")
+		return nil
+	}
+
+	fmt.Println("# Filter Rules")
+	fmt.Println()
+
+	for i, rule := range rules {
+		fmt.Printf("%d. Name: %s\n", i+1, rule.Name)
+		fmt.Printf("   Expression: %s\n", rule.Expression)
+		fmt.Printf("   Action: %s\n", rule.Action)
+		fmt.Printf("   Added: %s\n", rule.CreatedAt.Format("2006-01-02 15:04"))
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// testFilter показывает статьи, сохраненные за период since, которые подходят под правило фильтра,
+// то есть были бы отброшены правилом drop или сохранены правилом keep
+func (c *CLI) testFilter(ctx context.Context, name string, since time.Duration) error {
+	rules, err := c.db.GetFilterRules(ctx)
+	if err != nil {
+		return err
+	}
+
+	var rule *domain.FilterRule
+	for _, r := range rules {
+		if r.Name == name {
+			rule = r
+		}
+	}
+	if rule == nil {
+		return fmt.Errorf("%w: %s", domain.ErrFilterNotFound, name)
+	}
+
+	expr, err := domain.ParseExpression(rule.Expression)
+	if err != nil {
+		return fmt.Errorf("filter rule %s has an invalid expression: %w", rule.Name, err)
+	}
+
+	entries, err := c.db.GetArticlesSince(ctx, time.Now().Add(-since), 0)
+	if err != nil {
+		return err
+	}
+
+	matched := 0
+	for _, entry := range entries {
+		if !expr.Match(entry) {
+			continue
+		}
+		matched++
+		fmt.Printf("- [%s] %s\n  %s\n", entry.FeedName, entry.Article.Title, entry.Article.Link)
+	}
+	fmt.Printf("Filter rule %s (%s) matches %d of %d articles saved in the last %s\n",
+		rule.Name, rule.Action, matched, len(entries), since)
+	return nil
+}
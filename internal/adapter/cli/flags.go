@@ -0,0 +1,169 @@
+// internal/adapter/cli/flags.go
+package cli
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// flagSet флаги одной команды. Все команды разбирают аргументы одинаково: флаг со значением
+// указывается как --name value или --name=value, логический - как --name; неизвестный флаг,
+// флаг без значения и лишний аргумент - ошибки использования (код выхода 2)
+type flagSet struct {
+	flags map[string]*flagDef
+}
+
+// flagDef обработчик одного флага
+type flagDef struct {
+	boolean bool                     // Флаг без значения
+	set     func(value string) error // Для логического флага value пустое
+}
+
+// newFlagSet создает пустой набор флагов команды
+func newFlagSet() *flagSet {
+	return &flagSet{flags: make(map[string]*flagDef)}
+}
+
+// String регистрирует флаг со строковым значением
+func (f *flagSet) String(name string, p *string) {
+	f.Func(name, func(value string) error {
+		*p = value
+		return nil
+	})
+}
+
+// Int регистрирует флаг с целым значением; проверку диапазона выполняет команда
+func (f *flagSet) Int(name string, p *int) {
+	f.Func(name, func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return usageErrorf("invalid number: %s", value)
+		}
+		*p = n
+		return nil
+	})
+}
+
+// PositiveInt регистрирует флаг с целым положительным значением
+func (f *flagSet) PositiveInt(name string, p *int) {
+	f.Func(name, func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return usageErrorf("invalid %s value: %s (expected a positive number)", name, value)
+		}
+		*p = n
+		return nil
+	})
+}
+
+// PositiveDuration регистрирует флаг с положительной длительностью в формате Go (30s, 24h)
+func (f *flagSet) PositiveDuration(name string, p *time.Duration) {
+	f.Func(name, func(value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return usageErrorf("invalid duration: %s", value)
+		}
+		*p = d
+		return nil
+	})
+}
+
+// Duration регистрирует флаг с длительностью в формате Go (30s, 24h)
+func (f *flagSet) Duration(name string, p *time.Duration) {
+	f.Func(name, func(value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return usageErrorf("invalid duration: %s", value)
+		}
+		*p = d
+		return nil
+	})
+}
+
+// Bool регистрирует логический флаг, который устанавливает *p в true
+func (f *flagSet) Bool(name string, p *bool) {
+	f.BoolFunc(name, func() { *p = true })
+}
+
+// Func регистрирует флаг со значением, которое разбирает set; флаг можно повторять,
+// set вызывается для каждого значения
+func (f *flagSet) Func(name string, set func(value string) error) {
+	f.flags[name] = &flagDef{set: set}
+}
+
+// BoolFunc регистрирует логический флаг, при каждом указании которого вызывается set
+func (f *flagSet) BoolFunc(name string, set func()) {
+	f.flags[name] = &flagDef{boolean: true, set: func(string) error {
+		set()
+		return nil
+	}}
+}
+
+// parse разбирает флаги команды, не принимающей позиционных аргументов
+func (f *flagSet) parse(args []string) error {
+	positional, err := f.parseArgs(args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageErrorf("unexpected argument: %s", positional[0])
+	}
+	return nil
+}
+
+// parseAction разбирает флаги команды с действием (rule add, apikey list) и возвращает
+// действие - единственный позиционный аргумент (пусто, если действие не указано)
+func (f *flagSet) parseAction(args []string) (string, error) {
+	positional, err := f.parseArgs(args)
+	if err != nil {
+		return "", err
+	}
+	switch len(positional) {
+	case 0:
+		return "", nil
+	case 1:
+		return positional[0], nil
+	default:
+		return "", usageErrorf("unexpected argument: %s", positional[1])
+	}
+}
+
+// parseArgs разбирает флаги и возвращает позиционные аргументы в исходном порядке
+func (f *flagSet) parseArgs(args []string) ([]string, error) {
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			positional = append(positional, arg)
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(arg, "=")
+		def, ok := f.flags[name]
+		if !ok {
+			return nil, usageErrorf("unknown flag: %s", name)
+		}
+
+		switch {
+		case def.boolean && hasValue:
+			return nil, usageErrorf("%s does not take a value", name)
+		case !def.boolean && !hasValue:
+			if i+1 >= len(args) {
+				return nil, usageErrorf("%s requires a value", name)
+			}
+			value = args[i+1]
+			i++
+		}
+
+		if err := def.set(value); err != nil {
+			var exitErr *exitError
+			if !errors.As(err, &exitErr) {
+				err = usageError(err)
+			}
+			return nil, err
+		}
+	}
+	return positional, nil
+}
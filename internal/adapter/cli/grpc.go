@@ -0,0 +1,81 @@
+// internal/adapter/cli/grpc.go
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"rsshub/internal/adapter/control"
+	"rsshub/internal/adapter/grpcapi"
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+)
+
+// grpcController управляет агрегатором для gRPC API так же, как команды status --live,
+// refresh, set-interval и set-workers
+type grpcController struct {
+	c *CLI
+}
+
+// Status запрашивает состояние rsshub fetch через сокет управления; nil - он не запущен
+func (g grpcController) Status(ctx context.Context) (*domain.AggregatorStatus, error) {
+	status, err := control.Status(ctx, g.c.config.Aggregator.ControlSocket)
+	if errors.Is(err, control.ErrNotRunning) {
+		return nil, nil
+	}
+	return status, err
+}
+
+// Refresh получает ленту через запущенный агрегатор, а если он не запущен - в этом процессе
+func (g grpcController) Refresh(ctx context.Context, feed *domain.Feed, force bool) (*domain.FeedResult, error) {
+	return g.c.refreshFeed(ctx, feed, force, false)
+}
+
+// SetInterval сохраняет интервал получения лент и уведомляет запущенные агрегаторы
+func (g grpcController) SetInterval(ctx context.Context, interval time.Duration) error {
+	return g.c.settingsManager.SetInterval(ctx, interval)
+}
+
+// SetWorkers сохраняет количество воркеров и уведомляет запущенные агрегаторы
+func (g grpcController) SetWorkers(ctx context.Context, count int) error {
+	return g.c.settingsManager.SetWorkers(ctx, count)
+}
+
+// startGRPC начинает слушать addr и обслуживать gRPC API до отмены ctx. Ошибка прослушивания
+// возвращается сразу, чтобы serve не запустился с недоступным gRPC
+func (c *CLI) startGRPC(ctx context.Context, addr string, events port.ArticleEventSource) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for gRPC on %s: %w", addr, err)
+	}
+
+	server := grpcapi.NewServer(c.db, c.parser, events, grpcController{c: c})
+	go func() {
+		<-ctx.Done()
+		// Открытые потоки StreamArticles закрываются вместе с рассылкой статей; если вызовы
+		// не завершились за SHUTDOWN_TIMEOUT, соединения закрываются принудительно
+		stopped := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(SHUTDOWN_TIMEOUT):
+			server.Stop()
+		}
+	}()
+
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			logger.Error("gRPC server failed: %v", err)
+		}
+	}()
+
+	logger.Success("gRPC API listening on %s", addr)
+	return nil
+}
@@ -0,0 +1,157 @@
+// internal/adapter/cli/health.go
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"rsshub/internal/core/port"
+	aggregator "rsshub/internal/core/service"
+)
+
+// HEALTH_PROBE_LOCK блокировка, которой health проверяет работу механизма блокировок
+const HEALTH_PROBE_LOCK = "rsshub_health_probe"
+
+// Состояния проверок health
+const (
+	healthOK   = "ok"
+	healthFail = "fail"
+)
+
+// healthCheck результат одной проверки
+type healthCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// healthReport отчет команды health
+type healthReport struct {
+	Status string        `json:"status"`
+	Checks []healthCheck `json:"checks"`
+}
+
+// newHealthCheck создает результат проверки: при ошибке проверка неуспешна, а текст
+// ошибки заменяет detail
+func newHealthCheck(name string, err error, detail string) healthCheck {
+	if err != nil {
+		return healthCheck{Name: name, Status: healthFail, Detail: err.Error()}
+	}
+	return healthCheck{Name: name, Status: healthOK, Detail: detail}
+}
+
+// add добавляет проверку; любая неуспешная проверка делает отчет неуспешным
+func (r *healthReport) add(check healthCheck) {
+	if check.Status != healthOK {
+		r.Status = healthFail
+	}
+	r.Checks = append(r.Checks, check)
+}
+
+// print выводит отчет в stdout в формате JSON
+func (r *healthReport) print() {
+	data, _ := json.MarshalIndent(r, "", "  ")
+	fmt.Println(string(data))
+}
+
+// handleHealth проверяет подключение к БД, состояние миграций, работу блокировок
+// и, если агрегатор запущен, что он жив. Отчет выводится в JSON; код выхода 0, если
+// все проверки успешны, 4 - если БД недоступна, иначе 1
+func (c *CLI) handleHealth(ctx context.Context, args []string) error {
+	if err := newFlagSet().parse(args[2:]); err != nil {
+		return err
+	}
+	report := &healthReport{Status: healthOK}
+
+	if pinger, ok := c.db.(interface{ Ping() error }); ok {
+		if err := pinger.Ping(); err != nil {
+			report.add(newHealthCheck("database", err, ""))
+			report.print()
+			return &exitError{err: fmt.Errorf("health check failed: database unavailable"), code: ExitDBUnavailable}
+		}
+	}
+	report.add(newHealthCheck("database", nil, "connected"))
+
+	if migrator, ok := c.db.(port.Migrator); ok {
+		report.add(c.checkMigrations(migrator))
+	}
+
+	report.add(c.checkLocks(ctx))
+	report.add(c.checkAggregator(ctx))
+
+	report.print()
+	if report.Status != healthOK {
+		return fmt.Errorf("health check failed")
+	}
+	return nil
+}
+
+// checkMigrations проверяет, что все миграции схемы применены
+func (c *CLI) checkMigrations(migrator port.Migrator) healthCheck {
+	statuses, err := migrator.MigrationsStatus()
+	if err != nil {
+		return newHealthCheck("migrations", err, "")
+	}
+
+	pending := 0
+	for _, m := range statuses {
+		if m.AppliedAt == nil {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return newHealthCheck("migrations", fmt.Errorf("%d of %d migrations pending (run: rsshub migrate up)", pending, len(statuses)), "")
+	}
+	return newHealthCheck("migrations", nil, fmt.Sprintf("%d applied", len(statuses)))
+}
+
+// checkLocks проверяет, что блокировку можно получить и освободить, и сообщает,
+// удерживается ли блокировка fetch --exclusive
+func (c *CLI) checkLocks(ctx context.Context) healthCheck {
+	locked, err := c.db.TryLock(ctx, HEALTH_PROBE_LOCK)
+	if err != nil {
+		return newHealthCheck("locks", err, "")
+	}
+	if !locked {
+		return newHealthCheck("locks", fmt.Errorf("probe lock %s is held (another health check running?)", HEALTH_PROBE_LOCK), "")
+	}
+	if err := c.db.ReleaseLock(context.WithoutCancel(ctx), HEALTH_PROBE_LOCK); err != nil {
+		return newHealthCheck("locks", err, "")
+	}
+
+	if _, err := c.db.GetAggregatorSetting(ctx, DB_LOCK_NAME); err == nil {
+		return newHealthCheck("locks", nil, "available; exclusive fetch lock is held")
+	}
+	return newHealthCheck("locks", nil, "available")
+}
+
+// checkAggregator проверяет отметку фонового процесса: если агрегатор запущен,
+// отметка должна быть свежее HeartbeatTimeout
+func (c *CLI) checkAggregator(ctx context.Context) healthCheck {
+	heartbeat := aggregator.ReadHeartbeat(ctx, c.db)
+	switch {
+	case heartbeat == nil || heartbeat.Stopped:
+		return newHealthCheck("aggregator", nil, "not running")
+	case heartbeat.Alive(time.Now()):
+		detail := fmt.Sprintf("running (%s, last heartbeat %s)", heartbeat.Instance, heartbeat.At.Format(time.RFC3339))
+		if stats := aggregator.ReadCycleStats(ctx, c.db); stats != nil {
+			detail += fmt.Sprintf("; cycles: %d started, %d skipped, %d queued; articles: %d inserted, %d duplicates, %d updated",
+				stats.Started, stats.Skipped, stats.Queued, stats.Inserted, stats.Duplicates, stats.Updated)
+		}
+		return newHealthCheck("aggregator", nil, detail)
+	default:
+		return newHealthCheck("aggregator", fmt.Errorf("no heartbeat from %s since %s (process hung or crashed)",
+			heartbeat.Instance, heartbeat.At.Format(time.RFC3339)), "")
+	}
+}
+
+// ReportDatabaseDown выводит отчет health, когда подключиться к БД не удалось,
+// и возвращает код выхода ExitDBUnavailable
+func ReportDatabaseDown(err error) int {
+	report := &healthReport{Status: healthOK}
+	report.add(newHealthCheck("database", err, ""))
+	report.print()
+	return ExitDBUnavailable
+}
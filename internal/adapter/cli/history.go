@@ -0,0 +1,161 @@
+// internal/adapter/cli/history.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"rsshub/internal/core/domain"
+)
+
+// maxDiffCells ограничение размера таблицы LCS при сравнении описаний; более длинные
+// описания показываются целиком, как удаленные и добавленные
+const maxDiffCells = 1 << 20
+
+// handleHistory показывает версии содержимого статьи (--link URL) и изменения между ними;
+// с --full каждая версия выводится целиком
+func (c *CLI) handleHistory(ctx context.Context, args []string) error {
+	var link, feedName string
+	full := false
+
+	fs := newFlagSet()
+	fs.String("--link", &link)
+	fs.String("--feed-name", &feedName)
+	fs.Bool("--full", &full)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	if link == "" {
+		return usageErrorf("--link is required")
+	}
+
+	entries, err := c.db.GetArticlesByLink(ctx, link)
+	if err != nil {
+		return err
+	}
+
+	shown := 0
+	for _, entry := range entries {
+		if feedName != "" && entry.FeedName != feedName {
+			continue
+		}
+		versions, err := c.db.GetArticleVersions(ctx, entry.Article.ID)
+		if err != nil {
+			return err
+		}
+		if shown > 0 {
+			fmt.Println()
+		}
+		c.printHistory(entry, versions, full)
+		shown++
+	}
+
+	if shown == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrArticleNotFound, link)
+	}
+	return nil
+}
+
+// printHistory выводит версии статьи: первую целиком, следующие - как изменения относительно предыдущей
+func (c *CLI) printHistory(entry *domain.DigestEntry, versions []*domain.ArticleVersion, full bool) {
+	article := entry.Article
+	fmt.Printf("Article: %s\n", article.Title)
+	fmt.Printf("   Feed: %s\n", entry.FeedName)
+	fmt.Printf("   Link: %s\n", article.Link)
+
+	if len(versions) == 0 {
+		fmt.Println("   No versions recorded (the article was saved before version tracking)")
+		return
+	}
+	fmt.Printf("   Versions: %d\n", len(versions))
+
+	for i, v := range versions {
+		label := ""
+		if i == 0 {
+			label = " (first seen)"
+		}
+		if v.ContentHash == article.ContentHash {
+			label += " (current)"
+		}
+		fmt.Printf("\nVersion %d - %s%s\n", i+1, c.localTime(v.CreatedAt).Format("2006-01-02 15:04"), label)
+
+		if i == 0 || full {
+			fmt.Printf("   Title: %s\n", v.Title)
+			for _, line := range descriptionLines(v.Description) {
+				fmt.Printf("   %s\n", line)
+			}
+			continue
+		}
+
+		prev := versions[i-1]
+		if prev.Title != v.Title {
+			fmt.Printf("   - Title: %s\n", prev.Title)
+			fmt.Printf("   + Title: %s\n", v.Title)
+		}
+		for _, line := range diffLines(descriptionLines(prev.Description), descriptionLines(v.Description)) {
+			fmt.Printf("   %s\n", line)
+		}
+		if prev.Title == v.Title && prev.Description == v.Description {
+			fmt.Println("   (no visible changes)")
+		}
+	}
+}
+
+// descriptionLines разбивает описание на непустые строки без пробелов по краям
+func descriptionLines(description string) []string {
+	var lines []string
+	for _, line := range strings.Split(description, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// diffLines возвращает измененные строки: удаленные из a с "- " и добавленные в b с "+ ".
+// Общие строки находятся как наибольшая общая подпоследовательность и не выводятся
+func diffLines(a, b []string) []string {
+	var diff []string
+	if len(a)*len(b) > maxDiffCells {
+		for _, line := range a {
+			diff = append(diff, "- "+line)
+		}
+		for _, line := range b {
+			diff = append(diff, "+ "+line)
+		}
+		return diff
+	}
+
+	// lcs[i][j] - длина общей подпоследовательности a[i:] и b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			diff = append(diff, "- "+a[i])
+			i++
+		default:
+			diff = append(diff, "+ "+b[j])
+			j++
+		}
+	}
+	return diff
+}
@@ -0,0 +1,82 @@
+// internal/adapter/cli/icon.go
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/logger"
+)
+
+// handleIcon показывает закешированную иконку ленты, обновляет ее (--refresh)
+// или сохраняет изображение в файл (--output)
+func (c *CLI) handleIcon(ctx context.Context, args []string) error {
+	var feedName, output string
+	refresh := false
+
+	fs := newFlagSet()
+	fs.String("--feed-name", &feedName)
+	fs.String("--output", &output)
+	fs.Bool("--refresh", &refresh)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	if feedName == "" {
+		return usageErrorf("--feed-name is required")
+	}
+
+	feed, err := c.db.GetFeedByName(ctx, feedName)
+	if err != nil {
+		return err
+	}
+
+	var icon *domain.FeedIcon
+	if refresh {
+		icon, err = c.aggregator.RefreshIcon(ctx, feed)
+		if err != nil && icon == nil {
+			return fetchError(fmt.Errorf("failed to refresh icon of feed %s: %w", feed.Name, err))
+		}
+		if err != nil {
+			logger.Warn("Failed to refresh icon of feed %s: %v", feed.Name, err)
+		}
+	} else {
+		icon, err = c.db.GetFeedIcon(ctx, feed.ID)
+		if err != nil && !errors.Is(err, domain.ErrIconNotFound) {
+			return fmt.Errorf("failed to get feed icon: %w", err)
+		}
+	}
+
+	if icon == nil || len(icon.Data) == 0 {
+		fmt.Printf("No icon found for feed: %s\n", feed.Name)
+		if icon != nil && icon.LastError != "" {
+			fmt.Printf("Last attempt: %s (%s)\n", icon.CheckedAt.Format("2006-01-02 15:04"), icon.LastError)
+		}
+		if output != "" {
+			return fmt.Errorf("feed %s has no icon to save", feed.Name)
+		}
+		return nil
+	}
+
+	if output != "" {
+		if err := os.WriteFile(output, icon.Data, 0o644); err != nil {
+			return fmt.Errorf("failed to write icon: %w", err)
+		}
+		logger.Success("Saved icon of feed %s to %s", feed.Name, output)
+		return nil
+	}
+
+	fmt.Printf("Feed: %s\n", feed.Name)
+	fmt.Printf("   Source: %s\n", icon.URL)
+	fmt.Printf("   Type: %s (%d bytes)\n", icon.ContentType, len(icon.Data))
+	if icon.FetchedAt != nil {
+		fmt.Printf("   Fetched: %s\n", icon.FetchedAt.Format("2006-01-02 15:04"))
+	}
+	if icon.LastError != "" {
+		fmt.Printf("   Last refresh failed: %s (%s)\n", icon.CheckedAt.Format("2006-01-02 15:04"), icon.LastError)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// internal/adapter/cli/import.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"rsshub/internal/adapter/backup"
+	"rsshub/internal/adapter/importer"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+)
+
+// DEFAULT_IMPORT_MAX сколько прочитанных и сколько избранных статей переносит import --with-state без --max
+const DEFAULT_IMPORT_MAX = 1000
+
+// handleImport переносит подписки (и с --with-state состояние статей) из Miniflux или FreshRSS,
+// подписки с папками из файла OPML, либо восстанавливает ленты со всеми настройками из резервной
+// копии rsshub export (--format json)
+func (c *CLI) handleImport(ctx context.Context, args []string) error {
+	var from, format, baseURL, apiKey, user, password, file string
+	withState := false
+	maxEntries := DEFAULT_IMPORT_MAX
+
+	fs := newFlagSet()
+	fs.String("--from", &from)
+	fs.String("--format", &format)
+	fs.String("--url", &baseURL)
+	fs.String("--api-key", &apiKey)
+	fs.String("--user", &user)
+	fs.String("--password", &password)
+	fs.String("--file", &file)
+	fs.PositiveInt("--max", &maxEntries)
+	fs.Bool("--with-state", &withState)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	switch format {
+	case "":
+	case "json":
+		if from != "" {
+			return usageErrorf("--from can't be used with --format json")
+		}
+		if withState {
+			return usageErrorf("--with-state is not supported for json: backups contain only feeds and filter rules")
+		}
+		return c.restore(ctx, file)
+	case "opml":
+		if from != "" && from != "opml" {
+			return usageErrorf("--from %s can't be used with --format opml", from)
+		}
+		from = "opml"
+	default:
+		return usageErrorf("unknown import format: %s (expected json or opml)", format)
+	}
+
+	// Откуда импортируются подписки: адрес сервера или файл OPML
+	origin := baseURL
+	if from == "opml" {
+		if file == "" {
+			return usageErrorf("--file is required for opml")
+		}
+		if withState {
+			return usageErrorf("--with-state is not supported for opml: OPML files contain only subscriptions")
+		}
+		origin = file
+	} else if baseURL == "" {
+		return usageErrorf("--url is required")
+	}
+
+	var source port.SubscriptionSource
+	var err error
+	switch from {
+	case "opml":
+		source, err = importer.NewOPML(file)
+	case "miniflux":
+		if apiKey == "" {
+			return usageErrorf("--api-key is required for miniflux")
+		}
+		source, err = importer.NewMiniflux(baseURL, apiKey, c.config.Fetcher.UserAgent, c.config.Fetcher.Timeout)
+	case "freshrss":
+		if user == "" || password == "" {
+			return usageErrorf("--user and --password (API password) are required for freshrss")
+		}
+		source, err = importer.NewFreshRSS(baseURL, user, password, c.config.Fetcher.UserAgent, c.config.Fetcher.Timeout)
+	case "":
+		return usageErrorf("--from (miniflux, freshrss or opml) or --format json is required")
+	default:
+		return usageErrorf("unknown import source: %s (expected miniflux, freshrss or opml)", from)
+	}
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+
+	logger.Info("Importing subscriptions from %s (%s)", from, origin)
+	report, err := c.aggregator.Import(ctx, source, withState, maxEntries)
+
+	fmt.Printf("Imported %d feeds from %s (%d already present)\n", report.Feeds, from, report.ExistingFeeds)
+	if withState {
+		fmt.Printf("Articles: %d added, %d marked read, %d starred\n", report.Articles, report.Read, report.Starred)
+	}
+	if err != nil {
+		return fetchError(fmt.Errorf("import from %s failed: %w", from, err))
+	}
+	return nil
+}
+
+// restore восстанавливает ленты и правила фильтров из резервной копии rsshub export --format json
+func (c *CLI) restore(ctx context.Context, path string) error {
+	if path == "" {
+		return usageErrorf("--file is required for json")
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return usageErrorf("failed to open backup file: %v", err)
+	}
+	defer file.Close()
+
+	b, err := backup.Read(file)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+
+	logger.Info("Restoring %d feeds and %d filter rules from %s", len(b.Feeds), len(b.Filters), path)
+	report, err := c.aggregator.Restore(ctx, b)
+
+	fmt.Printf("Restored %d feeds (%d already present) and %d filter rules (%d already present)\n",
+		report.Feeds, report.ExistingFeeds, report.Filters, report.ExistingFilters)
+	return err
+}
@@ -0,0 +1,198 @@
+// internal/adapter/cli/maintenance.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+)
+
+const (
+	// MAINTENANCE_LOCK_NAME не дает двум rsshub maintenance работать одновременно
+	MAINTENANCE_LOCK_NAME = "rsshub_maintenance_lock"
+
+	// MIN_TRAFFIC_RETENTION меньше месяца учета трафика нельзя: по нему считается месячный лимит
+	MIN_TRAFFIC_RETENTION = 31 * 24 * time.Hour
+
+	// BLOAT_HINT_RATIO с какой доли мертвых строк таблица попадает в подсказки
+	BLOAT_HINT_RATIO = 0.2
+
+	// BLOAT_HINT_MIN_SIZE с какого размера таблица попадает в подсказки
+	BLOAT_HINT_MIN_SIZE = 1 << 20
+)
+
+// handleMaintenance обслуживает базу данных: удаляет данные старше сроков хранения и строки
+// без ленты или статьи, выполняет VACUUM (ANALYZE) и показывает, сколько места освобождено,
+// а также подсказки по раздутым таблицам и лишним или сломанным индексам
+func (c *CLI) handleMaintenance(ctx context.Context, args []string) error {
+	maintainer, ok := c.db.(port.Maintainer)
+	if !ok {
+		return fmt.Errorf("storage does not support maintenance")
+	}
+
+	retention := c.config.Retention
+	policy := domain.RetentionPolicy{
+		Articles:    retention.Articles,
+		KeepPerFeed: retention.KeepPerFeed,
+		FetchRuns:   retention.FetchRuns,
+		Traffic:     retention.Traffic,
+	}
+	dryRun, full, noVacuum := false, false, false
+
+	fs := newFlagSet()
+	fs.Duration("--article-retention", &policy.Articles)
+	fs.Int("--keep-per-feed", &policy.KeepPerFeed)
+	fs.Bool("--dry-run", &dryRun)
+	fs.Bool("--full", &full)
+	fs.Bool("--no-vacuum", &noVacuum)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	switch {
+	case policy.Articles < 0 || policy.FetchRuns < 0 || policy.Traffic < 0:
+		return usageErrorf("retention periods must not be negative (0 keeps the data forever)")
+	case policy.KeepPerFeed < 0:
+		return usageErrorf("--keep-per-feed must not be negative")
+	case policy.Traffic > 0 && policy.Traffic < MIN_TRAFFIC_RETENTION:
+		return usageErrorf("CLI_APP_TRAFFIC_RETENTION must be at least %v: the monthly bandwidth limit counts the traffic of the whole month", MIN_TRAFFIC_RETENTION)
+	case full && noVacuum:
+		return usageErrorf("use either --full or --no-vacuum, not both")
+	}
+
+	locked, err := c.db.TryLock(ctx, MAINTENANCE_LOCK_NAME)
+	if err != nil {
+		return fmt.Errorf("failed to acquire database lock: %w", err)
+	}
+	if !locked {
+		return fmt.Errorf("maintenance is already running")
+	}
+	// Прерванное обслуживание тоже освобождает блокировку
+	defer func() {
+		if err := c.db.ReleaseLock(context.WithoutCancel(ctx), MAINTENANCE_LOCK_NAME); err != nil {
+			logger.Error("Failed to release database lock: %v", err)
+		}
+	}()
+
+	before, err := maintainer.TableStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	report, err := maintainer.Prune(ctx, policy, dryRun)
+	printPruneReport(report, policy, dryRun)
+	if err != nil {
+		return err
+	}
+
+	after := before
+	if !dryRun && !noVacuum {
+		tables := make([]string, 0, len(before))
+		for _, t := range before {
+			tables = append(tables, t.Name)
+		}
+		if full {
+			logger.Warn("VACUUM FULL locks each table while it is rewritten: fetching and reading wait for it")
+		}
+		start := time.Now()
+		if err := maintainer.Vacuum(ctx, tables, full); err != nil {
+			return err
+		}
+		logger.Info("Vacuumed %d tables in %v", len(tables), time.Since(start).Round(time.Millisecond))
+
+		if after, err = maintainer.TableStats(ctx); err != nil {
+			return err
+		}
+	}
+	printTableSizes(before, after, full)
+
+	hints, err := maintainer.IndexHints(ctx)
+	if err != nil {
+		return err
+	}
+	printMaintenanceHints(before, hints)
+	return nil
+}
+
+// printPruneReport выводит, сколько строк удалено (или было бы удалено с --dry-run)
+func printPruneReport(report *domain.PruneReport, policy domain.RetentionPolicy, dryRun bool) {
+	verb := "Deleted"
+	if dryRun {
+		verb = "Would delete"
+	}
+
+	fmt.Println("# Retention")
+	fmt.Println()
+	if policy.Articles > 0 {
+		fmt.Printf("%s %d articles older than %v (starred articles and the newest %d of each feed are kept)\n",
+			verb, report.Articles, policy.Articles, policy.KeepPerFeed)
+	} else {
+		fmt.Println("Articles are kept forever (CLI_APP_ARTICLE_RETENTION=0)")
+	}
+	if policy.FetchRuns > 0 {
+		fmt.Printf("%s %d fetch runs older than %v\n", verb, report.FetchRuns, policy.FetchRuns)
+	}
+	if policy.Traffic > 0 {
+		fmt.Printf("%s %d feed traffic days older than %v\n", verb, report.Traffic, policy.Traffic)
+	}
+	fmt.Printf("%s %d orphaned articles and %d orphaned media and version rows\n", verb, report.OrphanArticles, report.OrphanRows)
+	fmt.Println()
+}
+
+// printTableSizes выводит размер таблиц до и после обслуживания и сколько места освобождено
+func printTableSizes(before, after []domain.TableStats, full bool) {
+	sizes := make(map[string]int64, len(after))
+	for _, t := range after {
+		sizes[t.Name] = t.TotalBytes
+	}
+
+	fmt.Println("# Tables")
+	fmt.Println()
+	fmt.Printf("%-24s %12s %12s %12s %10s\n", "TABLE", "BEFORE", "AFTER", "ROWS", "DEAD")
+	var reclaimed int64
+	for _, t := range before {
+		size := sizes[t.Name]
+		reclaimed += t.TotalBytes - size
+		fmt.Printf("%-24s %12s %12s %12d %9.0f%%\n", t.Name, domain.FormatBytes(t.TotalBytes), domain.FormatBytes(size),
+			t.LiveRows, t.DeadRatio()*100)
+	}
+	fmt.Println()
+
+	if reclaimed < 0 {
+		reclaimed = 0
+	}
+	fmt.Printf("Space reclaimed: %s\n", domain.FormatBytes(reclaimed))
+	if !full {
+		fmt.Println("VACUUM makes the space of deleted rows reusable by new ones; use --full to return it to the operating system")
+	}
+	fmt.Println()
+}
+
+// printMaintenanceHints выводит подсказки по таблицам с большой долей мертвых строк
+// до обслуживания и по индексам
+func printMaintenanceHints(before []domain.TableStats, indexes []domain.IndexHint) {
+	var lines []string
+	for _, t := range before {
+		if t.TotalBytes >= BLOAT_HINT_MIN_SIZE && t.DeadRatio() >= BLOAT_HINT_RATIO {
+			lines = append(lines, fmt.Sprintf("table %s (%s): %.0f%% dead rows before maintenance; autovacuum is not keeping up with it, consider a lower autovacuum_vacuum_scale_factor",
+				t.Name, domain.FormatBytes(t.TotalBytes), t.DeadRatio()*100))
+		}
+	}
+	for _, h := range indexes {
+		lines = append(lines, fmt.Sprintf("index %s on %s (%s): %s", h.Index, h.Table, domain.FormatBytes(h.Bytes), h.Hint))
+	}
+
+	if len(lines) == 0 {
+		fmt.Println("No maintenance hints")
+		return
+	}
+	fmt.Println("# Hints")
+	fmt.Println()
+	for _, line := range lines {
+		fmt.Printf("- %s\n", line)
+	}
+}
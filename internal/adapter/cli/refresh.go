@@ -0,0 +1,82 @@
+// internal/adapter/cli/refresh.go
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"rsshub/internal/adapter/control"
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/logger"
+)
+
+// handleRefresh сразу получает одну ленту и печатает количество новых статей. Если запущен
+// rsshub fetch, ленту обновляет он (через сокет управления), иначе или с --inline - эта команда.
+// --force получает ленту, даже если ее еще рано получать, и запрашивает ее безусловно,
+// без ETag и Last-Modified прошлого ответа
+func (c *CLI) handleRefresh(ctx context.Context, args []string) error {
+	var feedName, feedID, feedURL string
+	force, inline := false, false
+
+	fs := newFlagSet()
+	fs.String("--feed-name", &feedName)
+	fs.String("--feed-id", &feedID)
+	fs.String("--feed-url", &feedURL)
+	fs.Bool("--force", &force)
+	fs.Bool("--inline", &inline)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	feedName, err := c.feedNameByRef(ctx, feedName, feedID, feedURL)
+	if err != nil {
+		return err
+	}
+	feed, err := c.db.GetFeedByName(ctx, feedName)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.refreshFeed(ctx, feed, force, inline)
+	if err != nil {
+		return err
+	}
+	return printRefreshResult(feed, result)
+}
+
+// refreshFeed обновляет ленту через запущенный агрегатор, а если он не отвечает или inline -
+// в этом процессе
+func (c *CLI) refreshFeed(ctx context.Context, feed *domain.Feed, force, inline bool) (*domain.FeedResult, error) {
+	var result *domain.FeedResult
+	err := control.ErrNotRunning
+	if !inline {
+		result, err = control.Refresh(ctx, c.config.Aggregator.ControlSocket, feed, force)
+	}
+	if errors.Is(err, control.ErrNotRunning) {
+		logger.Debug("Refreshing feed %s in this process", feed.Name)
+		result, err = c.aggregator.Refresh(ctx, feed.ID, force)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh feed %s: %w", feed.Name, err)
+	}
+	return result, nil
+}
+
+// printRefreshResult выводит итог обновления ленты; неудачное получение - ошибка получения
+func printRefreshResult(feed *domain.Feed, result *domain.FeedResult) error {
+	switch {
+	case result.Err != nil:
+		return fetchError(fmt.Errorf("failed to refresh feed %s: %w", feed.Name, result.Err))
+	case result.Skipped:
+		logger.Info("Feed %s was fetched %v ago and is up to date (use --force to refresh it anyway)",
+			feed.Name, time.Since(feed.UpdatedAt).Round(time.Second))
+	case result.NotModified:
+		logger.Success("Feed %s is not modified since the last fetch", feed.Name)
+	default:
+		logger.Success("Refreshed feed %s: %d new articles (%d duplicates, %d updated), %s downloaded",
+			feed.Name, result.NewArticles, result.Duplicates, result.Updated, domain.FormatBytes(result.Bytes))
+	}
+	return nil
+}
@@ -0,0 +1,457 @@
+// internal/adapter/cli/registry.go
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+)
+
+// HELP_INDENT отступ описаний команд в справке
+const HELP_INDENT = 21
+
+// command команда CLI: по реестру команд выбирается обработчик и формируется справка
+type command struct {
+	name string
+	help string // Описание для справки; строки после первой выводятся с отступом под первой
+	run  func(c *CLI, ctx context.Context, args []string) error
+}
+
+// commands реестр команд в порядке их вывода в справке
+var commands = []command{
+	{
+		name: "add",
+		help: `add new RSS or Atom feed (--url), a YouTube channel (--youtube <channel ID, @handle or URL>)
+or a Mastodon account (--mastodon @user@instance);
+--url also accepts shorthands: reddit:r/<sub>, github:<owner>/<repo>[/releases|tags|commits], youtube:<channel>,
+mastodon:@user@instance;
+--mirror URL (repeatable) adds a fallback URL; --lang "en,ru" skips articles detected in other languages;
+--schedule "0 9 * * 1-5" fetches the feed only on a cron schedule instead of every cycle;
+--interval 6h fetches the feed at most once per interval;
+--folder "News/Tech" puts the feed into a nested folder; --refresh fetches its articles right away (see refresh);
+a URL already added under another name is refused unless --force is given`,
+		run: (*CLI).handleAdd,
+	},
+	{
+		name: "update",
+		help: `change name, URL, tags, folder (--folder "News/Tech", "" to clear), priority, timeout, interval (0 to clear),
+mirrors (--mirrors "url1,url2", "" to clear), expected languages (--lang "en,ru", "" to clear) or cron schedule (--schedule "@daily", "" to clear) of a feed;
+--force keeps a --url that another feed already uses`,
+		run: (*CLI).handleUpdate,
+	},
+	{
+		name: "set-interval",
+		help: `set RSS fetch interval (persisted in database)`,
+		run:  (*CLI).handleSetInterval,
+	},
+	{
+		name: "set-workers",
+		help: `set number of workers (persisted in database)`,
+		run:  (*CLI).handleSetWorkers,
+	},
+	{
+		name: "list",
+		help: `list available RSS feeds with their article and unread counts (--num N, --page N or --after <cursor>;
+--verbose: also show feed IDs, fetch status OK / failed N times / disabled and the last error;
+--disabled: only disabled feeds, with the reason dead feeds were disabled automatically;
+--folder "News/Tech": only feeds of a folder and its subfolders; --folders: the folder tree)`,
+		run: (*CLI).handleList,
+	},
+	{
+		name: "delete",
+		help: `delete RSS feed (--name X), or all feeds with a tag (--tag X) or matching a name pattern
+(--match "reddit-*") after confirmation (--yes to skip it)`,
+		run: (*CLI).handleDelete,
+	},
+	{
+		name: "disable",
+		help: `pause fetching of a feed, keeping its articles (--name X)`,
+		run:  func(c *CLI, ctx context.Context, args []string) error { return c.handleSetEnabled(ctx, args, false) },
+	},
+	{
+		name: "enable",
+		help: `resume fetching of a disabled feed (--name X), including a feed disabled as dead`,
+		run:  func(c *CLI, ctx context.Context, args []string) error { return c.handleSetEnabled(ctx, args, true) },
+	},
+	{
+		name: "articles",
+		help: `show latest articles of a feed or smart feed (unread are marked with *; --page N or --after <cursor>;
+--feed-id ID or --feed-url URL: select the feed by its ID (see list --verbose) or URL instead of --feed-name;
+--show-updated: only articles the feed changed after they were saved;
+--lang X: only articles detected in this language, e.g. en or ru;
+--show-description: also print descriptions wrapped to the terminal width, cut at a sentence
+or word after 300 characters (--description-length N to change, 0 for full descriptions);
+--color auto, always or never: highlight titles in the terminal, auto respects NO_COLOR and --no-color;
+--output json: print articles as JSON, including podcast metadata and media attachments)`,
+		run: (*CLI).handleArticles,
+	},
+	{
+		name: "smartfeed",
+		help: `manage saved searches shown as virtual feeds: add --name X --query Q, list, delete --name X`,
+		run:  (*CLI).handleSmartFeed,
+	},
+	{
+		name: "rule",
+		help: `manage notification rules sending new articles matching a query to a channel (telegram, desktop, slack, discord, hook):
+add --name X --query Q --channel C (or --expr 'feed.tag == "security" && contains(title, "CVE")' instead of --query),
+list, delete --name X,
+test --name X [--since 24h] (show recent articles the rule matches);
+a channel with rules only receives matching articles, one without rules receives all`,
+		run: (*CLI).handleRule,
+	},
+	{
+		name: "filter",
+		help: `manage filter rules applied to feed items before they are saved:
+add --name X --expr 'contains(title, "sponsored")' [--action drop|keep], list, delete --name X,
+test --name X [--since 24h] (show recent articles the expression matches);
+items matching a drop rule are not saved; with keep rules only items matching one of them are saved`,
+		run: (*CLI).handleFilter,
+	},
+	{
+		name: "open",
+		help: `open an article in the browser and mark it as read`,
+		run:  (*CLI).handleOpen,
+	},
+	{
+		name: "history",
+		help: `show content versions of an article (--link URL) and what changed between them
+(--feed-name X: only the article of this feed; --full: print every version in full)`,
+		run: (*CLI).handleHistory,
+	},
+	{
+		name: "related",
+		help: `show stored articles similar to an article (--link URL) by the words of its title
+(--num N, default 5; --feed-name X: the article of this feed)`,
+		run: (*CLI).handleRelated,
+	},
+	{
+		name: "icon",
+		help: `show the cached icon of a feed (--feed-name X), fetch it now (--refresh) or save it to a file (--output F)`,
+		run:  (*CLI).handleIcon,
+	},
+	{
+		name: "migrate",
+		help: `show migrations status, apply (up) or roll back (down N) schema migrations`,
+		run:  (*CLI).handleMigrate,
+	},
+	{
+		name: "maintenance",
+		help: `delete articles, fetch runs and traffic older than the retention periods (starred articles and the
+newest --keep-per-feed N of each feed are kept) and orphaned rows, then VACUUM (ANALYZE) and report table
+sizes, space reclaimed and bloat and index hints (--article-retention D; --dry-run: only count;
+--full: VACUUM FULL, locks tables; --no-vacuum)`,
+		run: (*CLI).handleMaintenance,
+	},
+	{
+		name: "export-articles",
+		help: `export feed or smart feed articles to json, md, csv or rss (--since YYYY-MM-DD, --output file)`,
+		run:  (*CLI).handleExportArticles,
+	},
+	{
+		name: "export",
+		help: `export all feeds with their settings (tags, folder, intervals, schedule, headers, mirrors,
+disabled state) and filter rules to json, restored by import --format json; --format opml: subscriptions
+and folders only; --output file; --with-credentials: include feed credentials in plain text`,
+		run: (*CLI).handleExport,
+	},
+	{
+		name: "backup",
+		help: `write a full database backup: feeds with settings and credentials, filter rules and articles
+with their read and starred state, to a portable gzipped JSON dump, independent of pg_dump (--out file)`,
+		run: (*CLI).handleBackup,
+	},
+	{
+		name: "restore",
+		help: `restore feeds, filter rules and articles from a backup dump (--in file); existing feeds, rules
+and articles are kept, so an interrupted restore can be repeated`,
+		run: (*CLI).handleRestore,
+	},
+	{
+		name: "archive",
+		help: `upload articles saved since the last run and their media attachments to S3 or MinIO
+(--to s3://bucket/prefix; --format json, md, csv or rss; --feed-name X: one feed;
+--full: everything, ignoring the last run; --no-enclosures: articles only)`,
+		run: (*CLI).handleArchive,
+	},
+	{
+		name: "stats",
+		help: `show publishing statistics and traffic per feed (--feed-name X for one feed)`,
+		run:  (*CLI).handleStats,
+	},
+	{
+		name: "trends",
+		help: `show title words mentioned more often in a period than in the same period before it
+(--since 7d, also 2w or 36h; --num N, default 20; --min N: at least N articles, default 3; --tag X)`,
+		run: (*CLI).handleTrends,
+	},
+	{
+		name: "runs",
+		help: `show history of fetch cycles: duration, feeds, new articles and errors (--num N, default 10)`,
+		run:  (*CLI).handleRuns,
+	},
+	{
+		name: "health",
+		help: `check database, migrations, locks and aggregator liveness (JSON report, exit code 0/1/4)`,
+		run:  (*CLI).handleHealth,
+	},
+	{
+		name: "status",
+		help: `show interval and workers stored in the database and whether the aggregator is running;
+--live asks the running fetch process over its control socket (--socket PATH, CLI_APP_CONTROL_SOCKET)
+for the interval and workers in effect, in-flight and queued feeds, the last cycle and what each worker is doing`,
+		run: (*CLI).handleStatus,
+	},
+	{
+		name: "digest",
+		help: `show new articles digest (--since 24h) or email it now (--send)`,
+		run:  (*CLI).handleDigest,
+	},
+	{
+		name: "audio-digest",
+		help: `read aloud the newest unread articles of each tag with the configured TTS backend: writes
+one MP3 per tag and a podcast feed (feed.xml) to CLI_APP_AUDIO_DIGEST_DIR
+(--since 24h, --per-tag N, --output DIR, --url URL where DIR is published)`,
+		run: (*CLI).handleAudioDigest,
+	},
+	{
+		name: "fetch",
+		help: `starts the background process that periodically fetches and processes RSS feeds using a worker pool
+(--once: run a single cycle and exit, non-zero exit code if any feed failed;
+--no-follow-permanent: keep stored URLs of feeds answering 301/308;
+--backfill: process all items of each feed, ignoring CLI_APP_MAX_ITEMS_PER_FEED;
+SIGHUP reloads configuration from CLI_APP_CONFIG_FILE and the environment)`,
+		run: (*CLI).handleFetch,
+	},
+	{
+		name: "refresh",
+		help: `fetch one feed now (--feed-name X, --feed-id or --feed-url) and print its new articles count, through the
+running fetch process, or in place if none is running or with --inline; a recently fetched feed is skipped and an unchanged
+one answers 304 Not Modified; --force fetches it anyway, ignoring the stored ETag and Last-Modified`,
+		run: (*CLI).handleRefresh,
+	},
+	{
+		name: "backfill",
+		help: `import historical articles of a feed from its archive (--feed-name X, --max N)`,
+		run:  (*CLI).handleBackfill,
+	},
+	{
+		name: "import",
+		help: `import subscriptions from another aggregator, categories become tags
+(--from miniflux --url U --api-key K, or --from freshrss --url U --user X --password <API password>;
+or --from opml --file F: subscriptions of an OPML file, nested outlines become folders;
+or --format json --file F: feeds and filter rules of an rsshub export backup, skipping existing ones;
+--with-state: also import read and starred articles, up to --max N of each, default 1000)`,
+		run: (*CLI).handleImport,
+	},
+	{
+		name: "discover",
+		help: `find and check feeds advertised by websites listed in a file, one URL per line or a browser
+bookmarks export (--file F; --add: add new valid feeds, named after their titles; --tags X)`,
+		run: (*CLI).handleDiscover,
+	},
+	{
+		name: "serve",
+		help: `start HTTP server with a web dashboard (/), the REST API, Fever API (/fever/), a server-sent events stream of new articles (/events) and WebSub push updates for feeds that advertise a hub (--addr :8080);
+--grpc-addr :9090 also serves the gRPC API`,
+		run: (*CLI).handleServe,
+	},
+	{
+		name: "apikey",
+		help: `manage HTTP API keys: create --name X (prints the key once), list, revoke --name X`,
+		run:  (*CLI).handleAPIKey,
+	},
+}
+
+// findCommand ищет команду в реестре по имени
+func findCommand(name string) *command {
+	for i := range commands {
+		if commands[i].name == name {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+// Run запускает CLI и обрабатывает аргументы командной строки: args[1] - команда,
+// остальные аргументы - ее флаги. --help после команды выводит справку по ней.
+// Ctrl+C (SIGINT) и SIGTERM отменяют контекст команды, и она завершается, прервав текущую
+// операцию; повторный сигнал завершает процесс сразу, если команда не успела остановиться
+func (c *CLI) Run(args []string) error {
+	if len(args) < 2 {
+		c.showHelp()
+		return usageErrorf("no command provided")
+	}
+
+	name := args[1]
+	switch name {
+	case "--help", "-h", "help":
+		if len(args) > 2 {
+			return c.showCommandHelp(args[2])
+		}
+		c.showHelp()
+		return nil
+	}
+
+	cmd := findCommand(name)
+	if cmd == nil {
+		c.showHelp()
+		return usageErrorf("unknown command: %s", name)
+	}
+	for _, arg := range args[2:] {
+		if arg == "--help" || arg == "-h" {
+			return c.showCommandHelp(name)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	err := cmd.run(c, ctx, args)
+	if err != nil && errors.Is(err, context.Canceled) && ctx.Err() != nil {
+		return fmt.Errorf("interrupted: %w", err)
+	}
+	return err
+}
+
+// showCommandHelp выводит справку по одной команде (rsshub help <команда>)
+func (c *CLI) showCommandHelp(name string) error {
+	cmd := findCommand(name)
+	if cmd == nil {
+		return usageErrorf("unknown command: %s", name)
+	}
+
+	fmt.Printf("Usage:\n  rsshub %s [OPTIONS]\n\n", cmd.name)
+	for _, line := range strings.Split(cmd.help, "\n") {
+		fmt.Printf("  %s\n", line)
+	}
+
+	// Примеры общей справки, относящиеся к команде
+	prefix := "rsshub " + cmd.name + " "
+	var examples []string
+	for _, line := range strings.Split(helpFooter, "\n") {
+		if line = strings.TrimSpace(line); strings.HasPrefix(line, prefix) {
+			examples = append(examples, line)
+		}
+	}
+	if len(examples) > 0 {
+		fmt.Println("\nExamples:")
+		for _, example := range examples {
+			fmt.Printf("  %s\n", example)
+		}
+	}
+	return nil
+}
+
+// showHelp выводит справку по использованию CLI; список команд формируется по реестру
+func (c *CLI) showHelp() {
+	var b strings.Builder
+	b.WriteString("Usage:\n  rsshub COMMAND [OPTIONS]\n  rsshub help COMMAND   (or rsshub COMMAND --help: help for one command)\n\nCommon Commands:\n")
+	for _, cmd := range commands {
+		for i, line := range strings.Split(cmd.help, "\n") {
+			name := ""
+			if i == 0 {
+				name = cmd.name
+			}
+			fmt.Fprintf(&b, "     %-*s%s\n", HELP_INDENT-5, name, line)
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString(helpFooter)
+	fmt.Println(b.String())
+}
+
+// helpFooter глобальные флаги, коды выхода и примеры общей справки
+const helpFooter = `Global Options:
+     --quiet         do not print logs and error messages, only set the exit code
+     --json-errors   print the error as a single JSON object to stderr
+     --tz ZONE       show article dates in this IANA time zone (e.g. Europe/Moscow or UTC; default: local time)
+     --no-color      do not color logs and output even in a terminal (also NO_COLOR=1)
+     --config FILE   read configuration from this .env file (instead of CLI_APP_CONFIG_FILE)
+
+Exit Codes:
+     0  success
+     1  other error
+     2  usage error (unknown command, invalid or missing arguments)
+     3  feed, smart feed, article, notification rule or API key not found
+     4  database unavailable
+     5  fetch failed (invalid RSS URL or a feed failed during fetch --once)
+
+Examples:
+     rsshub add --name "tech-crunch" --url "https://techcrunch.com/feed/"
+     rsshub add --name "golang-yt" --youtube "@golang"
+     rsshub add --name "gargron" --mastodon "@Gargron@mastodon.social"
+     rsshub add --name "r-golang" --url "reddit:r/golang"
+     rsshub add --name "go-releases" --url "github:golang/go/releases"
+     rsshub add --name "protected" --url "https://example.com/rss" --user-agent "Mozilla/5.0" --header "Cookie: session=abc"
+     rsshub add --name "breaking" --url "https://example.com/breaking.rss" --priority high
+     rsshub add --name "slow" --url "https://example.com/slow.rss" --timeout 2m
+     rsshub add --name "private" --url "https://example.com/private.rss" --username "user" --password "secret"
+     rsshub add --name "intranet" --url "https://intranet.local/rss" --proxy "socks5://127.0.0.1:1080" --insecure
+     rsshub update --name "tech-crunch" --new-name "techcrunch" --tags "tech,news"
+     rsshub add --name "flaky" --url "https://example.com/rss" --mirror "https://mirror.example.org/rss"
+     rsshub add --name "habr" --url "https://habr.com/ru/rss/all/" --lang ru
+     rsshub list --num 5
+     rsshub list --verbose
+     rsshub list --folder "News/Tech"
+     rsshub delete --name "tech-crunch"
+     rsshub delete --tag "news"
+     rsshub delete --match "reddit-*" --yes
+     rsshub articles --feed-name "tech-crunch" --num 5
+     rsshub articles --feed-name "tech-crunch" --show-updated
+     rsshub articles --feed-url "https://techcrunch.com/feed/" --num 5
+     rsshub articles --feed-name "golang" --lang en
+     rsshub articles --feed-name "tech-crunch" --show-description --color never
+     rsshub articles --feed-name "podcast" --output json
+     rsshub open --feed-name "tech-crunch" --index 2
+     rsshub history --link "https://techcrunch.com/2024/01/02/some-story/"
+     rsshub related --link "https://techcrunch.com/2024/01/02/some-story/" --num 5
+     rsshub trends --since 7d --tag news
+     rsshub icon --feed-name "tech-crunch" --refresh
+     rsshub smartfeed add --name "golang" --query "title:go OR tag:golang"
+     rsshub articles --feed-name "golang" --num 10
+     rsshub rule add --name "go-security" --query "tag:golang AND (CVE OR vulnerability)" --channel telegram
+     rsshub rule test --name "go-security" --since 168h
+     rsshub rule add --name "security-cve" --expr 'feed.tag == "security" && contains(title, "CVE")' --channel hook
+     rsshub filter add --name "no-sponsored" --expr 'contains(title, "sponsored")' --action drop
+     rsshub migrate status
+     rsshub migrate down 1
+     rsshub maintenance --article-retention 2160h --dry-run
+     rsshub set-interval 2m
+     rsshub set-workers 5
+     rsshub fetch
+     rsshub fetch --exclusive
+     rsshub fetch --once
+     rsshub fetch --once --dry-run
+     rsshub fetch --no-follow-permanent
+     rsshub fetch --once --backfill
+     rsshub refresh --feed-name "tech-crunch" --force
+     rsshub stats --feed-name "tech-crunch"
+     rsshub export-articles --feed-name "tech-crunch" --format md --since 2024-01-01 --output archive.md
+     rsshub archive --to s3://backups/rsshub --format md
+     rsshub digest --since 48h
+     rsshub digest --send
+     rsshub audio-digest --since 24h --per-tag 3
+     rsshub serve --addr :8080
+     rsshub backfill --feed-name "tech-crunch" --max 500
+     rsshub import --from miniflux --url "https://miniflux.example.com" --api-key "$MINIFLUX_API_KEY" --with-state
+     rsshub import --from opml --file subscriptions.opml
+     rsshub export --output rsshub-backup.json
+     rsshub import --format json --file rsshub-backup.json
+     rsshub backup --out rsshub.dump
+     rsshub restore --in rsshub.dump
+     rsshub import --from freshrss --url "https://freshrss.example.com" --user "alice" --password "$FRESHRSS_API_PASSWORD"
+     rsshub discover --file sites.txt
+     rsshub discover --file bookmarks.html --add --tags "blogs"
+     rsshub health
+     rsshub runs --num 10
+     rsshub apikey create --name "ci"
+     rsshub apikey revoke --name "ci"
+     rsshub --json-errors articles --feed-name "missing"`
@@ -0,0 +1,69 @@
+// internal/adapter/cli/related.go
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"rsshub/internal/core/domain"
+)
+
+// DEFAULT_RELATED_NUM сколько похожих статей показывает related без --num
+const DEFAULT_RELATED_NUM = 5
+
+// handleRelated показывает сохраненные статьи, похожие на статью по ссылке (--link URL):
+// в PostgreSQL по полнотекстовому рангу слов заголовка в заголовках и описаниях статей
+func (c *CLI) handleRelated(ctx context.Context, args []string) error {
+	var link, feedName string
+	limit := DEFAULT_RELATED_NUM
+
+	fs := newFlagSet()
+	fs.String("--link", &link)
+	fs.String("--feed-name", &feedName)
+	fs.PositiveInt("--num", &limit)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	if link == "" {
+		return usageErrorf("--link is required")
+	}
+
+	entries, err := c.db.GetArticlesByLink(ctx, link)
+	if err != nil {
+		return err
+	}
+
+	var source *domain.DigestEntry
+	for _, entry := range entries {
+		if feedName == "" || entry.FeedName == feedName {
+			source = entry
+			break
+		}
+	}
+	if source == nil {
+		return fmt.Errorf("%w: %s", domain.ErrArticleNotFound, link)
+	}
+
+	related, err := c.db.GetRelatedArticles(ctx, source.Article.ID, limit)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Article: %s\n", source.Article.Title)
+	fmt.Printf("   Feed: %s\n\n", source.FeedName)
+
+	if len(related) == 0 {
+		fmt.Println("No related articles found")
+		return nil
+	}
+
+	render := c.renderer(colorAuto, 0)
+	for i, entry := range related {
+		date := c.localTime(entry.Article.PublishedAt).Format("2006-01-02")
+		fmt.Println(render.articleLine(i+1, date, entry.Article.Title, ""))
+		fmt.Println(render.linkLine(entry.Article.Link, entry.FeedName))
+		fmt.Println()
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// internal/adapter/cli/reload.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"syscall"
+
+	aggregator "rsshub/internal/core/service"
+	"rsshub/internal/platform/config"
+	"rsshub/internal/platform/logger"
+)
+
+// watchReload перечитывает конфигурацию по SIGHUP, пока не отменен ctx. apply вызывается
+// после успешной перезагрузки, чтобы команда применила настройки, которыми владеет сама
+// (например, заново запустила дайджест). Ошибка перезагрузки оставляет прежние настройки
+func (c *CLI) watchReload(ctx context.Context, apply func()) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			logger.Info("Received SIGHUP, reloading configuration...")
+			if err := c.reloadConfig(ctx); err != nil {
+				logger.Error("Failed to reload configuration, keeping current settings: %v", err)
+				continue
+			}
+			if apply != nil {
+				apply()
+			}
+			logger.Success("Configuration reloaded")
+		}
+	}
+}
+
+// reloadConfig перечитывает файл конфигурации и окружение и применяет настройки агрегатора,
+// уведомлений и уровень логирования. Подключение к БД, HTTP клиент лент и адрес сервера
+// создаются при запуске, их изменения вступают в силу только после перезапуска
+func (c *CLI) reloadConfig(ctx context.Context) error {
+	if err := config.LoadFile(config.FilePath()); err != nil {
+		return err
+	}
+	cfg := config.Load()
+
+	applyLogLevel(cfg)
+
+	if agg, ok := c.aggregator.(*aggregator.Aggregator); ok {
+		configureAggregator(agg, cfg)
+		if err := agg.SetDefaults(ctx, cfg.Aggregator.DefaultInterval, cfg.Aggregator.DefaultWorkers); err != nil {
+			return fmt.Errorf("failed to apply interval and workers: %w", err)
+		}
+	}
+
+	if changed := restartOnlyChanges(c.config, cfg); len(changed) > 0 {
+		logger.Warn("Changes to %v settings take effect after restart", changed)
+	}
+
+	c.config = cfg
+	return nil
+}
+
+// restartOnlyChanges возвращает группы изменившихся настроек, которые нельзя применить на лету
+func restartOnlyChanges(old, cfg *config.Config) []string {
+	var changed []string
+	if old.Database != cfg.Database {
+		changed = append(changed, "database")
+	}
+	if !reflect.DeepEqual(old.Fetcher, cfg.Fetcher) {
+		changed = append(changed, "fetcher")
+	}
+	if old.Server != cfg.Server || old.WebSub != cfg.WebSub {
+		changed = append(changed, "server")
+	}
+	if old.SecretKey != cfg.SecretKey {
+		changed = append(changed, "secret key")
+	}
+	return changed
+}
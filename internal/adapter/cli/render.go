@@ -0,0 +1,190 @@
+// internal/adapter/cli/render.go
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/utils"
+)
+
+const (
+	// DEFAULT_DESCRIPTION_LENGTH до скольких символов обрезается описание статьи без --description-length
+	DEFAULT_DESCRIPTION_LENGTH = 300
+
+	// DEFAULT_TERMINAL_WIDTH ширина вывода, если ширину терминала определить не удалось
+	DEFAULT_TERMINAL_WIDTH = 80
+
+	// MIN_WRAP_WIDTH минимальная ширина текста при переносе строк
+	MIN_WRAP_WIDTH = 20
+)
+
+// Коды ANSI оформления текста
+const (
+	ansiReset   = "\033[0m"
+	ansiBold    = "\033[1m"
+	ansiDim     = "\033[2m"
+	ansiRed     = "\033[31m"
+	ansiGreen   = "\033[32m"
+	ansiYellow  = "\033[33m"
+	ansiMagenta = "\033[35m"
+	ansiCyan    = "\033[36m"
+)
+
+// colorMode режим цветного вывода (--color)
+type colorMode string
+
+const (
+	colorAuto   colorMode = "auto"
+	colorAlways colorMode = "always"
+	colorNever  colorMode = "never"
+)
+
+// parseColorMode разбирает значение --color
+func parseColorMode(s string) (colorMode, error) {
+	switch mode := colorMode(strings.ToLower(s)); mode {
+	case colorAuto, colorAlways, colorNever:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid color mode: %s (expected auto, always or never)", s)
+	}
+}
+
+// textRenderer оформляет списки статей для терминала: выделяет заголовки цветом, переносит
+// описания по ширине терминала и обрезает их по границе предложения или слова.
+// Используется всеми командами, выводящими статьи списком
+type textRenderer struct {
+	width             int // Ширина строки вывода в символах
+	color             bool
+	descriptionLength int // Максимальная длина описания в символах, 0 - без ограничения
+}
+
+// newTextRenderer создает оформление для стандартного вывода: ширина берется из терминала
+// (или переменной COLUMNS), цвет в режиме auto включается только в терминале без NO_COLOR
+func newTextRenderer(mode colorMode, descriptionLength int) *textRenderer {
+	width := terminalWidth(os.Stdout)
+	if width <= 0 {
+		width, _ = strconv.Atoi(os.Getenv("COLUMNS"))
+	}
+	if width <= 0 {
+		width = DEFAULT_TERMINAL_WIDTH
+	}
+
+	color := mode == colorAlways
+	if mode == colorAuto {
+		color = logger.ColorTerminal(os.Stdout)
+	}
+
+	return &textRenderer{width: width, color: color, descriptionLength: descriptionLength}
+}
+
+// style оформляет текст кодом ANSI, если цвет включен
+func (r *textRenderer) style(code, s string) string {
+	if !r.color || s == "" {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+// feedName выделяет имя ленты
+func (r *textRenderer) feedName(name string) string {
+	return r.style(ansiMagenta, name)
+}
+
+// date выделяет дату
+func (r *textRenderer) date(date string) string {
+	return r.style(ansiGreen, date)
+}
+
+// errorText выделяет ошибку или предупреждение
+func (r *textRenderer) errorText(s string) string {
+	return r.style(ansiRed, s)
+}
+
+// articleLine строка статьи в списке: номер, дата, заголовок и пометки (непрочитанная и т.п.)
+func (r *textRenderer) articleLine(index int, date, title, marker string) string {
+	return fmt.Sprintf("%d. [%s] %s%s", index, r.date(date), r.style(ansiBold, title), r.style(ansiYellow, marker))
+}
+
+// linkLine строка со ссылкой статьи и, если задано, именем ее ленты
+func (r *textRenderer) linkLine(link, feedName string) string {
+	line := "   " + r.style(ansiCyan, link)
+	if feedName != "" {
+		line += " (" + r.feedName(feedName) + ")"
+	}
+	return line
+}
+
+// descriptionLines описание статьи без HTML, обрезанное до descriptionLength и разбитое на строки
+// с отступом indent по ширине вывода
+func (r *textRenderer) descriptionLines(description, indent string) []string {
+	text := utils.StripHTML(description)
+	if r.descriptionLength > 0 {
+		text = truncateText(text, r.descriptionLength)
+	}
+
+	lines := wrapText(text, max(r.width-len(indent), MIN_WRAP_WIDTH))
+	for i, line := range lines {
+		lines[i] = indent + r.style(ansiDim, line)
+	}
+	return lines
+}
+
+// descriptionFlags флаги описаний для подсказки следующей страницы
+func descriptionFlags(showDescription bool, descriptionLength int) string {
+	switch {
+	case !showDescription:
+		return ""
+	case descriptionLength != DEFAULT_DESCRIPTION_LENGTH:
+		return fmt.Sprintf(" --description-length %d", descriptionLength)
+	default:
+		return " --show-description"
+	}
+}
+
+// truncateText обрезает текст до limit символов: по концу предложения, если оно занимает
+// больше половины лимита, иначе по границе слова с многоточием
+func truncateText(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	text = string(runes[:limit])
+	if end := strings.LastIndexAny(text, ".!?"); end > len(text)/2 {
+		return text[:end+1]
+	}
+	if end := strings.LastIndex(text, " "); end > 0 {
+		text = text[:end]
+	}
+	return strings.TrimRight(text, " ,;:-") + "..."
+}
+
+// wrapText разбивает текст на строки не длиннее width символов по границам слов;
+// слово длиннее строки (например, ссылка) занимает отдельную строку целиком
+func wrapText(text string, width int) []string {
+	var lines []string
+	var line strings.Builder
+	length := 0
+
+	for _, word := range strings.Fields(text) {
+		size := len([]rune(word))
+		if length > 0 && length+1+size > width {
+			lines = append(lines, line.String())
+			line.Reset()
+			length = 0
+		}
+		if length > 0 {
+			line.WriteByte(' ')
+			length++
+		}
+		line.WriteString(word)
+		length += size
+	}
+	if length > 0 {
+		lines = append(lines, line.String())
+	}
+	return lines
+}
@@ -0,0 +1,223 @@
+// internal/adapter/cli/rules.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"rsshub/internal/adapter/notifier/desktop"
+	"rsshub/internal/adapter/notifier/discord"
+	"rsshub/internal/adapter/notifier/hook"
+	"rsshub/internal/adapter/notifier/slack"
+	"rsshub/internal/adapter/notifier/telegram"
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/config"
+	"rsshub/internal/platform/logger"
+)
+
+// DEFAULT_RULE_TEST_SINCE за какой период rule test проверяет статьи без --since
+const DEFAULT_RULE_TEST_SINCE = 24 * time.Hour
+
+// notificationChannels имена каналов, которые можно указать в правилах уведомлений
+var notificationChannels = []string{"telegram", "desktop", "slack", "discord", "hook"}
+
+// newNotifiers создает настроенные каналы уведомлений по их именам
+func newNotifiers(cfg *config.Config) map[string]port.Notifier {
+	notifiers := make(map[string]port.Notifier)
+	if cfg.Telegram.BotToken != "" {
+		if notifier, err := telegram.New(&cfg.Telegram); err != nil {
+			logger.Warn("Telegram notifications disabled: %v", err)
+		} else {
+			notifiers["telegram"] = notifier
+		}
+	}
+	if cfg.Desktop.Enabled {
+		if notifier, err := desktop.New(&cfg.Desktop); err != nil {
+			logger.Warn("Desktop notifications disabled: %v", err)
+		} else {
+			notifiers["desktop"] = notifier
+		}
+	}
+	if cfg.Slack.WebhookURL != "" || len(cfg.Slack.TagWebhooks) > 0 {
+		if notifier, err := slack.New(&cfg.Slack); err != nil {
+			logger.Warn("Slack notifications disabled: %v", err)
+		} else {
+			notifiers["slack"] = notifier
+		}
+	}
+	if cfg.Discord.WebhookURL != "" {
+		if notifier, err := discord.New(&cfg.Discord); err != nil {
+			logger.Warn("Discord notifications disabled: %v", err)
+		} else {
+			notifiers["discord"] = notifier
+		}
+	}
+	if cfg.Hook.Command != "" {
+		if notifier, err := hook.New(&cfg.Hook); err != nil {
+			logger.Warn("Hook command disabled: %v", err)
+		} else {
+			notifiers["hook"] = notifier
+		}
+	}
+	return notifiers
+}
+
+// handleRule управляет правилами уведомлений: add, list, delete и test
+func (c *CLI) handleRule(ctx context.Context, args []string) error {
+	var name, query, expr, channel string
+	since := DEFAULT_RULE_TEST_SINCE
+
+	fs := newFlagSet()
+	fs.String("--name", &name)
+	fs.String("--query", &query)
+	fs.String("--expr", &expr)
+	fs.Func("--channel", func(value string) error {
+		channel = strings.ToLower(value)
+		return nil
+	})
+	fs.PositiveDuration("--since", &since)
+	action, err := fs.parseAction(args[2:])
+	if err != nil {
+		return err
+	}
+	if action == "" {
+		return usageErrorf("rule requires an action: add, list, delete or test")
+	}
+
+	switch action {
+	case "add":
+		if name == "" || (query == "") == (expr == "") || channel == "" {
+			return usageErrorf("--name, --channel and either --query or --expr are required")
+		}
+		return c.addRule(ctx, &domain.NotificationRule{Name: name, Query: query, Expression: expr, Channel: channel})
+	case "list":
+		return c.listRules(ctx)
+	case "delete":
+		if name == "" {
+			return usageErrorf("--name is required")
+		}
+		if err := c.db.DeleteNotificationRule(ctx, name); err != nil {
+			return err
+		}
+		logger.Success("Deleted notification rule: %s", name)
+		return nil
+	case "test":
+		if name == "" {
+			return usageErrorf("--name is required")
+		}
+		return c.testRule(ctx, name, since)
+	default:
+		return usageErrorf("unknown rule action: %s (expected add, list, delete or test)", action)
+	}
+}
+
+// addRule проверяет условие и канал и сохраняет правило уведомлений
+func (c *CLI) addRule(ctx context.Context, rule *domain.NotificationRule) error {
+	if _, err := rule.ParseCondition(); err != nil {
+		if rule.Expression != "" {
+			return usageErrorf("invalid expression: %v", err)
+		}
+		return usageErrorf("invalid query: %v", err)
+	}
+	if !knownChannel(rule.Channel) {
+		return usageErrorf("unknown channel: %s (expected %s)", rule.Channel, strings.Join(notificationChannels, ", "))
+	}
+
+	if err := c.db.CreateNotificationRule(ctx, rule); err != nil {
+		return err
+	}
+
+	logger.Success("Successfully added notification rule: %s (%s -> %s)", rule.Name, rule.Condition(), rule.Channel)
+	if _, ok := newNotifiers(c.config)[rule.Channel]; !ok {
+		logger.Warn("Channel %s is not configured: the rule applies once it is", rule.Channel)
+	}
+	return nil
+}
+
+// listRules выводит правила уведомлений, отмечая каналы, которые не настроены
+func (c *CLI) listRules(ctx context.Context) error {
+	rules, err := c.db.GetNotificationRules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get notification rules: %w", err)
+	}
+
+	if len(rules) == 0 {
+		fmt.Println("No notification rules found: every configured channel receives all new articles")
+		return nil
+	}
+
+	configured := newNotifiers(c.config)
+	fmt.Println("# Notification Rules")
+	fmt.Println()
+
+	for i, rule := range rules {
+		channel := rule.Channel
+		if _, ok := configured[channel]; !ok {
+			channel += " (not configured)"
+		}
+		fmt.Printf("%d. Name: %s\n", i+1, rule.Name)
+		if rule.Expression != "" {
+			fmt.Printf("   Expression: %s\n", rule.Expression)
+		} else {
+			fmt.Printf("   Query: %s\n", rule.Query)
+		}
+		fmt.Printf("   Channel: %s\n", channel)
+		fmt.Printf("   Added: %s\n", rule.CreatedAt.Format("2006-01-02 15:04"))
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// testRule показывает статьи, сохраненные за период since, которые правило отправило бы в канал
+func (c *CLI) testRule(ctx context.Context, name string, since time.Duration) error {
+	rules, err := c.db.GetNotificationRules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get notification rules: %w", err)
+	}
+
+	var rule *domain.NotificationRule
+	for _, r := range rules {
+		if r.Name == name {
+			rule = r
+		}
+	}
+	if rule == nil {
+		return fmt.Errorf("%w: %s", domain.ErrRuleNotFound, name)
+	}
+
+	query, err := rule.ParseCondition()
+	if err != nil {
+		return fmt.Errorf("rule %s has an invalid condition: %w", rule.Name, err)
+	}
+
+	entries, err := c.db.GetArticlesSince(ctx, time.Now().Add(-since), 0)
+	if err != nil {
+		return err
+	}
+
+	matched := 0
+	for _, entry := range entries {
+		if !query.Match(entry) {
+			continue
+		}
+		matched++
+		fmt.Printf("- [%s] %s\n  %s\n", entry.FeedName, entry.Article.Title, entry.Article.Link)
+	}
+	fmt.Printf("Rule %s matches %d of %d articles saved in the last %s (channel %s)\n",
+		rule.Name, matched, len(entries), since, rule.Channel)
+	return nil
+}
+
+// knownChannel проверяет, что канал поддерживается
+func knownChannel(channel string) bool {
+	for _, name := range notificationChannels {
+		if name == channel {
+			return true
+		}
+	}
+	return false
+}
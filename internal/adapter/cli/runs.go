@@ -0,0 +1,55 @@
+// internal/adapter/cli/runs.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"rsshub/internal/core/domain"
+)
+
+// DEFAULT_RUNS_NUM сколько последних циклов показывает runs без --num
+const DEFAULT_RUNS_NUM = 10
+
+// handleRuns показывает историю циклов получения лент: время, итоги и ошибки
+func (c *CLI) handleRuns(ctx context.Context, args []string) error {
+	limit := DEFAULT_RUNS_NUM
+
+	fs := newFlagSet()
+	fs.PositiveInt("--num", &limit)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	runs, err := c.db.GetFetchRuns(ctx, limit)
+	if err != nil {
+		return fmt.Errorf("failed to get fetch runs: %w", err)
+	}
+
+	if len(runs) == 0 {
+		fmt.Println("No fetch runs found")
+		return nil
+	}
+
+	fmt.Println("# Fetch Runs")
+	fmt.Println()
+
+	for i, run := range runs {
+		fmt.Printf("%d. Started: %s\n", i+1, run.StartedAt.Format("2006-01-02 15:04:05"))
+		fmt.Printf("   Duration: %v\n", run.Duration().Round(time.Millisecond))
+		if run.Instance != "" {
+			fmt.Printf("   Instance: %s\n", run.Instance)
+		}
+		fmt.Printf("   Feeds: %d (%d failed, %d timed out, %d skipped)\n", run.Feeds, run.Failed, run.TimedOut, run.Skipped)
+		fmt.Printf("   New articles: %d (%d duplicates, %d updated)\n", run.NewArticles, run.Duplicates, run.Updated)
+		fmt.Printf("   Downloaded: %s\n", domain.FormatBytes(run.Bytes))
+		fmt.Printf("   Queue wait: avg %v, max %v\n", run.QueueWaitAvg.Round(time.Millisecond), run.QueueWaitMax.Round(time.Millisecond))
+		for _, feedErr := range run.Errors {
+			fmt.Printf("   Error: %s: %s\n", feedErr.FeedName, feedErr.Error)
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+// internal/adapter/cli/serve.go
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"rsshub/internal/adapter/dashboard"
+	"rsshub/internal/adapter/httpapi"
+	"rsshub/internal/adapter/websub"
+	aggregator "rsshub/internal/core/service"
+	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/netguard"
+)
+
+const (
+	// API_PATH путь REST API; изменяющие запросы требуют ключ API
+	API_PATH = "/api/"
+
+	// FEVER_PATH путь Fever API для мобильных клиентов (Reeder, Unread)
+	FEVER_PATH = "/fever/"
+
+	// WEBSUB_PATH путь callback адресов WebSub подписок на сервере
+	WEBSUB_PATH = "/websub/"
+
+	// EVENTS_PATH путь потока server-sent events с новыми статьями
+	EVENTS_PATH = "/events"
+
+	// DASHBOARD_PATH путь веб-интерфейса; остальные пути сервера регистрируются отдельно
+	DASHBOARD_PATH = "/"
+
+	// SHUTDOWN_TIMEOUT сколько ждать завершения активных запросов при остановке сервера
+	SHUTDOWN_TIMEOUT = 10 * time.Second
+)
+
+// handleServe запускает HTTP сервер с REST API и приемом push уведомлений WebSub хабов,
+// gRPC API, если задан его адрес, и оформляет подписки для лент, которые объявляют хаб
+func (c *CLI) handleServe(ctx context.Context, args []string) error {
+	addr := c.config.Server.Addr
+	grpcAddr := c.config.Server.GRPCAddr
+	fs := newFlagSet()
+	fs.String("--addr", &addr)
+	fs.String("--grpc-addr", &grpcAddr)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(API_PATH, httpapi.RequireAPIKey(c.db, httpapi.NewHandler(c.db, c.parser)))
+
+	// Fever клиенты передают ключ в параметре api_key. Путь без "/" регистрируется отдельно:
+	// иначе ServeMux перенаправит POST запрос, и клиент потеряет тело формы
+	fever := httpapi.NewFeverHandler(c.db)
+	mux.Handle(FEVER_PATH, fever)
+	mux.Handle(strings.TrimSuffix(FEVER_PATH, "/"), fever)
+
+	// Поток новых статей для дашбордов и ботов; чтение, как и в API, не требует ключа
+	events := aggregator.NewArticleEvents(c.db, c.config.Server.EventsPoll)
+	mux.Handle("GET "+EVENTS_PATH, httpapi.NewEventStream(c.db, events))
+	go events.Run(ctx)
+
+	// gRPC API для внутренних сервисов; ключи API и поток новых статей общие с HTTP
+	if grpcAddr != "" {
+		if err := c.startGRPC(ctx, grpcAddr, events); err != nil {
+			return err
+		}
+	}
+
+	// Веб-интерфейс для браузера; отметить статьи прочитанными можно после входа с ключом API
+	dashboardHandler, err := dashboard.NewHandler(c.db)
+	if err != nil {
+		return fmt.Errorf("failed to load dashboard templates: %w", err)
+	}
+	mux.Handle(DASHBOARD_PATH, dashboardHandler)
+
+	// По SIGHUP перечитываются уровень лога и получатели уведомлений о статьях WebSub
+	go c.watchReload(ctx, nil)
+
+	// Без публичного адреса хабы не смогут обратиться к серверу, поэтому WebSub отключается
+	publicURL := strings.TrimSuffix(c.config.Server.PublicURL, "/")
+	if publicURL != "" {
+		guard, err := netguard.New(c.config.Fetcher.BlockPrivateNetworks, c.config.Fetcher.AllowedNetworks)
+		if err != nil {
+			return fmt.Errorf("invalid CLI_APP_ALLOWED_NETWORKS: %w", err)
+		}
+
+		hub := websub.NewHub(c.config.Fetcher.Timeout, c.config.Fetcher.UserAgent, guard)
+		subscriber := aggregator.NewWebSubSubscriber(c.db, c.parser, hub, c.aggregator,
+			publicURL+WEBSUB_PATH, c.config.WebSub.Lease, c.config.WebSub.RenewBefore)
+
+		// Хабы не знают ключей API; доставки WebSub проверяются подписью HMAC
+		mux.Handle(WEBSUB_PATH, websub.NewHandler(subscriber, WEBSUB_PATH))
+		go subscriber.Run(ctx)
+	} else {
+		logger.Warn("CLI_APP_PUBLIC_URL is not set: WebSub subscriptions are disabled")
+	}
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		logger.Info("Shutting down server...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), SHUTDOWN_TIMEOUT)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Error("Error shutting down server: %v", err)
+		}
+	}()
+
+	logger.Success("Server listening on %s (dashboard at %s, API at %s, Fever API at %s, events at %s)", addr, DASHBOARD_PATH, API_PATH, FEVER_PATH, EVENTS_PATH)
+	if publicURL != "" {
+		logger.Info("WebSub callbacks at %s%s", publicURL, WEBSUB_PATH)
+	}
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("server failed: %w", err)
+	}
+
+	logger.Success("Graceful shutdown: server stopped")
+	return nil
+}
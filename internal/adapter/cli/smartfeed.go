@@ -0,0 +1,198 @@
+// internal/adapter/cli/smartfeed.go
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/logger"
+)
+
+// handleSmartFeed управляет смарт-лентами (сохраненными поисками): add, list, delete
+func (c *CLI) handleSmartFeed(ctx context.Context, args []string) error {
+	var name, query string
+	fs := newFlagSet()
+	fs.String("--name", &name)
+	fs.String("--query", &query)
+	action, err := fs.parseAction(args[2:])
+	if err != nil {
+		return err
+	}
+	if action == "" {
+		return usageErrorf("smartfeed requires an action: add, list or delete")
+	}
+
+	switch action {
+	case "add":
+		if name == "" || query == "" {
+			return usageErrorf("both --name and --query are required")
+		}
+		return c.addSmartFeed(ctx, name, query)
+	case "list":
+		return c.listSmartFeeds(ctx)
+	case "delete":
+		if name == "" {
+			return usageErrorf("--name is required")
+		}
+		if err := c.db.DeleteSmartFeed(ctx, name); err != nil {
+			return err
+		}
+		logger.Success("Deleted smart feed: %s", name)
+		return nil
+	default:
+		return usageErrorf("unknown smartfeed action: %s (expected add, list or delete)", action)
+	}
+}
+
+// addSmartFeed проверяет запрос и сохраняет смарт-ленту. Имя не должно совпадать с именем
+// обычной ленты: смарт-ленты открываются через те же --feed-name
+func (c *CLI) addSmartFeed(ctx context.Context, name, query string) error {
+	if _, err := domain.ParseSmartQuery(query); err != nil {
+		return usageErrorf("invalid query: %v", err)
+	}
+
+	if _, err := c.db.GetFeedByName(ctx, name); err == nil {
+		return fmt.Errorf("%w: %s", domain.ErrDuplicateFeed, name)
+	} else if !errors.Is(err, domain.ErrFeedNotFound) {
+		return err
+	}
+
+	smartFeed := &domain.SmartFeed{Name: name, Query: query}
+	if err := c.db.CreateSmartFeed(ctx, smartFeed); err != nil {
+		return err
+	}
+
+	logger.Success("Successfully added smart feed: %s (%s)", name, query)
+	return nil
+}
+
+// listSmartFeeds выводит все смарт-ленты с их запросами
+func (c *CLI) listSmartFeeds(ctx context.Context) error {
+	smartFeeds, err := c.db.GetSmartFeeds(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get smart feeds: %w", err)
+	}
+
+	if len(smartFeeds) == 0 {
+		fmt.Println("No smart feeds found")
+		return nil
+	}
+
+	fmt.Println("# Smart Feeds")
+	fmt.Println()
+
+	for i, smartFeed := range smartFeeds {
+		fmt.Printf("%d. Name: %s\n", i+1, smartFeed.Name)
+		fmt.Printf("   Query: %s\n", smartFeed.Query)
+		fmt.Printf("   Added: %s\n", smartFeed.CreatedAt.Format("2006-01-02 15:04"))
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// findSmartFeed ищет смарт-ленту с именем обычной ленты, которая не найдена (notFound).
+// Если смарт-ленты тоже нет, возвращается исходная ошибка
+func (c *CLI) findSmartFeed(ctx context.Context, name string, notFound error) (*domain.SmartFeed, domain.QueryNode, error) {
+	if !errors.Is(notFound, domain.ErrFeedNotFound) {
+		return nil, nil, notFound
+	}
+
+	smartFeed, err := c.db.GetSmartFeedByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, domain.ErrSmartFeedNotFound) {
+			return nil, nil, notFound
+		}
+		return nil, nil, err
+	}
+
+	query, err := domain.ParseSmartQuery(smartFeed.Query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("smart feed %s has an invalid query: %w", name, err)
+	}
+	return smartFeed, query, nil
+}
+
+// showSmartFeedArticles выводит страницу статей смарт-ленты с именами их лент
+// (lang - язык, которым дополнительно ограничен запрос, для подсказки следующей страницы;
+// с showDescription под ссылкой выводится описание статьи)
+func (c *CLI) showSmartFeedArticles(ctx context.Context, smartFeed *domain.SmartFeed, query domain.QueryNode, paging *pageArgs, limit int, lang string,
+	render *textRenderer, showDescription bool) error {
+	entries, err := c.smartFeedPage(ctx, query, paging, limit)
+	if err != nil {
+		return fmt.Errorf("failed to get articles: %w", err)
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("No articles found for smart feed: %s\n", smartFeed.Name)
+		return nil
+	}
+
+	fmt.Printf("Smart feed: %s (%s)\n\n", smartFeed.Name, smartFeed.Query)
+
+	for i, entry := range entries {
+		article := entry.Article
+		date := c.localTime(article.PublishedAt).Format("2006-01-02")
+		marker := ""
+		if article.ReadAt == nil {
+			marker = " *" // Непрочитанная статья
+		}
+		fmt.Println(render.articleLine(paging.offset(limit)+i+1, date, article.Title, marker))
+		fmt.Println(render.linkLine(article.Link, entry.FeedName))
+		if showDescription {
+			for _, line := range render.descriptionLines(article.Description, "   ") {
+				fmt.Println(line)
+			}
+		}
+		fmt.Println()
+	}
+
+	if len(entries) == limit {
+		last := entries[len(entries)-1].Article
+		cursor := &domain.PageCursor{Time: last.PublishedAt, ID: last.ID}
+		flags := ""
+		if lang != "" {
+			flags = " --lang " + lang
+		}
+		flags += descriptionFlags(showDescription, render.descriptionLength)
+		fmt.Printf("Next page: rsshub articles --feed-name %q --num %d%s --after %s\n", smartFeed.Name, limit, flags, cursor.Encode())
+	}
+
+	return nil
+}
+
+// smartFeedPage получает страницу статей смарт-ленты, как articlesPage для обычной ленты
+func (c *CLI) smartFeedPage(ctx context.Context, query domain.QueryNode, paging *pageArgs, limit int) ([]*domain.DigestEntry, error) {
+	after := paging.after
+	for p := 1; ; p++ {
+		entries, err := c.db.GetSmartFeedArticles(ctx, query, after, limit)
+		if err != nil || p >= paging.page || len(entries) < limit {
+			if p < paging.page {
+				return nil, err // Страницы с таким номером нет
+			}
+			return entries, err
+		}
+		last := entries[len(entries)-1].Article
+		after = &domain.PageCursor{Time: last.PublishedAt, ID: last.ID}
+	}
+}
+
+// smartFeedArticlesSince возвращает статьи смарт-ленты, опубликованные не раньше since,
+// в хронологическом порядке, как FindArticles для обычной ленты
+func (c *CLI) smartFeedArticlesSince(ctx context.Context, query domain.QueryNode, since time.Time) ([]*domain.Article, error) {
+	entries, err := c.db.GetSmartFeedArticles(ctx, query, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	articles := make([]*domain.Article, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		if article := entries[i].Article; !article.PublishedAt.Before(since) {
+			articles = append(articles, article)
+		}
+	}
+	return articles, nil
+}
@@ -0,0 +1,139 @@
+// internal/adapter/cli/status.go
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"rsshub/internal/adapter/control"
+	"rsshub/internal/core/domain"
+	aggregator "rsshub/internal/core/service"
+	"rsshub/internal/platform/logger"
+)
+
+// STATUS_TIME_FORMAT формат времени в выводе status
+const STATUS_TIME_FORMAT = "2006-01-02 15:04:05"
+
+// handleStatus выводит настройки агрегатора, сохраненные в БД, и отметку фонового процесса.
+// С --live запрашивает у запущенного rsshub fetch через сокет управления действующие
+// интервал и количество воркеров, занятость воркеров и итоги последнего цикла
+func (c *CLI) handleStatus(ctx context.Context, args []string) error {
+	live := false
+	socket := c.config.Aggregator.ControlSocket
+
+	fs := newFlagSet()
+	fs.Bool("--live", &live)
+	fs.String("--socket", &socket)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	if !live {
+		return c.printStoredStatus(ctx)
+	}
+
+	status, err := control.Status(ctx, socket)
+	if errors.Is(err, control.ErrNotRunning) {
+		return fmt.Errorf("%w; start it with: rsshub fetch", err)
+	}
+	if err != nil {
+		return err
+	}
+	c.printLiveStatus(status)
+	return nil
+}
+
+// printStoredStatus выводит интервал и количество воркеров из БД (или значения по умолчанию)
+// и состояние фонового процесса по его отметке
+func (c *CLI) printStoredStatus(ctx context.Context) error {
+	interval := c.config.Aggregator.DefaultInterval.String() + " (default)"
+	if stored, err := c.db.GetAggregatorSetting(ctx, "interval"); err == nil {
+		interval = stored
+	}
+	workers := fmt.Sprintf("%d (default)", c.config.Aggregator.DefaultWorkers)
+	if stored, err := c.db.GetAggregatorSetting(ctx, "workers"); err == nil {
+		workers = stored
+	}
+
+	fmt.Printf("Interval: %s\n", interval)
+	fmt.Printf("Workers: %s\n", workers)
+
+	heartbeat := aggregator.ReadHeartbeat(ctx, c.db)
+	switch {
+	case heartbeat == nil || heartbeat.Stopped:
+		fmt.Println("Aggregator: not running")
+	case heartbeat.Alive(time.Now()):
+		fmt.Printf("Aggregator: running (%s, last heartbeat %s); see rsshub status --live\n",
+			heartbeat.Instance, heartbeat.At.In(c.location).Format(STATUS_TIME_FORMAT))
+	default:
+		fmt.Printf("Aggregator: no heartbeat from %s since %s\n",
+			heartbeat.Instance, heartbeat.At.In(c.location).Format(STATUS_TIME_FORMAT))
+	}
+	return nil
+}
+
+// printLiveStatus выводит состояние запущенного агрегатора
+func (c *CLI) printLiveStatus(status *domain.AggregatorStatus) {
+	now := time.Now()
+
+	fmt.Printf("Aggregator: running (%s)\n", status.Instance)
+	if !status.StartedAt.IsZero() {
+		fmt.Printf("Started: %s (up %v)\n", status.StartedAt.In(c.location).Format(STATUS_TIME_FORMAT),
+			now.Sub(status.StartedAt).Round(time.Second))
+	}
+	fmt.Printf("Interval: %v\n", status.Interval)
+	fmt.Printf("Workers: %d (%d in flight, %d queued", status.Workers, status.InFlight, status.Queued)
+	if status.Queued > 0 {
+		fmt.Printf(", oldest waiting %v", status.QueueWait.Round(time.Millisecond))
+	}
+	fmt.Println(")")
+	if status.CycleRunning {
+		fmt.Println("Cycle: running")
+	} else {
+		fmt.Println("Cycle: idle")
+	}
+
+	if run := status.LastCycle; run != nil {
+		fmt.Printf("Last cycle: %s, took %v: %d feeds (%d failed, %d timed out, %d skipped), %d new articles, queue wait avg %v, max %v\n",
+			run.StartedAt.In(c.location).Format(STATUS_TIME_FORMAT), run.Duration().Round(time.Millisecond),
+			run.Feeds, run.Failed, run.TimedOut, run.Skipped, run.NewArticles,
+			run.QueueWaitAvg.Round(time.Millisecond), run.QueueWaitMax.Round(time.Millisecond))
+	} else {
+		fmt.Println("Last cycle: none yet")
+	}
+
+	for _, w := range status.Activity {
+		state := fmt.Sprintf("idle for %v", now.Sub(w.Since).Round(time.Second))
+		switch {
+		case w.TimedOut:
+			state = fmt.Sprintf("timed out on %s, running for %v", w.Feed, now.Sub(w.Since).Round(time.Second))
+		case w.Busy():
+			state = fmt.Sprintf("fetching %s for %v", w.Feed, now.Sub(w.Since).Round(time.Second))
+		}
+		fmt.Printf("   Worker %d: %s; %d feeds processed", w.ID, state, w.Jobs)
+		if w.LastFeed != "" {
+			fmt.Printf(", last %s in %v", w.LastFeed, w.LastDuration.Round(time.Millisecond))
+		}
+		fmt.Println()
+	}
+}
+
+// startControlSocket открывает сокет управления для status --live и возвращает функцию,
+// которая его закрывает. Агрегатор работает и без сокета, поэтому ошибка только в логе
+func (c *CLI) startControlSocket() func() {
+	path := c.config.Aggregator.ControlSocket
+	server := control.NewServer(path, c.aggregator)
+	if err := server.Start(); err != nil {
+		logger.Warn("status --live is unavailable: %v", err)
+		return func() {}
+	}
+	logger.Debug("Control socket listening on %s", path)
+
+	return func() {
+		if err := server.Close(); err != nil {
+			logger.Warn("Failed to close control socket: %v", err)
+		}
+	}
+}
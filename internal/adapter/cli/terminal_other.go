@@ -0,0 +1,11 @@
+// internal/adapter/cli/terminal_other.go
+//go:build !linux && !darwin
+
+package cli
+
+import "os"
+
+// terminalWidth не определяет ширину терминала на этой платформе; используется COLUMNS
+func terminalWidth(f *os.File) int {
+	return 0
+}
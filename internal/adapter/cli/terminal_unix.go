@@ -0,0 +1,22 @@
+// internal/adapter/cli/terminal_unix.go
+//go:build linux || darwin
+
+package cli
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// terminalWidth возвращает ширину терминала в символах (0, если файл - не терминал)
+func terminalWidth(f *os.File) int {
+	var size struct {
+		rows, cols, x, y uint16
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&size)))
+	if errno != 0 {
+		return 0
+	}
+	return int(size.cols)
+}
@@ -0,0 +1,136 @@
+// internal/adapter/cli/trends.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"rsshub/internal/core/domain"
+)
+
+const (
+	// DEFAULT_TRENDS_SINCE период, который trends сравнивает с предыдущим, без --since
+	DEFAULT_TRENDS_SINCE = 7 * 24 * time.Hour
+
+	// DEFAULT_TRENDS_NUM сколько слов показывает trends без --num
+	DEFAULT_TRENDS_NUM = 20
+
+	// DEFAULT_TRENDS_MIN_COUNT в скольких заголовках периода должно встретиться слово без --min
+	DEFAULT_TRENDS_MIN_COUNT = 3
+)
+
+// handleTrends показывает слова заголовков, упоминания которых за период (--since 7d) выросли
+// сильнее всего по сравнению с таким же периодом до него
+func (c *CLI) handleTrends(ctx context.Context, args []string) error {
+	since := DEFAULT_TRENDS_SINCE
+	limit := DEFAULT_TRENDS_NUM
+	minCount := DEFAULT_TRENDS_MIN_COUNT
+	var tag string
+
+	fs := newFlagSet()
+	fs.Func("--since", func(value string) (err error) {
+		since, err = parsePeriod(value)
+		return err
+	})
+	fs.PositiveInt("--num", &limit)
+	fs.PositiveInt("--min", &minCount)
+	fs.String("--tag", &tag)
+	if err := fs.parse(args[2:]); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	start := now.Add(-since)
+	entries, err := c.db.GetArticlesSince(ctx, start.Add(-since), 0)
+	if err != nil {
+		return err
+	}
+
+	// Периоды считаются по дате публикации: старые статьи, впервые полученные из новой ленты,
+	// не попадают в текущий период. Статья нескольких лент с одной ссылкой учитывается один раз
+	var current, previous []string
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		article := entry.Article
+		published := article.PublishedAt
+		if published.IsZero() {
+			published = article.CreatedAt
+		}
+		if published.Before(start.Add(-since)) || (tag != "" && !slices.Contains(entry.FeedTags, tag)) {
+			continue
+		}
+		if article.Link != "" {
+			if seen[article.Link] {
+				continue
+			}
+			seen[article.Link] = true
+		}
+		if published.Before(start) {
+			previous = append(previous, article.Title)
+		} else {
+			current = append(current, article.Title)
+		}
+	}
+
+	period := formatPeriod(since)
+	fmt.Printf("Trending terms: last %s (%d articles) vs. the %s before (%d articles)\n\n",
+		period, len(current), period, len(previous))
+
+	trends := domain.RisingTerms(current, previous, minCount, limit)
+	if len(trends) == 0 {
+		fmt.Println("No rising terms found")
+		return nil
+	}
+
+	width := 0
+	for _, t := range trends {
+		width = max(width, len([]rune(t.Term)))
+	}
+	for i, t := range trends {
+		change := "new"
+		if t.Previous > 0 {
+			change = fmt.Sprintf("x%.1f", t.Growth())
+		}
+		padding := strings.Repeat(" ", width-len([]rune(t.Term)))
+		fmt.Printf("%2d. %s%s  %4d (was %d, %s)\n", i+1, t.Term, padding, t.Current, t.Previous, change)
+	}
+	return nil
+}
+
+// parsePeriod разбирает длительность периода: дни (7d), недели (2w) или длительность Go (36h)
+func parsePeriod(s string) (time.Duration, error) {
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(s, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(s, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+
+	if unit > 0 {
+		n, err := strconv.Atoi(strings.TrimSpace(s[:len(s)-1]))
+		if err == nil && n > 0 {
+			return time.Duration(n) * unit, nil
+		}
+	} else if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid period: %s (expected e.g. 7d, 2w or 36h)", s)
+}
+
+// formatPeriod выводит период в днях, если он кратен дню
+func formatPeriod(d time.Duration) string {
+	day := 24 * time.Hour
+	switch {
+	case d == day:
+		return "1 day"
+	case d%day == 0:
+		return fmt.Sprintf("%d days", d/day)
+	default:
+		return d.String()
+	}
+}
@@ -0,0 +1,243 @@
+// internal/adapter/control/control.go
+
+// Package control реализует сокет управления запущенным агрегатором: процесс rsshub fetch
+// отдает через unix сокет свое текущее состояние (rsshub status --live) и обновляет ленты
+// по запросу (rsshub refresh)
+package control
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/utils"
+)
+
+const (
+	// statusPath запрос состояния агрегатора
+	statusPath = "/status"
+
+	// refreshPath запрос немедленного обновления ленты
+	refreshPath = "/refresh"
+
+	// requestTimeout сколько клиент ждет ответа на запрос состояния; обновление ленты
+	// ограничено сроком обработки ленты в самом агрегаторе
+	requestTimeout = 5 * time.Second
+
+	// maxRequestSize максимальный размер тела запроса
+	maxRequestSize = 1 << 10
+)
+
+// ErrNotRunning возвращается клиентом, если по сокету никто не отвечает
+var ErrNotRunning = errors.New("aggregator is not running")
+
+// Controller запущенный агрегатор, которым управляет сокет
+type Controller interface {
+	Status() *domain.AggregatorStatus
+	Refresh(ctx context.Context, feedID utils.UUID, force bool) (*domain.FeedResult, error)
+}
+
+// refreshRequest тело запроса обновления ленты
+type refreshRequest struct {
+	FeedID utils.UUID `json:"feed_id"`
+	Force  bool       `json:"force"`
+}
+
+// refreshResponse результат обновления ленты. Ошибка получения ленты передается текстом
+type refreshResponse struct {
+	NewArticles int    `json:"new_articles"`
+	Duplicates  int    `json:"duplicates"`
+	Updated     int    `json:"updated"`
+	NotModified bool   `json:"not_modified,omitempty"`
+	Skipped     bool   `json:"skipped,omitempty"`
+	Bytes       int64  `json:"bytes"`
+	Error       string `json:"error,omitempty"`
+}
+
+// Server сервер сокета управления
+type Server struct {
+	path       string
+	controller Controller
+	server     *http.Server
+}
+
+// NewServer создает сервер сокета управления по пути path
+func NewServer(path string, controller Controller) *Server {
+	s := &Server{path: path, controller: controller}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+statusPath, s.status)
+	mux.HandleFunc("POST "+refreshPath, s.refresh)
+	s.server = &http.Server{Handler: mux, ReadHeaderTimeout: requestTimeout}
+	return s
+}
+
+// Start открывает сокет и обслуживает запросы в фоне. Файл сокета, оставшийся от упавшего
+// процесса, заменяется; сокет, на котором отвечает другой процесс, - ошибка
+func (s *Server) Start() error {
+	if conn, err := net.DialTimeout("unix", s.path, time.Second); err == nil {
+		conn.Close()
+		return fmt.Errorf("control socket %s is used by another running aggregator", s.path)
+	}
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove stale control socket: %w", err)
+	}
+
+	listener, err := net.Listen("unix", s.path)
+	if err != nil {
+		return fmt.Errorf("failed to open control socket: %w", err)
+	}
+	// Состояние агрегатора доступно только владельцу процесса
+	if err := os.Chmod(s.path, 0o600); err != nil {
+		listener.Close()
+		return fmt.Errorf("failed to open control socket: %w", err)
+	}
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("Control socket stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Close закрывает сокет и удаляет его файл
+func (s *Server) Close() error {
+	return s.server.Close()
+}
+
+// status отдает текущее состояние агрегатора в JSON
+func (s *Server) status(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.controller.Status()); err != nil {
+		logger.Warn("Failed to write aggregator status: %v", err)
+	}
+}
+
+// refresh обновляет ленту и отдает результат в JSON. Если обновление невозможно (лента
+// не найдена, отключена или уже обрабатывается), отвечает 409 с текстом ошибки
+func (s *Server) refresh(w http.ResponseWriter, r *http.Request) {
+	var req refreshRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&req); err != nil {
+		http.Error(w, "invalid refresh request", http.StatusBadRequest)
+		return
+	}
+
+	result, err := s.controller.Refresh(r.Context(), req.FeedID, req.Force)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	resp := refreshResponse{NewArticles: result.NewArticles, Duplicates: result.Duplicates, Updated: result.Updated,
+		NotModified: result.NotModified, Skipped: result.Skipped, Bytes: result.Bytes}
+	if result.Err != nil {
+		resp.Error = result.Err.Error()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		logger.Warn("Failed to write refresh result: %v", err)
+	}
+}
+
+// Status запрашивает состояние агрегатора, слушающего сокет управления path.
+// Если сокета нет или по нему никто не отвечает, возвращает ErrNotRunning
+func Status(ctx context.Context, path string) (*domain.AggregatorStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, socketURL(statusPath), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := do(newClient(path, requestTimeout), req, path)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	status := &domain.AggregatorStatus{}
+	if err := json.NewDecoder(resp.Body).Decode(status); err != nil {
+		return nil, fmt.Errorf("invalid aggregator status: %w", err)
+	}
+	return status, nil
+}
+
+// Refresh просит агрегатор, слушающий сокет path, сразу обновить ленту. Ошибка получения
+// ленты возвращается в FeedResult.Err; если по сокету никто не отвечает - ErrNotRunning
+func Refresh(ctx context.Context, path string, feed *domain.Feed, force bool) (*domain.FeedResult, error) {
+	body, err := json.Marshal(refreshRequest{FeedID: feed.ID, Force: force})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, socketURL(refreshPath), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Время обновления ограничивает сам агрегатор (CLI_APP_MAX_JOB_DURATION)
+	resp, err := do(newClient(path, 0), req, path)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var refreshed refreshResponse
+	if err := json.NewDecoder(resp.Body).Decode(&refreshed); err != nil {
+		return nil, fmt.Errorf("invalid refresh result: %w", err)
+	}
+	result := &domain.FeedResult{FeedName: feed.Name, NewArticles: refreshed.NewArticles, Duplicates: refreshed.Duplicates,
+		Updated: refreshed.Updated, NotModified: refreshed.NotModified, Skipped: refreshed.Skipped, Bytes: refreshed.Bytes}
+	if refreshed.Error != "" {
+		result.Err = errors.New(refreshed.Error)
+	}
+	return result, nil
+}
+
+// newClient создает HTTP клиент, который соединяется через сокет path (timeout 0 - без ограничения)
+func newClient(path string, timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", path)
+			},
+		},
+	}
+}
+
+// socketURL возвращает адрес запроса. Хост не используется: соединение всегда идет через сокет
+func socketURL(path string) string {
+	return "http://rsshub" + path
+}
+
+// do выполняет запрос к агрегатору. Ответ с ошибкой превращается в ошибку с ее текстом
+func do(client *http.Client, req *http.Request, path string) (*http.Response, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return nil, fmt.Errorf("%w (no answer on control socket %s)", ErrNotRunning, path)
+		}
+		return nil, fmt.Errorf("failed to query aggregator: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, maxRequestSize))
+		if text := strings.TrimSpace(string(message)); text != "" {
+			return nil, errors.New(text)
+		}
+		return nil, fmt.Errorf("failed to query aggregator: %s", resp.Status)
+	}
+	return resp, nil
+}
@@ -0,0 +1,386 @@
+// internal/adapter/dashboard/dashboard.go
+package dashboard
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"html/template"
+	"io/fs"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/utils"
+)
+
+const (
+	// articlesLimit сколько последних статей показывает дашборд
+	articlesLimit = 50
+
+	// descriptionLimit до скольких символов сокращается описание статьи
+	descriptionLimit = 280
+
+	// keyCookie cookie с ключом API, введенным на странице входа
+	keyCookie = "rsshub_api_key"
+
+	// keyCookieMaxAge сколько браузер хранит ключ API, в секундах
+	keyCookieMaxAge = 30 * 24 * 60 * 60
+)
+
+// templatesFS шаблоны страниц и стили дашборда, встроенные в бинарный файл
+//
+//go:embed templates/*.html static/*
+var templatesFS embed.FS
+
+// Handler веб-интерфейс: ленты с количеством непрочитанных статей и последние статьи.
+// Как и в API, чтение доступно без ключа; отмечать статьи прочитанными можно после входа
+// с ключом API, который хранится в cookie браузера
+type Handler struct {
+	db        port.FeedArticleRepository
+	templates *template.Template
+	mux       *http.ServeMux
+}
+
+// NewHandler создает дашборд с маршрутами от корня сервера
+func NewHandler(db port.FeedArticleRepository) (*Handler, error) {
+	templates, err := template.New("").Funcs(template.FuncMap{
+		"ago":     ago,
+		"summary": summary,
+	}).ParseFS(templatesFS, "templates/*.html")
+	if err != nil {
+		return nil, err
+	}
+	static, err := fs.Sub(templatesFS, "static")
+	if err != nil {
+		return nil, err
+	}
+
+	h := &Handler{db: db, templates: templates, mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET /{$}", h.index)
+	h.mux.HandleFunc("POST /read", h.markRead)
+	h.mux.HandleFunc("GET /login", h.loginPage)
+	h.mux.HandleFunc("POST /login", h.login)
+	h.mux.HandleFunc("POST /logout", h.logout)
+	h.mux.Handle("GET /static/", http.StripPrefix("/static/", http.FileServerFS(static)))
+	return h, nil
+}
+
+// ServeHTTP реализует http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Страницы дашборда нельзя встраивать в чужие сайты: кнопки отмечают статьи
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'self'; img-src 'self'; form-action 'self'; frame-ancestors 'none'")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	h.mux.ServeHTTP(w, r)
+}
+
+// feedRow лента в списке дашборда
+type feedRow struct {
+	Name     string
+	Folder   string
+	Unread   int
+	Total    int
+	Enabled  bool
+	Selected bool
+}
+
+// articleRow статья в списке дашборда
+type articleRow struct {
+	Seq         int64
+	Title       string
+	Link        string
+	Feed        string
+	Description string
+	PublishedAt time.Time
+	Unread      bool
+	Saved       bool
+}
+
+// indexPage данные главной страницы
+type indexPage struct {
+	Feeds     []feedRow
+	Articles  []articleRow
+	Feed      string // Выбранная лента (пусто - статьи всех лент)
+	Unread    int    // Непрочитанных статей всех лент
+	SignedIn  bool
+	KeyName   string
+	Before    int64  // Время показа страницы: "отметить ленту" не трогает статьи, пришедшие позже
+	ReturnURL string // Адрес страницы, на которую возвращают кнопки
+}
+
+// index показывает ленты и последние статьи всех лент или выбранной ленты (?feed=имя)
+func (h *Handler) index(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	selected := r.URL.Query().Get("feed")
+	page := indexPage{Feed: selected, Before: time.Now().Unix(), ReturnURL: r.URL.RequestURI()}
+	if key := h.signedIn(r); key != nil {
+		page.SignedIn, page.KeyName = true, key.Name
+	}
+
+	feeds, err := h.db.GetAllFeeds(ctx, 0)
+	if err != nil {
+		h.internalError(w, "failed to get feeds", err)
+		return
+	}
+	counts, err := h.db.GetFeedArticleCounts(ctx)
+	if err != nil {
+		h.internalError(w, "failed to get article counts", err)
+		return
+	}
+
+	names := make(map[utils.UUID]string, len(feeds))
+	for _, feed := range feeds {
+		names[feed.ID] = feed.Name
+		count := counts[feed.ID]
+		page.Unread += count.Unread
+		page.Feeds = append(page.Feeds, feedRow{
+			Name:     feed.Name,
+			Folder:   feed.Folder,
+			Unread:   count.Unread,
+			Total:    count.Total,
+			Enabled:  feed.Enabled,
+			Selected: feed.Name == selected,
+		})
+	}
+	sort.Slice(page.Feeds, func(i, j int) bool {
+		if page.Feeds[i].Folder != page.Feeds[j].Folder {
+			return page.Feeds[i].Folder < page.Feeds[j].Folder
+		}
+		return page.Feeds[i].Name < page.Feeds[j].Name
+	})
+
+	var articles []*domain.Article
+	if selected != "" {
+		if _, err := h.db.GetFeedByName(ctx, selected); err != nil {
+			if errors.Is(err, domain.ErrFeedNotFound) {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			h.internalError(w, "failed to get feed", err)
+			return
+		}
+		articles, err = h.db.GetArticlesPage(ctx, selected, nil, articlesLimit)
+	} else {
+		articles, err = h.db.GetArticlesBySeq(ctx, domain.ArticleSeqFilter{MaxSeq: math.MaxInt64, Limit: articlesLimit})
+	}
+	if err != nil {
+		h.internalError(w, "failed to get articles", err)
+		return
+	}
+
+	for _, a := range articles {
+		page.Articles = append(page.Articles, articleRow{
+			Seq:         a.Seq,
+			Title:       a.Title,
+			Link:        a.Link,
+			Feed:        names[a.FeedID],
+			Description: a.Description,
+			PublishedAt: a.PublishedAt,
+			Unread:      a.ReadAt == nil,
+			Saved:       a.SavedAt != nil,
+		})
+	}
+
+	h.render(w, "index.html", page)
+}
+
+// markRead отмечает прочитанными статьи с номерами seq или, если задана лента feed, все ее
+// статьи, сохраненные не позже before, и возвращает на страницу return
+func (h *Handler) markRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if h.signedIn(r) == nil {
+		http.Redirect(w, r, "/login?return="+url.QueryEscape(returnURL(r)), http.StatusSeeOther)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if name := r.PostForm.Get("feed"); name != "" {
+		feed, err := h.db.GetFeedByName(ctx, name)
+		if err != nil {
+			if errors.Is(err, domain.ErrFeedNotFound) {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			h.internalError(w, "failed to get feed", err)
+			return
+		}
+		before := time.Now()
+		if ts, err := strconv.ParseInt(r.PostForm.Get("before"), 10, 64); err == nil {
+			before = time.Unix(ts, 0)
+		}
+		if err := h.db.MarkFeedsReadBefore(ctx, []utils.UUID{feed.ID}, before); err != nil {
+			h.internalError(w, "failed to mark feed read", err)
+			return
+		}
+	} else {
+		var seqs []int64
+		for _, value := range r.PostForm["seq"] {
+			seq, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				http.Error(w, "invalid article number: "+value, http.StatusBadRequest)
+				return
+			}
+			seqs = append(seqs, seq)
+		}
+		if len(seqs) > 0 {
+			if err := h.db.MarkArticlesBySeq(ctx, seqs, domain.MarkRead); err != nil {
+				h.internalError(w, "failed to mark articles read", err)
+				return
+			}
+		}
+	}
+
+	http.Redirect(w, r, returnURL(r), http.StatusSeeOther)
+}
+
+// loginForm данные страницы входа
+type loginForm struct {
+	Error     string
+	ReturnURL string
+}
+
+// loginPage показывает форму ввода ключа API
+func (h *Handler) loginPage(w http.ResponseWriter, r *http.Request) {
+	h.render(w, "login.html", loginForm{ReturnURL: returnURL(r)})
+}
+
+// login проверяет ключ API и сохраняет его в cookie. Cookie недоступна скриптам и не
+// отправляется с запросами с других сайтов, поэтому чужая страница не может отметить статьи
+func (h *Handler) login(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	secret := strings.TrimSpace(r.PostFormValue("key"))
+	key, err := h.checkKey(ctx, secret)
+	if err != nil {
+		h.internalError(w, "failed to check API key", err)
+		return
+	}
+	if key == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		h.render(w, "login.html", loginForm{Error: "Invalid or revoked API key", ReturnURL: returnURL(r)})
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     keyCookie,
+		Value:    secret,
+		Path:     "/",
+		MaxAge:   keyCookieMaxAge,
+		HttpOnly: true,
+		Secure:   isHTTPS(r),
+		SameSite: http.SameSiteStrictMode,
+	})
+	logger.Info("Dashboard: signed in with key %s", key.Name)
+	http.Redirect(w, r, returnURL(r), http.StatusSeeOther)
+}
+
+// logout удаляет cookie с ключом API
+func (h *Handler) logout(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     keyCookie,
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   isHTTPS(r),
+		SameSite: http.SameSiteStrictMode,
+	})
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// signedIn возвращает ключ API из cookie запроса, если он действует (nil - вход не выполнен)
+func (h *Handler) signedIn(r *http.Request) *domain.APIKey {
+	cookie, err := r.Cookie(keyCookie)
+	if err != nil || cookie.Value == "" {
+		return nil
+	}
+	key, err := h.checkKey(r.Context(), cookie.Value)
+	if err != nil {
+		logger.Error("Dashboard: failed to check API key: %v", err)
+		return nil
+	}
+	return key
+}
+
+// checkKey ищет ключ API и отмечает его использование; nil - ключа нет или он отозван
+func (h *Handler) checkKey(ctx context.Context, secret string) (*domain.APIKey, error) {
+	if secret == "" {
+		return nil, nil
+	}
+	key, err := h.db.GetAPIKeyByHash(ctx, domain.HashAPIKey(secret))
+	if errors.Is(err, domain.ErrAPIKeyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := h.db.TouchAPIKey(ctx, key.ID); err != nil {
+		logger.Warn("Dashboard: failed to record usage of API key %s: %v", key.Name, err)
+	}
+	return key, nil
+}
+
+// render выполняет шаблон в буфер, чтобы ошибка шаблона не оставила страницу оборванной
+func (h *Handler) render(w http.ResponseWriter, name string, data interface{}) {
+	var buf bytes.Buffer
+	if err := h.templates.ExecuteTemplate(&buf, name, data); err != nil {
+		h.internalError(w, "failed to render page", err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if _, err := buf.WriteTo(w); err != nil {
+		logger.Debug("Dashboard: failed to write page: %v", err)
+	}
+}
+
+// internalError логирует ошибку и отвечает 500 без подробностей
+func (h *Handler) internalError(w http.ResponseWriter, message string, err error) {
+	logger.Error("Dashboard: %s: %v", message, err)
+	http.Error(w, message, http.StatusInternalServerError)
+}
+
+// returnURL возвращает адрес страницы дашборда из параметра return; адреса других сайтов
+// не принимаются, чтобы форму нельзя было использовать для перенаправления на них
+func returnURL(r *http.Request) string {
+	target := r.FormValue("return")
+	if !strings.HasPrefix(target, "/") || strings.HasPrefix(target, "//") || strings.HasPrefix(target, "/\\") {
+		return "/"
+	}
+	return target
+}
+
+// isHTTPS сообщает, пришел ли запрос по HTTPS напрямую или через прокси
+func isHTTPS(r *http.Request) bool {
+	return r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https")
+}
+
+// ago возвращает, сколько времени прошло с t, в кратком виде: 5m, 3h, 2d
+func ago(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "now"
+	case d < time.Hour:
+		return strconv.Itoa(int(d/time.Minute)) + "m"
+	case d < 24*time.Hour:
+		return strconv.Itoa(int(d/time.Hour)) + "h"
+	default:
+		return strconv.Itoa(int(d/(24*time.Hour))) + "d"
+	}
+}
+
+// summary возвращает текст HTML описания без тегов, сокращенный до descriptionLimit символов
+func summary(description string) string {
+	return utils.Truncate(utils.StripHTML(description), descriptionLimit)
+}
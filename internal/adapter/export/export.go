@@ -0,0 +1,233 @@
+// internal/adapter/export/export.go
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"rsshub/internal/core/domain"
+)
+
+// Format формат выгрузки статей
+type Format string
+
+const (
+	FormatJSON     Format = "json"
+	FormatMarkdown Format = "md"
+	FormatCSV      Format = "csv"
+	FormatRSS      Format = "rss"
+)
+
+// ParseFormat разбирает название формата выгрузки
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "json":
+		return FormatJSON, nil
+	case "md", "markdown":
+		return FormatMarkdown, nil
+	case "csv":
+		return FormatCSV, nil
+	case "rss":
+		return FormatRSS, nil
+	default:
+		return "", fmt.Errorf("unknown export format %q (expected json, md, csv or rss)", s)
+	}
+}
+
+// record статья в выгрузке JSON
+type record struct {
+	Feed        string    `json:"feed"`
+	Title       string    `json:"title"`
+	Link        string    `json:"link"`
+	PublishedAt time.Time `json:"published_at"`
+	Description string    `json:"description"`
+
+	Podcast *domain.PodcastInfo `json:"podcast,omitempty"` // Метаданные эпизода подкаста
+	Media   []domain.MediaItem  `json:"media,omitempty"`   // Вложения Media RSS
+}
+
+// Write записывает статьи ленты feedName в w в указанном формате
+func Write(w io.Writer, format Format, feedName string, articles []*domain.Article) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, feedName, articles)
+	case FormatMarkdown:
+		return writeMarkdown(w, feedName, articles)
+	case FormatCSV:
+		return writeCSV(w, feedName, articles)
+	case FormatRSS:
+		return writeRSS(w, feedName, articles)
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// writeJSON записывает статьи массивом JSON объектов
+func writeJSON(w io.Writer, feedName string, articles []*domain.Article) error {
+	records := make([]record, 0, len(articles))
+	for _, a := range articles {
+		records = append(records, record{
+			Feed:        feedName,
+			Title:       a.Title,
+			Link:        a.Link,
+			PublishedAt: a.PublishedAt,
+			Description: a.Description,
+			Podcast:     a.Podcast,
+			Media:       a.Media,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(records)
+}
+
+// writeMarkdown записывает статьи документом Markdown (например, для генератора статических сайтов)
+func writeMarkdown(w io.Writer, feedName string, articles []*domain.Article) error {
+	if _, err := fmt.Fprintf(w, "# %s\n", feedName); err != nil {
+		return err
+	}
+
+	for _, a := range articles {
+		title := strings.NewReplacer("[", `\[`, "]", `\]`).Replace(a.Title)
+		if _, err := fmt.Fprintf(w, "\n## [%s](%s)\n\n*%s*\n", title, a.Link, a.PublishedAt.Format("2006-01-02 15:04")); err != nil {
+			return err
+		}
+		if description := strings.TrimSpace(a.Description); description != "" {
+			if _, err := fmt.Fprintf(w, "\n%s\n", description); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeCSV записывает статьи таблицей CSV с заголовком
+func writeCSV(w io.Writer, feedName string, articles []*domain.Article) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"feed", "title", "published_at", "link", "description"}); err != nil {
+		return err
+	}
+
+	for _, a := range articles {
+		row := []string{feedName, a.Title, a.PublishedAt.Format(time.RFC3339), a.Link, a.Description}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// rssDocument документ RSS 2.0 для публикации статей (например, смарт-ленты) как ленты
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	Items       []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link,omitempty"`
+	Description string        `xml:"description,omitempty"`
+	PubDate     string        `xml:"pubDate"`
+	GUID        rssGUID       `xml:"guid"`
+	Enclosure   *rssEnclosure `xml:"enclosure,omitempty"`
+}
+
+// rssEnclosure аудиофайл эпизода подкаста
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// Episode эпизод ленты подкаста
+type Episode struct {
+	Title       string
+	Description string
+	URL         string // Адрес аудиофайла
+	Size        int64  // Размер аудиофайла в байтах
+	ContentType string // MIME тип аудиофайла, например audio/mpeg
+	PublishedAt time.Time
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// WritePodcast записывает ленту подкаста RSS 2.0: каждый эпизод - элемент с аудиофайлом
+// в <enclosure>, поэтому ленту можно добавить в любое приложение для подкастов
+func WritePodcast(w io.Writer, title, link, description string, episodes []Episode) error {
+	doc := rssDocument{
+		Version: "2.0",
+		Channel: rssChannel{Title: title, Link: link, Description: description},
+	}
+
+	for _, e := range episodes {
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			Title:       e.Title,
+			Description: e.Description,
+			PubDate:     e.PublishedAt.Format(time.RFC1123Z),
+			GUID:        rssGUID{IsPermaLink: false, Value: e.URL},
+			Enclosure:   &rssEnclosure{URL: e.URL, Length: e.Size, Type: e.ContentType},
+		})
+	}
+
+	return encodeRSS(w, doc)
+}
+
+// writeRSS записывает статьи документом RSS 2.0; статьи идут в порядке из articles
+func writeRSS(w io.Writer, feedName string, articles []*domain.Article) error {
+	doc := rssDocument{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       feedName,
+			Description: "Articles of " + feedName + " published by RSSHub",
+		},
+	}
+
+	for _, a := range articles {
+		guid := rssGUID{IsPermaLink: a.GUID == "", Value: a.GUID}
+		if guid.IsPermaLink {
+			guid.Value = a.Link
+		}
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			Title:       a.Title,
+			Link:        a.Link,
+			Description: a.Description,
+			PubDate:     a.PublishedAt.Format(time.RFC1123Z),
+			GUID:        guid,
+		})
+	}
+
+	return encodeRSS(w, doc)
+}
+
+// encodeRSS записывает документ RSS с XML заголовком
+func encodeRSS(w io.Writer, doc rssDocument) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,108 @@
+// internal/adapter/fetcher/http/atom.go
+package httpfetcher
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/logger"
+)
+
+// convertAtomFeed конвертирует ленту Atom в ту же обработанную структуру, что и RSS
+func (p *Parser) convertAtomFeed(feed *domain.RSSFeed) *domain.ParsedRSSFeed {
+	parsed := &domain.ParsedRSSFeed{
+		Title: strings.TrimSpace(feed.AtomTitle),
+		Items: make([]domain.ParsedRSSItem, 0, len(feed.AtomEntries)),
+		// icon - квадратная иконка, logo - баннер; иконка предпочтительнее
+		ImageURL: strings.TrimSpace(feed.AtomIcon),
+	}
+	if parsed.ImageURL == "" {
+		parsed.ImageURL = strings.TrimSpace(feed.AtomLogo)
+	}
+
+	for _, link := range feed.AtomLinks {
+		switch strings.ToLower(link.Rel) {
+		case "", "alternate":
+			if parsed.Link == "" {
+				parsed.Link = strings.TrimSpace(link.Href)
+			}
+		case "hub":
+			if parsed.HubURL == "" {
+				parsed.HubURL = strings.TrimSpace(link.Href)
+			}
+		case "self":
+			parsed.SelfURL = strings.TrimSpace(link.Href)
+		case "prev-archive":
+			parsed.PrevArchive = strings.TrimSpace(link.Href)
+		}
+	}
+
+	for _, entry := range feed.AtomEntries {
+		item, err := p.convertAtomEntry(&entry)
+		if err != nil {
+			// Логируем ошибку, но продолжаем обработку остальных записей
+			logger.Warn("Failed to parse Atom entry '%s': %v", entry.Title, err)
+			continue
+		}
+		parsed.Items = append(parsed.Items, *item)
+	}
+
+	return parsed
+}
+
+// convertAtomEntry конвертирует запись Atom в статью
+func (p *Parser) convertAtomEntry(entry *domain.AtomEntry) (*domain.ParsedRSSItem, error) {
+	parsed := &domain.ParsedRSSItem{
+		Title: strings.TrimSpace(entry.Title),
+		GUID:  strings.TrimSpace(entry.ID),
+		Media: mediaItems(entry.MediaContents, entry.MediaThumbnails, entry.MediaGroups),
+	}
+
+	for _, link := range entry.Links {
+		if rel := strings.ToLower(link.Rel); rel == "" || rel == "alternate" {
+			parsed.Link = strings.TrimSpace(link.Href)
+			break
+		}
+	}
+	// У роликов YouTube ссылка восстанавливается по yt:videoId
+	if videoID := strings.TrimSpace(entry.VideoID); parsed.Link == "" && videoID != "" {
+		parsed.Link = "https://www.youtube.com/watch?v=" + videoID
+	}
+
+	// Описание: summary, content или описание из media:group (YouTube)
+	parsed.Description = strings.TrimSpace(entry.Summary)
+	if parsed.Description == "" {
+		parsed.Description = strings.TrimSpace(entry.Content)
+	}
+	for _, group := range entry.MediaGroups {
+		if parsed.Description != "" {
+			break
+		}
+		parsed.Description = strings.TrimSpace(group.Description)
+	}
+
+	// Дата публикации: published, иначе updated
+	date := strings.TrimSpace(entry.Published)
+	if date == "" {
+		date = strings.TrimSpace(entry.Updated)
+	}
+	parsed.PublishedAt = time.Now()
+	if date != "" {
+		if publishedAt, err := p.parseRSSDate(date); err == nil {
+			parsed.PublishedAt = publishedAt
+		} else {
+			logger.Warn("Failed to parse date '%s' for entry '%s': %v", date, entry.Title, err)
+		}
+	}
+
+	if parsed.Title == "" {
+		return nil, fmt.Errorf("article title is empty")
+	}
+	if parsed.Link == "" {
+		return nil, fmt.Errorf("article link is empty")
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,137 @@
+// internal/adapter/fetcher/http/dates.go
+package httpfetcher
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dateFormats форматы дат, которые встречаются в RSS и Atom лентах
+var dateFormats = []string{
+	time.RFC1123Z,                    // "Mon, 02 Jan 2006 15:04:05 -0700"
+	time.RFC1123,                     // "Mon, 02 Jan 2006 15:04:05 MST"
+	"Mon, 2 Jan 2006 15:04:05 -0700", // День без ведущего нуля
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"Mon, 2 Jan 2006 15:04 -0700", // Без секунд
+	"Mon, 2 Jan 2006 15:04 MST",
+	"2 Jan 2006 15:04:05 -0700", // Без дня недели
+	"2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04 -0700",
+	"2 Jan 2006 15:04:05", // Без часового пояса (считается UTC)
+	"2 Jan 2006 15:04",
+	"2 Jan 2006",
+	time.RFC822Z,                // "02 Jan 06 15:04 -0700"
+	time.RFC822,                 // "02 Jan 06 15:04 MST"
+	"2006-01-02T15:04:05Z07:00", // ISO 8601
+	"2006-01-02T15:04:05",       // ISO 8601 без часового пояса (считается UTC)
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05", // Простой формат
+	"2006-01-02",          // Только дата
+}
+
+// parseRSSDate парсит дату из RSS формата в time.Time и приводит ее к UTC
+// RSS использует RFC 2822 формат, например: "Mon, 06 Sep 2021 12:00:00 GMT".
+// Кроме стандартных форматов понимает русские и французские названия месяцев
+// и форматы из CLI_APP_DATE_FORMATS
+func (p *Parser) parseRSSDate(dateStr string) (time.Time, error) {
+	normalized := normalizeDate(dateStr)
+
+	// Пробуем каждый формат: сначала встроенные, затем заданные пользователем
+	for _, formats := range [][]string{dateFormats, p.dateFormats} {
+		for _, format := range formats {
+			if parsedTime, err := time.Parse(format, normalized); err == nil {
+				return fixZoneAbbreviation(parsedTime).UTC(), nil
+			}
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unable to parse date: %s", dateStr)
+}
+
+// localizedMonths названия месяцев на русском и французском (полные, в родительном падеже и
+// сокращенные), которые встречаются в датах лент. Сокращения, совпадающие с английскими, не нужны
+var localizedMonths = map[string]string{
+	"январь": "Jan", "января": "Jan", "янв": "Jan",
+	"февраль": "Feb", "февраля": "Feb", "фев": "Feb", "февр": "Feb",
+	"март": "Mar", "марта": "Mar", "мар": "Mar",
+	"апрель": "Apr", "апреля": "Apr", "апр": "Apr",
+	"май": "May", "мая": "May",
+	"июнь": "Jun", "июня": "Jun", "июн": "Jun",
+	"июль": "Jul", "июля": "Jul", "июл": "Jul",
+	"август": "Aug", "августа": "Aug", "авг": "Aug",
+	"сентябрь": "Sep", "сентября": "Sep", "сен": "Sep", "сент": "Sep",
+	"октябрь": "Oct", "октября": "Oct", "окт": "Oct",
+	"ноябрь": "Nov", "ноября": "Nov", "ноя": "Nov",
+	"декабрь": "Dec", "декабря": "Dec", "дек": "Dec",
+
+	"janvier": "Jan", "janv": "Jan",
+	"février": "Feb", "fevrier": "Feb", "févr": "Feb", "fevr": "Feb",
+	"mars":  "Mar",
+	"avril": "Apr", "avr": "Apr",
+	"mai":     "May",
+	"juin":    "Jun",
+	"juillet": "Jul", "juil": "Jul",
+	"août": "Aug", "aout": "Aug",
+	"septembre": "Sep", "sept": "Sep",
+	"octobre":  "Oct",
+	"novembre": "Nov",
+	"décembre": "Dec", "decembre": "Dec", "déc": "Dec",
+}
+
+// localizedWeekdays названия дней недели на русском и французском; день недели в дате
+// избыточен, поэтому он просто отбрасывается
+var localizedWeekdays = map[string]bool{
+	"пн": true, "вт": true, "ср": true, "чт": true, "пт": true, "сб": true, "вс": true,
+	"понедельник": true, "вторник": true, "среда": true, "четверг": true, "пятница": true, "суббота": true, "воскресенье": true,
+	"lun": true, "mar": true, "mer": true, "jeu": true, "ven": true, "sam": true, "dim": true,
+	"lundi": true, "mardi": true, "mercredi": true, "jeudi": true, "vendredi": true, "samedi": true, "dimanche": true,
+}
+
+// normalizeDate приводит нестандартную дату к виду, который понимает time.Parse:
+// переводит названия месяцев на английский, отбрасывает день недели на другом языке
+// и заменяет часовой пояс "UT" (RFC 822) на "UTC"
+func normalizeDate(s string) string {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	if first := strings.TrimRight(fields[0], ".,"); strings.HasSuffix(fields[0], ",") && localizedWeekdays[strings.ToLower(first)] {
+		fields = fields[1:]
+	}
+
+	for i, field := range fields {
+		if month, ok := localizedMonths[strings.ToLower(strings.TrimRight(field, ".,"))]; ok {
+			fields[i] = month
+		}
+	}
+
+	if last := len(fields) - 1; last >= 0 && fields[last] == "UT" {
+		fields[last] = "UTC"
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// zoneOffsets смещения часовых поясов RFC 822 (в секундах), которые встречаются в датах лент
+var zoneOffsets = map[string]int{
+	"UT": 0, "GMT": 0, "UTC": 0, "Z": 0,
+	"EST": -5 * 3600, "EDT": -4 * 3600,
+	"CST": -6 * 3600, "CDT": -5 * 3600,
+	"MST": -7 * 3600, "MDT": -6 * 3600,
+	"PST": -8 * 3600, "PDT": -7 * 3600,
+}
+
+// fixZoneAbbreviation исправляет смещение даты с буквенным часовым поясом: time.Parse
+// считает неизвестное ему сокращение (например, EST на сервере в UTC) нулевым смещением
+func fixZoneAbbreviation(t time.Time) time.Time {
+	name, offset := t.Zone()
+	known, ok := zoneOffsets[name]
+	if !ok || offset == known {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(),
+		time.FixedZone(name, known))
+}
@@ -0,0 +1,115 @@
+// internal/adapter/fetcher/http/discover.go
+package httpfetcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"mime"
+	"strings"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/logger"
+
+	"golang.org/x/net/html/charset"
+)
+
+// discoverAccept заголовок Accept запроса страницы сайта: адрес из списка может оказаться самой лентой
+const discoverAccept = "text/html, application/xhtml+xml, application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8"
+
+var (
+	// feedLinkTypes MIME типы лент в <link rel="alternate" type="...">
+	feedLinkTypes = map[string]bool{
+		"application/rss+xml":  true,
+		"application/atom+xml": true,
+		"application/rdf+xml":  true,
+		"application/xml":      true,
+		"text/xml":             true,
+	}
+
+	// commonFeedPaths пути, по которым ленты часто лежат на сайтах, не объявляющих их на странице
+	commonFeedPaths = []string{"/feed", "/rss", "/rss.xml", "/feed.xml", "/atom.xml", "/index.xml"}
+)
+
+// DiscoverFeeds находит ленты сайта: сам адрес, если он уже лента, ленты из <link rel="alternate">
+// главной страницы, а если их нет - существующие ленты по распространенным путям (/feed, /rss.xml...).
+// Ленты комментариев пропускаются
+func (p *Parser) DiscoverFeeds(ctx context.Context, siteURL string) ([]string, error) {
+	site := &domain.Feed{URL: siteURL}
+	page, _, err := p.getResource(ctx, site, siteURL, discoverAccept, maxSitePageSize)
+	if err != nil {
+		return nil, err
+	}
+	if p.isFeed(page) {
+		return []string{siteURL}, nil
+	}
+
+	var feeds []string
+	seen := make(map[string]bool)
+	for _, tag := range htmlLinkTag.FindAllString(string(page), -1) {
+		attrs := make(map[string]string)
+		for _, m := range htmlAttr.FindAllStringSubmatch(tag, -1) {
+			attrs[strings.ToLower(m[1])] = m[2] + m[3] + m[4]
+		}
+		if !hasRel(attrs["rel"], "alternate") || strings.Contains(strings.ToLower(attrs["title"]), "comments") {
+			continue
+		}
+		if mediaType, _, _ := mime.ParseMediaType(attrs["type"]); !feedLinkTypes[mediaType] {
+			continue
+		}
+		if u := resolveIconURL(siteURL, attrs["href"]); u != "" && !seen[u] {
+			seen[u] = true
+			feeds = append(feeds, u)
+		}
+	}
+	if len(feeds) > 0 {
+		return feeds, nil
+	}
+
+	for _, path := range commonFeedPaths {
+		candidate := resolveIconURL(siteURL, path)
+		data, _, err := p.getResource(ctx, site, candidate, discoverAccept, maxSitePageSize)
+		if err != nil {
+			logger.Debug("Feed candidate %s rejected: %v", candidate, err)
+			continue
+		}
+		if !p.isFeed(data) {
+			logger.Debug("Feed candidate %s rejected: not a feed", candidate)
+			continue
+		}
+		feeds = append(feeds, candidate)
+		break
+	}
+	return feeds, nil
+}
+
+// isFeed проверяет, что документ - лента: корневой элемент rss, feed или RDF и документ разбирается.
+// Одного разбора недостаточно: XHTML страница тоже декодируется без ошибок
+func (p *Parser) isFeed(data []byte) bool {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = charset.NewReaderLabel
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return false
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			switch strings.ToLower(start.Name.Local) {
+			case "rss", "feed", "rdf":
+				_, err := p.Parse(bytes.NewReader(data))
+				return err == nil
+			}
+			return false
+		}
+	}
+}
+
+// hasRel проверяет, что значение атрибута rel содержит relation
+func hasRel(rel, relation string) bool {
+	for _, r := range strings.Fields(strings.ToLower(rel)) {
+		if r == relation {
+			return true
+		}
+	}
+	return false
+}
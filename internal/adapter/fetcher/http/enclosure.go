@@ -0,0 +1,30 @@
+// internal/adapter/fetcher/http/enclosure.go
+package httpfetcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"rsshub/internal/core/domain"
+)
+
+// FetchEnclosure загружает вложение статьи ленты (аудио, видео, изображение) в w через транспорт
+// ленты, то есть с ее прокси и ограничениями сетей. Загрузка больше limit байт прерывается.
+// Таймаут лент не применяется: вложения бывают большими, время загрузки ограничивает ctx
+func (p *Parser) FetchEnclosure(ctx context.Context, feed *domain.Feed, rawURL string, w io.Writer, limit int64) (string, error) {
+	body, contentType, err := p.openResource(ctx, feed, rawURL, "*/*", limit)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	n, err := io.Copy(w, io.LimitReader(body, limit+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read response from %s: %w", rawURL, err)
+	}
+	if n > limit {
+		return "", fmt.Errorf("response exceeds %d bytes limit: %s", limit, rawURL)
+	}
+	return contentType, nil
+}
@@ -0,0 +1,92 @@
+package httpfetcher
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// acceptEncoding перечисляет поддерживаемые алгоритмы сжатия ответа
+const acceptEncoding = "gzip, deflate, br"
+
+// gzipMagic сигнатура gzip потока (некоторые серверы отдают gzip без Content-Encoding)
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// readCloser объединяет распаковывающий reader и закрытие исходного тела ответа
+type readCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+// Close закрывает распаковщик и исходное тело ответа
+func (r *readCloser) Close() error {
+	var firstErr error
+	for _, c := range r.closers {
+		if err := c.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// decodeBody возвращает распакованное тело ответа согласно Content-Encoding.
+// Если заголовок отсутствует, но тело начинается с gzip сигнатуры, оно тоже распаковывается
+func decodeBody(resp *http.Response) (io.ReadCloser, error) {
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	body := bufio.NewReader(resp.Body)
+
+	switch encoding {
+	case "gzip", "x-gzip":
+		return newGzipReader(body, resp.Body)
+	case "deflate":
+		return newDeflateReader(body, resp.Body)
+	case "br":
+		return &readCloser{Reader: brotli.NewReader(body), closers: []io.Closer{resp.Body}}, nil
+	case "", "identity":
+		// Проверяем сигнатуру: сжатый ответ без корректного заголовка
+		if magic, err := body.Peek(len(gzipMagic)); err == nil && bytes.Equal(magic, gzipMagic) {
+			return newGzipReader(body, resp.Body)
+		}
+		return &readCloser{Reader: body, closers: []io.Closer{resp.Body}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported content encoding: %s", encoding)
+	}
+}
+
+// newGzipReader создает распаковщик gzip
+func newGzipReader(r io.Reader, body io.Closer) (io.ReadCloser, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gzip response: %w", err)
+	}
+	return &readCloser{Reader: gz, closers: []io.Closer{gz, body}}, nil
+}
+
+// newDeflateReader создает распаковщик deflate. По стандарту это zlib поток,
+// но многие серверы отдают "сырой" deflate без zlib заголовка
+func newDeflateReader(r *bufio.Reader, body io.Closer) (io.ReadCloser, error) {
+	header, err := r.Peek(2)
+	if err == nil && isZlibHeader(header) {
+		zr, err := zlib.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deflate response: %w", err)
+		}
+		return &readCloser{Reader: zr, closers: []io.Closer{zr, body}}, nil
+	}
+
+	fr := flate.NewReader(r)
+	return &readCloser{Reader: fr, closers: []io.Closer{fr, body}}, nil
+}
+
+// isZlibHeader проверяет двухбайтовый заголовок zlib (RFC 1950)
+func isZlibHeader(h []byte) bool {
+	return h[0]&0x0f == 8 && (uint16(h[0])<<8|uint16(h[1]))%31 == 0
+}
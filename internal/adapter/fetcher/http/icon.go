@@ -0,0 +1,218 @@
+// internal/adapter/fetcher/http/icon.go
+package httpfetcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/logger"
+)
+
+const (
+	// maxIconSize максимальный размер изображения иконки
+	maxIconSize = 512 << 10
+
+	// maxSitePageSize сколько байт главной страницы сайта читается в поисках <link rel="icon">
+	maxSitePageSize = 1 << 20
+)
+
+var (
+	// htmlLinkTag элемент <link ...> страницы сайта
+	htmlLinkTag = regexp.MustCompile(`(?is)<link\b[^>]*>`)
+
+	// htmlAttr атрибут элемента: имя="значение", имя='значение' или имя=значение
+	htmlAttr = regexp.MustCompile(`(?is)\b([a-z-]+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+)
+
+// FetchIcon получает иконку ленты. Кандидаты пробуются по очереди: иконка со страницы сайта
+// (<link rel="icon">), /favicon.ico сайта, картинка канала и /favicon.ico хоста ленты.
+// Учетные данные и заголовки ленты не отправляются: иконки часто лежат на других хостах
+func (p *Parser) FetchIcon(ctx context.Context, feed *domain.Feed, parsed *domain.ParsedRSSFeed) (*domain.FeedIcon, error) {
+	var candidates []string
+	add := func(base, ref string) {
+		if u := resolveIconURL(base, ref); u != "" {
+			for _, c := range candidates {
+				if c == u {
+					return
+				}
+			}
+			candidates = append(candidates, u)
+		}
+	}
+
+	if site := resolveIconURL(feed.URL, parsed.Link); site != "" {
+		href, err := p.siteIconLink(ctx, feed, site)
+		if err != nil {
+			logger.Debug("Failed to find icon link on %s: %v", site, err)
+		}
+		add(site, href)
+		add(site, "/favicon.ico")
+	}
+	add(feed.URL, parsed.ImageURL)
+	add(feed.URL, "/favicon.ico")
+
+	err := fmt.Errorf("no icon candidates")
+	for _, candidate := range candidates {
+		var data []byte
+		var contentType string
+		data, contentType, err = p.fetchIconImage(ctx, feed, candidate)
+		if err == nil {
+			return &domain.FeedIcon{FeedID: feed.ID, URL: candidate, ContentType: contentType, Data: data}, nil
+		}
+		logger.Debug("Icon candidate %s of feed %s rejected: %v", candidate, feed.Name, err)
+	}
+	return nil, fmt.Errorf("no icon found for feed %s: %w", feed.Name, err)
+}
+
+// siteIconLink загружает главную страницу сайта и возвращает href ее иконки
+// (rel="icon", "shortcut icon" или "apple-touch-icon"); пусто, если иконка не указана
+func (p *Parser) siteIconLink(ctx context.Context, feed *domain.Feed, siteURL string) (string, error) {
+	page, _, err := p.getResource(ctx, feed, siteURL, "text/html", maxSitePageSize)
+	if err != nil {
+		return "", err
+	}
+
+	var touchIcon string
+	for _, tag := range htmlLinkTag.FindAllString(string(page), -1) {
+		attrs := make(map[string]string)
+		for _, m := range htmlAttr.FindAllStringSubmatch(tag, -1) {
+			attrs[strings.ToLower(m[1])] = m[2] + m[3] + m[4]
+		}
+		href := strings.TrimSpace(attrs["href"])
+		if href == "" {
+			continue
+		}
+		for _, rel := range strings.Fields(strings.ToLower(attrs["rel"])) {
+			switch rel {
+			case "icon":
+				return href, nil
+			case "apple-touch-icon":
+				if touchIcon == "" {
+					touchIcon = href
+				}
+			}
+		}
+	}
+	return touchIcon, nil
+}
+
+// fetchIconImage загружает изображение иконки и определяет его MIME тип
+func (p *Parser) fetchIconImage(ctx context.Context, feed *domain.Feed, iconURL string) ([]byte, string, error) {
+	data, contentType, err := p.getResource(ctx, feed, iconURL, "image/*", maxIconSize)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(data) == 0 {
+		return nil, "", fmt.Errorf("empty response")
+	}
+
+	// Серверы нередко отдают .ico как application/octet-stream - тогда тип определяется по содержимому
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if !strings.HasPrefix(mediaType, "image/") {
+		mediaType = http.DetectContentType(data)
+	}
+	if !strings.HasPrefix(mediaType, "image/") {
+		return nil, "", fmt.Errorf("not an image (%s)", mediaType)
+	}
+	return data, mediaType, nil
+}
+
+// getResource выполняет GET запрос через транспорт ленты и читает не больше limit байт ответа
+func (p *Parser) getResource(ctx context.Context, feed *domain.Feed, rawURL, accept string, limit int64) ([]byte, string, error) {
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+
+	body, contentType, err := p.openResource(ctx, feed, rawURL, accept, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read response from %s: %w", rawURL, err)
+	}
+	if int64(len(data)) > limit {
+		return nil, "", fmt.Errorf("response exceeds %d bytes limit: %s", limit, rawURL)
+	}
+	return data, contentType, nil
+}
+
+// openResource выполняет GET запрос через транспорт ленты и возвращает распакованное тело ответа
+// и его Content-Type. Ответ, объявивший размер больше limit, отклоняется
+func (p *Parser) openResource(ctx context.Context, feed *domain.Feed, rawURL, accept string, limit int64) (io.ReadCloser, string, error) {
+	transport, err := p.transports.forFeed(feed)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to prepare transport for %s: %w", rawURL, err)
+	}
+	client := &http.Client{Transport: transport}
+
+	if host, err := hostOf(rawURL); err == nil {
+		if err := p.limiter.Wait(ctx, host); err != nil {
+			return nil, "", fmt.Errorf("rate limit wait for %s interrupted: %w", rawURL, err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to build request for %s: %w", rawURL, err)
+	}
+	if p.userAgent != "" {
+		req.Header.Set("User-Agent", p.userAgent)
+	}
+	req.Header.Set("Accept", accept)
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, "", fmt.Errorf("%s returned status %d", rawURL, resp.StatusCode)
+	}
+	if resp.ContentLength > limit {
+		resp.Body.Close()
+		return nil, "", fmt.Errorf("response of %d bytes exceeds %d bytes limit: %s", resp.ContentLength, limit, rawURL)
+	}
+
+	body, err := decodeBody(resp)
+	if err != nil {
+		resp.Body.Close()
+		return nil, "", fmt.Errorf("failed to decode response from %s: %w", rawURL, err)
+	}
+	return body, resp.Header.Get("Content-Type"), nil
+}
+
+// resolveIconURL возвращает HTTP(S) адрес ref относительно base (пусто, если адрес некорректен)
+func resolveIconURL(base, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return ""
+	}
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return ""
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	resolved := baseURL.ResolveReference(refURL)
+	if resolved.Scheme != "http" && resolved.Scheme != "https" {
+		return ""
+	}
+	resolved.Fragment = ""
+	return resolved.String()
+}
@@ -0,0 +1,53 @@
+// internal/adapter/fetcher/http/itunes.go
+package httpfetcher
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"rsshub/internal/core/domain"
+)
+
+// podcastInfo собирает метаданные эпизода подкаста из элементов itunes:*.
+// Возвращает nil, если элемент не содержит метаданных подкаста
+func podcastInfo(item *domain.RSSItem) *domain.PodcastInfo {
+	info := &domain.PodcastInfo{
+		Author: strings.TrimSpace(item.ITunesAuthor),
+		Image:  strings.TrimSpace(item.ITunesImage.Href),
+	}
+
+	if value := strings.TrimSpace(item.ITunesDuration); value != "" {
+		if duration, err := parseITunesDuration(value); err == nil {
+			info.Duration = duration
+		}
+	}
+	if value := strings.TrimSpace(item.ITunesEpisode); value != "" {
+		if episode, err := strconv.Atoi(value); err == nil && episode > 0 {
+			info.Episode = episode
+		}
+	}
+
+	if info.IsZero() {
+		return nil
+	}
+	return info
+}
+
+// parseITunesDuration разбирает itunes:duration в секундах: "3600", "62:03" или "1:02:03"
+func parseITunesDuration(value string) (int, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	seconds := 0
+	for _, part := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		seconds = seconds*60 + n
+	}
+	return seconds, nil
+}
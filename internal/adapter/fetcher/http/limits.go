@@ -0,0 +1,83 @@
+package httpfetcher
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// limitedReader возвращает ошибку, а не обрывает поток молча (как io.LimitReader),
+// если тело ответа превышает допустимый размер
+type limitedReader struct {
+	r     io.Reader
+	limit int64 // Максимальный размер в байтах
+	read  int64 // Прочитано байт
+}
+
+// newLimitedReader ограничивает r размером limit байт (limit <= 0 - без ограничения)
+func newLimitedReader(r io.Reader, limit int64) io.Reader {
+	if limit <= 0 {
+		return r
+	}
+	return &limitedReader{r: r, limit: limit}
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	// Читаем на байт больше лимита, чтобы отличить ровно limit байт от превышения
+	if remaining := l.limit - l.read + 1; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.limit {
+		return n - int(l.read-l.limit), fmt.Errorf("response body exceeds %d bytes limit", l.limit)
+	}
+	return n, err
+}
+
+// limitedTokenReader ограничивает глубину вложенности и количество XML токенов документа,
+// защищая от лент с патологически глубоким или огромным XML
+type limitedTokenReader struct {
+	d         *xml.Decoder
+	maxDepth  int // Максимальная глубина вложенности элементов (0 - без ограничения)
+	maxTokens int // Максимальное количество токенов (0 - без ограничения)
+	depth     int
+	tokens    int
+}
+
+func (t *limitedTokenReader) Token() (xml.Token, error) {
+	tok, err := t.d.Token()
+	if err != nil {
+		return tok, err
+	}
+
+	t.tokens++
+	if t.maxTokens > 0 && t.tokens > t.maxTokens {
+		return nil, fmt.Errorf("XML document exceeds %d tokens limit", t.maxTokens)
+	}
+
+	switch tok.(type) {
+	case xml.StartElement:
+		t.depth++
+		if t.maxDepth > 0 && t.depth > t.maxDepth {
+			return nil, fmt.Errorf("XML nesting exceeds depth limit %d", t.maxDepth)
+		}
+	case xml.EndElement:
+		t.depth--
+	}
+
+	return tok, nil
+}
+
+// countingReader считает байты, прочитанные из r
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
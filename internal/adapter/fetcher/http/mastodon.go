@@ -0,0 +1,193 @@
+// internal/adapter/fetcher/http/mastodon.go
+package httpfetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/utils"
+)
+
+// webfingerProfilePage rel ссылки WebFinger на HTML страницу профиля
+const webfingerProfilePage = "http://webfinger.net/rel/profile-page"
+
+// maxGeneratedTitle длина заголовка, составленного из текста поста без заголовка (в символах)
+const maxGeneratedTitle = 80
+
+var (
+	// mastodonAccount имя аккаунта: буквы, цифры, подчеркивание, точка и дефис
+	mastodonAccount = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+	// mastodonFeedPath путь ленты аккаунта Mastodon: /@user.rss или /users/user.rss
+	mastodonFeedPath = regexp.MustCompile(`^/(@[^/]+|users/[^/]+)\.rss$`)
+
+	// mastodonStatusID числовой идентификатор поста в конце ссылки (/@user/110123456789)
+	mastodonStatusID = regexp.MustCompile(`/(\d+)/?$`)
+)
+
+// resolveMastodon возвращает URL ленты RSS аккаунта Mastodon (сокращение mastodon:). input -
+// адрес аккаунта @user@instance (user@instance) или ссылка на профиль https://instance/@user.
+// Адрес аккаунта проверяется через WebFinger: домен аккаунта может отличаться от домена сервера
+func (p *Parser) resolveMastodon(ctx context.Context, input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", fmt.Errorf("Mastodon account is empty")
+	}
+
+	if strings.HasPrefix(input, "https://") || strings.HasPrefix(input, "http://") {
+		return mastodonProfileFeed(input)
+	}
+
+	user, host, ok := strings.Cut(strings.TrimPrefix(input, "@"), "@")
+	if !ok || !mastodonAccount.MatchString(user) || host == "" || strings.ContainsAny(host, "/@?#") {
+		return "", fmt.Errorf("invalid Mastodon account %q (expected @user@instance or https://instance/@user)", input)
+	}
+
+	logger.Info("Resolving Mastodon account: @%s@%s", user, host)
+	profile, err := p.fetchMastodonProfile(ctx, user, host)
+	if err != nil {
+		return "", err
+	}
+	return mastodonProfileFeed(profile)
+}
+
+// mastodonProfileFeed возвращает URL ленты по ссылке на профиль https://instance/@user
+func mastodonProfileFeed(profile string) (string, error) {
+	u, err := url.Parse(profile)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid Mastodon profile URL: %s", profile)
+	}
+
+	path := strings.TrimSuffix(u.Path, "/")
+	if mastodonFeedPath.MatchString(path) {
+		return (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: path}).String(), nil
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(parts) == 1 && strings.HasPrefix(parts[0], "@") && mastodonAccount.MatchString(parts[0][1:]):
+		path = "/" + parts[0] + ".rss"
+	case len(parts) == 2 && parts[0] == "users" && mastodonAccount.MatchString(parts[1]):
+		path = "/users/" + parts[1] + ".rss"
+	default:
+		return "", fmt.Errorf("unrecognized Mastodon profile URL: %s (expected https://instance/@user)", profile)
+	}
+	return (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: path}).String(), nil
+}
+
+// fetchMastodonProfile запрашивает WebFinger сервера domainName и возвращает ссылку на профиль user
+func (p *Parser) fetchMastodonProfile(ctx context.Context, user, domainName string) (string, error) {
+	resource := "acct:" + user + "@" + domainName
+	lookupURL := "https://" + domainName + "/.well-known/webfinger?resource=" + url.QueryEscape(resource)
+
+	feed := &domain.Feed{URL: lookupURL}
+	transport, err := p.transports.forFeed(feed)
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare transport for %s: %w", lookupURL, err)
+	}
+	client := &http.Client{Transport: transport}
+
+	if host, err := hostOf(lookupURL); err == nil {
+		if err := p.limiter.Wait(ctx, host); err != nil {
+			return "", fmt.Errorf("rate limit wait for %s interrupted: %w", lookupURL, err)
+		}
+	}
+
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, lookupURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build request for %s: %w", lookupURL, err)
+	}
+	if p.userAgent != "" {
+		req.Header.Set("User-Agent", p.userAgent)
+	}
+	req.Header.Set("Accept", "application/jrd+json, application/json")
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up Mastodon account %s: %w", resource, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("Mastodon account not found: @%s@%s", user, domainName)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("WebFinger returned status %d: %s", resp.StatusCode, lookupURL)
+	}
+
+	body, err := decodeBody(resp)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode response from %s: %w", lookupURL, err)
+	}
+	defer body.Close()
+
+	var jrd struct {
+		Links []struct {
+			Rel  string `json:"rel"`
+			Type string `json:"type"`
+			Href string `json:"href"`
+		} `json:"links"`
+	}
+	if err := json.NewDecoder(newLimitedReader(body, p.maxBodySize)).Decode(&jrd); err != nil {
+		return "", fmt.Errorf("failed to parse WebFinger response from %s: %w", lookupURL, err)
+	}
+
+	for _, link := range jrd.Links {
+		if link.Rel == webfingerProfilePage && link.Href != "" {
+			return link.Href, nil
+		}
+	}
+	return "", fmt.Errorf("profile page of @%s@%s not found in WebFinger response", user, domainName)
+}
+
+// mastodonNextPage возвращает URL следующей (более старой) страницы ленты аккаунта Mastodon.
+// Лента отдает только последние посты, более старые запрашиваются параметром max_id -
+// идентификатором самого старого поста страницы. Для других лент возвращает ""
+func mastodonNextPage(feedURL string, items []domain.ParsedRSSItem) string {
+	u, err := url.Parse(feedURL)
+	if err != nil || !mastodonFeedPath.MatchString(u.Path) {
+		return ""
+	}
+
+	var oldest uint64
+	for _, item := range items {
+		for _, ref := range []string{item.GUID, item.Link} {
+			m := mastodonStatusID.FindStringSubmatch(ref)
+			if m == nil {
+				continue
+			}
+			if id, err := strconv.ParseUint(m[1], 10, 64); err == nil && (oldest == 0 || id < oldest) {
+				oldest = id
+			}
+			break
+		}
+	}
+	if oldest == 0 {
+		return ""
+	}
+
+	query := u.Query()
+	query.Set("max_id", strconv.FormatUint(oldest, 10))
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// titleFromDescription составляет заголовок из начала описания. RSS 2.0 допускает элементы
+// без заголовка (так публикуются посты Mastodon), обязательно только одно из двух
+func titleFromDescription(description string) string {
+	return utils.Truncate(utils.StripHTML(description), maxGeneratedTitle)
+}
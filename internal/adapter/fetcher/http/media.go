@@ -0,0 +1,76 @@
+// internal/adapter/fetcher/http/media.go
+package httpfetcher
+
+import (
+	"strconv"
+	"strings"
+
+	"rsshub/internal/core/domain"
+)
+
+// mediaItems собирает вложения Media RSS элемента ленты: media:content и media:thumbnail,
+// в том числе вложенные в media:group и media:content. Повторяющиеся URL пропускаются
+func mediaItems(contents []domain.MediaContent, thumbnails []domain.MediaThumbnail, groups []domain.MediaGroup) []domain.MediaItem {
+	var items []domain.MediaItem
+	seen := make(map[string]bool)
+
+	add := func(m domain.MediaItem) {
+		m.URL = strings.TrimSpace(m.URL)
+		key := string(m.Kind) + " " + m.URL
+		if m.URL == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		items = append(items, m)
+	}
+
+	addContents := func(contents []domain.MediaContent) {
+		for _, c := range contents {
+			add(domain.MediaItem{
+				Kind:   domain.MediaKindContent,
+				URL:    c.URL,
+				Type:   strings.TrimSpace(c.Type),
+				Medium: strings.ToLower(strings.TrimSpace(c.Medium)),
+				Width:  mediaSize(c.Width),
+				Height: mediaSize(c.Height),
+			})
+		}
+	}
+	addThumbnails := func(thumbnails []domain.MediaThumbnail) {
+		for _, t := range thumbnails {
+			add(domain.MediaItem{
+				Kind:   domain.MediaKindThumbnail,
+				URL:    t.URL,
+				Width:  mediaSize(t.Width),
+				Height: mediaSize(t.Height),
+			})
+		}
+	}
+
+	addContents(contents)
+	for _, group := range groups {
+		addContents(group.Contents)
+	}
+
+	addThumbnails(thumbnails)
+	for _, group := range groups {
+		addThumbnails(group.Thumbnails)
+		for _, c := range group.Contents {
+			addThumbnails(c.Thumbnails)
+		}
+	}
+	for _, c := range contents {
+		addThumbnails(c.Thumbnails)
+	}
+
+	return items
+}
+
+// mediaSize разбирает атрибут размера вложения; некорректное значение - 0 (неизвестно)
+func mediaSize(value string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
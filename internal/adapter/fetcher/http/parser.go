@@ -1,71 +1,337 @@
 package httpfetcher
 
 import (
+	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"rsshub/internal/core/domain"
 	"rsshub/internal/core/port"
+	"rsshub/internal/platform/config"
 	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/netguard"
+
+	"golang.org/x/net/html/charset"
 )
 
 // Parser отвечает за получение и парсинг RSS лент
 type Parser struct {
-	client *http.Client
+	timeout    time.Duration   // Таймаут для HTTP запросов (для лент без собственного таймаута)
+	guard      *netguard.Guard // Проверка адресов (защита от SSRF)
+	transports *transportPool  // Транспорты с учетом прокси и TLS настроек
+	limiter    *hostLimiter    // Ограничение частоты запросов к одному хосту
+	userAgent  string          // User-Agent по умолчанию
+
+	maxRedirects int // Максимальная длина цепочки перенаправлений (0 - не следовать)
+
+	// Ограничения на размер и структуру документа ленты (0 - без ограничения)
+	maxBodySize  int64 // Максимальный размер распакованного тела ответа в байтах
+	maxXMLDepth  int   // Максимальная глубина вложенности XML элементов
+	maxXMLTokens int   // Максимальное количество XML токенов
+
+	dateFormats []string // Дополнительные форматы дат публикации (layout Go)
+
+	resolversMu sync.RWMutex
+	resolvers   map[string]ShorthandResolver // Сокращения URL лент по префиксу (reddit:, github:, ...)
 }
 
 // NewParser создает новый RSS парсер
-func NewParser() port.Parser {
-	return &Parser{
-		client: &http.Client{
-			Timeout: 30 * time.Second, // Таймаут для HTTP запросов
-		},
+func NewParser(cfg *config.FetcherConfig) (port.Parser, error) {
+	guard, err := netguard.New(cfg.BlockPrivateNetworks, cfg.AllowedNetworks)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CLI_APP_ALLOWED_NETWORKS: %w", err)
+	}
+
+	transports, err := newTransportPool(cfg, guard)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure HTTP transport: %w", err)
+	}
+
+	p := &Parser{
+		timeout:    cfg.Timeout,
+		guard:      guard,
+		transports: transports,
+		limiter:    newHostLimiter(cfg.HostRateLimit, cfg.HostRateBurst),
+		userAgent:  cfg.UserAgent,
+
+		maxRedirects: cfg.MaxRedirects,
+
+		maxBodySize:  int64(cfg.MaxResponseSize),
+		maxXMLDepth:  cfg.MaxXMLDepth,
+		maxXMLTokens: cfg.MaxXMLTokens,
+
+		dateFormats: cfg.DateFormats,
 	}
+	p.registerDefaultResolvers()
+	return p, nil
+}
+
+// FetchAndParse получает RSS ленту и парсит её; отмена ctx прерывает запрос
+func (p *Parser) FetchAndParse(ctx context.Context, feed *domain.Feed) (*domain.ParsedRSSFeed, error) {
+	return p.fetch(ctx, feed, domain.CacheValidators{})
 }
 
-// FetchAndParse получает RSS ленту по URL и парсит её
-func (p *Parser) FetchAndParse(url string) (*domain.ParsedRSSFeed, error) {
+// FetchIfModified получает ленту условным запросом с валидаторами feed.Cache
+// (If-None-Match и If-Modified-Since). На ответ 304 возвращает ленту с NotModified без элементов
+func (p *Parser) FetchIfModified(ctx context.Context, feed *domain.Feed) (*domain.ParsedRSSFeed, error) {
+	return p.fetch(ctx, feed, feed.Cache)
+}
+
+// fetch получает и парсит ленту; непустые валидаторы cache делают запрос условным
+func (p *Parser) fetch(ctx context.Context, feed *domain.Feed, cache domain.CacheValidators) (*domain.ParsedRSSFeed, error) {
+	url := feed.URL
 	logger.Info("Fetching RSS feed: %s", url)
 
+	transport, err := p.transports.forFeed(feed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare transport for %s: %w", url, err)
+	}
+	// Запоминаем адрес, на который лента перенаправлена только постоянными редиректами
+	var movedTo string
+	permanent := true
+	client := &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > p.maxRedirects {
+				if p.maxRedirects == 0 {
+					return fmt.Errorf("redirect to %s refused: following redirects is disabled by CLI_APP_MAX_REDIRECTS=0", req.URL)
+				}
+				return fmt.Errorf("stopped after %d redirects", p.maxRedirects)
+			}
+			// Цель редиректа проверяется и здесь: через прокси соединение с ней устанавливает он
+			if err := p.guard.CheckHost(req.Context(), req.URL.Hostname()); err != nil {
+				return err
+			}
+			for _, prev := range via {
+				if prev.URL.String() == req.URL.String() {
+					return fmt.Errorf("redirect loop detected at %s", req.URL)
+				}
+			}
+			if permanent && isPermanentRedirect(req.Response) {
+				movedTo = req.URL.String()
+			} else {
+				permanent = false
+			}
+			return nil
+		},
+	}
+
+	// Соблюдаем лимит запросов к хосту, общий для всех воркеров
+	if host, err := hostOf(url); err == nil {
+		if err := p.limiter.Wait(ctx, host); err != nil {
+			return nil, fmt.Errorf("rate limit wait for %s interrupted: %w", url, err)
+		}
+	}
+
+	req, err := p.newRequest(feed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request for %s: %w", url, err)
+	}
+	if cache.ETag != "" {
+		req.Header.Set("If-None-Match", cache.ETag)
+	}
+	if cache.LastModified != "" {
+		req.Header.Set("If-Modified-Since", cache.LastModified)
+	}
+
+	// Срок отсчитывается после ожидания лимита хоста и покрывает запрос, редиректы и чтение тела
+	cancel := context.CancelFunc(func() {})
+	if timeout := p.timeoutFor(feed); timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	defer cancel()
+	req = req.WithContext(ctx)
+
 	// Делаем HTTP запрос к RSS ленте
-	resp, err := p.client.Get(url)
+	resp, err := client.Do(req)
 	if err != nil {
+		if errors.Is(err, netguard.ErrForbiddenAddress) {
+			return nil, fmt.Errorf("refused to fetch RSS feed %s: %w (allow it with CLI_APP_ALLOWED_NETWORKS)", url, err)
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("failed to fetch RSS feed %s: timed out after %v", url, p.timeoutFor(feed))
+		}
 		return nil, fmt.Errorf("failed to fetch RSS feed %s: %w", url, err)
 	}
 	defer resp.Body.Close()
 
-	// Проверяем статус код ответа
+	// Проверяем статус код ответа; 410 означает, что лента удалена навсегда
+	if resp.StatusCode == http.StatusGone {
+		return nil, fmt.Errorf("%w: %s", domain.ErrFeedGone, url)
+	}
+	// На условный запрос неизменившаяся лента отвечает 304 без тела
+	if resp.StatusCode == http.StatusNotModified && !cache.IsZero() {
+		logger.Info("RSS feed not modified: %s", url)
+		parsed := &domain.ParsedRSSFeed{NotModified: true, Cache: responseCache(resp.Header, cache)}
+		if movedTo != "" && movedTo != url {
+			parsed.MovedTo = movedTo
+		}
+		return parsed, nil
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("RSS feed returned status %d: %s", resp.StatusCode, url)
 	}
 
-	// Парсим XML в структуру RSS
+	// Заведомо слишком большой ответ отклоняем, не читая тело
+	if p.maxBodySize > 0 && resp.ContentLength > p.maxBodySize {
+		return nil, fmt.Errorf("RSS feed response of %d bytes exceeds %d bytes limit: %s", resp.ContentLength, p.maxBodySize, url)
+	}
+
+	// Трафик считается по сжатому ответу, как он передан по сети
+	counter := &countingReader{r: resp.Body}
+	resp.Body = &readCloser{Reader: counter, closers: []io.Closer{resp.Body}}
+
+	body, err := decodeBody(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response from %s: %w", url, err)
+	}
+	defer body.Close()
+
+	parsed, err := p.Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("%w (%s)", err, url)
+	}
+	parsed.Bytes = counter.n
+	parsed.Cache = responseCache(resp.Header, domain.CacheValidators{})
+
+	// WebSub хаб может объявляться в заголовке Link; он имеет приоритет над документом
+	links := linkRelations(resp.Header.Values("Link"))
+	if hub, ok := links["hub"]; ok {
+		parsed.HubURL = hub
+	}
+	if self, ok := links["self"]; ok {
+		parsed.SelfURL = self
+	}
+
+	if movedTo != "" && movedTo != url {
+		parsed.MovedTo = movedTo
+	}
+
+	// Архив ленты аккаунта Mastodon листается по max_id, а не ссылками prev-archive
+	if parsed.PrevArchive == "" {
+		parsed.PrevArchive = mastodonNextPage(url, parsed.Items)
+	}
+
+	logger.Info("Successfully parsed RSS feed: %s (%d items)", url, len(parsed.Items))
+	return parsed, nil
+}
+
+// Parse разбирает RSS документ из r
+func (p *Parser) Parse(r io.Reader) (*domain.ParsedRSSFeed, error) {
+	// Парсим XML в структуру RSS. Лимит размера применяется к распакованным данным,
+	// поэтому защищает и от "zip бомб"
 	var rssFeed domain.RSSFeed
-	decoder := xml.NewDecoder(resp.Body)
-	if err := decoder.Decode(&rssFeed); err != nil {
-		return nil, fmt.Errorf("failed to parse RSS XML from %s: %w", url, err)
+	decoder := xml.NewDecoder(newLimitedReader(r, p.maxBodySize))
+	// Ленты в windows-1251, ISO-8859-1, GBK и т.п. перекодируются в UTF-8
+	// согласно encoding из XML декларации
+	decoder.CharsetReader = charset.NewReaderLabel
+	tokens := &limitedTokenReader{d: decoder, maxDepth: p.maxXMLDepth, maxTokens: p.maxXMLTokens}
+	if err := xml.NewTokenDecoder(tokens).Decode(&rssFeed); err != nil {
+		return nil, fmt.Errorf("failed to parse RSS XML: %w", err)
 	}
 
 	// Конвертируем сырую RSS структуру в нашу обработанную версию
 	parsed, err := p.convertToParsedFeed(&rssFeed)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert RSS feed %s: %w", url, err)
+		return nil, fmt.Errorf("failed to convert RSS feed: %w", err)
 	}
 
-	logger.Info("Successfully parsed RSS feed: %s (%d items)", url, len(parsed.Items))
 	return parsed, nil
 }
 
+// timeoutFor возвращает таймаут получения ленты: собственный, если он задан, иначе глобальный
+func (p *Parser) timeoutFor(feed *domain.Feed) time.Duration {
+	if feed.Timeout > 0 {
+		return feed.Timeout
+	}
+	return p.timeout
+}
+
+// responseCache возвращает валидаторы кеша из заголовков ответа. Заголовок, которого в ответе нет,
+// берется из previous: ответ 304 может не повторять валидаторы документа
+func responseCache(header http.Header, previous domain.CacheValidators) domain.CacheValidators {
+	cache := domain.CacheValidators{ETag: header.Get("ETag"), LastModified: header.Get("Last-Modified")}
+	if cache.ETag == "" {
+		cache.ETag = previous.ETag
+	}
+	if cache.LastModified == "" {
+		cache.LastModified = previous.LastModified
+	}
+	return cache
+}
+
+// isPermanentRedirect проверяет, что ответ - постоянное перенаправление (301 или 308)
+func isPermanentRedirect(resp *http.Response) bool {
+	return resp != nil && (resp.StatusCode == http.StatusMovedPermanently || resp.StatusCode == http.StatusPermanentRedirect)
+}
+
+// newRequest создает GET запрос с заголовками по умолчанию и заголовками ленты
+func (p *Parser) newRequest(feed *domain.Feed) (*http.Request, error) {
+	req, err := http.NewRequest(http.MethodGet, feed.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.userAgent != "" {
+		req.Header.Set("User-Agent", p.userAgent)
+	}
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, text/xml;q=0.8, */*;q=0.5")
+	// Явно запрашиваем сжатие; при этом распаковка выполняется нами, а не транспортом
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+
+	// Заголовки ленты имеют приоритет над заголовками по умолчанию
+	for name, value := range feed.Headers {
+		req.Header.Set(name, value)
+	}
+
+	// Учетные данные приватной ленты
+	if !feed.Auth.IsZero() {
+		if feed.Auth.BearerToken != "" {
+			req.Header.Set("Authorization", "Bearer "+feed.Auth.BearerToken)
+		} else {
+			req.SetBasicAuth(feed.Auth.Username, feed.Auth.Password)
+		}
+	}
+
+	return req, nil
+}
+
 // convertToParsedFeed конвертирует сырую RSS структуру в обработанную
 func (p *Parser) convertToParsedFeed(rssFeed *domain.RSSFeed) (*domain.ParsedRSSFeed, error) {
+	if rssFeed.IsAtom() {
+		return p.convertAtomFeed(rssFeed), nil
+	}
+
 	parsed := &domain.ParsedRSSFeed{
 		Title:       rssFeed.Channel.Title,
 		Link:        rssFeed.Channel.Link,
 		Description: rssFeed.Channel.Description,
 		Items:       make([]domain.ParsedRSSItem, 0, len(rssFeed.Channel.Items)),
+		ImageURL:    strings.TrimSpace(rssFeed.Channel.Image.URL),
+	}
+	if parsed.ImageURL == "" {
+		parsed.ImageURL = strings.TrimSpace(rssFeed.Channel.ITunesImage.Href)
+	}
+
+	// Ссылки WebSub: хаб и канонический URL ленты
+	for _, link := range rssFeed.Channel.AtomLinks {
+		switch strings.ToLower(link.Rel) {
+		case "hub":
+			if parsed.HubURL == "" {
+				parsed.HubURL = strings.TrimSpace(link.Href)
+			}
+		case "self":
+			parsed.SelfURL = strings.TrimSpace(link.Href)
+		case "prev-archive":
+			parsed.PrevArchive = strings.TrimSpace(link.Href)
+		}
 	}
 
 	// Обрабатываем каждый элемент RSS ленты
@@ -88,6 +354,9 @@ func (p *Parser) convertRSSItem(item *domain.RSSItem) (*domain.ParsedRSSItem, er
 		Title:       strings.TrimSpace(item.Title),
 		Link:        strings.TrimSpace(item.Link),
 		Description: strings.TrimSpace(item.Description),
+		GUID:        strings.TrimSpace(item.GUID),
+		Podcast:     podcastInfo(item),
+		Media:       mediaItems(item.MediaContents, item.MediaThumbnails, item.MediaGroups),
 	}
 
 	// Парсим дату публикации
@@ -106,6 +375,9 @@ func (p *Parser) convertRSSItem(item *domain.RSSItem) (*domain.ParsedRSSItem, er
 	}
 
 	// Валидируем обязательные поля
+	if parsed.Title == "" {
+		parsed.Title = titleFromDescription(parsed.Description)
+	}
 	if parsed.Title == "" {
 		return nil, fmt.Errorf("article title is empty")
 	}
@@ -116,42 +388,55 @@ func (p *Parser) convertRSSItem(item *domain.RSSItem) (*domain.ParsedRSSItem, er
 	return parsed, nil
 }
 
-// parseRSSDate парсит дату из RSS формата в time.Time
-// RSS использует RFC 2822 формат, например: "Mon, 06 Sep 2021 12:00:00 GMT"
-func (p *Parser) parseRSSDate(dateStr string) (time.Time, error) {
-	dateStr = strings.TrimSpace(dateStr)
-
-	// Список возможных форматов даты в RSS
-	formats := []string{
-		time.RFC1123Z,               // "Mon, 02 Jan 2006 15:04:05 -0700"
-		time.RFC1123,                // "Mon, 02 Jan 2006 15:04:05 MST"
-		time.RFC822Z,                // "02 Jan 06 15:04 -0700"
-		time.RFC822,                 // "02 Jan 06 15:04 MST"
-		"2006-01-02T15:04:05Z07:00", // ISO 8601
-		"2006-01-02 15:04:05",       // Простой формат
-		"2006-01-02",                // Только дата
-	}
-
-	// Пробуем каждый формат
-	for _, format := range formats {
-		if parsedTime, err := time.Parse(format, dateStr); err == nil {
-			return parsedTime, nil
-		}
-	}
-
-	return time.Time{}, fmt.Errorf("unable to parse date: %s", dateStr)
-}
-
-// ValidateRSSURL проверяет, является ли URL валидным RSS источником
-func (p *Parser) ValidateRSSURL(url string) error {
-	logger.Info("Validating RSS URL: %s", url)
+// ValidateFeed проверяет, является ли URL ленты валидным RSS источником
+func (p *Parser) ValidateFeed(ctx context.Context, feed *domain.Feed) error {
+	logger.Info("Validating RSS URL: %s", feed.URL)
 
 	// Пробуем получить и парсить RSS ленту
-	_, err := p.FetchAndParse(url)
+	_, err := p.FetchAndParse(ctx, feed)
 	if err != nil {
 		return fmt.Errorf("RSS URL validation failed: %w", err)
 	}
 
-	logger.Info("RSS URL is valid: %s", url)
+	logger.Info("RSS URL is valid: %s", feed.URL)
 	return nil
 }
+
+// linkRelations разбирает HTTP заголовки Link (RFC 8288) вида
+// <https://hub.example.com/>; rel="hub" и возвращает первый URL для каждого rel
+func linkRelations(values []string) map[string]string {
+	result := make(map[string]string)
+	for _, value := range values {
+		for _, link := range strings.Split(value, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			target = strings.Trim(target, "<>")
+
+			for _, param := range parts[1:] {
+				name, val, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || !strings.EqualFold(strings.TrimSpace(name), "rel") {
+					continue
+				}
+				// rel может содержать несколько значений через пробел
+				for _, rel := range strings.Fields(strings.ToLower(strings.Trim(val, `"`))) {
+					if _, exists := result[rel]; !exists {
+						result[rel] = target
+					}
+				}
+			}
+		}
+	}
+	return result
+}
+
+// hostOf извлекает имя хоста из URL ленты
+func hostOf(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	return u.Hostname(), nil
+}
@@ -0,0 +1,101 @@
+package httpfetcher
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// hostLimiter ограничивает частоту запросов к каждому хосту (token bucket на хост)
+type hostLimiter struct {
+	mu      sync.Mutex
+	rate    float64 // Токенов в секунду
+	burst   float64 // Максимальный размер корзины
+	buckets map[string]*bucket
+}
+
+// bucket хранит состояние корзины токенов для одного хоста
+type bucket struct {
+	tokens float64   // Текущее количество токенов (может быть отрицательным при резервировании)
+	last   time.Time // Время последнего пополнения
+}
+
+// newHostLimiter создает лимитер; perMinute <= 0 отключает ограничение
+func newHostLimiter(perMinute, burst int) *hostLimiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+	return &hostLimiter{
+		rate:    float64(perMinute) / 60.0,
+		burst:   float64(burst),
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Wait блокирует вызывающего, пока для хоста не появится свободный токен. Отмена ctx
+// прерывает ожидание, возвращает зарезервированный токен и возвращает ctx.Err()
+func (l *hostLimiter) Wait(ctx context.Context, host string) error {
+	if l == nil {
+		return ctx.Err()
+	}
+	host = strings.ToLower(host)
+	delay := l.reserve(host)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.release(host)
+		return ctx.Err()
+	}
+}
+
+// release возвращает токен, зарезервированный прерванным ожиданием, чтобы следующие
+// запросы к хосту не ждали за него
+func (l *hostLimiter) release(host string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b, ok := l.buckets[host]; ok {
+		b.tokens++
+		if b.tokens > l.burst {
+			b.tokens = l.burst
+		}
+	}
+}
+
+// reserve резервирует токен и возвращает время ожидания до его появления.
+// Резервирование в долг гарантирует, что конкурентные воркеры выстраиваются в очередь
+func (l *hostLimiter) reserve(host string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	b, ok := l.buckets[host]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[host] = b
+	}
+
+	// Пополняем корзину пропорционально прошедшему времени
+	b.tokens += now.Sub(b.last).Seconds() * l.rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / l.rate * float64(time.Second))
+}
@@ -0,0 +1,122 @@
+// internal/adapter/fetcher/http/resolver.go
+package httpfetcher
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ShorthandResolver раскрывает значение сокращения (часть после "prefix:") в URL ленты
+type ShorthandResolver func(ctx context.Context, value string) (string, error)
+
+// registerDefaultResolvers регистрирует встроенные сокращения reddit:, github:, youtube: и mastodon:
+func (p *Parser) registerDefaultResolvers() {
+	p.RegisterResolver("reddit", resolveReddit)
+	p.RegisterResolver("github", resolveGitHub)
+	p.RegisterResolver("youtube", p.resolveYouTube)
+	p.RegisterResolver("mastodon", p.resolveMastodon)
+}
+
+// RegisterResolver регистрирует сокращение prefix:value; повторная регистрация заменяет прежнюю
+func (p *Parser) RegisterResolver(prefix string, resolver ShorthandResolver) {
+	p.resolversMu.Lock()
+	defer p.resolversMu.Unlock()
+
+	if p.resolvers == nil {
+		p.resolvers = make(map[string]ShorthandResolver)
+	}
+	p.resolvers[strings.ToLower(prefix)] = resolver
+}
+
+// ResolveFeedURL раскрывает сокращение вида "reddit:r/golang" в URL ленты.
+// Обычные URL и строки без зарегистрированного префикса возвращаются без изменений
+func (p *Parser) ResolveFeedURL(ctx context.Context, raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	prefix, value, ok := strings.Cut(raw, ":")
+	if !ok {
+		return raw, nil
+	}
+
+	p.resolversMu.RLock()
+	resolver, ok := p.resolvers[strings.ToLower(prefix)]
+	p.resolversMu.RUnlock()
+	if !ok {
+		return raw, nil
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("shorthand %s requires a value", raw)
+	}
+
+	resolved, err := resolver(ctx, value)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", raw, err)
+	}
+	return resolved, nil
+}
+
+// resolveReddit раскрывает r/<subreddit>[/<hot|new|top|rising>], u/<user> или просто <subreddit>
+func resolveReddit(_ context.Context, value string) (string, error) {
+	parts := strings.Split(strings.Trim(value, "/"), "/")
+	if len(parts) == 1 {
+		parts = []string{"r", parts[0]}
+	}
+
+	switch parts[0] {
+	case "r":
+		if len(parts) < 2 || len(parts) > 3 || parts[1] == "" {
+			return "", fmt.Errorf("expected r/<subreddit> or r/<subreddit>/<sort>")
+		}
+		path := "r/" + url.PathEscape(parts[1])
+		if len(parts) == 3 {
+			switch parts[2] {
+			case "hot", "new", "top", "rising":
+				path += "/" + parts[2]
+			default:
+				return "", fmt.Errorf("unknown sort %q (expected hot, new, top or rising)", parts[2])
+			}
+		}
+		return "https://www.reddit.com/" + path + "/.rss", nil
+	case "u", "user":
+		if len(parts) != 2 || parts[1] == "" {
+			return "", fmt.Errorf("expected u/<user>")
+		}
+		return "https://www.reddit.com/user/" + url.PathEscape(parts[1]) + "/.rss", nil
+	default:
+		return "", fmt.Errorf("expected r/<subreddit> or u/<user>")
+	}
+}
+
+// resolveGitHub раскрывает <owner>/<repo>[/releases|tags|commits[/<branch>]] или <user> (активность)
+func resolveGitHub(_ context.Context, value string) (string, error) {
+	parts := strings.Split(strings.Trim(value, "/"), "/")
+	for _, part := range parts {
+		if part == "" {
+			return "", fmt.Errorf("expected <owner>/<repo>[/releases|tags|commits]")
+		}
+	}
+
+	if len(parts) == 1 {
+		return "https://github.com/" + url.PathEscape(parts[0]) + ".atom", nil
+	}
+
+	repo := "https://github.com/" + url.PathEscape(parts[0]) + "/" + url.PathEscape(parts[1])
+	if len(parts) == 2 {
+		return repo + "/releases.atom", nil // Релизы - самая частая подписка
+	}
+
+	switch kind := parts[2]; {
+	case (kind == "releases" || kind == "tags") && len(parts) == 3:
+		return repo + "/" + kind + ".atom", nil
+	case kind == "commits" && len(parts) == 3:
+		return repo + "/commits.atom", nil
+	case kind == "commits":
+		// Имя ветки может содержать "/"
+		return repo + "/commits/" + strings.Join(parts[3:], "/") + ".atom", nil
+	default:
+		return "", fmt.Errorf("unknown GitHub feed %q (expected releases, tags or commits)", strings.Join(parts[2:], "/"))
+	}
+}
@@ -0,0 +1,132 @@
+package httpfetcher
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/config"
+	"rsshub/internal/platform/netguard"
+)
+
+// transportPool хранит HTTP транспорты для разных комбинаций прокси/TLS настроек лент
+type transportPool struct {
+	mu       sync.Mutex
+	rootCAs  *x509.CertPool  // Доверенные CA (системные + пользовательский бандл)
+	proxy    string          // Глобальный прокси из конфигурации
+	insecure bool            // Глобальное отключение проверки TLS
+	guard    *netguard.Guard // Проверка адресов соединений (защита от SSRF)
+	base     *http.Transport
+	byKey    map[string]*http.Transport
+}
+
+// newTransportPool создает пул с базовым транспортом из глобальной конфигурации
+func newTransportPool(cfg *config.FetcherConfig, guard *netguard.Guard) (*transportPool, error) {
+	rootCAs, err := loadRootCAs(cfg.CABundle)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := &transportPool{
+		rootCAs:  rootCAs,
+		proxy:    cfg.ProxyURL,
+		insecure: cfg.TLSInsecure,
+		guard:    guard,
+		byKey:    make(map[string]*http.Transport),
+	}
+
+	pool.base, err = pool.newTransport(cfg.ProxyURL, cfg.TLSInsecure)
+	if err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+// forFeed возвращает транспорт с учетом индивидуальных настроек ленты
+func (p *transportPool) forFeed(feed *domain.Feed) (*http.Transport, error) {
+	if feed == nil || (feed.ProxyURL == "" && !feed.TLSInsecure) {
+		return p.base, nil
+	}
+
+	key := feed.ProxyURL + "|" + strconv.FormatBool(feed.TLSInsecure)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if t, ok := p.byKey[key]; ok {
+		return t, nil
+	}
+
+	proxy := feed.ProxyURL
+	if proxy == "" {
+		// Лента без своего прокси использует глобальный (или переменные окружения)
+		proxy = p.proxy
+	}
+
+	t, err := p.newTransport(proxy, feed.TLSInsecure || p.insecure)
+	if err != nil {
+		return nil, err
+	}
+	p.byKey[key] = t
+	return t, nil
+}
+
+// newTransport создает транспорт с указанным прокси (http, https или socks5) и TLS настройками
+func (p *transportPool) newTransport(proxy string, insecure bool) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+
+	// Прокси проверяется один раз, при создании транспорта; дальше guard пропускает
+	// соединения с ним и проверяет цели запросов, которые идут через него
+	var proxyURL *url.URL
+	if proxy != "" {
+		var err error
+		proxyURL, err = url.Parse(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %s: %w", proxy, err)
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return nil, fmt.Errorf("unsupported proxy scheme: %s", proxyURL.Scheme)
+		}
+		if proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %s: missing host", proxy)
+		}
+	}
+	p.guard.SecureTransport(t, proxyURL)
+
+	t.TLSClientConfig = &tls.Config{
+		RootCAs:            p.rootCAs,
+		InsecureSkipVerify: insecure, // Только для внутренних лент по явному запросу
+	}
+
+	return t, nil
+}
+
+// loadRootCAs загружает системные сертификаты и добавляет к ним пользовательский CA бандл
+func loadRootCAs(bundlePath string) (*x509.CertPool, error) {
+	if bundlePath == "" {
+		return nil, nil // nil означает системный пул по умолчанию
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	pem, err := os.ReadFile(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle %s: %w", bundlePath, err)
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no valid certificates found in CA bundle %s", bundlePath)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,163 @@
+// internal/adapter/fetcher/http/youtube.go
+package httpfetcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/logger"
+)
+
+// youtubeFeedURL адрес ленты Atom с роликами канала YouTube
+const youtubeFeedURL = "https://www.youtube.com/feeds/videos.xml?channel_id="
+
+var (
+	// youtubeChannelID идентификатор канала YouTube: "UC" и 22 символа base64url
+	youtubeChannelID = regexp.MustCompile(`^UC[0-9A-Za-z_-]{22}$`)
+
+	// youtubeChannelPage признаки идентификатора канала на его странице, от самого надежного:
+	// каноническая ссылка, externalId в данных страницы и микроразметка
+	youtubeChannelPage = []*regexp.Regexp{
+		regexp.MustCompile(`<link rel="canonical" href="https://www\.youtube\.com/channel/(UC[0-9A-Za-z_-]{22})"`),
+		regexp.MustCompile(`"externalId":"(UC[0-9A-Za-z_-]{22})"`),
+		regexp.MustCompile(`<meta itemprop="(?:identifier|channelId)" content="(UC[0-9A-Za-z_-]{22})"`),
+	}
+)
+
+// resolveYouTube возвращает URL ленты канала YouTube (сокращение youtube:). input - идентификатор
+// канала (UC...), handle (@name) или ссылка на канал (/channel/UC..., /@name, /c/name, /user/name).
+// Для handle и ссылок без идентификатора загружается страница канала
+func (p *Parser) resolveYouTube(ctx context.Context, input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", fmt.Errorf("YouTube channel is empty")
+	}
+
+	if youtubeChannelID.MatchString(input) {
+		return youtubeFeedURL + input, nil
+	}
+
+	pageURL, channelID, err := youtubeChannelURL(input)
+	if err != nil {
+		return "", err
+	}
+	if channelID != "" {
+		return youtubeFeedURL + channelID, nil
+	}
+
+	logger.Info("Resolving YouTube channel: %s", pageURL)
+	channelID, err = p.fetchYouTubeChannelID(ctx, pageURL)
+	if err != nil {
+		return "", err
+	}
+	return youtubeFeedURL + channelID, nil
+}
+
+// youtubeChannelURL разбирает handle или ссылку на канал. Возвращает идентификатор канала,
+// если он есть в ссылке, иначе адрес страницы канала
+func youtubeChannelURL(input string) (pageURL, channelID string, err error) {
+	// Handle без ссылки: "@name" или просто "name"
+	if !strings.Contains(input, "/") {
+		if !strings.HasPrefix(input, "@") {
+			input = "@" + input
+		}
+		return "https://www.youtube.com/" + url.PathEscape(input), "", nil
+	}
+
+	if !strings.Contains(input, "://") {
+		input = "https://" + input
+	}
+	u, err := url.Parse(input)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid YouTube channel URL: %s", input)
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if host != "youtube.com" && host != "m.youtube.com" {
+		return "", "", fmt.Errorf("not a YouTube URL: %s", input)
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch {
+	case len(parts) >= 2 && parts[0] == "channel" && youtubeChannelID.MatchString(parts[1]):
+		return "", parts[1], nil
+	case len(parts) >= 1 && strings.HasPrefix(parts[0], "@") && len(parts[0]) > 1:
+		return "https://www.youtube.com/" + parts[0], "", nil
+	case len(parts) >= 2 && (parts[0] == "c" || parts[0] == "user") && parts[1] != "":
+		return "https://www.youtube.com/" + parts[0] + "/" + parts[1], "", nil
+	case len(parts) >= 1 && parts[0] == "feeds" && youtubeChannelID.MatchString(u.Query().Get("channel_id")):
+		return "", u.Query().Get("channel_id"), nil
+	default:
+		return "", "", fmt.Errorf("unrecognized YouTube channel URL: %s (expected /channel/UC..., /@handle, /c/name or /user/name)", input)
+	}
+}
+
+// fetchYouTubeChannelID загружает страницу канала и находит на ней идентификатор канала
+func (p *Parser) fetchYouTubeChannelID(ctx context.Context, pageURL string) (string, error) {
+	feed := &domain.Feed{URL: pageURL}
+	transport, err := p.transports.forFeed(feed)
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare transport for %s: %w", pageURL, err)
+	}
+	client := &http.Client{Transport: transport}
+
+	if host, err := hostOf(pageURL); err == nil {
+		if err := p.limiter.Wait(ctx, host); err != nil {
+			return "", fmt.Errorf("rate limit wait for %s interrupted: %w", pageURL, err)
+		}
+	}
+
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build request for %s: %w", pageURL, err)
+	}
+	if p.userAgent != "" {
+		req.Header.Set("User-Agent", p.userAgent)
+	}
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+	// Без согласия на cookie YouTube в некоторых регионах отдает страницу согласия вместо канала
+	req.Header.Set("Cookie", "CONSENT=YES+1")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch YouTube channel page %s: %w", pageURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("YouTube channel not found: %s", pageURL)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("YouTube channel page returned status %d: %s", resp.StatusCode, pageURL)
+	}
+
+	body, err := decodeBody(resp)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode response from %s: %w", pageURL, err)
+	}
+	defer body.Close()
+
+	page, err := io.ReadAll(newLimitedReader(body, p.maxBodySize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read YouTube channel page %s: %w", pageURL, err)
+	}
+
+	for _, re := range youtubeChannelPage {
+		if m := re.FindSubmatch(page); m != nil {
+			return string(m[1]), nil
+		}
+	}
+	return "", fmt.Errorf("channel ID not found on YouTube page %s", pageURL)
+}
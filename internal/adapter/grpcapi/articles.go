@@ -0,0 +1,237 @@
+// internal/adapter/grpcapi/articles.go
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	rsshubv1 "rsshub/api/rsshub/v1"
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// defaultPageSize сколько статей возвращает ListArticles, если page_size не задан
+	defaultPageSize = 20
+
+	// maxPageSize больше статей ListArticles за один вызов не возвращает
+	maxPageSize = 500
+
+	// streamBatchSize сколько пропущенных статей StreamArticles читает из БД одним запросом
+	streamBatchSize = 500
+
+	// streamReplayLimit сколько пропущенных статей получает клиент, переподключившийся с after_seq
+	streamReplayLimit = 1000
+)
+
+// articlesServer сервис Articles
+type articlesServer struct {
+	rsshubv1.UnimplementedArticlesServer
+
+	db     port.FeedArticleRepository
+	events port.ArticleEventSource
+}
+
+// ListArticles возвращает страницу статей ленты, новые первыми
+func (s *articlesServer) ListArticles(ctx context.Context, req *rsshubv1.ListArticlesRequest) (*rsshubv1.ListArticlesResponse, error) {
+	size := int(req.GetPageSize())
+	switch {
+	case size < 0:
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	case size == 0:
+		size = defaultPageSize
+	case size > maxPageSize:
+		size = maxPageSize
+	}
+
+	var after *domain.PageCursor
+	if req.GetPageToken() != "" {
+		cursor, err := domain.ParsePageCursor(req.GetPageToken())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+		after = cursor
+	}
+
+	feed, err := s.db.GetFeedByName(ctx, req.GetFeed())
+	if err != nil {
+		return nil, feedError(err)
+	}
+	articles, err := s.db.GetArticlesPage(ctx, feed.Name, after, size)
+	if err != nil {
+		return nil, internalError("failed to get articles", err)
+	}
+
+	resp := &rsshubv1.ListArticlesResponse{Articles: make([]*rsshubv1.Article, 0, len(articles))}
+	for _, a := range articles {
+		resp.Articles = append(resp.Articles, newArticle(&domain.DigestEntry{
+			Article: a, FeedName: feed.Name, FeedTags: feed.Tags, FeedFolder: feed.Folder,
+		}))
+	}
+	if len(articles) == size {
+		last := articles[len(articles)-1]
+		resp.NextPageToken = (&domain.PageCursor{Time: last.PublishedAt, ID: last.ID}).Encode()
+	}
+	return resp, nil
+}
+
+// MarkArticles меняет состояние статей с перечисленными номерами
+func (s *articlesServer) MarkArticles(ctx context.Context, req *rsshubv1.MarkArticlesRequest) (*rsshubv1.MarkArticlesResponse, error) {
+	var mark domain.ArticleMark
+	switch req.GetMark() {
+	case rsshubv1.Mark_MARK_READ:
+		mark = domain.MarkRead
+	case rsshubv1.Mark_MARK_UNREAD:
+		mark = domain.MarkUnread
+	case rsshubv1.Mark_MARK_SAVED:
+		mark = domain.MarkSaved
+	case rsshubv1.Mark_MARK_UNSAVED:
+		mark = domain.MarkUnsaved
+	default:
+		return nil, status.Error(codes.InvalidArgument, "mark is required")
+	}
+	if len(req.GetSeqs()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "seqs are required")
+	}
+
+	if err := s.db.MarkArticlesBySeq(ctx, req.GetSeqs(), mark); err != nil {
+		return nil, internalError("failed to mark articles", err)
+	}
+	return &rsshubv1.MarkArticlesResponse{}, nil
+}
+
+// StreamArticles отправляет подходящие статьи, сохраненные после вызова, а с after_seq -
+// сначала пропущенные статьи с большим номером. Поток закрывается с UNAVAILABLE, если клиент
+// не успевает читать или сервер останавливается: клиент переподключается с номером
+// последней полученной статьи
+func (s *articlesServer) StreamArticles(req *rsshubv1.StreamArticlesRequest, stream grpc.ServerStreamingServer[rsshubv1.Article]) error {
+	if req.GetAfterSeq() < 0 {
+		return status.Error(codes.InvalidArgument, "after_seq must not be negative")
+	}
+
+	filter := domain.ArticleEventFilter{Feeds: make(map[string]bool), Tags: make(map[string]bool)}
+	for _, name := range req.GetFeeds() {
+		if _, err := s.db.GetFeedByName(stream.Context(), name); err != nil {
+			return feedError(err)
+		}
+		filter.Feeds[name] = true
+	}
+	for _, tag := range req.GetTags() {
+		for _, t := range domain.ParseTags(tag) {
+			filter.Tags[t] = true
+		}
+	}
+
+	// Подписка до досылки пропущенного: статьи, сохраненные во время досылки, ждут в очереди
+	entries, unsubscribe := s.events.Subscribe(filter)
+	defer unsubscribe()
+	if entries == nil {
+		return status.Error(codes.Unavailable, "server is shutting down")
+	}
+
+	sent := req.GetAfterSeq()
+	if sent > 0 {
+		var err error
+		if sent, err = s.replay(stream, filter, sent); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e, ok := <-entries:
+			if !ok {
+				return status.Errorf(codes.Unavailable, "stream closed, reconnect with after_seq %d", sent)
+			}
+			if e.Article.Seq <= sent {
+				continue
+			}
+			if err := stream.Send(newArticle(e)); err != nil {
+				return err
+			}
+			sent = e.Article.Seq
+		}
+	}
+}
+
+// replay отправляет подходящие статьи с номером больше after, но не больше
+// streamReplayLimit, и возвращает номер последней прочитанной статьи
+func (s *articlesServer) replay(stream grpc.ServerStreamingServer[rsshubv1.Article], filter domain.ArticleEventFilter, after int64) (int64, error) {
+	for read := 0; read < streamReplayLimit; read += streamBatchSize {
+		entries, next, err := s.events.ArticlesAfter(stream.Context(), after, streamBatchSize)
+		if err != nil {
+			logger.Error("gRPC: failed to get missed articles: %v", err)
+			return after, nil
+		}
+		for _, e := range entries {
+			if filter.Match(e) {
+				if err := stream.Send(newArticle(e)); err != nil {
+					return after, err
+				}
+			}
+		}
+		if next == after {
+			break
+		}
+		after = next
+	}
+	return after, nil
+}
+
+// newArticle преобразует статью вместе с данными ее ленты в сообщение API
+func newArticle(e *domain.DigestEntry) *rsshubv1.Article {
+	a := e.Article
+	pb := &rsshubv1.Article{
+		Seq:         a.Seq,
+		Id:          a.ID.String(),
+		Feed:        e.FeedName,
+		Tags:        e.FeedTags,
+		Folder:      e.FeedFolder,
+		Title:       a.Title,
+		Link:        a.Link,
+		PublishedAt: timestamppb.New(a.PublishedAt),
+		Description: a.Description,
+		Language:    a.Language,
+		CreatedAt:   timestamppb.New(a.CreatedAt),
+		ReadAt:      newTimestamp(a.ReadAt),
+		SavedAt:     newTimestamp(a.SavedAt),
+	}
+	if !a.Podcast.IsZero() {
+		pb.Podcast = &rsshubv1.Podcast{
+			Author:  a.Podcast.Author,
+			Image:   a.Podcast.Image,
+			Episode: int32(a.Podcast.Episode),
+		}
+		if a.Podcast.Duration > 0 {
+			pb.Podcast.Duration = durationpb.New(time.Duration(a.Podcast.Duration) * time.Second)
+		}
+	}
+	for _, m := range a.Media {
+		pb.Media = append(pb.Media, &rsshubv1.MediaItem{
+			Kind:   string(m.Kind),
+			Url:    m.URL,
+			Type:   m.Type,
+			Medium: m.Medium,
+			Width:  int32(m.Width),
+			Height: int32(m.Height),
+		})
+	}
+	return pb
+}
+
+// newTimestamp преобразует необязательное время (nil - поле не задано)
+func newTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,107 @@
+// internal/adapter/grpcapi/control.go
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	rsshubv1 "rsshub/api/rsshub/v1"
+	"rsshub/internal/core/port"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// controlServer сервис AggregatorControl
+type controlServer struct {
+	rsshubv1.UnimplementedAggregatorControlServer
+
+	db         port.FeedArticleRepository
+	controller Controller
+}
+
+// GetStatus возвращает состояние запущенного агрегатора
+func (s *controlServer) GetStatus(ctx context.Context, req *rsshubv1.GetStatusRequest) (*rsshubv1.AggregatorStatus, error) {
+	st, err := s.controller.Status(ctx)
+	if err != nil {
+		return nil, internalError("failed to get aggregator status", err)
+	}
+	if st == nil {
+		return nil, status.Error(codes.Unavailable, "aggregator is not running")
+	}
+
+	resp := &rsshubv1.AggregatorStatus{
+		Instance:     st.Instance,
+		StartedAt:    timestamppb.New(st.StartedAt),
+		Interval:     durationpb.New(st.Interval),
+		Workers:      int32(st.Workers),
+		InFlight:     int32(st.InFlight),
+		Queued:       int32(st.Queued),
+		CycleRunning: st.CycleRunning,
+	}
+	for _, a := range st.Activity {
+		resp.Activity = append(resp.Activity, &rsshubv1.WorkerActivity{
+			Id:    int32(a.ID),
+			Feed:  a.Feed,
+			Since: timestamppb.New(a.Since),
+			Jobs:  int32(a.Jobs),
+		})
+	}
+	return resp, nil
+}
+
+// RefreshFeed сразу получает ленту; ошибка получения возвращается в поле error ответа
+func (s *controlServer) RefreshFeed(ctx context.Context, req *rsshubv1.RefreshFeedRequest) (*rsshubv1.RefreshFeedResponse, error) {
+	feed, err := s.db.GetFeedByName(ctx, req.GetFeed())
+	if err != nil {
+		return nil, feedError(err)
+	}
+
+	result, err := s.controller.Refresh(ctx, feed, req.GetForce())
+	if err != nil {
+		return nil, internalError("failed to refresh feed", err)
+	}
+
+	resp := &rsshubv1.RefreshFeedResponse{
+		NewArticles: int32(result.NewArticles),
+		Duplicates:  int32(result.Duplicates),
+		Updated:     int32(result.Updated),
+		NotModified: result.NotModified,
+		Skipped:     result.Skipped,
+		Bytes:       result.Bytes,
+	}
+	if result.Err != nil {
+		resp.Error = result.Err.Error()
+	}
+	return resp, nil
+}
+
+// SetInterval меняет интервал получения лент
+func (s *controlServer) SetInterval(ctx context.Context, req *rsshubv1.SetIntervalRequest) (*rsshubv1.SetIntervalResponse, error) {
+	if err := req.GetInterval().CheckValid(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "interval is required")
+	}
+	interval := req.GetInterval().AsDuration()
+	if interval < time.Second {
+		return nil, status.Error(codes.InvalidArgument, "interval must be at least 1 second")
+	}
+
+	if err := s.controller.SetInterval(ctx, interval); err != nil {
+		return nil, internalError("failed to set interval", err)
+	}
+	return &rsshubv1.SetIntervalResponse{}, nil
+}
+
+// SetWorkers меняет количество воркеров
+func (s *controlServer) SetWorkers(ctx context.Context, req *rsshubv1.SetWorkersRequest) (*rsshubv1.SetWorkersResponse, error) {
+	if req.GetWorkers() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "workers count must be positive")
+	}
+
+	if err := s.controller.SetWorkers(ctx, int(req.GetWorkers())); err != nil {
+		return nil, internalError("failed to set workers count", err)
+	}
+	return &rsshubv1.SetWorkersResponse{}, nil
+}
@@ -0,0 +1,138 @@
+// internal/adapter/grpcapi/feeds.go
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	rsshubv1 "rsshub/api/rsshub/v1"
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/urlnorm"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// feedsServer сервис Feeds
+type feedsServer struct {
+	rsshubv1.UnimplementedFeedsServer
+
+	db     port.FeedArticleRepository
+	parser port.Parser
+}
+
+// ListFeeds возвращает все ленты с количеством всех и непрочитанных статей
+func (s *feedsServer) ListFeeds(ctx context.Context, req *rsshubv1.ListFeedsRequest) (*rsshubv1.ListFeedsResponse, error) {
+	feeds, err := s.db.GetAllFeeds(ctx, 0)
+	if err != nil {
+		return nil, internalError("failed to get feeds", err)
+	}
+	counts, err := s.db.GetFeedArticleCounts(ctx)
+	if err != nil {
+		return nil, internalError("failed to get article counts", err)
+	}
+
+	resp := &rsshubv1.ListFeedsResponse{Feeds: make([]*rsshubv1.Feed, 0, len(feeds))}
+	for _, feed := range feeds {
+		pb := newFeed(feed)
+		pb.Articles = int64(counts[feed.ID].Total)
+		pb.Unread = int64(counts[feed.ID].Unread)
+		resp.Feeds = append(resp.Feeds, pb)
+	}
+	return resp, nil
+}
+
+// GetFeed возвращает ленту по имени
+func (s *feedsServer) GetFeed(ctx context.Context, req *rsshubv1.GetFeedRequest) (*rsshubv1.Feed, error) {
+	feed, err := s.db.GetFeedByName(ctx, req.GetName())
+	if err != nil {
+		return nil, feedError(err)
+	}
+	return newFeed(feed), nil
+}
+
+// CreateFeed проверяет и добавляет новую ленту
+func (s *feedsServer) CreateFeed(ctx context.Context, req *rsshubv1.CreateFeedRequest) (*rsshubv1.Feed, error) {
+	if req.GetName() == "" || req.GetUrl() == "" {
+		return nil, status.Error(codes.InvalidArgument, "both name and url are required")
+	}
+
+	feed := &domain.Feed{Name: req.GetName(), URL: urlnorm.Normalize(req.GetUrl()), Priority: domain.PriorityNormal, Enabled: true, Tags: []string{}}
+	switch req.GetPriority() {
+	case rsshubv1.Priority_PRIORITY_LOW:
+		feed.Priority = domain.PriorityLow
+	case rsshubv1.Priority_PRIORITY_HIGH:
+		feed.Priority = domain.PriorityHigh
+	}
+	for _, tag := range req.GetTags() {
+		feed.Tags = append(feed.Tags, domain.ParseTags(tag)...)
+	}
+
+	if !req.GetForce() {
+		if existing, err := s.db.GetFeedByURL(ctx, feed.URL); err == nil {
+			return nil, status.Errorf(codes.AlreadyExists, "%s: %s is already fetched as feed %s (set force to add it anyway)",
+				domain.ErrDuplicateFeedURL, feed.URL, existing.Name)
+		} else if !errors.Is(err, domain.ErrFeedNotFound) {
+			return nil, internalError("failed to check feed URL", err)
+		}
+	}
+
+	if err := s.parser.ValidateFeed(ctx, feed); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid RSS URL: "+err.Error())
+	}
+
+	if err := s.db.CreateFeed(ctx, feed); err != nil {
+		if errors.Is(err, domain.ErrDuplicateFeed) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		return nil, internalError("failed to create feed", err)
+	}
+
+	logger.Success("gRPC: added feed %s (%s)", feed.Name, feed.URL)
+	return newFeed(feed), nil
+}
+
+// DeleteFeed удаляет ленту по имени
+func (s *feedsServer) DeleteFeed(ctx context.Context, req *rsshubv1.DeleteFeedRequest) (*rsshubv1.DeleteFeedResponse, error) {
+	if err := s.db.DeleteFeed(ctx, req.GetName()); err != nil {
+		if errors.Is(err, domain.ErrFeedNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, internalError("failed to delete feed", err)
+	}
+
+	logger.Success("gRPC: deleted feed %s", req.GetName())
+	return &rsshubv1.DeleteFeedResponse{}, nil
+}
+
+// newFeed преобразует ленту в сообщение API. Заголовки, прокси и учетные данные
+// не отдаются: чтение API не требует ключа
+func newFeed(feed *domain.Feed) *rsshubv1.Feed {
+	return &rsshubv1.Feed{
+		Id:             feed.ID.String(),
+		Name:           feed.Name,
+		Url:            feed.URL,
+		Priority:       newPriority(feed.Priority),
+		Tags:           feed.Tags,
+		Folder:         feed.Folder,
+		Enabled:        feed.Enabled,
+		DisabledReason: feed.DisabledReason,
+		CreatedAt:      timestamppb.New(feed.CreatedAt),
+		UpdatedAt:      timestamppb.New(feed.UpdatedAt),
+	}
+}
+
+// newPriority преобразует приоритет ленты в перечисление API
+func newPriority(p domain.FeedPriority) rsshubv1.Priority {
+	switch p {
+	case domain.PriorityLow:
+		return rsshubv1.Priority_PRIORITY_LOW
+	case domain.PriorityHigh:
+		return rsshubv1.Priority_PRIORITY_HIGH
+	default:
+		return rsshubv1.Priority_PRIORITY_NORMAL
+	}
+}
@@ -0,0 +1,112 @@
+// internal/adapter/grpcapi/server.go
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	rsshubv1 "rsshub/api/rsshub/v1"
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// protectedMethods изменяющие вызовы, которые, как и изменяющие запросы REST API, требуют
+// ключ API; потоковый StreamArticles только читает
+var protectedMethods = map[string]bool{
+	rsshubv1.Feeds_CreateFeed_FullMethodName:              true,
+	rsshubv1.Feeds_DeleteFeed_FullMethodName:              true,
+	rsshubv1.Articles_MarkArticles_FullMethodName:         true,
+	rsshubv1.AggregatorControl_RefreshFeed_FullMethodName: true,
+	rsshubv1.AggregatorControl_SetInterval_FullMethodName: true,
+	rsshubv1.AggregatorControl_SetWorkers_FullMethodName:  true,
+}
+
+// Controller управляет агрегатором для сервиса AggregatorControl
+type Controller interface {
+	// Status возвращает состояние запущенного агрегатора (nil - агрегатор не запущен)
+	Status(ctx context.Context) (*domain.AggregatorStatus, error)
+	// Refresh сразу получает ленту; ошибка получения возвращается в FeedResult.Err
+	Refresh(ctx context.Context, feed *domain.Feed, force bool) (*domain.FeedResult, error)
+	// SetInterval сохраняет интервал получения лент для запущенных агрегаторов
+	SetInterval(ctx context.Context, interval time.Duration) error
+	// SetWorkers сохраняет количество воркеров для запущенных агрегаторов
+	SetWorkers(ctx context.Context, count int) error
+}
+
+// NewServer создает gRPC сервер с сервисами Feeds, Articles и AggregatorControl
+func NewServer(db port.FeedArticleRepository, parser port.Parser, events port.ArticleEventSource, controller Controller) *grpc.Server {
+	server := grpc.NewServer(grpc.UnaryInterceptor(requireAPIKey(db)))
+	rsshubv1.RegisterFeedsServer(server, &feedsServer{db: db, parser: parser})
+	rsshubv1.RegisterArticlesServer(server, &articlesServer{db: db, events: events})
+	rsshubv1.RegisterAggregatorControlServer(server, &controlServer{db: db, controller: controller})
+	return server
+}
+
+// requireAPIKey пропускает вызовы на чтение без проверки, а изменяющие вызовы - только
+// с действующим ключом API в метаданных "authorization: Bearer <ключ>" или "x-api-key"
+func requireAPIKey(repo port.FeedArticleRepository) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !protectedMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		secret := requestAPIKey(ctx)
+		if secret == "" {
+			return nil, status.Error(codes.Unauthenticated, "API key is required")
+		}
+
+		key, err := repo.GetAPIKeyByHash(ctx, domain.HashAPIKey(secret))
+		if err != nil {
+			if errors.Is(err, domain.ErrAPIKeyNotFound) {
+				return nil, status.Error(codes.Unauthenticated, "invalid or revoked API key")
+			}
+			return nil, internalError("failed to check API key", err)
+		}
+
+		if err := repo.TouchAPIKey(ctx, key.ID); err != nil {
+			logger.Warn("gRPC: failed to record usage of API key %s: %v", key.Name, err)
+		}
+
+		logger.Debug("gRPC: %s authorized with key %s", info.FullMethod, key.Name)
+		return handler(ctx, req)
+	}
+}
+
+// requestAPIKey извлекает ключ API из метаданных вызова
+func requestAPIKey(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, auth := range md.Get("authorization") {
+		scheme, token, ok := strings.Cut(auth, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+	for _, key := range md.Get("x-api-key") {
+		if key = strings.TrimSpace(key); key != "" {
+			return key
+		}
+	}
+	return ""
+}
+
+// internalError логирует ошибку и возвращает INTERNAL без подробностей
+func internalError(message string, err error) error {
+	logger.Error("gRPC: %s: %v", message, err)
+	return status.Error(codes.Internal, message)
+}
+
+// feedError преобразует ошибку получения ленты: неизвестная лента - NOT_FOUND
+func feedError(err error) error {
+	if errors.Is(err, domain.ErrFeedNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return internalError("failed to get feed", err)
+}
@@ -0,0 +1,355 @@
+// internal/adapter/httpapi/api.go
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"rsshub/internal/adapter/export"
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/urlnorm"
+	"rsshub/internal/platform/utils"
+)
+
+const (
+	// maxRequestSize максимальный размер тела запроса к API
+	maxRequestSize = 1 << 20
+
+	// rssItemsLimit сколько последних статей публикуется в RSS ленте
+	rssItemsLimit = 50
+
+	// iconMaxAge сколько клиенты могут кешировать иконку ленты, в секундах
+	iconMaxAge = 24 * 60 * 60
+)
+
+// Handler REST API для управления лентами. Маршруты регистрируются от корня,
+// поэтому обработчик монтируется на сервере как есть, без StripPrefix
+type Handler struct {
+	db     port.FeedArticleRepository
+	parser port.Parser
+	mux    *http.ServeMux
+}
+
+// NewHandler создает обработчик API с маршрутами под /api/
+func NewHandler(db port.FeedArticleRepository, parser port.Parser) *Handler {
+	h := &Handler{db: db, parser: parser, mux: http.NewServeMux()}
+
+	h.mux.HandleFunc("GET /api/feeds", h.listFeeds)
+	h.mux.HandleFunc("POST /api/feeds", h.createFeed)
+	h.mux.HandleFunc("DELETE /api/feeds/{name}", h.deleteFeed)
+	h.mux.HandleFunc("GET /api/feeds/{name}/rss", h.feedRSS)
+	h.mux.HandleFunc("GET /api/feeds/{name}/articles", h.feedArticles)
+	h.mux.HandleFunc("GET /api/feeds/{name}/icon", h.feedIcon)
+	h.mux.HandleFunc("GET /api/smartfeeds", h.listSmartFeeds)
+
+	return h
+}
+
+// ServeHTTP реализует http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// feedResponse представление ленты в ответах API. Заголовки, прокси и учетные данные
+// не отдаются: чтение API не требует ключа
+type feedResponse struct {
+	ID        utils.UUID          `json:"id"`
+	Name      string              `json:"name"`
+	URL       string              `json:"url"`
+	Priority  domain.FeedPriority `json:"priority"`
+	Tags      []string            `json:"tags"`
+	Folder    string              `json:"folder,omitempty"` // Путь папки ленты, например "News/Tech"
+	Enabled   bool                `json:"enabled"`
+	IconURL   string              `json:"icon_url,omitempty"` // Адрес иконки в API, если она получена
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+// newFeedResponse преобразует ленту в представление API; hasIcon - у ленты есть иконка
+func newFeedResponse(feed *domain.Feed, hasIcon bool) feedResponse {
+	tags := feed.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	resp := feedResponse{
+		ID:        feed.ID,
+		Name:      feed.Name,
+		URL:       feed.URL,
+		Priority:  feed.Priority,
+		Tags:      tags,
+		Folder:    feed.Folder,
+		Enabled:   feed.Enabled,
+		CreatedAt: feed.CreatedAt,
+		UpdatedAt: feed.UpdatedAt,
+	}
+	if hasIcon {
+		resp.IconURL = "/api/feeds/" + url.PathEscape(feed.Name) + "/icon"
+	}
+	return resp
+}
+
+// createFeedRequest тело запроса на добавление ленты
+type createFeedRequest struct {
+	Name     string   `json:"name"`
+	URL      string   `json:"url"`
+	Tags     []string `json:"tags"`
+	Priority string   `json:"priority"`
+	Force    bool     `json:"force"` // Добавить ленту, даже если ее URL уже получается под другим именем
+}
+
+// listFeeds возвращает все ленты
+func (h *Handler) listFeeds(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	feeds, err := h.db.GetAllFeeds(ctx, 0)
+	if err != nil {
+		h.internalError(w, "failed to get feeds", err)
+		return
+	}
+
+	icons, err := h.db.GetFeedIcons(ctx)
+	if err != nil {
+		h.internalError(w, "failed to get feed icons", err)
+		return
+	}
+	hasIcon := make(map[utils.UUID]bool, len(icons))
+	for _, icon := range icons {
+		hasIcon[icon.FeedID] = true
+	}
+
+	result := make([]feedResponse, 0, len(feeds))
+	for _, feed := range feeds {
+		result = append(result, newFeedResponse(feed, hasIcon[feed.ID]))
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// createFeed проверяет и добавляет новую ленту
+func (h *Handler) createFeed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req createFeedRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	if req.Name == "" || req.URL == "" {
+		writeError(w, http.StatusBadRequest, "both name and url are required")
+		return
+	}
+
+	feed := &domain.Feed{Name: req.Name, URL: urlnorm.Normalize(req.URL), Priority: domain.PriorityNormal, Enabled: true, Tags: []string{}}
+	if req.Priority != "" {
+		priority, err := domain.ParseFeedPriority(req.Priority)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		feed.Priority = priority
+	}
+	for _, tag := range req.Tags {
+		feed.Tags = append(feed.Tags, domain.ParseTags(tag)...)
+	}
+
+	if !req.Force {
+		if existing, err := h.db.GetFeedByURL(ctx, feed.URL); err == nil {
+			writeError(w, http.StatusConflict, fmt.Sprintf("%s: %s is already fetched as feed %s (set force to add it anyway)",
+				domain.ErrDuplicateFeedURL, feed.URL, existing.Name))
+			return
+		} else if !errors.Is(err, domain.ErrFeedNotFound) {
+			h.internalError(w, "failed to check feed URL", err)
+			return
+		}
+	}
+
+	if err := h.parser.ValidateFeed(r.Context(), feed); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "invalid RSS URL: "+err.Error())
+		return
+	}
+
+	if err := h.db.CreateFeed(ctx, feed); err != nil {
+		if errors.Is(err, domain.ErrDuplicateFeed) {
+			writeError(w, http.StatusConflict, err.Error())
+			return
+		}
+		h.internalError(w, "failed to create feed", err)
+		return
+	}
+
+	logger.Success("API: added feed %s (%s)", feed.Name, feed.URL)
+	writeJSON(w, http.StatusCreated, newFeedResponse(feed, false))
+}
+
+// deleteFeed удаляет ленту по имени
+func (h *Handler) deleteFeed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := r.PathValue("name")
+	if err := h.db.DeleteFeed(ctx, name); err != nil {
+		if errors.Is(err, domain.ErrFeedNotFound) {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		h.internalError(w, "failed to delete feed", err)
+		return
+	}
+
+	logger.Success("API: deleted feed %s", name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listSmartFeeds возвращает все смарт-ленты
+func (h *Handler) listSmartFeeds(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	smartFeeds, err := h.db.GetSmartFeeds(ctx)
+	if err != nil {
+		h.internalError(w, "failed to get smart feeds", err)
+		return
+	}
+	if smartFeeds == nil {
+		smartFeeds = []*domain.SmartFeed{}
+	}
+	writeJSON(w, http.StatusOK, smartFeeds)
+}
+
+// feedRSS публикует последние статьи ленты или смарт-ленты с этим именем как RSS 2.0
+func (h *Handler) feedRSS(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := r.PathValue("name")
+
+	articles, err := h.latestArticles(ctx, name)
+	if err != nil {
+		if errors.Is(err, domain.ErrFeedNotFound) {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		h.internalError(w, "failed to get articles", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+	if err := export.Write(w, export.FormatRSS, name, articles); err != nil {
+		logger.Warn("API: failed to write RSS of %s: %v", name, err)
+	}
+}
+
+// feedArticles возвращает последние статьи ленты или смарт-ленты в JSON вместе с
+// метаданными подкастов и вложениями Media RSS
+func (h *Handler) feedArticles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := r.PathValue("name")
+
+	articles, err := h.latestArticles(ctx, name)
+	if err != nil {
+		if errors.Is(err, domain.ErrFeedNotFound) {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		h.internalError(w, "failed to get articles", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := export.Write(w, export.FormatJSON, name, articles); err != nil {
+		logger.Warn("API: failed to write articles of %s: %v", name, err)
+	}
+}
+
+// feedIcon отдает закешированную иконку ленты. Ответ поддерживает If-Modified-Since;
+// CSP запрещает выполнение скриптов в SVG иконках, открытых напрямую
+func (h *Handler) feedIcon(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := r.PathValue("name")
+
+	feed, err := h.db.GetFeedByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, domain.ErrFeedNotFound) {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		h.internalError(w, "failed to get feed", err)
+		return
+	}
+
+	icon, err := h.db.GetFeedIcon(ctx, feed.ID)
+	if err != nil && !errors.Is(err, domain.ErrIconNotFound) {
+		h.internalError(w, "failed to get feed icon", err)
+		return
+	}
+	if icon == nil || len(icon.Data) == 0 {
+		writeError(w, http.StatusNotFound, "feed icon not found: "+name)
+		return
+	}
+
+	var modified time.Time
+	if icon.FetchedAt != nil {
+		modified = *icon.FetchedAt
+	}
+	w.Header().Set("Content-Type", icon.ContentType)
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", iconMaxAge))
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, "", modified, bytes.NewReader(icon.Data))
+}
+
+// latestArticles возвращает последние статьи обычной ленты, а если ее нет - смарт-ленты
+func (h *Handler) latestArticles(ctx context.Context, name string) ([]*domain.Article, error) {
+	_, err := h.db.GetFeedByName(ctx, name)
+	if err == nil {
+		return h.db.GetArticlesPage(ctx, name, nil, rssItemsLimit)
+	}
+	if !errors.Is(err, domain.ErrFeedNotFound) {
+		return nil, err
+	}
+
+	smartFeed, smartErr := h.db.GetSmartFeedByName(ctx, name)
+	if smartErr != nil {
+		if errors.Is(smartErr, domain.ErrSmartFeedNotFound) {
+			return nil, err
+		}
+		return nil, smartErr
+	}
+
+	query, err := domain.ParseSmartQuery(smartFeed.Query)
+	if err != nil {
+		return nil, fmt.Errorf("smart feed %s has an invalid query: %w", name, err)
+	}
+
+	entries, err := h.db.GetSmartFeedArticles(ctx, query, nil, rssItemsLimit)
+	if err != nil {
+		return nil, err
+	}
+	articles := make([]*domain.Article, 0, len(entries))
+	for _, entry := range entries {
+		articles = append(articles, entry.Article)
+	}
+	return articles, nil
+}
+
+// internalError логирует ошибку и отвечает 500 без подробностей
+func (h *Handler) internalError(w http.ResponseWriter, message string, err error) {
+	logger.Error("API: %s: %v", message, err)
+	writeError(w, http.StatusInternalServerError, message)
+}
+
+// writeJSON отправляет ответ в формате JSON
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Warn("API: failed to write response: %v", err)
+	}
+}
+
+// writeError отправляет ошибку в формате {"error": "..."}
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
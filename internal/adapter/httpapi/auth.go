@@ -0,0 +1,63 @@
+// internal/adapter/httpapi/auth.go
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+)
+
+// RequireAPIKey пропускает запросы на чтение без проверки, а изменяющие запросы
+// (POST, PUT, PATCH, DELETE) - только с действующим ключом API в заголовке
+// "Authorization: Bearer <ключ>" или "X-API-Key"
+func RequireAPIKey(repo port.FeedArticleRepository, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		secret := requestAPIKey(r)
+		if secret == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="rsshub"`)
+			writeError(w, http.StatusUnauthorized, "API key is required")
+			return
+		}
+
+		ctx := r.Context()
+		key, err := repo.GetAPIKeyByHash(ctx, domain.HashAPIKey(secret))
+		if err != nil {
+			if errors.Is(err, domain.ErrAPIKeyNotFound) {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="rsshub", error="invalid_token"`)
+				writeError(w, http.StatusUnauthorized, "invalid or revoked API key")
+				return
+			}
+			logger.Error("API: failed to check API key: %v", err)
+			writeError(w, http.StatusInternalServerError, "failed to check API key")
+			return
+		}
+
+		if err := repo.TouchAPIKey(ctx, key.ID); err != nil {
+			logger.Warn("API: failed to record usage of API key %s: %v", key.Name, err)
+		}
+
+		logger.Debug("API: %s %s authorized with key %s", r.Method, r.URL.Path, key.Name)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requestAPIKey извлекает ключ API из заголовков запроса
+func requestAPIKey(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		scheme, token, ok := strings.Cut(auth, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+	return strings.TrimSpace(r.Header.Get("X-API-Key"))
+}
@@ -0,0 +1,236 @@
+// internal/adapter/httpapi/events.go
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/utils"
+)
+
+const (
+	// eventsBatchSize сколько пропущенных статей читается из БД одним запросом
+	eventsBatchSize = 500
+
+	// eventsReplayLimit сколько пропущенных статей получает клиент, переподключившийся с Last-Event-ID
+	eventsReplayLimit = 1000
+
+	// eventsHeartbeat как часто в открытый поток отправляется комментарий, чтобы прокси
+	// не закрывали соединение без трафика
+	eventsHeartbeat = 30 * time.Second
+
+	// eventsRetry через сколько миллисекунд EventSource переподключается после обрыва
+	eventsRetry = 5000
+)
+
+// articleEvent новая статья в потоке событий
+type articleEvent struct {
+	Seq         int64               `json:"seq"` // Номер статьи, он же id события
+	ID          utils.UUID          `json:"id"`
+	Feed        string              `json:"feed"`
+	Tags        []string            `json:"tags"`
+	Folder      string              `json:"folder,omitempty"`
+	Title       string              `json:"title"`
+	Link        string              `json:"link"`
+	PublishedAt time.Time           `json:"published_at"`
+	Description string              `json:"description"`
+	Language    string              `json:"language,omitempty"`
+	Podcast     *domain.PodcastInfo `json:"podcast,omitempty"`
+	Media       []domain.MediaItem  `json:"media,omitempty"`
+}
+
+// newArticleEvent создает событие для статьи
+func newArticleEvent(e *domain.DigestEntry) *articleEvent {
+	a := e.Article
+	tags := e.FeedTags
+	if tags == nil {
+		tags = []string{}
+	}
+	return &articleEvent{
+		Seq:         a.Seq,
+		ID:          a.ID,
+		Feed:        e.FeedName,
+		Tags:        tags,
+		Folder:      e.FeedFolder,
+		Title:       a.Title,
+		Link:        a.Link,
+		PublishedAt: a.PublishedAt,
+		Description: a.Description,
+		Language:    a.Language,
+		Podcast:     a.Podcast,
+		Media:       a.Media,
+	}
+}
+
+// EventStream отдает новые статьи как поток server-sent events
+type EventStream struct {
+	db     port.FeedArticleRepository
+	source port.ArticleEventSource
+}
+
+// NewEventStream создает поток событий над рассылкой новых статей source
+func NewEventStream(db port.FeedArticleRepository, source port.ArticleEventSource) *EventStream {
+	return &EventStream{db: db, source: source}
+}
+
+// ServeHTTP отдает поток событий article с новыми статьями. Параметры feed и tag (можно
+// повторять или перечислять через запятую) оставляют статьи этих лент и с этими тегами;
+// заголовок Last-Event-ID (или параметр last_event_id) досылает статьи, пропущенные после
+// события с этим номером
+func (s *EventStream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+
+	filter, err := s.parseFilter(r)
+	if err != nil {
+		if errors.Is(err, domain.ErrFeedNotFound) {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		logger.Error("API: failed to get feed: %v", err)
+		writeError(w, http.StatusInternalServerError, "failed to get feed")
+		return
+	}
+
+	lastID := r.Header.Get("Last-Event-ID")
+	if lastID == "" {
+		lastID = r.URL.Query().Get("last_event_id")
+	}
+	var after int64
+	if lastID != "" {
+		if after, err = strconv.ParseInt(lastID, 10, 64); err != nil || after < 0 {
+			writeError(w, http.StatusBadRequest, "invalid Last-Event-ID: "+lastID)
+			return
+		}
+	}
+
+	// Подписка до досылки пропущенного: статьи, сохраненные во время досылки, ждут в очереди
+	entries, unsubscribe := s.source.Subscribe(filter)
+	defer unsubscribe()
+	if entries == nil {
+		writeError(w, http.StatusServiceUnavailable, "server is shutting down")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // nginx не должен буферизовать поток
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", eventsRetry); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	sent := after
+	if after > 0 {
+		if sent, err = s.replay(ctx, w, filter, after); err != nil {
+			logger.Debug("Events: replay stopped: %v", err)
+			return
+		}
+		flusher.Flush()
+	}
+
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case e, ok := <-entries:
+			if !ok {
+				return
+			}
+			if e.Article.Seq <= sent {
+				continue
+			}
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+			sent = e.Article.Seq
+		}
+		flusher.Flush()
+	}
+}
+
+// parseFilter разбирает параметры feed и tag; неизвестная лента - domain.ErrFeedNotFound
+func (s *EventStream) parseFilter(r *http.Request) (domain.ArticleEventFilter, error) {
+	ctx := r.Context()
+	query := r.URL.Query()
+	filter := domain.ArticleEventFilter{Feeds: make(map[string]bool), Tags: make(map[string]bool)}
+
+	for _, name := range splitValues(query["feed"]) {
+		if _, err := s.db.GetFeedByName(ctx, name); err != nil {
+			return filter, err
+		}
+		filter.Feeds[name] = true
+	}
+	for _, tag := range domain.ParseTags(strings.Join(query["tag"], ",")) {
+		filter.Tags[tag] = true
+	}
+	return filter, nil
+}
+
+// replay отправляет клиенту подходящие статьи с номером больше after, но не больше
+// eventsReplayLimit, и возвращает номер последней прочитанной статьи
+func (s *EventStream) replay(ctx context.Context, w http.ResponseWriter, filter domain.ArticleEventFilter, after int64) (int64, error) {
+	for read := 0; read < eventsReplayLimit; read += eventsBatchSize {
+		entries, next, err := s.source.ArticlesAfter(ctx, after, eventsBatchSize)
+		if err != nil {
+			logger.Error("Events: failed to get missed articles: %v", err)
+			return after, nil
+		}
+		for _, e := range entries {
+			if filter.Match(e) {
+				if err := writeEvent(w, e); err != nil {
+					return after, err
+				}
+			}
+		}
+		if next == after {
+			break
+		}
+		after = next
+	}
+	return after, nil
+}
+
+// writeEvent записывает событие article; id события - номер статьи
+func writeEvent(w http.ResponseWriter, e *domain.DigestEntry) error {
+	data, err := json.Marshal(newArticleEvent(e))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: article\ndata: %s\n\n", e.Article.Seq, data)
+	return err
+}
+
+// splitValues разбивает повторенные и перечисленные через запятую значения параметра
+func splitValues(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				result = append(result, v)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,470 @@
+// internal/adapter/httpapi/fever.go
+package httpapi
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/utils"
+)
+
+const (
+	// feverAPIVersion версия протокола Fever, которую ожидают клиенты
+	feverAPIVersion = 3
+
+	// feverItemsLimit сколько статей отдается за один запрос items (ограничение протокола)
+	feverItemsLimit = 50
+)
+
+// errFeverRequest некорректные параметры запроса Fever (ответ 400)
+var errFeverRequest = errors.New("invalid Fever request")
+
+// FeverHandler совместимый с Fever API обработчик для мобильных клиентов (Reeder, Unread).
+// Клиент авторизуется параметром api_key = md5("имя ключа:ключ"); группы Fever - теги лент
+type FeverHandler struct {
+	db port.FeedArticleRepository
+}
+
+// NewFeverHandler создает обработчик Fever API
+func NewFeverHandler(db port.FeedArticleRepository) *FeverHandler {
+	return &FeverHandler{db: db}
+}
+
+// feverGroup группа Fever (тег ленты)
+type feverGroup struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+}
+
+// feverFeedsGroup ленты группы: номера лент через запятую
+type feverFeedsGroup struct {
+	GroupID int64  `json:"group_id"`
+	FeedIDs string `json:"feed_ids"`
+}
+
+// feverFeed лента в ответе Fever
+type feverFeed struct {
+	ID                int64  `json:"id"`
+	FaviconID         int64  `json:"favicon_id"`
+	Title             string `json:"title"`
+	URL               string `json:"url"`
+	SiteURL           string `json:"site_url"`
+	IsSpark           int    `json:"is_spark"`
+	LastUpdatedOnTime int64  `json:"last_updated_on_time"`
+}
+
+// feverFavicon иконка ленты в ответе Fever: data - "<MIME тип>;base64,<данные>"
+type feverFavicon struct {
+	ID   int64  `json:"id"`
+	Data string `json:"data"`
+}
+
+// feverItem статья в ответе Fever
+type feverItem struct {
+	ID            int64  `json:"id"`
+	FeedID        int64  `json:"feed_id"`
+	Title         string `json:"title"`
+	Author        string `json:"author"`
+	HTML          string `json:"html"`
+	URL           string `json:"url"`
+	IsSaved       int    `json:"is_saved"`
+	IsRead        int    `json:"is_read"`
+	CreatedOnTime int64  `json:"created_on_time"`
+}
+
+// ServeHTTP обрабатывает запрос Fever. Параметры принимаются и в строке запроса, и в теле формы;
+// без действующего ключа отвечает auth: 0, как того ожидают клиенты
+func (h *FeverHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+	if _, ok := r.Form["api"]; !ok {
+		writeError(w, http.StatusBadRequest, "Fever requests must include the api parameter")
+		return
+	}
+
+	resp := map[string]interface{}{"api_version": feverAPIVersion, "auth": 0}
+
+	apiKey := strings.ToLower(strings.TrimSpace(r.FormValue("api_key")))
+	if apiKey == "" {
+		writeJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	key, err := h.db.GetAPIKeyByFeverHash(ctx, apiKey)
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			logger.Debug("Fever: rejected request with unknown api_key")
+			writeJSON(w, http.StatusOK, resp)
+			return
+		}
+		h.internalError(w, "failed to check API key", err)
+		return
+	}
+
+	if err := h.db.TouchAPIKey(ctx, key.ID); err != nil {
+		logger.Warn("Fever: failed to record usage of API key %s: %v", key.Name, err)
+	}
+	logger.Debug("Fever: %s authorized with key %s", r.URL.RawQuery, key.Name)
+
+	resp["auth"] = 1
+	if err := h.respond(ctx, r.Form, resp); err != nil {
+		if errors.Is(err, errFeverRequest) {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.internalError(w, "failed to handle request", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// respond выполняет запрошенные действия и добавляет в ответ запрошенные разделы.
+// Отметки применяются первыми, чтобы разделы ответа уже учитывали их
+func (h *FeverHandler) respond(ctx context.Context, form url.Values, resp map[string]interface{}) error {
+	has := func(name string) bool {
+		_, ok := form[name]
+		return ok
+	}
+
+	feeds, err := h.db.GetAllFeeds(ctx, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get feeds: %w", err)
+	}
+	resp["last_refreshed_on_time"] = lastRefreshed(feeds)
+
+	wantUnread, wantSaved := has("unread_item_ids"), has("saved_item_ids")
+	if has("mark") {
+		state, err := h.mark(ctx, form, feeds)
+		if err != nil {
+			return err
+		}
+		// Клиенты ждут обновленный список после отметки
+		wantUnread = wantUnread || state == domain.ArticleUnread
+		wantSaved = wantSaved || state == domain.ArticleSaved
+	}
+
+	if has("groups") {
+		resp["groups"], resp["feeds_groups"] = feverGroups(feeds)
+	}
+	// Номер иконки совпадает с номером ее ленты
+	var icons map[utils.UUID]*domain.FeedIcon
+	if has("feeds") || has("favicons") {
+		list, err := h.db.GetFeedIcons(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get feed icons: %w", err)
+		}
+		icons = make(map[utils.UUID]*domain.FeedIcon, len(list))
+		for _, icon := range list {
+			icons[icon.FeedID] = icon
+		}
+	}
+
+	if has("feeds") {
+		result := make([]feverFeed, 0, len(feeds))
+		for _, feed := range feeds {
+			var faviconID int64
+			if icons[feed.ID] != nil {
+				faviconID = feed.Seq
+			}
+			result = append(result, feverFeed{
+				ID:                feed.Seq,
+				FaviconID:         faviconID,
+				Title:             feed.Name,
+				URL:               feed.URL,
+				SiteURL:           feed.URL,
+				LastUpdatedOnTime: feed.UpdatedAt.Unix(),
+			})
+		}
+		resp["feeds"] = result
+		_, resp["feeds_groups"] = feverGroups(feeds)
+	}
+	if has("favicons") {
+		result := make([]feverFavicon, 0, len(icons))
+		for _, feed := range feeds {
+			if icon := icons[feed.ID]; icon != nil {
+				result = append(result, feverFavicon{
+					ID:   feed.Seq,
+					Data: icon.ContentType + ";base64," + base64.StdEncoding.EncodeToString(icon.Data),
+				})
+			}
+		}
+		resp["favicons"] = result
+	}
+	if has("links") {
+		resp["links"] = []struct{}{} // Горячие ссылки Fever не поддерживаются
+	}
+
+	if has("items") {
+		items, err := h.items(ctx, form, feeds)
+		if err != nil {
+			return err
+		}
+		resp["items"] = items
+
+		stats, err := h.db.GetFeedStats(ctx, "")
+		if err != nil {
+			return fmt.Errorf("failed to count articles: %w", err)
+		}
+		total := 0
+		for _, s := range stats {
+			total += s.TotalArticles
+		}
+		resp["total_items"] = total
+	}
+
+	if wantUnread {
+		seqs, err := h.db.GetArticleSeqs(ctx, domain.ArticleUnread)
+		if err != nil {
+			return err
+		}
+		resp["unread_item_ids"] = joinSeqs(seqs)
+	}
+	if wantSaved {
+		seqs, err := h.db.GetArticleSeqs(ctx, domain.ArticleSaved)
+		if err != nil {
+			return err
+		}
+		resp["saved_item_ids"] = joinSeqs(seqs)
+	}
+
+	return nil
+}
+
+// items выбирает статьи по since_id, max_id или with_ids
+func (h *FeverHandler) items(ctx context.Context, form url.Values, feeds []*domain.Feed) ([]feverItem, error) {
+	filter := domain.ArticleSeqFilter{Limit: feverItemsLimit}
+	switch {
+	case form.Get("with_ids") != "":
+		seqs, err := parseSeqs(form.Get("with_ids"))
+		if err != nil {
+			return nil, err
+		}
+		if len(seqs) > feverItemsLimit {
+			seqs = seqs[:feverItemsLimit]
+		}
+		filter.Seqs = seqs
+	case form.Has("max_id"):
+		maxSeq, err := formSeq(form, "max_id")
+		if err != nil {
+			return nil, err
+		}
+		// max_id=0 - самые новые статьи
+		if maxSeq == 0 {
+			maxSeq = math.MaxInt64
+		}
+		filter.MaxSeq = maxSeq
+	case form.Has("since_id"):
+		sinceSeq, err := formSeq(form, "since_id")
+		if err != nil {
+			return nil, err
+		}
+		filter.SinceSeq = sinceSeq
+	}
+
+	articles, err := h.db.GetArticlesBySeq(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	feedSeqs := make(map[utils.UUID]int64, len(feeds))
+	for _, feed := range feeds {
+		feedSeqs[feed.ID] = feed.Seq
+	}
+
+	items := make([]feverItem, 0, len(articles))
+	for _, article := range articles {
+		item := feverItem{
+			ID:            article.Seq,
+			FeedID:        feedSeqs[article.FeedID],
+			Title:         article.Title,
+			HTML:          article.Description,
+			URL:           article.Link,
+			CreatedOnTime: article.PublishedAt.Unix(),
+		}
+		if article.Podcast != nil {
+			item.Author = article.Podcast.Author
+		}
+		if article.ReadAt != nil {
+			item.IsRead = 1
+		}
+		if article.SavedAt != nil {
+			item.IsSaved = 1
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// mark применяет mark=item|feed|group с as=read|unread|saved|unsaved и возвращает,
+// какой список номеров статей изменился
+func (h *FeverHandler) mark(ctx context.Context, form url.Values, feeds []*domain.Feed) (domain.ArticleState, error) {
+	mark, err := domain.ParseArticleMark(form.Get("as"))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errFeverRequest, err)
+	}
+	id, err := formSeq(form, "id")
+	if err != nil {
+		return "", err
+	}
+
+	state := domain.ArticleUnread
+	if mark == domain.MarkSaved || mark == domain.MarkUnsaved {
+		state = domain.ArticleSaved
+	}
+
+	switch form.Get("mark") {
+	case "item":
+		return state, h.db.MarkArticlesBySeq(ctx, []int64{id}, mark)
+
+	case "feed", "group":
+		if mark != domain.MarkRead {
+			return "", fmt.Errorf("%w: %s can only be marked as read", errFeverRequest, form.Get("mark"))
+		}
+
+		// before - время, когда пользователь отметил ленту; статьи, пришедшие позже, остаются непрочитанными
+		before := time.Now()
+		if form.Get("before") != "" {
+			ts, err := formSeq(form, "before")
+			if err != nil {
+				return "", err
+			}
+			before = time.Unix(ts, 0)
+		}
+
+		var feedIDs []utils.UUID
+		for _, feed := range feeds {
+			if (form.Get("mark") == "feed" && feed.Seq == id) ||
+				(form.Get("mark") == "group" && feedInGroup(feed, id)) {
+				feedIDs = append(feedIDs, feed.ID)
+			}
+		}
+		if len(feedIDs) == 0 {
+			return state, nil
+		}
+		return state, h.db.MarkFeedsReadBefore(ctx, feedIDs, before)
+
+	default:
+		return "", fmt.Errorf("%w: unknown mark %q (expected item, feed or group)", errFeverRequest, form.Get("mark"))
+	}
+}
+
+// internalError логирует ошибку и отвечает 500 без подробностей
+func (h *FeverHandler) internalError(w http.ResponseWriter, message string, err error) {
+	logger.Error("Fever: %s: %v", message, err)
+	writeError(w, http.StatusInternalServerError, message)
+}
+
+// feverGroups строит группы Fever из тегов лент
+func feverGroups(feeds []*domain.Feed) ([]feverGroup, []feverFeedsGroup) {
+	members := make(map[string][]int64)
+	for _, feed := range feeds {
+		for _, tag := range feed.Tags {
+			members[tag] = append(members[tag], feed.Seq)
+		}
+	}
+
+	tags := make([]string, 0, len(members))
+	for tag := range members {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	groups := make([]feverGroup, 0, len(tags))
+	feedsGroups := make([]feverFeedsGroup, 0, len(tags))
+	for _, tag := range tags {
+		id := feverGroupID(tag)
+		groups = append(groups, feverGroup{ID: id, Title: tag})
+		feedsGroups = append(feedsGroups, feverFeedsGroup{GroupID: id, FeedIDs: joinSeqs(members[tag])})
+	}
+	return groups, feedsGroups
+}
+
+// feverGroupID номер группы для тега. Номер выводится из самого тега, поэтому не меняется
+// при добавлении и удалении других тегов; 0 у Fever означает все ленты
+func feverGroupID(tag string) int64 {
+	id := int64(crc32.ChecksumIEEE([]byte(tag)) & math.MaxInt32)
+	if id == 0 {
+		id = 1
+	}
+	return id
+}
+
+// feedInGroup проверяет, входит ли лента в группу (группа 0 - все ленты)
+func feedInGroup(feed *domain.Feed, groupID int64) bool {
+	if groupID == 0 {
+		return true
+	}
+	for _, tag := range feed.Tags {
+		if feverGroupID(tag) == groupID {
+			return true
+		}
+	}
+	return false
+}
+
+// lastRefreshed время последнего получения любой из лент в секундах Unix
+func lastRefreshed(feeds []*domain.Feed) int64 {
+	var last time.Time
+	for _, feed := range feeds {
+		if feed.UpdatedAt.After(last) {
+			last = feed.UpdatedAt
+		}
+	}
+	if last.IsZero() {
+		return 0
+	}
+	return last.Unix()
+}
+
+// formSeq разбирает неотрицательный целочисленный параметр запроса
+func formSeq(form url.Values, name string) (int64, error) {
+	n, err := strconv.ParseInt(strings.TrimSpace(form.Get(name)), 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%w: %s must be a non-negative integer", errFeverRequest, name)
+	}
+	return n, nil
+}
+
+// parseSeqs разбирает список номеров через запятую
+func parseSeqs(s string) ([]int64, error) {
+	var seqs []int64
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("%w: invalid item id %q", errFeverRequest, part)
+		}
+		seqs = append(seqs, n)
+	}
+	return seqs, nil
+}
+
+// joinSeqs соединяет номера через запятую, как их передает Fever
+func joinSeqs(seqs []int64) string {
+	parts := make([]string, 0, len(seqs))
+	for _, seq := range seqs {
+		parts = append(parts, strconv.FormatInt(seq, 10))
+	}
+	return strings.Join(parts, ",")
+}
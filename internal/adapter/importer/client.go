@@ -0,0 +1,90 @@
+// internal/adapter/importer/client.go
+
+// Package importer получает подписки и состояние статей из других агрегаторов
+// (Miniflux, FreshRSS) через их API для команды import
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxResponseSize максимальный размер ответа API агрегатора
+const maxResponseSize = 64 << 20
+
+// client общий HTTP клиент API агрегатора
+type client struct {
+	http      *http.Client
+	baseURL   string
+	userAgent string
+}
+
+// newClient создает клиент для API по адресу baseURL
+func newClient(baseURL, userAgent string, timeout time.Duration) (*client, error) {
+	u, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid URL: %s (expected http:// or https://)", baseURL)
+	}
+
+	return &client{
+		http:      &http.Client{Timeout: timeout},
+		baseURL:   strings.TrimRight(u.String(), "/"),
+		userAgent: userAgent,
+	}, nil
+}
+
+// do выполняет запрос и возвращает тело ответа со статусом 200
+func (c *client) do(ctx context.Context, method, path string, query url.Values, header http.Header, body io.Reader) ([]byte, error) {
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request to %s failed: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from %s: %w", path, err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, fmt.Errorf("%s: access denied (status %d), check the credentials", path, resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("%s returned status %d: %s", path, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return data, nil
+}
+
+// getJSON выполняет GET запрос и декодирует ответ в v
+func (c *client) getJSON(ctx context.Context, path string, query url.Values, header http.Header, v interface{}) error {
+	data, err := c.do(ctx, http.MethodGet, path, query, header, nil)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// internal/adapter/importer/miniflux.go
+package importer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+)
+
+// Проверяем на этапе компиляции, что Miniflux реализует источник подписок
+var _ port.SubscriptionSource = (*Miniflux)(nil)
+
+// minifluxPageSize сколько статей запрашивается у Miniflux за раз
+const minifluxPageSize = 100
+
+// Miniflux источник подписок из Miniflux (REST API /v1, ключ API в X-Auth-Token)
+type Miniflux struct {
+	client *client
+	apiKey string
+}
+
+// minifluxFeed лента Miniflux
+type minifluxFeed struct {
+	Title    string `json:"title"`
+	FeedURL  string `json:"feed_url"`
+	Category struct {
+		Title string `json:"title"`
+	} `json:"category"`
+}
+
+// minifluxEntries страница статей Miniflux
+type minifluxEntries struct {
+	Total   int `json:"total"`
+	Entries []struct {
+		Title       string    `json:"title"`
+		URL         string    `json:"url"`
+		Content     string    `json:"content"`
+		PublishedAt time.Time `json:"published_at"`
+		Status      string    `json:"status"`
+		Starred     bool      `json:"starred"`
+		Feed        struct {
+			FeedURL string `json:"feed_url"`
+		} `json:"feed"`
+	} `json:"entries"`
+}
+
+// NewMiniflux создает источник для Miniflux по адресу baseURL
+func NewMiniflux(baseURL, apiKey, userAgent string, timeout time.Duration) (*Miniflux, error) {
+	if strings.TrimSpace(apiKey) == "" {
+		return nil, fmt.Errorf("miniflux API key is required")
+	}
+	c, err := newClient(baseURL, userAgent, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return &Miniflux{client: c, apiKey: strings.TrimSpace(apiKey)}, nil
+}
+
+// Subscriptions возвращает ленты Miniflux; категория ленты становится тегом
+func (m *Miniflux) Subscriptions(ctx context.Context) ([]domain.Subscription, error) {
+	var feeds []minifluxFeed
+	if err := m.client.getJSON(ctx, "/v1/feeds", nil, m.header(), &feeds); err != nil {
+		return nil, err
+	}
+
+	subs := make([]domain.Subscription, 0, len(feeds))
+	for _, feed := range feeds {
+		sub := domain.Subscription{Title: feed.Title, FeedURL: feed.FeedURL}
+		if feed.Category.Title != "" {
+			sub.Categories = []string{feed.Category.Title}
+		}
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
+
+// Entries возвращает последние прочитанные и избранные статьи Miniflux
+func (m *Miniflux) Entries(ctx context.Context, limit int) ([]domain.ImportedEntry, error) {
+	read, err := m.entries(ctx, url.Values{"status": {"read"}}, limit)
+	if err != nil {
+		return nil, err
+	}
+	starred, err := m.entries(ctx, url.Values{"starred": {"true"}}, limit)
+	if err != nil {
+		return nil, err
+	}
+	return append(read, starred...), nil
+}
+
+// entries загружает статьи по фильтру страницами, новые сначала
+func (m *Miniflux) entries(ctx context.Context, filter url.Values, limit int) ([]domain.ImportedEntry, error) {
+	var result []domain.ImportedEntry
+	for len(result) < limit {
+		query := url.Values{
+			"order":     {"published_at"},
+			"direction": {"desc"},
+			"limit":     {strconv.Itoa(min(minifluxPageSize, limit-len(result)))},
+			"offset":    {strconv.Itoa(len(result))},
+		}
+		for name, values := range filter {
+			query[name] = values
+		}
+
+		var page minifluxEntries
+		if err := m.client.getJSON(ctx, "/v1/entries", query, m.header(), &page); err != nil {
+			return nil, err
+		}
+
+		for _, e := range page.Entries {
+			result = append(result, domain.ImportedEntry{
+				FeedURL:     e.Feed.FeedURL,
+				Title:       e.Title,
+				Link:        e.URL,
+				Description: e.Content,
+				PublishedAt: e.PublishedAt,
+				Read:        e.Status == "read",
+				Starred:     e.Starred,
+			})
+		}
+		if len(page.Entries) < minifluxPageSize || len(result) >= page.Total {
+			break
+		}
+	}
+	return result, nil
+}
+
+// header заголовки авторизации Miniflux
+func (m *Miniflux) header() http.Header {
+	return http.Header{"X-Auth-Token": {m.apiKey}, "Accept": {"application/json"}}
+}
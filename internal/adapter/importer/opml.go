@@ -0,0 +1,94 @@
+// internal/adapter/importer/opml.go
+package importer
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+)
+
+// Проверяем на этапе компиляции, что OPML реализует источник подписок
+var _ port.SubscriptionSource = (*OPML)(nil)
+
+// OPML источник подписок из файла OPML, который экспортируют почти все читалки лент.
+// Вложенные outline без xmlUrl - папки: подписка из <outline text="News"><outline text="Tech">
+// попадает в папку "News/Tech". Состояния статей в OPML нет
+type OPML struct {
+	path string
+}
+
+// opmlDocument корень документа OPML
+type opmlDocument struct {
+	XMLName xml.Name      `xml:"opml"`
+	Body    []opmlOutline `xml:"body>outline"`
+}
+
+// opmlOutline элемент outline: подписка (с xmlUrl) или папка с вложенными outline
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr"`
+	XMLURL   string        `xml:"xmlUrl,attr"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// NewOPML создает источник подписок из файла OPML
+func NewOPML(path string) (*OPML, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("OPML file is required")
+	}
+	return &OPML{path: path}, nil
+}
+
+// Subscriptions читает подписки файла вместе с путями их папок
+func (o *OPML) Subscriptions(ctx context.Context) ([]domain.Subscription, error) {
+	data, err := os.ReadFile(o.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OPML file: %w", err)
+	}
+
+	var doc opmlDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid OPML file %s: %w", o.path, err)
+	}
+
+	var subs []domain.Subscription
+	collectOutlines(doc.Body, nil, &subs)
+	return subs, nil
+}
+
+// Entries ничего не возвращает: OPML содержит только подписки
+func (o *OPML) Entries(ctx context.Context, limit int) ([]domain.ImportedEntry, error) {
+	return nil, nil
+}
+
+// collectOutlines обходит outline и добавляет подписки в subs; folders - папки, в которые вложены
+// outline. "/" в имени папки заменяется на "-", чтобы не образовать лишний уровень
+func collectOutlines(outlines []opmlOutline, folders []string, subs *[]domain.Subscription) {
+	for _, outline := range outlines {
+		title := strings.TrimSpace(outline.Title)
+		if title == "" {
+			title = strings.TrimSpace(outline.Text)
+		}
+
+		if feedURL := strings.TrimSpace(outline.XMLURL); feedURL != "" {
+			*subs = append(*subs, domain.Subscription{
+				Title:   title,
+				FeedURL: feedURL,
+				Folder:  strings.Join(folders, domain.FolderSeparator),
+			})
+		}
+
+		if len(outline.Outlines) > 0 {
+			nested := folders
+			if outline.XMLURL == "" && title != "" {
+				nested = append(folders[:len(folders):len(folders)], strings.ReplaceAll(title, domain.FolderSeparator, "-"))
+			}
+			collectOutlines(outline.Outlines, nested, subs)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// internal/adapter/notifier/desktop/notifier.go
+package desktop
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/config"
+	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/utils"
+)
+
+// Проверяем на этапе компиляции, что Notifier реализует получателя уведомлений
+var _ port.Notifier = (*Notifier)(nil)
+
+const (
+	// maxBodyLength максимальная длина текста уведомления (в символах)
+	maxBodyLength = 200
+
+	// commandTimeout сколько ждать завершения команды показа одного уведомления
+	commandTimeout = 10 * time.Second
+)
+
+// windowsToast скрипт PowerShell, показывающий toast уведомление. Заголовок и текст передаются
+// через переменные окружения, чтобы их не приходилось экранировать в тексте скрипта
+const windowsToast = `
+[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $template.GetElementsByTagName('text')
+$text.Item(0).AppendChild($template.CreateTextNode($env:RSSHUB_TITLE)) | Out-Null
+$text.Item(1).AppendChild($template.CreateTextNode($env:RSSHUB_BODY)) | Out-Null
+$toast = [Windows.UI.Notifications.ToastNotification]::new($template)
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('rsshub').Show($toast)`
+
+// Notifier показывает новые статьи уведомлениями рабочего стола: notify-send (libnotify) в Linux,
+// osascript в macOS и toast через PowerShell в Windows. Если статей за цикл больше лимита,
+// сверх него показывается одно общее уведомление
+type Notifier struct {
+	command string                            // Путь к программе показа уведомлений
+	args    func(title, body string) []string // Аргументы команды для одного уведомления
+	env     func(title, body string) []string // Дополнительные переменные окружения команды
+	max     int                               // Уведомлений за цикл, включая общее
+}
+
+// New создает получателя уведомлений рабочего стола для текущей ОС
+func New(cfg *config.DesktopConfig) (*Notifier, error) {
+	n := &Notifier{
+		max: cfg.MaxNotifications,
+		env: func(string, string) []string { return nil },
+	}
+	if n.max <= 0 {
+		n.max = 1
+	}
+
+	var program string
+	switch runtime.GOOS {
+	case "darwin":
+		program = "osascript"
+		n.args = func(title, body string) []string {
+			// Текст передается аргументами скрипта, а не подставляется в его код
+			return []string{"-e", "on run argv", "-e", "display notification (item 2 of argv) with title (item 1 of argv)",
+				"-e", "end run", title, body}
+		}
+	case "windows":
+		program = "powershell"
+		n.args = func(string, string) []string {
+			return []string{"-NoProfile", "-NonInteractive", "-Command", windowsToast}
+		}
+		n.env = func(title, body string) []string {
+			return []string{"RSSHUB_TITLE=" + title, "RSSHUB_BODY=" + body}
+		}
+	default:
+		program = "notify-send"
+		n.args = func(title, body string) []string {
+			return []string{"--app-name=rsshub", "--", title, body}
+		}
+	}
+
+	path, err := exec.LookPath(program)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not available: %w", program, err)
+	}
+	n.command = path
+	return n, nil
+}
+
+// Notify показывает уведомления о статьях одного цикла: по одному на статью, но не больше лимита
+func (n *Notifier) Notify(ctx context.Context, entries []*domain.DigestEntry) error {
+	shown := entries
+	if len(shown) > n.max {
+		// Последнее место занимает общее уведомление об остальных статьях
+		shown = entries[:n.max-1]
+	}
+
+	for _, e := range shown {
+		if err := n.show(ctx, e.FeedName, e.Article.Title); err != nil {
+			return err
+		}
+	}
+	if rest := len(entries) - len(shown); rest > 0 {
+		if err := n.show(ctx, "rsshub", fmt.Sprintf("%d more new articles", rest)); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("Showed %d new articles as desktop notifications", len(entries))
+	return nil
+}
+
+// show показывает одно уведомление
+func (n *Notifier) show(ctx context.Context, title, body string) error {
+	title, body = utils.Truncate(strings.TrimSpace(title), maxBodyLength), utils.Truncate(strings.TrimSpace(body), maxBodyLength)
+	if err := runCommand(ctx, n.env(title, body), n.command, n.args(title, body)...); err != nil {
+		return fmt.Errorf("desktop notification failed: %w", err)
+	}
+	return nil
+}
+
+// runCommand выполняет команду с таймаутом, добавляя к окружению процесса env
+func runCommand(ctx context.Context, env []string, name string, args ...string) error {
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(output)); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+// internal/adapter/notifier/discord/notifier.go
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/config"
+	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/utils"
+)
+
+// Проверяем на этапе компиляции, что Notifier реализует получателя уведомлений
+var _ port.Notifier = (*Notifier)(nil)
+
+const (
+	// maxEmbeds ограничение Discord на число embed в сообщении
+	maxEmbeds = 10
+
+	// maxEmbedsLength ограничение Discord на суммарную длину текста всех embed сообщения (в символах)
+	maxEmbedsLength = 6000
+
+	// maxTitleLength ограничение Discord на длину заголовка embed (в символах)
+	maxTitleLength = 256
+
+	// maxAuthorLength ограничение Discord на длину имени автора embed (в символах)
+	maxAuthorLength = 256
+
+	// maxDescriptionLength максимальная длина описания статьи в embed (в символах)
+	maxDescriptionLength = 350
+
+	// maxRetries сколько раз повторять сообщение, отклоненное из-за превышения лимита
+	maxRetries = 3
+
+	// requestTimeout таймаут запроса к webhook
+	requestTimeout = 15 * time.Second
+)
+
+// embed статья в формате Discord embed
+type embed struct {
+	Title       string       `json:"title"`
+	URL         string       `json:"url,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Timestamp   string       `json:"timestamp,omitempty"`
+	Author      *embedAuthor `json:"author,omitempty"`
+}
+
+// embedAuthor блок автора embed, в нем показывается имя ленты
+type embedAuthor struct {
+	Name string `json:"name"`
+}
+
+// rateLimitResponse тело ответа 429 от Discord
+type rateLimitResponse struct {
+	RetryAfter float64 `json:"retry_after"`
+}
+
+// Notifier отправляет новые статьи в канал Discord через webhook: каждая статья - embed
+// с заголовком, описанием, датой публикации и именем ленты в качестве автора
+type Notifier struct {
+	client   *http.Client
+	webhook  string
+	interval time.Duration
+
+	mu        sync.Mutex // Сериализует отправку, чтобы соблюдать интервал и ограничения webhook
+	nextAllow time.Time  // Раньше этого момента следующее сообщение не отправляется
+}
+
+// New создает получателя уведомлений Discord
+func New(cfg *config.DiscordConfig) (*Notifier, error) {
+	if cfg.WebhookURL == "" {
+		return nil, fmt.Errorf("discord webhook URL is not configured")
+	}
+	if u, err := url.Parse(cfg.WebhookURL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("invalid discord webhook URL")
+	}
+
+	return &Notifier{
+		client:   &http.Client{Timeout: requestTimeout},
+		webhook:  cfg.WebhookURL,
+		interval: cfg.MessageInterval,
+	}, nil
+}
+
+// Notify отправляет статьи одного цикла, объединяя их в сообщения в пределах ограничений Discord
+func (n *Notifier) Notify(ctx context.Context, entries []*domain.DigestEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	batches := batchEmbeds(entries)
+	for i, batch := range batches {
+		payload, err := json.Marshal(map[string]interface{}{"embeds": batch})
+		if err != nil {
+			return fmt.Errorf("failed to encode discord message: %w", err)
+		}
+		if err := n.send(ctx, payload); err != nil {
+			return fmt.Errorf("discord message %d of %d: %w", i+1, len(batches), err)
+		}
+	}
+
+	logger.Info("Sent %d new articles to Discord in %d messages", len(entries), len(batches))
+	return nil
+}
+
+// batchEmbeds раскладывает статьи по сообщениям не больше maxEmbeds embed и maxEmbedsLength символов
+func batchEmbeds(entries []*domain.DigestEntry) [][]embed {
+	var batches [][]embed
+	var batch []embed
+	length := 0
+
+	for _, e := range entries {
+		em := newEmbed(e)
+		size := embedLength(em)
+		if len(batch) > 0 && (len(batch) == maxEmbeds || length+size > maxEmbedsLength) {
+			batches = append(batches, batch)
+			batch, length = nil, 0
+		}
+		batch = append(batch, em)
+		length += size
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// newEmbed формирует embed статьи
+func newEmbed(e *domain.DigestEntry) embed {
+	em := embed{
+		Title:       utils.Truncate(strings.TrimSpace(e.Article.Title), maxTitleLength),
+		Description: summarize(e.Article.Description),
+		Author:      &embedAuthor{Name: utils.Truncate(e.FeedName, maxAuthorLength)},
+	}
+	if em.Title == "" {
+		em.Title = "(untitled)"
+	}
+	if u, err := url.Parse(e.Article.Link); err == nil && (u.Scheme == "https" || u.Scheme == "http") {
+		em.URL = e.Article.Link
+	}
+	if !e.Article.PublishedAt.IsZero() {
+		em.Timestamp = e.Article.PublishedAt.UTC().Format(time.RFC3339)
+	}
+	return em
+}
+
+// embedLength длина текста embed, которая учитывается в ограничении maxEmbedsLength
+func embedLength(em embed) int {
+	return len([]rune(em.Title)) + len([]rune(em.Description)) + len([]rune(em.Author.Name))
+}
+
+// summarize возвращает описание статьи без HTML разметки, обрезанное до maxDescriptionLength
+func summarize(description string) string {
+	return utils.Truncate(utils.StripHTML(description), maxDescriptionLength)
+}
+
+// send отправляет одно сообщение, соблюдая интервал между сообщениями и ограничения Discord
+func (n *Notifier) send(ctx context.Context, payload []byte) error {
+	for attempt := 0; ; attempt++ {
+		if err := sleep(ctx, time.Until(n.nextAllow)); err != nil {
+			return err
+		}
+
+		wait, retry, err := n.post(ctx, payload)
+		n.nextAllow = time.Now().Add(max(n.interval, wait))
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= maxRetries {
+			return err
+		}
+		logger.Warn("Discord rate limit exceeded, retrying in %v", wait)
+	}
+}
+
+// post выполняет запрос к webhook. Возвращает, сколько ждать до следующего запроса по заголовкам
+// ограничений Discord, и нужно ли повторить сообщение после превышения лимита
+func (n *Notifier) post(ctx context.Context, payload []byte) (time.Duration, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhook, bytes.NewReader(payload))
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		// URL webhook содержит токен, поэтому оставляем только причину
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return 0, false, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	// Когда запросы в текущем окне закончились, ждем его сброса
+	var wait time.Duration
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		wait = parseSeconds(resp.Header.Get("X-RateLimit-Reset-After"))
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return wait, false, nil
+	}
+
+	err = fmt.Errorf("discord webhook error (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	if resp.StatusCode == http.StatusTooManyRequests {
+		var limit rateLimitResponse
+		if json.Unmarshal(body, &limit) == nil && limit.RetryAfter > 0 {
+			wait = time.Duration(limit.RetryAfter * float64(time.Second))
+		} else if retryAfter := parseSeconds(resp.Header.Get("Retry-After")); retryAfter > 0 {
+			wait = retryAfter
+		}
+		if wait <= 0 {
+			wait = time.Second
+		}
+		return wait, true, err
+	}
+	return wait, false, err
+}
+
+// parseSeconds разбирает число секунд (возможно дробное) из заголовка ответа
+func parseSeconds(value string) time.Duration {
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// sleep ждет d или отмены контекста
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
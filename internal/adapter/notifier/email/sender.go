@@ -0,0 +1,227 @@
+// internal/adapter/notifier/email/sender.go
+package email
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/config"
+)
+
+// Проверяем на этапе компиляции, что Sender реализует отправку дайджестов
+var _ port.DigestSender = (*Sender)(nil)
+
+// implicitTLSPort порт SMTP с TLS с момента подключения (SMTPS)
+const implicitTLSPort = 465
+
+// dialTimeout таймаут подключения к SMTP серверу
+const dialTimeout = 30 * time.Second
+
+// defaultTemplate встроенный текстовый шаблон письма
+const defaultTemplate = `New articles from {{.From.Format "02 Jan 2006 15:04"}} to {{.To.Format "02 Jan 2006 15:04"}}: {{.Total}}
+{{range .Groups}}
+== {{.Name}} ({{len .Entries}}) ==
+{{range .Entries}}
+- {{.Article.Title}}
+  {{.Article.Link}}
+{{end}}{{end}}`
+
+// executor общий интерфейс текстовых и HTML шаблонов
+type executor interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// Sender отправляет дайджесты по email через SMTP
+type Sender struct {
+	smtp    config.SMTPConfig
+	subject *texttemplate.Template
+	body    executor
+	html    bool // Шаблон письма в формате HTML
+}
+
+// NewSender создает отправителя дайджестов и загружает шаблоны письма
+func NewSender(smtpCfg *config.SMTPConfig, digestCfg *config.DigestConfig) (*Sender, error) {
+	if smtpCfg.Host == "" {
+		return nil, fmt.Errorf("SMTP host is not configured")
+	}
+	if smtpCfg.From == "" {
+		return nil, fmt.Errorf("SMTP sender address is not configured")
+	}
+	if len(smtpCfg.To) == 0 {
+		return nil, fmt.Errorf("digest recipients are not configured")
+	}
+
+	subject, err := texttemplate.New("subject").Parse(digestCfg.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("invalid digest subject template: %w", err)
+	}
+
+	s := &Sender{smtp: *smtpCfg, subject: subject}
+	if err := s.loadBody(digestCfg.Template); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// loadBody загружает шаблон письма из файла или использует встроенный
+func (s *Sender) loadBody(path string) error {
+	if path == "" {
+		body, err := texttemplate.New("body").Parse(defaultTemplate)
+		if err != nil {
+			return fmt.Errorf("invalid default digest template: %w", err)
+		}
+		s.body = body
+		return nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read digest template: %w", err)
+	}
+
+	// HTML шаблоны экранируют данные статей
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		s.html = true
+		s.body, err = htmltemplate.New(filepath.Base(path)).Parse(string(content))
+	default:
+		s.body, err = texttemplate.New(filepath.Base(path)).Parse(string(content))
+	}
+	if err != nil {
+		return fmt.Errorf("invalid digest template %s: %w", path, err)
+	}
+	return nil
+}
+
+// Render формирует тему и текст письма для дайджеста
+func (s *Sender) Render(digest *domain.Digest) (string, string, error) {
+	var subject, body bytes.Buffer
+	if err := s.subject.Execute(&subject, digest); err != nil {
+		return "", "", fmt.Errorf("failed to render digest subject: %w", err)
+	}
+	if err := s.body.Execute(&body, digest); err != nil {
+		return "", "", fmt.Errorf("failed to render digest body: %w", err)
+	}
+	return strings.TrimSpace(subject.String()), body.String(), nil
+}
+
+// SendDigest формирует письмо с дайджестом и отправляет его всем получателям
+func (s *Sender) SendDigest(digest *domain.Digest) error {
+	subject, body, err := s.Render(digest)
+	if err != nil {
+		return err
+	}
+
+	message, err := s.buildMessage(subject, body)
+	if err != nil {
+		return err
+	}
+
+	return s.send(message)
+}
+
+// buildMessage собирает MIME сообщение с заголовками и телом в quoted-printable
+func (s *Sender) buildMessage(subject, body string) ([]byte, error) {
+	contentType := "text/plain"
+	if s.html {
+		contentType = "text/html"
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.smtp.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.smtp.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: %s; charset=UTF-8\r\n", contentType)
+	fmt.Fprintf(&msg, "Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	qp := quotedprintable.NewWriter(&msg)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return nil, fmt.Errorf("failed to encode digest body: %w", err)
+	}
+	if err := qp.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode digest body: %w", err)
+	}
+
+	return msg.Bytes(), nil
+}
+
+// send доставляет сообщение через SMTP сервер. На порту 465 используется TLS сразу,
+// на остальных - STARTTLS, если сервер его поддерживает
+func (s *Sender) send(message []byte) error {
+	addr := net.JoinHostPort(s.smtp.Host, strconv.Itoa(s.smtp.Port))
+	tlsConfig := &tls.Config{ServerName: s.smtp.Host}
+
+	dialer := &net.Dialer{Timeout: dialTimeout}
+
+	var conn net.Conn
+	var err error
+	if s.smtp.Port == implicitTLSPort {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server %s: %w", addr, err)
+	}
+
+	client, err := smtp.NewClient(conn, s.smtp.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if s.smtp.Port != implicitTLSPort {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("failed to start TLS: %w", err)
+			}
+		}
+	}
+
+	if s.smtp.Username != "" {
+		auth := smtp.PlainAuth("", s.smtp.Username, s.smtp.Password, s.smtp.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.smtp.From); err != nil {
+		return fmt.Errorf("SMTP server rejected sender: %w", err)
+	}
+	for _, to := range s.smtp.To {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("SMTP server rejected recipient %s: %w", to, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to send digest: %w", err)
+	}
+	if _, err := w.Write(message); err != nil {
+		return fmt.Errorf("failed to send digest: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send digest: %w", err)
+	}
+
+	return client.Quit()
+}
@@ -0,0 +1,207 @@
+// internal/adapter/notifier/hook/notifier.go
+package hook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/config"
+	"rsshub/internal/platform/logger"
+)
+
+// Проверяем на этапе компиляции, что Notifier реализует получателя уведомлений
+var _ port.Notifier = (*Notifier)(nil)
+
+// waitDelay сколько ждать закрытия stdout и stderr после завершения команды (например,
+// если фоновый процесс, запущенный командой, унаследовал их)
+const waitDelay = time.Second
+
+// Mode как часто запускается команда
+type Mode string
+
+const (
+	ModeArticle Mode = "article" // Отдельный запуск на каждую новую статью
+	ModeCycle   Mode = "cycle"   // Один запуск со всеми новыми статьями цикла
+)
+
+// ParseMode разбирает режим запуска команды
+func ParseMode(s string) (Mode, error) {
+	switch mode := Mode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case ModeArticle, ModeCycle:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown hook mode %q (expected article or cycle)", s)
+	}
+}
+
+// article статья в JSON, который получает команда
+type article struct {
+	ID          string    `json:"id"`
+	Feed        string    `json:"feed"`
+	FeedTags    []string  `json:"feed_tags,omitempty"`
+	FeedFolder  string    `json:"feed_folder,omitempty"`
+	Title       string    `json:"title"`
+	Link        string    `json:"link"`
+	GUID        string    `json:"guid,omitempty"`
+	PublishedAt time.Time `json:"published_at"`
+	Language    string    `json:"language,omitempty"`
+	Score       float64   `json:"score,omitempty"`
+	Description string    `json:"description"`
+
+	Podcast *domain.PodcastInfo `json:"podcast,omitempty"` // Метаданные эпизода подкаста
+	Media   []domain.MediaItem  `json:"media,omitempty"`   // Вложения Media RSS
+}
+
+// cyclePayload JSON, который получает команда в режиме cycle
+type cyclePayload struct {
+	Articles []article `json:"articles"`
+}
+
+// Notifier запускает команду пользователя через sh -c для новых статей и передает их в stdin
+// в JSON: в режиме article - объект статьи на каждый запуск, в режиме cycle - {"articles": [...]}
+// один раз за цикл. Каждый запуск ограничен таймаутом; одновременно выполняется не больше
+// concurrency запусков, в том числе из разных циклов и rsshub refresh
+type Notifier struct {
+	command string
+	mode    Mode
+	timeout time.Duration
+	slots   chan struct{}
+}
+
+// New создает получателя уведомлений, запускающего внешнюю команду
+func New(cfg *config.HookConfig) (*Notifier, error) {
+	if strings.TrimSpace(cfg.Command) == "" {
+		return nil, fmt.Errorf("hook command is not configured")
+	}
+	mode, err := ParseMode(cfg.Mode)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Timeout <= 0 {
+		return nil, fmt.Errorf("hook timeout must be positive")
+	}
+	if cfg.Concurrency < 1 {
+		return nil, fmt.Errorf("hook concurrency must be at least 1")
+	}
+
+	return &Notifier{
+		command: cfg.Command,
+		mode:    mode,
+		timeout: cfg.Timeout,
+		slots:   make(chan struct{}, cfg.Concurrency),
+	}, nil
+}
+
+// Notify запускает команду для статей одного цикла и ждет завершения всех запусков
+func (n *Notifier) Notify(ctx context.Context, entries []*domain.DigestEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	if n.mode == ModeCycle {
+		payload := cyclePayload{Articles: make([]article, 0, len(entries))}
+		for _, e := range entries {
+			payload.Articles = append(payload.Articles, newArticle(e))
+		}
+		if err := n.run(ctx, payload); err != nil {
+			return err
+		}
+		logger.Info("Hook command processed %d new articles", len(entries))
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(entries))
+	for i, e := range entries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := n.run(ctx, newArticle(e)); err != nil {
+				errs[i] = fmt.Errorf("article '%s': %w", e.Article.Title, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("hook command failed for %d of %d articles: %w", failed, len(entries), errors.Join(errs...))
+	}
+	logger.Info("Hook command ran for %d new articles", len(entries))
+	return nil
+}
+
+// run запускает команду, когда освободится место, и передает ей payload в stdin.
+// Вывод команды пишется в лог отладки
+func (n *Notifier) run(ctx context.Context, payload interface{}) error {
+	input, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode hook input: %w", err)
+	}
+
+	select {
+	case n.slots <- struct{}{}:
+		defer func() { <-n.slots }()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", n.command)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = waitDelay
+	killProcessGroup(cmd)
+
+	err = cmd.Run()
+	if output := strings.TrimSpace(stdout.String()); output != "" {
+		logger.Debug("Hook command output: %s", output)
+	}
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("hook command timed out after %v", n.timeout)
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("hook command failed: %w: %s", err, message)
+		}
+		return fmt.Errorf("hook command failed: %w", err)
+	}
+	return nil
+}
+
+// newArticle формирует статью для команды
+func newArticle(e *domain.DigestEntry) article {
+	return article{
+		ID:          e.Article.ID.String(),
+		Feed:        e.FeedName,
+		FeedTags:    e.FeedTags,
+		FeedFolder:  e.FeedFolder,
+		Title:       e.Article.Title,
+		Link:        e.Article.Link,
+		GUID:        e.Article.GUID,
+		PublishedAt: e.Article.PublishedAt,
+		Language:    e.Article.Language,
+		Score:       e.Score,
+		Description: e.Article.Description,
+		Podcast:     e.Article.Podcast,
+		Media:       e.Article.Media,
+	}
+}
@@ -0,0 +1,9 @@
+// internal/adapter/notifier/hook/process_other.go
+//go:build !linux && !darwin
+
+package hook
+
+import "os/exec"
+
+// killProcessGroup на этой платформе не меняется: при отмене завершается только sh
+func killProcessGroup(cmd *exec.Cmd) {}
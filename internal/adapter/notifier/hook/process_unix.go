@@ -0,0 +1,18 @@
+// internal/adapter/notifier/hook/process_unix.go
+//go:build linux || darwin
+
+package hook
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// killProcessGroup запускает команду в отдельной группе процессов и при отмене завершает
+// всю группу, чтобы по таймауту останавливались и процессы, запущенные командой
+func killProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
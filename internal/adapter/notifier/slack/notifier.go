@@ -0,0 +1,286 @@
+// internal/adapter/notifier/slack/notifier.go
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/config"
+	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/utils"
+)
+
+// Проверяем на этапе компиляции, что Notifier реализует получателя уведомлений
+var _ port.Notifier = (*Notifier)(nil)
+
+const (
+	// maxBlocks ограничение Slack на число блоков в сообщении
+	maxBlocks = 50
+
+	// blocksPerArticle блоков на статью: секция с заголовком и кратким содержанием, контекст с лентой и разделитель
+	blocksPerArticle = 3
+
+	// maxSummaryLength максимальная длина краткого содержания статьи (в символах)
+	maxSummaryLength = 300
+
+	// maxTitleLength максимальная длина заголовка статьи (в символах)
+	maxTitleLength = 250
+
+	// maxRetries сколько раз повторять сообщение, отклоненное из-за превышения лимита
+	maxRetries = 3
+
+	// requestTimeout таймаут запроса к webhook
+	requestTimeout = 15 * time.Second
+)
+
+// Notifier отправляет новые статьи в Slack через incoming webhooks в формате Block Kit.
+// Статьи лент с тегом, для которого задан свой webhook, уходят в его канал,
+// остальные - в webhook по умолчанию
+type Notifier struct {
+	client      *http.Client
+	webhook     string            // Webhook по умолчанию (пусто - статьи без маршрута не отправляются)
+	tagWebhooks map[string]string // Webhook для статей лент с тегом
+	interval    time.Duration
+
+	mu       sync.Mutex // Сериализует отправку, чтобы соблюдать интервал между сообщениями
+	lastSent time.Time
+}
+
+// New создает получателя уведомлений Slack
+func New(cfg *config.SlackConfig) (*Notifier, error) {
+	n := &Notifier{
+		client:      &http.Client{Timeout: requestTimeout},
+		webhook:     cfg.WebhookURL,
+		tagWebhooks: make(map[string]string),
+		interval:    cfg.MessageInterval,
+	}
+
+	for _, route := range cfg.TagWebhooks {
+		tag, webhook, ok := strings.Cut(route, "=")
+		tags := domain.ParseTags(tag)
+		if !ok || len(tags) != 1 || !isHTTPURL(strings.TrimSpace(webhook)) {
+			return nil, fmt.Errorf("invalid slack tag route %q (expected tag=https://hooks.slack.com/...)", route)
+		}
+		n.tagWebhooks[tags[0]] = strings.TrimSpace(webhook)
+	}
+
+	if n.webhook != "" && !isHTTPURL(n.webhook) {
+		return nil, fmt.Errorf("invalid slack webhook URL")
+	}
+	if n.webhook == "" && len(n.tagWebhooks) == 0 {
+		return nil, fmt.Errorf("slack webhook URL is not configured")
+	}
+	return n, nil
+}
+
+// Notify отправляет статьи одного цикла, разложив их по webhook и сообщениям
+func (n *Notifier) Notify(ctx context.Context, entries []*domain.DigestEntry) error {
+	routes, order := n.route(entries)
+	if len(order) == 0 {
+		return nil
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	sent, messages := 0, 0
+	for _, webhook := range order {
+		batches := batchPayloads(routes[webhook])
+		for i, payload := range batches {
+			if err := n.send(ctx, webhook, payload); err != nil {
+				return fmt.Errorf("slack message %d of %d: %w", i+1, len(batches), err)
+			}
+		}
+		sent += len(routes[webhook])
+		messages += len(batches)
+	}
+
+	logger.Info("Sent %d new articles to Slack in %d messages", sent, messages)
+	return nil
+}
+
+// route распределяет статьи по webhook: все webhook тегов ленты, а если их нет - webhook по умолчанию.
+// Возвращает статьи каждого webhook и порядок webhook по первой статье
+func (n *Notifier) route(entries []*domain.DigestEntry) (map[string][]*domain.DigestEntry, []string) {
+	routes := make(map[string][]*domain.DigestEntry)
+	var order []string
+	add := func(webhook string, e *domain.DigestEntry) {
+		list := routes[webhook]
+		if len(list) > 0 && list[len(list)-1] == e {
+			return // Несколько тегов ленты ведут в один канал
+		}
+		if list == nil {
+			order = append(order, webhook)
+		}
+		routes[webhook] = append(list, e)
+	}
+
+	for _, e := range entries {
+		routed := false
+		for _, tag := range e.FeedTags {
+			if webhook, ok := n.tagWebhooks[tag]; ok {
+				add(webhook, e)
+				routed = true
+			}
+		}
+		if !routed && n.webhook != "" {
+			add(n.webhook, e)
+		}
+	}
+	return routes, order
+}
+
+// batchPayloads раскладывает статьи по сообщениям, не превышающим лимит блоков Slack
+func batchPayloads(entries []*domain.DigestEntry) [][]byte {
+	perMessage := (maxBlocks - 1) / blocksPerArticle // Первый блок - заголовок сообщения
+
+	var payloads [][]byte
+	for start := 0; start < len(entries); start += perMessage {
+		end := min(start+perMessage, len(entries))
+		payloads = append(payloads, buildPayload(entries[start:end], len(entries)))
+	}
+	return payloads
+}
+
+// buildPayload формирует сообщение Block Kit; поле text - запасной текст для push уведомлений
+func buildPayload(entries []*domain.DigestEntry, total int) []byte {
+	heading := fmt.Sprintf("%d new articles", total)
+	if total == 1 {
+		heading = "1 new article"
+	}
+
+	blocks := []map[string]interface{}{{
+		"type": "header",
+		"text": map[string]interface{}{"type": "plain_text", "text": heading},
+	}}
+	for _, e := range entries {
+		text := fmt.Sprintf("*<%s|%s>*", escape(e.Article.Link),
+			strings.ReplaceAll(escape(utils.Truncate(e.Article.Title, maxTitleLength)), "|", "∣"))
+		if summary := summarize(e.Article.Description); summary != "" {
+			text += "\n" + escape(summary)
+		}
+
+		meta := escape(e.FeedName)
+		if !e.Article.PublishedAt.IsZero() {
+			meta += fmt.Sprintf(" · <!date^%d^{date_short_pretty} {time}|%s>",
+				e.Article.PublishedAt.Unix(), e.Article.PublishedAt.UTC().Format("2006-01-02 15:04 UTC"))
+		}
+
+		blocks = append(blocks,
+			map[string]interface{}{"type": "section", "text": map[string]interface{}{"type": "mrkdwn", "text": text}},
+			map[string]interface{}{"type": "context", "elements": []interface{}{
+				map[string]interface{}{"type": "mrkdwn", "text": meta},
+			}},
+			map[string]interface{}{"type": "divider"},
+		)
+	}
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"text":   heading,
+		"blocks": blocks,
+	})
+	return payload
+}
+
+// summarize возвращает описание статьи без HTML разметки, обрезанное до maxSummaryLength
+func summarize(description string) string {
+	return utils.Truncate(utils.StripHTML(description), maxSummaryLength)
+}
+
+// escape экранирует управляющие символы разметки mrkdwn
+func escape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// send отправляет одно сообщение, соблюдая интервал между сообщениями и Retry-After от Slack
+func (n *Notifier) send(ctx context.Context, webhook string, payload []byte) error {
+	for attempt := 0; ; attempt++ {
+		if err := sleep(ctx, time.Until(n.lastSent.Add(n.interval))); err != nil {
+			return err
+		}
+
+		retryAfter, err := n.post(ctx, webhook, payload)
+		n.lastSent = time.Now()
+		if err == nil {
+			return nil
+		}
+		if retryAfter == 0 || attempt >= maxRetries {
+			return err
+		}
+
+		logger.Warn("Slack rate limit exceeded, retrying in %v", retryAfter)
+		if err := sleep(ctx, retryAfter); err != nil {
+			return err
+		}
+	}
+}
+
+// post выполняет запрос к webhook. При превышении лимита возвращает время ожидания
+func (n *Notifier) post(ctx context.Context, webhook string, payload []byte) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		// URL webhook является секретом, поэтому оставляем только причину
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if resp.StatusCode == http.StatusOK {
+		return 0, nil
+	}
+
+	err = fmt.Errorf("slack webhook error (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter := time.Second
+		if seconds, convErr := strconv.Atoi(resp.Header.Get("Retry-After")); convErr == nil && seconds > 0 {
+			retryAfter = time.Duration(seconds) * time.Second
+		}
+		return retryAfter, err
+	}
+	return 0, err
+}
+
+// isHTTPURL проверяет, что строка - HTTP(S) адрес
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "https" || u.Scheme == "http") && u.Host != ""
+}
+
+// sleep ждет d или отмены контекста
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
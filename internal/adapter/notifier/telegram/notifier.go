@@ -0,0 +1,251 @@
+// internal/adapter/notifier/telegram/notifier.go
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/config"
+	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/utils"
+)
+
+// Проверяем на этапе компиляции, что Notifier реализует получателя уведомлений
+var _ port.Notifier = (*Notifier)(nil)
+
+const (
+	// maxMessageLength ограничение Telegram на длину текста сообщения
+	maxMessageLength = 4096
+
+	// maxTitleLength максимальная длина заголовка статьи в сообщении (в символах)
+	maxTitleLength = 300
+
+	// maxRetries сколько раз повторять сообщение, отклоненное из-за превышения лимита
+	maxRetries = 3
+
+	// requestTimeout таймаут запроса к Bot API
+	requestTimeout = 15 * time.Second
+)
+
+// Notifier отправляет новые статьи в чат Telegram через Bot API.
+// Статьи одного цикла объединяются в минимальное количество сообщений
+type Notifier struct {
+	client   *http.Client
+	endpoint string // URL метода sendMessage (содержит токен бота)
+	chatID   string
+	tags     map[string]struct{}
+	keywords []string
+	interval time.Duration
+
+	mu       sync.Mutex // Сериализует отправку, чтобы соблюдать интервал между сообщениями
+	lastSent time.Time
+}
+
+// apiResponse ответ Bot API
+type apiResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+	Parameters  struct {
+		RetryAfter int `json:"retry_after"`
+	} `json:"parameters"`
+}
+
+// New создает получателя уведомлений Telegram
+func New(cfg *config.TelegramConfig) (*Notifier, error) {
+	if cfg.BotToken == "" {
+		return nil, fmt.Errorf("telegram bot token is not configured")
+	}
+	if cfg.ChatID == "" {
+		return nil, fmt.Errorf("telegram chat ID is not configured")
+	}
+
+	n := &Notifier{
+		client:   &http.Client{Timeout: requestTimeout},
+		endpoint: strings.TrimRight(cfg.APIURL, "/") + "/bot" + cfg.BotToken + "/sendMessage",
+		chatID:   cfg.ChatID,
+		tags:     make(map[string]struct{}),
+		interval: cfg.MessageInterval,
+	}
+	for _, tag := range domain.ParseTags(strings.Join(cfg.Tags, ",")) {
+		n.tags[tag] = struct{}{}
+	}
+	for _, keyword := range cfg.Keywords {
+		n.keywords = append(n.keywords, strings.ToLower(keyword))
+	}
+
+	return n, nil
+}
+
+// Notify отправляет подходящие под фильтры статьи одного цикла
+func (n *Notifier) Notify(ctx context.Context, entries []*domain.DigestEntry) error {
+	var selected []*domain.DigestEntry
+	for _, e := range entries {
+		if n.matches(e) {
+			selected = append(selected, e)
+		}
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	messages := batchMessages(selected)
+	for i, text := range messages {
+		if err := n.send(ctx, text); err != nil {
+			return fmt.Errorf("telegram message %d of %d: %w", i+1, len(messages), err)
+		}
+	}
+
+	logger.Info("Sent %d new articles to Telegram in %d messages", len(selected), len(messages))
+	return nil
+}
+
+// matches проверяет статью по фильтрам тегов и ключевых слов
+func (n *Notifier) matches(e *domain.DigestEntry) bool {
+	if len(n.tags) > 0 {
+		tagged := false
+		for _, tag := range e.FeedTags {
+			if _, ok := n.tags[tag]; ok {
+				tagged = true
+				break
+			}
+		}
+		if !tagged {
+			return false
+		}
+	}
+
+	if len(n.keywords) == 0 {
+		return true
+	}
+	text := strings.ToLower(e.Article.Title + " " + e.Article.Description)
+	for _, keyword := range n.keywords {
+		if strings.Contains(text, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// batchMessages раскладывает статьи по сообщениям, не превышающим лимит длины Telegram
+func batchMessages(entries []*domain.DigestEntry) []string {
+	var messages []string
+	var current strings.Builder
+
+	for _, e := range entries {
+		line := fmt.Sprintf("<b>%s</b>: <a href=\"%s\">%s</a>\n",
+			html.EscapeString(e.FeedName), html.EscapeString(e.Article.Link), html.EscapeString(utils.Truncate(e.Article.Title, maxTitleLength)))
+
+		if current.Len()+len(line) > maxMessageLength {
+			messages = append(messages, current.String())
+			current.Reset()
+		}
+		current.WriteString(line)
+	}
+	if current.Len() > 0 {
+		messages = append(messages, current.String())
+	}
+
+	return messages
+}
+
+// send отправляет одно сообщение, соблюдая интервал между сообщениями и retry_after от Telegram
+func (n *Notifier) send(ctx context.Context, text string) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"chat_id":                  n.chatID,
+		"text":                     text,
+		"parse_mode":               "HTML",
+		"disable_web_page_preview": true,
+	})
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := sleep(ctx, time.Until(n.lastSent.Add(n.interval))); err != nil {
+			return err
+		}
+
+		retryAfter, err := n.post(ctx, payload)
+		n.lastSent = time.Now()
+		if err == nil {
+			return nil
+		}
+		if retryAfter == 0 || attempt >= maxRetries {
+			return err
+		}
+
+		logger.Warn("Telegram rate limit exceeded, retrying in %v", retryAfter)
+		if err := sleep(ctx, retryAfter); err != nil {
+			return err
+		}
+	}
+}
+
+// post выполняет запрос sendMessage. При превышении лимита возвращает время ожидания
+func (n *Notifier) post(ctx context.Context, payload []byte) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		// Ошибка содержит URL с токеном бота, поэтому оставляем только причину
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("invalid response (HTTP %d): %w", resp.StatusCode, err)
+	}
+	if result.OK {
+		return 0, nil
+	}
+
+	err = fmt.Errorf("telegram API error (HTTP %d): %s", resp.StatusCode, result.Description)
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter := time.Duration(result.Parameters.RetryAfter) * time.Second
+		if retryAfter <= 0 {
+			retryAfter = time.Second
+		}
+		return retryAfter, err
+	}
+	return 0, err
+}
+
+// sleep ждет d или отмены контекста
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -2,328 +2,2413 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
 	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/secret"
+	"rsshub/internal/platform/urlnorm"
 	"rsshub/internal/platform/utils"
 
-	_ "github.com/lib/pq" // PostgreSQL драйвер
+	"github.com/lib/pq" // PostgreSQL драйвер
+)
+
+// Проверяем на этапе компиляции, что DB реализует порты хранилища
+var (
+	_ port.FeedArticleRepository = (*DB)(nil)
+	_ port.Migrator              = (*DB)(nil)
+	_ port.Maintainer            = (*DB)(nil)
 )
 
 // DB оборачивает sql.DB и предоставляет методы для работы с нашими моделями
 type DB struct {
 	*sql.DB
+	dsn    string         // Строка подключения для отдельного соединения LISTEN (см. ListenSettingsChanges)
+	cipher *secret.Cipher // Шифрование учетных данных лент (nil - не настроено)
+	tx     *sql.Tx        // Транзакция unit of work (nil - запросы выполняются по отдельности), см. WithinTransaction
+}
+
+// querier запросы, общие для соединения, транзакции и точки сохранения
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// savepoint имя точки сохранения операции внутри транзакции unit of work
+const savepoint = "rsshub_operation"
+
+// New создает новое подключение к базе данных
+func New(dsn string, cipher *secret.Cipher) (*DB, error) {
+	// Открываем соединение с PostgreSQL
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Проверяем соединение
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	// Настраиваем пул соединений
+	db.SetMaxOpenConns(25)                 // Максимум открытых соединений
+	db.SetMaxIdleConns(25)                 // Максимум неактивных соединений
+	db.SetConnMaxLifetime(5 * time.Minute) // Время жизни соединения
+
+	logger.Info("Successfully connected to PostgreSQL database")
+
+	return &DB{DB: db, dsn: dsn, cipher: cipher}, nil
+}
+
+// WithinTransaction выполняет fn в одной транзакции: все изменения, сделанные через переданный
+// репозиторий, сохраняются вместе при успехе fn и откатываются при ошибке или панике. Ошибка
+// отдельного запроса прерывает транзакцию в PostgreSQL, поэтому fn должна вернуть ее, а не
+// продолжать; операции из нескольких запросов (CreateArticle и т.п.) откатываются точкой сохранения
+// и транзакцию не прерывают. Внутри уже открытой транзакции fn выполняется в ней же
+func (db *DB) WithinTransaction(ctx context.Context, fn func(repo port.FeedArticleRepository) error) (err error) {
+	if db.tx != nil {
+		return fn(db)
+	}
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(&DB{DB: db.DB, dsn: db.dsn, cipher: db.cipher, tx: tx}); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ExecContext выполняет запрос в транзакции unit of work, если она открыта; отмена ctx
+// прерывает запрос
+func (db *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if db.tx != nil {
+		return db.tx.ExecContext(ctx, query, args...)
+	}
+	return db.DB.ExecContext(ctx, query, args...)
+}
+
+// QueryContext выполняет запрос в транзакции unit of work, если она открыта; отмена ctx
+// прерывает запрос
+func (db *DB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if db.tx != nil {
+		return db.tx.QueryContext(ctx, query, args...)
+	}
+	return db.DB.QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext выполняет запрос в транзакции unit of work, если она открыта; отмена ctx
+// прерывает запрос
+func (db *DB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if db.tx != nil {
+		return db.tx.QueryRowContext(ctx, query, args...)
+	}
+	return db.DB.QueryRowContext(ctx, query, args...)
+}
+
+// operation транзакция операции из нескольких запросов: собственная транзакция или,
+// внутри unit of work, точка сохранения в ее транзакции
+type operation struct {
+	*sql.Tx
+	nested bool // Точка сохранения в транзакции unit of work
+	done   bool
+}
+
+// begin начинает операцию из нескольких запросов. Внутри unit of work ошибка операции
+// откатывает только ее запросы, не прерывая всю транзакцию. Отмена ctx откатывает
+// собственную транзакцию операции
+func (db *DB) begin(ctx context.Context) (*operation, error) {
+	if db.tx == nil {
+		tx, err := db.DB.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		return &operation{Tx: tx}, nil
+	}
+
+	if _, err := db.tx.ExecContext(ctx, `SAVEPOINT `+savepoint); err != nil {
+		return nil, err
+	}
+	return &operation{Tx: db.tx, nested: true}, nil
+}
+
+// Commit сохраняет запросы операции
+func (op *operation) Commit() error {
+	if !op.nested {
+		return op.Tx.Commit()
+	}
+	op.done = true
+	_, err := op.Tx.Exec(`RELEASE SAVEPOINT ` + savepoint)
+	return err
+}
+
+// Rollback отменяет запросы операции, если она не сохранена
+func (op *operation) Rollback() error {
+	if !op.nested {
+		return op.Tx.Rollback()
+	}
+	if op.done {
+		return nil
+	}
+	op.done = true
+	_, err := op.Tx.Exec(`ROLLBACK TO SAVEPOINT ` + savepoint)
+	return err
+}
+
+// uniqueViolation код ошибки PostgreSQL при нарушении уникальности
+const uniqueViolation = "23505"
+
+// isUniqueViolation проверяет, что ошибка вызвана нарушением ограничения уникальности
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
+
+// feedColumns перечисляет колонки ленты в порядке, ожидаемом scanFeed
+const feedColumns = `id, created_at, updated_at, name, url, proxy_url, tls_insecure, headers, credentials, priority, tags, enabled, timeout_ms,
+	mirrors, fetch_failures, active_url, seq, last_error, last_error_at, languages, schedule, next_run_at,
+	failing_since, disabled_reason, disabled_at, folder, interval_ms, etag, last_modified`
+
+// rowScanner общий интерфейс для *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanFeed читает ленту из строки результата запроса
+func (db *DB) scanFeed(row rowScanner) (*domain.Feed, error) {
+	feed := &domain.Feed{}
+	var idFeed string
+	var headers []byte
+	var credentials string
+	var timeoutMs, intervalMs int64
+	var lastErrorAt, nextRunAt, failingSince, disabledAt sql.NullTime
+	var folder sql.NullString
+	err := row.Scan(&idFeed, &feed.CreatedAt, &feed.UpdatedAt, &feed.Name, &feed.URL,
+		&feed.ProxyURL, &feed.TLSInsecure, &headers, &credentials, &feed.Priority, pq.Array(&feed.Tags), &feed.Enabled, &timeoutMs,
+		pq.Array(&feed.Mirrors), &feed.FetchFailures, &feed.ActiveURL, &feed.Seq,
+		&feed.LastError, &lastErrorAt, pq.Array(&feed.Languages), &feed.Schedule, &nextRunAt,
+		&failingSince, &feed.DisabledReason, &disabledAt, &folder, &intervalMs, &feed.Cache.ETag, &feed.Cache.LastModified)
+	if err != nil {
+		return nil, err
+	}
+	feed.Folder = folder.String
+	feed.Timeout = time.Duration(timeoutMs) * time.Millisecond
+	feed.Interval = time.Duration(intervalMs) * time.Millisecond
+	if lastErrorAt.Valid {
+		feed.LastErrorAt = &lastErrorAt.Time
+	}
+	if nextRunAt.Valid {
+		feed.NextRunAt = &nextRunAt.Time
+	}
+	if failingSince.Valid {
+		feed.FailingSince = &failingSince.Time
+	}
+	if disabledAt.Valid {
+		feed.DisabledAt = &disabledAt.Time
+	}
+
+	if feed.Auth, err = db.decryptAuth(credentials); err != nil {
+		return nil, fmt.Errorf("failed to decrypt credentials of feed %s: %w", feed.Name, err)
+	}
+
+	if len(headers) > 0 {
+		if err := json.Unmarshal(headers, &feed.Headers); err != nil {
+			return nil, fmt.Errorf("failed to decode feed headers: %w", err)
+		}
+	}
+
+	feed.ID, err = utils.ParseUUID(idFeed)
+	if err != nil {
+		return nil, fmt.Errorf("UUID error: %v", err)
+	}
+
+	return feed, nil
+}
+
+// scanFeeds читает все ленты из результата запроса
+func (db *DB) scanFeeds(rows *sql.Rows) ([]*domain.Feed, error) {
+	var feeds []*domain.Feed
+	for rows.Next() {
+		feed, err := db.scanFeed(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan feed: %w", err)
+		}
+		feeds = append(feeds, feed)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read feeds: %w", err)
+	}
+
+	return feeds, nil
+}
+
+// encodeHeaders сериализует заголовки ленты для колонки JSONB
+func encodeHeaders(headers map[string]string) ([]byte, error) {
+	if len(headers) == 0 {
+		return []byte(`{}`), nil
+	}
+	data, err := json.Marshal(headers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode feed headers: %w", err)
+	}
+	return data, nil
+}
+
+// encryptAuth шифрует учетные данные ленты; пустые данные хранятся как пустая строка
+func (db *DB) encryptAuth(auth *domain.FeedAuth) (string, error) {
+	if auth.IsZero() {
+		return "", nil
+	}
+	if db.cipher == nil {
+		return "", fmt.Errorf("CLI_APP_SECRET_KEY must be set to store feed credentials")
+	}
+
+	data, err := json.Marshal(auth)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode credentials: %w", err)
+	}
+
+	return db.cipher.Encrypt(data)
+}
+
+// decryptAuth расшифровывает учетные данные ленты
+func (db *DB) decryptAuth(encoded string) (*domain.FeedAuth, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+	if db.cipher == nil {
+		return nil, fmt.Errorf("CLI_APP_SECRET_KEY is not set")
+	}
+
+	data, err := db.cipher.Decrypt(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	auth := &domain.FeedAuth{}
+	if err := json.Unmarshal(data, auth); err != nil {
+		return nil, fmt.Errorf("failed to decode credentials: %w", err)
+	}
+	return auth, nil
+}
+
+// CreateFeed создает новую RSS ленту в базе данных. ID, время создания и номер ленты
+// назначает база данных и записывает в feed
+func (db *DB) CreateFeed(ctx context.Context, feed *domain.Feed) error {
+	headers, err := encodeHeaders(feed.Headers)
+	if err != nil {
+		return err
+	}
+
+	credentials, err := db.encryptAuth(feed.Auth)
+	if err != nil {
+		return err
+	}
+
+	if err := db.createFolder(ctx, feed.Folder); err != nil {
+		return err
+	}
+
+	// SQL запрос для вставки новой ленты
+	query := `
+		INSERT INTO feeds (name, url, proxy_url, tls_insecure, headers, credentials, priority, tags, enabled, timeout_ms, mirrors, languages,
+			schedule, next_run_at, folder, interval_ms)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NULLIF($15, ''), $16)
+		RETURNING id, created_at, updated_at, seq`
+
+	var id string
+	err = db.QueryRowContext(ctx, query, feed.Name, feed.URL,
+		feed.ProxyURL, feed.TLSInsecure, headers, credentials, feed.Priority, pq.Array(feed.Tags), feed.Enabled,
+		feed.Timeout.Milliseconds(), pq.Array(nonNilStrings(feed.Mirrors)), pq.Array(nonNilStrings(feed.Languages)),
+		feed.Schedule, feed.NextRunAt, feed.Folder, feed.Interval.Milliseconds()).Scan(&id, &feed.CreatedAt, &feed.UpdatedAt, &feed.Seq)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: %s", domain.ErrDuplicateFeed, feed.Name)
+		}
+		return fmt.Errorf("failed to create feed: %w", err)
+	}
+
+	if feed.ID, err = utils.ParseUUID(id); err != nil {
+		return fmt.Errorf("UUID error: %v", err)
+	}
+
+	logger.Info("Created new feed: %s (%s)", feed.Name, feed.URL)
+	return nil
+}
+
+// GetFeedByName получает ленту по имени
+func (db *DB) GetFeedByName(ctx context.Context, name string) (*domain.Feed, error) {
+	query := `SELECT ` + feedColumns + ` FROM feeds WHERE name = $1`
+
+	feed, err := db.scanFeed(db.QueryRowContext(ctx, query, name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: %s", domain.ErrFeedNotFound, name)
+		}
+		return nil, fmt.Errorf("failed to get feed: %w", err)
+	}
+
+	return feed, nil
+}
+
+// GetFeedByURL получает ленту с тем же нормализованным URL. Нормализация выполняется в Go,
+// поэтому сравниваются URL всех лент, а найденная лента читается по ID
+func (db *DB) GetFeedByURL(ctx context.Context, url string) (*domain.Feed, error) {
+	rows, err := db.QueryContext(ctx, `SELECT id, url FROM feeds ORDER BY created_at, id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed urls: %w", err)
+	}
+	defer rows.Close()
+
+	key := urlnorm.Key(url)
+	var found string
+	for rows.Next() {
+		var id, feedURL string
+		if err := rows.Scan(&id, &feedURL); err != nil {
+			return nil, fmt.Errorf("failed to scan feed url: %w", err)
+		}
+		if urlnorm.Key(feedURL) == key {
+			found = id
+			break
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get feed urls: %w", err)
+	}
+	rows.Close()
+
+	if found == "" {
+		return nil, fmt.Errorf("%w: %s", domain.ErrFeedNotFound, url)
+	}
+	id, err := utils.ParseUUID(found)
+	if err != nil {
+		return nil, fmt.Errorf("UUID error: %v", err)
+	}
+	return db.GetFeedByID(ctx, id)
+}
+
+// GetFeedByID получает ленту по ID
+func (db *DB) GetFeedByID(ctx context.Context, id utils.UUID) (*domain.Feed, error) {
+	query := `SELECT ` + feedColumns + ` FROM feeds WHERE id = $1`
+
+	feed, err := db.scanFeed(db.QueryRowContext(ctx, query, id.String()))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: %s", domain.ErrFeedNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get feed: %w", err)
+	}
+
+	return feed, nil
+}
+
+// GetAllFeeds получает все ленты, опционально ограничивая количество
+func (db *DB) GetAllFeeds(ctx context.Context, limit int) ([]*domain.Feed, error) {
+	var args []interface{}
+
+	// Сортируем по дате создания (новые сначала)
+	query := `SELECT ` + feedColumns + ` FROM feeds ORDER BY created_at DESC`
+	if limit > 0 {
+		query += ` LIMIT $1`
+		args = append(args, limit)
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feeds: %w", err)
+	}
+	defer rows.Close()
+
+	return db.scanFeeds(rows)
+}
+
+// GetFeedsPage получает страницу лент (новые сначала) после курсора after (nil - первая страница).
+// Keyset пагинация по (created_at, id) не зависит от глубины страницы, в отличие от OFFSET
+func (db *DB) GetFeedsPage(ctx context.Context, after *domain.PageCursor, limit int) ([]*domain.Feed, error) {
+	var afterTime interface{}
+	var afterID interface{}
+	if after != nil {
+		afterTime, afterID = after.Time, after.ID.String()
+	}
+
+	query := `
+		SELECT ` + feedColumns + `
+		FROM feeds
+		WHERE $1::timestamp IS NULL OR (created_at, id) < ($1, $2::uuid)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3`
+
+	rows, err := db.QueryContext(ctx, query, afterTime, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feeds page: %w", err)
+	}
+	defer rows.Close()
+
+	return db.scanFeeds(rows)
+}
+
+// staleFeedCondition отбирает ленты, которые пора получать (см. domain.Feed.IsStale): $N - текущее
+// время, $N+1 - staleAfter в секундах для лент без своего интервала. Текущее время передается
+// из Go, так же как записывается updated_at (UpdateFeedTimestamp)
+func staleFeedCondition(n int) string {
+	return fmt.Sprintf(`(updated_at = created_at OR updated_at <= $%d::timestamp -
+		CASE WHEN interval_ms > 0 THEN interval_ms / 1000.0 ELSE $%d END * INTERVAL '1 second')`, n, n+1)
+}
+
+// GetOldestFeeds получает до limit (<= 0 - все) самых устаревших включенных лент, которые пора
+// получать по их интервалу или staleAfter.
+// Ленты с более высоким приоритетом идут первыми, внутри приоритета - самые устаревшие
+func (db *DB) GetOldestFeeds(ctx context.Context, limit int, staleAfter time.Duration) ([]*domain.Feed, error) {
+	query := `
+		SELECT ` + feedColumns + `
+		FROM feeds
+		WHERE enabled AND ` + staleFeedCondition(2) + `
+		ORDER BY priority DESC, updated_at ASC
+		LIMIT $1`
+
+	// LIMIT NULL в PostgreSQL означает отсутствие ограничения
+	var limitArg interface{}
+	if limit > 0 {
+		limitArg = limit
+	}
+
+	rows, err := db.QueryContext(ctx, query, limitArg, time.Now(), staleAfter.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oldest feeds: %w", err)
+	}
+	defer rows.Close()
+
+	return db.scanFeeds(rows)
+}
+
+// ClaimFeeds атомарно резервирует до limit (<= 0 - все) самых устаревших включенных лент за экземпляром агрегатора.
+// FOR UPDATE SKIP LOCKED гарантирует, что конкурирующие экземпляры получат разные ленты,
+// а аренда (lease) освобождает ленты упавших экземпляров. Ленты, с получения которых не прошел
+// их интервал или staleAfter (например, полученные WebSub или fetch --once), не резервируются
+func (db *DB) ClaimFeeds(ctx context.Context, owner string, limit int, lease, staleAfter time.Duration) ([]*domain.Feed, error) {
+	query := `
+		WITH due AS (
+			SELECT id
+			FROM feeds
+			WHERE enabled AND (claimed_until IS NULL OR claimed_until < NOW())
+				AND (next_run_at IS NULL OR next_run_at <= NOW())
+				AND ` + staleFeedCondition(4) + `
+			ORDER BY priority DESC, updated_at ASC
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE feeds f
+		SET claimed_by = $2, claimed_until = NOW() + $3 * INTERVAL '1 second'
+		FROM due
+		WHERE f.id = due.id
+		RETURNING ` + prefixColumns("f", feedColumns)
+
+	// LIMIT NULL в PostgreSQL означает отсутствие ограничения
+	var limitArg interface{}
+	if limit > 0 {
+		limitArg = limit
+	}
+
+	rows, err := db.QueryContext(ctx, query, limitArg, owner, lease.Seconds(), time.Now(), staleAfter.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim feeds: %w", err)
+	}
+	defer rows.Close()
+
+	feeds, err := db.scanFeeds(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// UPDATE ... RETURNING не сохраняет порядок подзапроса
+	sort.SliceStable(feeds, func(i, j int) bool {
+		if feeds[i].Priority != feeds[j].Priority {
+			return feeds[i].Priority > feeds[j].Priority
+		}
+		return feeds[i].UpdatedAt.Before(feeds[j].UpdatedAt)
+	})
+
+	return feeds, nil
+}
+
+// ReleaseFeedClaim снимает резервирование ленты без обновления времени получения
+func (db *DB) ReleaseFeedClaim(ctx context.Context, feedID utils.UUID) error {
+	query := `UPDATE feeds SET claimed_by = NULL, claimed_until = NULL WHERE id = $1`
+
+	if _, err := db.ExecContext(ctx, query, feedID.String()); err != nil {
+		return fmt.Errorf("failed to release feed claim: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimFeed резервирует ленту за владельцем, если ее не резервировал другой экземпляр или воркер
+func (db *DB) ClaimFeed(ctx context.Context, feedID utils.UUID, owner string, lease time.Duration) (bool, error) {
+	query := `
+		UPDATE feeds
+		SET claimed_by = $2, claimed_until = NOW() + $3 * INTERVAL '1 second'
+		WHERE id = $1 AND (claimed_until IS NULL OR claimed_until < NOW())`
+
+	result, err := db.ExecContext(ctx, query, feedID.String(), owner, lease.Seconds())
+	if err != nil {
+		return false, fmt.Errorf("failed to claim feed: %w", err)
+	}
+	claimed, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim feed: %w", err)
+	}
+	return claimed > 0, nil
+}
+
+// prefixColumns добавляет псевдоним таблицы к списку колонок
+func prefixColumns(alias, columns string) string {
+	parts := strings.Split(columns, ",")
+	for i, column := range parts {
+		parts[i] = alias + "." + strings.TrimSpace(column)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// UpdateFeedTimestamp обновляет время последнего обновления ленты и снимает ее резервирование
+func (db *DB) UpdateFeedTimestamp(ctx context.Context, feedID utils.UUID) error {
+	query := `UPDATE feeds SET updated_at = $1, claimed_by = NULL, claimed_until = NULL WHERE id = $2`
+
+	_, err := db.ExecContext(ctx, query, time.Now(), feedID.String())
+	if err != nil {
+		return fmt.Errorf("failed to update feed timestamp: %w", err)
+	}
+
+	return nil
+}
+
+// SetFeedCache сохраняет валидаторы кеша последнего ответа ленты
+func (db *DB) SetFeedCache(ctx context.Context, feedID utils.UUID, cache domain.CacheValidators) error {
+	query := `UPDATE feeds SET etag = $1, last_modified = $2 WHERE id = $3`
+
+	if _, err := db.ExecContext(ctx, query, cache.ETag, cache.LastModified, feedID.String()); err != nil {
+		return fmt.Errorf("failed to save feed cache validators: %w", err)
+	}
+	return nil
+}
+
+// SetFeedNextRun сохраняет время следующего запуска ленты по расписанию (nil - без расписания)
+func (db *DB) SetFeedNextRun(ctx context.Context, feedID utils.UUID, next *time.Time) error {
+	if _, err := db.ExecContext(ctx, `UPDATE feeds SET next_run_at = $1 WHERE id = $2`, next, feedID.String()); err != nil {
+		return fmt.Errorf("failed to save next run of feed: %w", err)
+	}
+	return nil
+}
+
+// UpdateFeed сохраняет изменяемые поля ленты (имя, URL, настройки, теги, включенность, таймаут, зеркала,
+// расписание) по ее ID. Включение отключенной ленты сбрасывает причину отключения и серию неудач.
+// Время получения (updated_at) не меняется, чтобы не нарушать расписание обновлений; смена URL
+// сбрасывает валидаторы кеша, полученные с прежнего адреса
+func (db *DB) UpdateFeed(ctx context.Context, feed *domain.Feed) error {
+	headers, err := encodeHeaders(feed.Headers)
+	if err != nil {
+		return err
+	}
+
+	credentials, err := db.encryptAuth(feed.Auth)
+	if err != nil {
+		return err
+	}
+
+	if err := db.createFolder(ctx, feed.Folder); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE feeds
+		SET name = $1, url = $2, proxy_url = $3, tls_insecure = $4, headers = $5,
+			credentials = $6, priority = $7, tags = $8, enabled = $9, timeout_ms = $10, mirrors = $11, languages = $12,
+			schedule = $13, next_run_at = $14, folder = NULLIF($16, ''), interval_ms = $17,
+			failing_since = CASE WHEN $9 AND NOT enabled THEN NULL ELSE failing_since END,
+			disabled_reason = CASE WHEN $9 THEN '' ELSE disabled_reason END,
+			disabled_at = CASE WHEN $9 THEN NULL ELSE disabled_at END,
+			etag = CASE WHEN url = $2 THEN etag ELSE '' END,
+			last_modified = CASE WHEN url = $2 THEN last_modified ELSE '' END
+		WHERE id = $15`
+
+	result, err := db.ExecContext(ctx, query, feed.Name, feed.URL, feed.ProxyURL, feed.TLSInsecure, headers,
+		credentials, feed.Priority, pq.Array(feed.Tags), feed.Enabled, feed.Timeout.Milliseconds(),
+		pq.Array(nonNilStrings(feed.Mirrors)), pq.Array(nonNilStrings(feed.Languages)),
+		feed.Schedule, feed.NextRunAt, feed.ID.String(), feed.Folder, feed.Interval.Milliseconds())
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: %s", domain.ErrDuplicateFeed, feed.Name)
+		}
+		return fmt.Errorf("failed to update feed: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrFeedNotFound, feed.Name)
+	}
+
+	logger.Info("Updated feed: %s (%s)", feed.Name, feed.URL)
+	return nil
+}
+
+// createFolder добавляет папку и все ее родительские папки, которых еще нет
+func (db *DB) createFolder(ctx context.Context, path string) error {
+	if path == "" {
+		return nil
+	}
+
+	query := `INSERT INTO folders (path) SELECT unnest($1::text[]) ON CONFLICT (path) DO NOTHING`
+	if _, err := db.ExecContext(ctx, query, pq.Array(domain.FolderAncestors(path))); err != nil {
+		return fmt.Errorf("failed to create folder %s: %w", path, err)
+	}
+	return nil
+}
+
+// GetFolders возвращает пути всех папок, включая папки без лент, по алфавиту
+func (db *DB) GetFolders(ctx context.Context) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `SELECT path FROM folders ORDER BY path`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get folders: %w", err)
+	}
+	defer rows.Close()
+
+	var folders []string
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, fmt.Errorf("failed to scan folder: %w", err)
+		}
+		folders = append(folders, path)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read folders: %w", err)
+	}
+	return folders, nil
+}
+
+// SetFeedFetchState сохраняет число неудач основного URL подряд и зеркало, с которого получена лента
+func (db *DB) SetFeedFetchState(ctx context.Context, feedID utils.UUID, failures int, activeURL string) error {
+	query := `UPDATE feeds SET fetch_failures = $1, active_url = $2 WHERE id = $3`
+
+	if _, err := db.ExecContext(ctx, query, failures, activeURL, feedID.String()); err != nil {
+		return fmt.Errorf("failed to update feed fetch state: %w", err)
+	}
+	return nil
+}
+
+// SetFeedLastError сохраняет последнюю ошибку обработки ленты; пустое сообщение ее сбрасывает.
+// Первая ошибка после успешной обработки начинает серию неудач (failing_since)
+func (db *DB) SetFeedLastError(ctx context.Context, feedID utils.UUID, message string) error {
+	query := `
+		UPDATE feeds
+		SET last_error = $1,
+			last_error_at = CASE WHEN $1 = '' THEN NULL ELSE NOW() END,
+			failing_since = CASE WHEN $1 = '' THEN NULL ELSE COALESCE(failing_since, NOW()) END
+		WHERE id = $2`
+
+	if _, err := db.ExecContext(ctx, query, message, feedID.String()); err != nil {
+		return fmt.Errorf("failed to update feed last error: %w", err)
+	}
+	return nil
+}
+
+// DisableFeed отключает мертвую ленту, сохраняя причину и время отключения
+func (db *DB) DisableFeed(ctx context.Context, feedID utils.UUID, reason string) error {
+	query := `UPDATE feeds SET enabled = FALSE, disabled_reason = $1, disabled_at = NOW() WHERE id = $2`
+
+	result, err := db.ExecContext(ctx, query, reason, feedID.String())
+	if err != nil {
+		return fmt.Errorf("failed to disable feed: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrFeedNotFound, feedID)
+	}
+	return nil
+}
+
+// nonNilStrings заменяет nil пустым срезом: колонки TEXT[] объявлены NOT NULL
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// DeleteFeed удаляет ленту по имени
+func (db *DB) DeleteFeed(ctx context.Context, name string) error {
+	// Сначала проверяем, существует ли лента
+	_, err := db.GetFeedByName(ctx, name)
+	if err != nil {
+		return err // Лента не найдена или другая ошибка
+	}
+
+	query := `DELETE FROM feeds WHERE name = $1`
+
+	result, err := db.ExecContext(ctx, query, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete feed: %w", err)
+	}
+
+	// Проверяем, что что-то было удалено
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("no feed was deleted")
+	}
+
+	logger.Info("Deleted feed: %s", name)
+	return nil
+}
+
+// DeleteFeeds удаляет ленты с указанными именами в одной транзакции. Если какой-то
+// ленты уже нет, ничего не удаляется и возвращается domain.ErrFeedNotFound
+func (db *DB) DeleteFeeds(ctx context.Context, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `DELETE FROM feeds WHERE name = ANY($1) RETURNING name`, pq.Array(names))
+	if err != nil {
+		return fmt.Errorf("failed to delete feeds: %w", err)
+	}
+
+	deleted := make(map[string]bool, len(names))
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan deleted feed: %w", err)
+		}
+		deleted[name] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to delete feeds: %w", err)
+	}
+
+	for _, name := range names {
+		if !deleted[name] {
+			return fmt.Errorf("%w: %s", domain.ErrFeedNotFound, name)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit feeds deletion: %w", err)
+	}
+
+	logger.Info("Deleted %d feeds", len(names))
+	return nil
+}
+
+// articleConflictUpdate обновляет содержимое статьи той же ленты, если хеш изменился; статьи,
+// сохраненные до появления хеша, обновляются без отметки об изменении (как в агрегаторе)
+const articleConflictUpdate = `
+	ON CONFLICT (dedup_key) DO UPDATE
+	SET title = EXCLUDED.title, description = EXCLUDED.description, content_hash = EXCLUDED.content_hash,
+		modified_at = CASE WHEN articles.content_hash <> '' THEN NOW() ELSE articles.modified_at END,
+		updated_at = NOW()
+	WHERE articles.feed_id = EXCLUDED.feed_id AND articles.content_hash <> EXCLUDED.content_hash`
+
+// CreateArticle создает новую статью в базе данных. ID, время создания и номер статьи
+// назначает база данных и записывает в article. Добавленную строку от обновленной отличает
+// xmax: у только что вставленной строки он равен 0
+func (db *DB) CreateArticle(ctx context.Context, article *domain.Article, onConflict domain.ConflictMode) (domain.InsertResult, error) {
+	// Без явного ключа статья уникальна по ссылке
+	if article.DedupKey == "" {
+		article.DedupKey = domain.ArticleDedupKey(domain.DedupByLink, article.FeedID, article.GUID, article.Link)
+	}
+
+	conflict := `ON CONFLICT (dedup_key) DO NOTHING`
+	if onConflict == domain.ConflictUpdate {
+		conflict = articleConflictUpdate
+	}
+
+	query := `
+		INSERT INTO articles (title, link, published_at, description, feed_id, guid, dedup_key, content_hash,
+			itunes_author, itunes_duration, itunes_image, itunes_episode, lang)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8,
+			NULLIF($9, ''), NULLIF($10, 0), NULLIF($11, ''), NULLIF($12, 0), $13)
+		` + conflict + `
+		RETURNING id, created_at, updated_at, seq, modified_at, (xmax = 0)`
+
+	podcast := domain.PodcastInfo{}
+	if article.Podcast != nil {
+		podcast = *article.Podcast
+	}
+
+	// Статья и ее вложения сохраняются вместе
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id string
+	var inserted bool
+	err = tx.QueryRowContext(ctx, query,
+		article.Title, article.Link, article.PublishedAt,
+		article.Description, article.FeedID.String(), article.GUID, article.DedupKey, article.ContentHash,
+		podcast.Author, podcast.Duration, podcast.Image, podcast.Episode, article.Language).Scan(
+		&id, &article.CreatedAt, &article.UpdatedAt, &article.Seq, &article.ModifiedAt, &inserted)
+	if err != nil {
+		// Конфликт без обновления не вставляет и не возвращает строку
+		if err == sql.ErrNoRows {
+			return domain.ArticleSkipped, nil
+		}
+		return "", fmt.Errorf("failed to create article: %w", err)
+	}
+
+	if article.ID, err = utils.ParseUUID(id); err != nil {
+		return "", fmt.Errorf("failed parsing article ID: %w", err)
+	}
+
+	if err := saveArticleVersion(ctx, tx, article); err != nil {
+		return "", err
+	}
+
+	// Вложения обновленной статьи остаются прежними
+	result := domain.ArticleUpdated
+	if inserted {
+		result = domain.ArticleInserted
+		if err := saveArticleMedia(ctx, tx, article); err != nil {
+			return "", err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to commit article: %w", err)
+	}
+
+	return result, nil
+}
+
+// RestoreArticle сохраняет статью из резервной копии с ее временем сохранения и состоянием
+func (db *DB) RestoreArticle(ctx context.Context, article *domain.Article) (domain.InsertResult, error) {
+	if article.DedupKey == "" {
+		article.DedupKey = domain.ArticleDedupKey(domain.DedupByLink, article.FeedID, article.GUID, article.Link)
+	}
+	if article.CreatedAt.IsZero() {
+		article.CreatedAt = time.Now()
+	}
+	if article.UpdatedAt.IsZero() {
+		article.UpdatedAt = article.CreatedAt
+	}
+
+	query := `
+		INSERT INTO articles (title, link, published_at, description, feed_id, guid, dedup_key, content_hash,
+			itunes_author, itunes_duration, itunes_image, itunes_episode, lang,
+			created_at, updated_at, read_at, saved_at, modified_at)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8,
+			NULLIF($9, ''), NULLIF($10, 0), NULLIF($11, ''), NULLIF($12, 0), $13,
+			$14, $15, $16, $17, $18)
+		ON CONFLICT (dedup_key) DO NOTHING
+		RETURNING id, seq`
+
+	podcast := domain.PodcastInfo{}
+	if article.Podcast != nil {
+		podcast = *article.Podcast
+	}
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id string
+	err = tx.QueryRowContext(ctx, query,
+		article.Title, article.Link, article.PublishedAt,
+		article.Description, article.FeedID.String(), article.GUID, article.DedupKey, article.ContentHash,
+		podcast.Author, podcast.Duration, podcast.Image, podcast.Episode, article.Language,
+		article.CreatedAt, article.UpdatedAt, article.ReadAt, article.SavedAt, article.ModifiedAt).Scan(&id, &article.Seq)
+	if err == sql.ErrNoRows {
+		return domain.ArticleSkipped, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to restore article: %w", err)
+	}
+
+	if article.ID, err = utils.ParseUUID(id); err != nil {
+		return "", fmt.Errorf("failed parsing article ID: %w", err)
+	}
+	if err := saveArticleVersion(ctx, tx, article); err != nil {
+		return "", err
+	}
+	if err := saveArticleMedia(ctx, tx, article); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to commit article: %w", err)
+	}
+	return domain.ArticleInserted, nil
+}
+
+// saveArticleMedia сохраняет вложения Media RSS добавленной статьи в порядке ленты
+func saveArticleMedia(ctx context.Context, tx querier, article *domain.Article) error {
+	for i, m := range article.Media {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO article_media (article_id, position, kind, url, type, medium, width, height)
+			VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, 0), NULLIF($8, 0))`,
+			article.ID.String(), i, string(m.Kind), m.URL, m.Type, m.Medium, m.Width, m.Height)
+		if err != nil {
+			return fmt.Errorf("failed to save article media: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetArticlesByFeedName получает статьи для конкретной ленты по имени
+func (db *DB) GetArticlesByFeedName(ctx context.Context, feedName string, limit int) ([]*domain.Article, error) {
+	if limit <= 0 {
+		limit = 3 // Значение по умолчанию
+	}
+
+	query := `
+		SELECT ` + prefixColumns("a", articleColumns) + `
+		FROM articles a
+		JOIN feeds f ON a.feed_id = f.id
+		WHERE f.name = $1
+		ORDER BY a.published_at DESC
+		LIMIT $2`
+
+	rows, err := db.QueryContext(ctx, query, feedName, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get articles: %w", err)
+	}
+	defer rows.Close()
+
+	return scanArticles(rows)
+}
+
+// GetArticlesPage получает страницу статей ленты (новые сначала) после курсора after (nil - первая страница).
+// Сортировка по (published_at, id) делает порядок однозначным при одинаковых датах публикации
+func (db *DB) GetArticlesPage(ctx context.Context, feedName string, after *domain.PageCursor, limit int) ([]*domain.Article, error) {
+	var afterTime interface{}
+	var afterID interface{}
+	if after != nil {
+		afterTime, afterID = after.Time, after.ID.String()
+	}
+
+	query := `
+		SELECT ` + prefixColumns("a", articleColumns) + `
+		FROM articles a
+		JOIN feeds f ON a.feed_id = f.id
+		WHERE f.name = $1
+			AND ($2::timestamptz IS NULL OR (a.published_at, a.id) < ($2, $3::uuid))
+		ORDER BY a.published_at DESC, a.id DESC
+		LIMIT $4`
+
+	rows, err := db.QueryContext(ctx, query, feedName, afterTime, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get articles page: %w", err)
+	}
+	defer rows.Close()
+
+	articles, err := scanArticles(rows)
+	if err != nil {
+		return nil, err
+	}
+	return articles, db.attachMedia(ctx, articles)
+}
+
+// GetUpdatedArticlesPage получает страницу статей ленты, измененных лентой после сохранения
+func (db *DB) GetUpdatedArticlesPage(ctx context.Context, feedName string, after *domain.PageCursor, limit int) ([]*domain.Article, error) {
+	var afterTime interface{}
+	var afterID interface{}
+	if after != nil {
+		afterTime, afterID = after.Time, after.ID.String()
+	}
+
+	query := `
+		SELECT ` + prefixColumns("a", articleColumns) + `
+		FROM articles a
+		JOIN feeds f ON a.feed_id = f.id
+		WHERE f.name = $1 AND a.modified_at IS NOT NULL
+			AND ($2::timestamptz IS NULL OR (a.published_at, a.id) < ($2, $3::uuid))
+		ORDER BY a.published_at DESC, a.id DESC
+		LIMIT $4`
+
+	rows, err := db.QueryContext(ctx, query, feedName, afterTime, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated articles page: %w", err)
+	}
+	defer rows.Close()
+
+	articles, err := scanArticles(rows)
+	if err != nil {
+		return nil, err
+	}
+	return articles, db.attachMedia(ctx, articles)
+}
+
+// articleColumns перечисляет колонки статьи в порядке, ожидаемом scanArticle
+const articleColumns = `id, created_at, updated_at, title, link, published_at, description, feed_id, read_at, guid, content_hash, modified_at,
+	itunes_author, itunes_duration, itunes_image, itunes_episode, saved_at, seq, lang`
+
+// scanArticle читает статью из строки результата запроса.
+// extra - приемники для дополнительных колонок, выбранных после articleColumns
+func scanArticle(row rowScanner, extra ...interface{}) (*domain.Article, error) {
+	article := &domain.Article{}
+	var articleID, feedID string
+	var readAt, modifiedAt, savedAt sql.NullTime
+	var guid, itunesAuthor, itunesImage sql.NullString
+	var itunesDuration, itunesEpisode sql.NullInt64
+
+	dest := []interface{}{
+		&articleID, &article.CreatedAt, &article.UpdatedAt,
+		&article.Title, &article.Link, &article.PublishedAt,
+		&article.Description, &feedID, &readAt, &guid,
+		&article.ContentHash, &modifiedAt,
+		&itunesAuthor, &itunesDuration, &itunesImage, &itunesEpisode,
+		&savedAt, &article.Seq, &article.Language,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	article.ID, err = utils.ParseUUID(articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing article ID: %w", err)
+	}
+
+	article.FeedID, err = utils.ParseUUID(feedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing feed ID: %w", err)
+	}
+
+	if readAt.Valid {
+		article.ReadAt = &readAt.Time
+	}
+	if modifiedAt.Valid {
+		article.ModifiedAt = &modifiedAt.Time
+	}
+	if savedAt.Valid {
+		article.SavedAt = &savedAt.Time
+	}
+	article.GUID = guid.String
+
+	podcast := &domain.PodcastInfo{
+		Author:   itunesAuthor.String,
+		Duration: int(itunesDuration.Int64),
+		Image:    itunesImage.String,
+		Episode:  int(itunesEpisode.Int64),
+	}
+	if !podcast.IsZero() {
+		article.Podcast = podcast
+	}
+
+	return article, nil
+}
+
+// scanArticles читает все статьи из результата запроса
+func scanArticles(rows *sql.Rows) ([]*domain.Article, error) {
+	var articles []*domain.Article
+	for rows.Next() {
+		article, err := scanArticle(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan article: %w", err)
+		}
+		articles = append(articles, article)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read articles: %w", err)
+	}
+
+	return articles, nil
+}
+
+// attachMedia загружает вложения Media RSS для статей одним запросом
+func (db *DB) attachMedia(ctx context.Context, articles []*domain.Article) error {
+	if len(articles) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*domain.Article, len(articles))
+	ids := make([]string, 0, len(articles))
+	for _, article := range articles {
+		byID[article.ID.String()] = article
+		ids = append(ids, article.ID.String())
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT article_id, kind, url, type, medium, width, height
+		FROM article_media
+		WHERE article_id = ANY($1::uuid[])
+		ORDER BY article_id, position`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to get article media: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var articleID, kind, url string
+		var mediaType, medium sql.NullString
+		var width, height sql.NullInt64
+		if err := rows.Scan(&articleID, &kind, &url, &mediaType, &medium, &width, &height); err != nil {
+			return fmt.Errorf("failed to scan article media: %w", err)
+		}
+
+		if article, ok := byID[articleID]; ok {
+			article.Media = append(article.Media, domain.MediaItem{
+				Kind:   domain.MediaKind(kind),
+				URL:    url,
+				Type:   mediaType.String,
+				Medium: medium.String,
+				Width:  int(width.Int64),
+				Height: int(height.Int64),
+			})
+		}
+	}
+	return rows.Err()
+}
+
+// MarkArticleRead отмечает статью как прочитанную
+func (db *DB) MarkArticleRead(ctx context.Context, articleID utils.UUID) error {
+	query := `UPDATE articles SET read_at = NOW() WHERE id = $1 AND read_at IS NULL`
+
+	if _, err := db.ExecContext(ctx, query, articleID.String()); err != nil {
+		return fmt.Errorf("failed to mark article as read: %w", err)
+	}
+
+	return nil
+}
+
+// GetArticlesBySeq выбирает статьи по номерам: после SinceSeq по возрастанию, до MaxSeq по убыванию
+// или перечисленные в Seqs
+func (db *DB) GetArticlesBySeq(ctx context.Context, filter domain.ArticleSeqFilter) ([]*domain.Article, error) {
+	var where, order string
+	var arg interface{}
+	switch {
+	case len(filter.Seqs) > 0:
+		where, order, arg = "seq = ANY($1::bigint[])", "seq ASC", pq.Array(filter.Seqs)
+	case filter.MaxSeq > 0:
+		where, order, arg = "seq < $1", "seq DESC", filter.MaxSeq
+	default:
+		where, order, arg = "seq > $1", "seq ASC", filter.SinceSeq
+	}
+
+	query := `SELECT ` + articleColumns + ` FROM articles WHERE ` + where + ` ORDER BY ` + order + ` LIMIT $2`
+
+	// LIMIT NULL в PostgreSQL означает отсутствие ограничения
+	var limit interface{}
+	if filter.Limit > 0 {
+		limit = filter.Limit
+	}
+
+	rows, err := db.QueryContext(ctx, query, arg, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get articles by seq: %w", err)
+	}
+	defer rows.Close()
+
+	articles, err := scanArticles(rows)
+	if err != nil {
+		return nil, err
+	}
+	return articles, db.attachMedia(ctx, articles)
+}
+
+// GetArticleSeqs возвращает номера непрочитанных или избранных статей по возрастанию
+func (db *DB) GetArticleSeqs(ctx context.Context, state domain.ArticleState) ([]int64, error) {
+	var query string
+	switch state {
+	case domain.ArticleUnread:
+		query = `SELECT seq FROM articles WHERE read_at IS NULL ORDER BY seq`
+	case domain.ArticleSaved:
+		query = `SELECT seq FROM articles WHERE saved_at IS NOT NULL ORDER BY seq`
+	default:
+		return nil, fmt.Errorf("unknown article state: %s", state)
+	}
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s article seqs: %w", state, err)
+	}
+	defer rows.Close()
+
+	var seqs []int64
+	for rows.Next() {
+		var seq int64
+		if err := rows.Scan(&seq); err != nil {
+			return nil, fmt.Errorf("failed to scan article seq: %w", err)
+		}
+		seqs = append(seqs, seq)
+	}
+
+	return seqs, rows.Err()
+}
+
+// MarkArticlesBySeq меняет состояние статей с перечисленными номерами
+func (db *DB) MarkArticlesBySeq(ctx context.Context, seqs []int64, mark domain.ArticleMark) error {
+	var set string
+	switch mark {
+	case domain.MarkRead:
+		set = "read_at = COALESCE(read_at, NOW())"
+	case domain.MarkUnread:
+		set = "read_at = NULL"
+	case domain.MarkSaved:
+		set = "saved_at = COALESCE(saved_at, NOW())"
+	case domain.MarkUnsaved:
+		set = "saved_at = NULL"
+	default:
+		return fmt.Errorf("unknown article mark: %s", mark)
+	}
+
+	query := `UPDATE articles SET ` + set + ` WHERE seq = ANY($1::bigint[])`
+
+	if _, err := db.ExecContext(ctx, query, pq.Array(seqs)); err != nil {
+		return fmt.Errorf("failed to mark articles as %s: %w", mark, err)
+	}
+
+	return nil
+}
+
+// MarkFeedsReadBefore отмечает прочитанными статьи лент, сохраненные не позже before
+func (db *DB) MarkFeedsReadBefore(ctx context.Context, feedIDs []utils.UUID, before time.Time) error {
+	ids := make([]string, 0, len(feedIDs))
+	for _, id := range feedIDs {
+		ids = append(ids, id.String())
+	}
+
+	query := `
+		UPDATE articles SET read_at = NOW()
+		WHERE feed_id = ANY($1::uuid[]) AND read_at IS NULL AND created_at <= $2`
+
+	if _, err := db.ExecContext(ctx, query, pq.Array(ids), before); err != nil {
+		return fmt.Errorf("failed to mark feeds as read: %w", err)
+	}
+
+	return nil
+}
+
+// GetArticleByKey ищет статью по ключу уникальности или URL; совпадение по ключу важнее.
+// Поиск по URL нужен для статей, сохраненных до появления GUID (их ключ - ссылка), поэтому
+// статьи с ключом по GUID по ссылке не находятся: лента может публиковать под одной ссылкой
+// разные элементы
+func (db *DB) GetArticleByKey(ctx context.Context, feedID utils.UUID, dedupKey, link string) (*domain.Article, error) {
+	query := `
+		SELECT ` + articleColumns + `
+		FROM articles
+		WHERE dedup_key = $1 OR (feed_id = $3 AND link = $2 AND dedup_key LIKE 'link:%')
+		ORDER BY (dedup_key = $1) DESC
+		LIMIT 1`
+
+	article, err := scanArticle(db.QueryRowContext(ctx, query, dedupKey, link, feedID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: %s", domain.ErrArticleNotFound, link)
+		}
+		return nil, fmt.Errorf("failed to get article: %w", err)
+	}
+
+	return article, nil
+}
+
+// UpdateArticleContent сохраняет измененное лентой содержимое статьи и обновляет updated_at
+func (db *DB) UpdateArticleContent(ctx context.Context, article *domain.Article) error {
+	query := `
+		UPDATE articles
+		SET title = $2, description = $3, content_hash = $4, modified_at = $5, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at`
+
+	// Статья и ее новая версия сохраняются вместе
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, query, article.ID.String(), article.Title, article.Description,
+		article.ContentHash, article.ModifiedAt).Scan(&article.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%w: %s", domain.ErrArticleNotFound, article.ID)
+		}
+		return fmt.Errorf("failed to update article: %w", err)
+	}
+
+	if err := saveArticleVersion(ctx, tx, article); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit article: %w", err)
+	}
+
+	return nil
+}
+
+// saveArticleVersion сохраняет текущее содержимое статьи как ее версию. Версия с тем же
+// хешем сохраняется один раз: при возврате к прежнему содержимому новая версия не появляется.
+// Статьи без хеша (сохраненные до его появления) версий не получают
+func saveArticleVersion(ctx context.Context, tx querier, article *domain.Article) error {
+	if article.ContentHash == "" {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO article_versions (article_id, content_hash, title, description)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (article_id, content_hash) DO NOTHING`,
+		article.ID.String(), article.ContentHash, article.Title, article.Description)
+	if err != nil {
+		return fmt.Errorf("failed to save article version: %w", err)
+	}
+	return nil
+}
+
+// GetArticlesByLink возвращает статьи всех лент с этой ссылкой, новые первыми
+func (db *DB) GetArticlesByLink(ctx context.Context, link string) ([]*domain.DigestEntry, error) {
+	query := `
+		SELECT ` + prefixColumns("a", articleColumns) + `, f.name, f.tags, COALESCE(f.folder, '')
+		FROM articles a
+		JOIN feeds f ON a.feed_id = f.id
+		WHERE a.link = $1
+		ORDER BY a.created_at DESC`
+
+	rows, err := db.QueryContext(ctx, query, link)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get articles by link: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*domain.DigestEntry
+	for rows.Next() {
+		entry := &domain.DigestEntry{}
+		article, err := scanArticle(rows, &entry.FeedName, pq.Array(&entry.FeedTags), &entry.FeedFolder)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan article: %w", err)
+		}
+
+		entry.Article = article
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read articles: %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetRelatedArticles ищет статьи, похожие на articleID, полнотекстовым поиском: запрос состоит
+// из слов заголовка статьи (после стемминга, через ИЛИ), статьи упорядочены по ts_rank
+// с учетом весов заголовка и описания
+func (db *DB) GetRelatedArticles(ctx context.Context, articleID utils.UUID, limit int) ([]*domain.DigestEntry, error) {
+	query := `
+		WITH q AS (
+			SELECT to_tsquery('simple', string_agg(quote_literal(t.lexeme), ' | ')) AS query
+			FROM articles src, unnest(ts_filter(src.search_vector, '{a}')) AS t
+			WHERE src.id = $1
+		)
+		SELECT ` + prefixColumns("a", articleColumns) + `, f.name, f.tags, COALESCE(f.folder, '')
+		FROM articles a
+		JOIN feeds f ON a.feed_id = f.id
+		CROSS JOIN q
+		WHERE a.id <> $1 AND a.search_vector @@ q.query
+		ORDER BY ts_rank(a.search_vector, q.query) DESC, a.published_at DESC
+		LIMIT $2`
+
+	rows, err := db.QueryContext(ctx, query, articleID.String(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get related articles: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*domain.DigestEntry
+	for rows.Next() {
+		entry := &domain.DigestEntry{}
+		article, err := scanArticle(rows, &entry.FeedName, pq.Array(&entry.FeedTags), &entry.FeedFolder)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan article: %w", err)
+		}
+
+		entry.Article = article
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read articles: %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetArticleVersions возвращает версии содержимого статьи в порядке получения
+func (db *DB) GetArticleVersions(ctx context.Context, articleID utils.UUID) ([]*domain.ArticleVersion, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT content_hash, title, description, created_at
+		FROM article_versions
+		WHERE article_id = $1
+		ORDER BY created_at ASC`, articleID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get article versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []*domain.ArticleVersion
+	for rows.Next() {
+		version := &domain.ArticleVersion{ArticleID: articleID}
+		if err := rows.Scan(&version.ContentHash, &version.Title, &version.Description, &version.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan article version: %w", err)
+		}
+		versions = append(versions, version)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read article versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// GetArticlesSince возвращает статьи, добавленные начиная с since, вместе с именем и тегами ленты.
+// Используется для дайджестов; limit <= 0 - без ограничения
+func (db *DB) GetArticlesSince(ctx context.Context, since time.Time, limit int) ([]*domain.DigestEntry, error) {
+	query := `
+		SELECT ` + prefixColumns("a", articleColumns) + `, f.name, f.tags, COALESCE(f.folder, '')
+		FROM articles a
+		JOIN feeds f ON a.feed_id = f.id
+		WHERE a.created_at >= $1
+		ORDER BY a.created_at DESC
+		LIMIT $2`
+
+	// LIMIT NULL в PostgreSQL означает отсутствие ограничения
+	var limitArg interface{}
+	if limit > 0 {
+		limitArg = limit
+	}
+
+	rows, err := db.QueryContext(ctx, query, since, limitArg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get articles since %v: %w", since, err)
+	}
+	defer rows.Close()
+
+	var entries []*domain.DigestEntry
+	for rows.Next() {
+		entry := &domain.DigestEntry{}
+		article, err := scanArticle(rows, &entry.FeedName, pq.Array(&entry.FeedTags), &entry.FeedFolder)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan article: %w", err)
+		}
+
+		entry.Article = article
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read articles: %w", err)
+	}
+
+	return entries, nil
+}
+
+// FindArticles возвращает статьи по фильтру в хронологическом порядке (от старых к новым)
+func (db *DB) FindArticles(ctx context.Context, filter domain.ArticleFilter) ([]*domain.Article, error) {
+	query := `
+		SELECT ` + prefixColumns("a", articleColumns) + `
+		FROM articles a
+		JOIN feeds f ON a.feed_id = f.id
+		WHERE ($1 = '' OR f.name = $1)
+			AND ($2::timestamptz IS NULL OR a.published_at >= $2)
+		ORDER BY a.published_at ASC, a.id ASC
+		LIMIT $3`
+
+	var since, limit interface{}
+	if !filter.Since.IsZero() {
+		since = filter.Since
+	}
+	// LIMIT NULL в PostgreSQL означает отсутствие ограничения
+	if filter.Limit > 0 {
+		limit = filter.Limit
+	}
+
+	rows, err := db.QueryContext(ctx, query, filter.FeedName, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find articles: %w", err)
+	}
+	defer rows.Close()
+
+	articles, err := scanArticles(rows)
+	if err != nil {
+		return nil, err
+	}
+	return articles, db.attachMedia(ctx, articles)
+}
+
+// GetFeedStats возвращает статистику статей по ленте feedName или по всем лентам (пустое имя)
+func (db *DB) GetFeedStats(ctx context.Context, feedName string) ([]*domain.FeedStats, error) {
+	query := `
+		SELECT f.name, f.updated_at,
+			COUNT(a.id),
+			MIN(a.published_at),
+			MAX(a.published_at),
+			COUNT(a.id) FILTER (WHERE a.published_at >= NOW() - INTERVAL '1 day'),
+			COUNT(a.id) FILTER (WHERE a.published_at >= NOW() - INTERVAL '7 days'),
+			COUNT(a.id) FILTER (WHERE a.published_at >= NOW() - INTERVAL '14 days'
+				AND a.published_at < NOW() - INTERVAL '7 days')
+		FROM feeds f
+		LEFT JOIN articles a ON a.feed_id = f.id
+		WHERE $1 = '' OR f.name = $1
+		GROUP BY f.id, f.name, f.updated_at
+		ORDER BY f.name`
+
+	rows, err := db.QueryContext(ctx, query, feedName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []*domain.FeedStats
+	for rows.Next() {
+		s := &domain.FeedStats{}
+		var first, last sql.NullTime
+
+		err := rows.Scan(&s.FeedName, &s.LastFetchedAt, &s.TotalArticles, &first, &last,
+			&s.LastDay, &s.LastWeek, &s.PrevWeek)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan feed stats: %w", err)
+		}
+		if first.Valid {
+			s.FirstArticleAt = &first.Time
+		}
+		if last.Valid {
+			s.LastArticleAt = &last.Time
+		}
+		stats = append(stats, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read feed stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetFeedArticleCounts считает все и непрочитанные статьи каждой ленты одним запросом
+func (db *DB) GetFeedArticleCounts(ctx context.Context) (map[utils.UUID]domain.ArticleCounts, error) {
+	query := `
+		SELECT f.id, COUNT(a.id), COUNT(a.id) FILTER (WHERE a.read_at IS NULL)
+		FROM feeds f
+		LEFT JOIN articles a ON a.feed_id = f.id
+		GROUP BY f.id`
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get article counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[utils.UUID]domain.ArticleCounts)
+	for rows.Next() {
+		var id string
+		var c domain.ArticleCounts
+		if err := rows.Scan(&id, &c.Total, &c.Unread); err != nil {
+			return nil, fmt.Errorf("failed to scan article counts: %w", err)
+		}
+		feedID, err := utils.ParseUUID(id)
+		if err != nil {
+			return nil, fmt.Errorf("UUID error: %v", err)
+		}
+		counts[feedID] = c
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read article counts: %w", err)
+	}
+	return counts, nil
+}
+
+// TrimFeedArticles удаляет статьи ленты старше keep самых новых (по времени публикации), кроме
+// статей в избранном; их версии и вложения удаляются каскадно
+func (db *DB) TrimFeedArticles(ctx context.Context, feedID utils.UUID, keep int) (int64, error) {
+	query := `
+		DELETE FROM articles
+		WHERE id IN (
+			SELECT id FROM (
+				SELECT id, saved_at, ROW_NUMBER() OVER (ORDER BY published_at DESC, id DESC) AS n
+				FROM articles
+				WHERE feed_id = $1
+			) ranked
+			WHERE n > $2 AND saved_at IS NULL
+		)`
+
+	result, err := db.ExecContext(ctx, query, feedID, keep)
+	if err != nil {
+		return 0, fmt.Errorf("failed to trim articles: %w", err)
+	}
+	deleted, _ := result.RowsAffected()
+	return deleted, nil
+}
+
+// WebSub subscriptions methods
+
+// websubColumns перечисляет колонки подписки в порядке, ожидаемом scanWebSubSubscription
+const websubColumns = `feed_id, hub_url, topic, secret, lease_seconds, expires_at, created_at, updated_at`
+
+// scanWebSubSubscription читает подписку из строки результата запроса
+func scanWebSubSubscription(row rowScanner) (*domain.WebSubSubscription, error) {
+	sub := &domain.WebSubSubscription{}
+	var feedID string
+	var expiresAt sql.NullTime
+	err := row.Scan(&feedID, &sub.HubURL, &sub.Topic, &sub.Secret, &sub.LeaseSeconds,
+		&expiresAt, &sub.CreatedAt, &sub.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if expiresAt.Valid {
+		sub.ExpiresAt = &expiresAt.Time
+	}
+
+	sub.FeedID, err = utils.ParseUUID(feedID)
+	if err != nil {
+		return nil, fmt.Errorf("UUID error: %v", err)
+	}
+
+	return sub, nil
+}
+
+// SaveWebSubSubscription создает или обновляет подписку ленты
+func (db *DB) SaveWebSubSubscription(ctx context.Context, sub *domain.WebSubSubscription) error {
+	query := `
+		INSERT INTO websub_subscriptions (feed_id, hub_url, topic, secret, lease_seconds, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (feed_id) DO UPDATE SET
+			hub_url = EXCLUDED.hub_url, topic = EXCLUDED.topic, secret = EXCLUDED.secret,
+			lease_seconds = EXCLUDED.lease_seconds, expires_at = EXCLUDED.expires_at, updated_at = NOW()`
+
+	_, err := db.ExecContext(ctx, query, sub.FeedID.String(), sub.HubURL, sub.Topic, sub.Secret, sub.LeaseSeconds, sub.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to save websub subscription: %w", err)
+	}
+
+	return nil
+}
+
+// GetWebSubSubscription получает подписку ленты
+func (db *DB) GetWebSubSubscription(ctx context.Context, feedID utils.UUID) (*domain.WebSubSubscription, error) {
+	query := `SELECT ` + websubColumns + ` FROM websub_subscriptions WHERE feed_id = $1`
+
+	sub, err := scanWebSubSubscription(db.QueryRowContext(ctx, query, feedID.String()))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: %s", domain.ErrSubscriptionNotFound, feedID)
+		}
+		return nil, fmt.Errorf("failed to get websub subscription: %w", err)
+	}
+
+	return sub, nil
+}
+
+// GetWebSubSubscriptions получает все подписки
+func (db *DB) GetWebSubSubscriptions(ctx context.Context) ([]*domain.WebSubSubscription, error) {
+	query := `SELECT ` + websubColumns + ` FROM websub_subscriptions ORDER BY created_at`
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get websub subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []*domain.WebSubSubscription
+	for rows.Next() {
+		sub, err := scanWebSubSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan websub subscription: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+
+	return subs, rows.Err()
+}
+
+// DeleteWebSubSubscription удаляет подписку ленты
+func (db *DB) DeleteWebSubSubscription(ctx context.Context, feedID utils.UUID) error {
+	query := `DELETE FROM websub_subscriptions WHERE feed_id = $1`
+
+	if _, err := db.ExecContext(ctx, query, feedID.String()); err != nil {
+		return fmt.Errorf("failed to delete websub subscription: %w", err)
+	}
+
+	return nil
+}
+
+// Feed icons methods
+
+// iconColumns перечисляет колонки иконки в порядке, ожидаемом scanFeedIcon
+const iconColumns = `feed_id, url, content_type, data, fetched_at, checked_at, last_error`
+
+// scanFeedIcon читает иконку ленты из строки результата запроса
+func scanFeedIcon(row rowScanner) (*domain.FeedIcon, error) {
+	icon := &domain.FeedIcon{}
+	var feedID string
+	var fetchedAt sql.NullTime
+	err := row.Scan(&feedID, &icon.URL, &icon.ContentType, &icon.Data, &fetchedAt, &icon.CheckedAt, &icon.LastError)
+	if err != nil {
+		return nil, err
+	}
+
+	if fetchedAt.Valid {
+		icon.FetchedAt = &fetchedAt.Time
+	}
+
+	icon.FeedID, err = utils.ParseUUID(feedID)
+	if err != nil {
+		return nil, fmt.Errorf("UUID error: %v", err)
+	}
+
+	return icon, nil
+}
+
+// SaveFeedIcon создает или обновляет иконку ленты
+func (db *DB) SaveFeedIcon(ctx context.Context, icon *domain.FeedIcon) error {
+	query := `
+		INSERT INTO feed_icons (feed_id, url, content_type, data, fetched_at, checked_at, last_error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (feed_id) DO UPDATE SET
+			url = EXCLUDED.url, content_type = EXCLUDED.content_type, data = EXCLUDED.data,
+			fetched_at = EXCLUDED.fetched_at, checked_at = EXCLUDED.checked_at, last_error = EXCLUDED.last_error`
+
+	_, err := db.ExecContext(ctx, query, icon.FeedID.String(), icon.URL, icon.ContentType, icon.Data,
+		icon.FetchedAt, icon.CheckedAt, icon.LastError)
+	if err != nil {
+		return fmt.Errorf("failed to save feed icon: %w", err)
+	}
+
+	return nil
 }
 
-// New создает новое подключение к базе данных
-func New(dsn string) (*DB, error) {
-	// Открываем соединение с PostgreSQL
-	db, err := sql.Open("postgres", dsn)
+// GetFeedIcon получает иконку ленты
+func (db *DB) GetFeedIcon(ctx context.Context, feedID utils.UUID) (*domain.FeedIcon, error) {
+	query := `SELECT ` + iconColumns + ` FROM feed_icons WHERE feed_id = $1`
+
+	icon, err := scanFeedIcon(db.QueryRowContext(ctx, query, feedID.String()))
 	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: %s", domain.ErrIconNotFound, feedID)
+		}
+		return nil, fmt.Errorf("failed to get feed icon: %w", err)
 	}
 
-	// Проверяем соединение
-	if err := db.Ping(); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
-	}
+	return icon, nil
+}
 
-	// Настраиваем пул соединений
-	db.SetMaxOpenConns(25)                 // Максимум открытых соединений
-	db.SetMaxIdleConns(25)                 // Максимум неактивных соединений
-	db.SetConnMaxLifetime(5 * time.Minute) // Время жизни соединения
+// GetFeedIcons получает все иконки с изображением
+func (db *DB) GetFeedIcons(ctx context.Context) ([]*domain.FeedIcon, error) {
+	query := `SELECT ` + iconColumns + ` FROM feed_icons WHERE LENGTH(data) > 0`
 
-	logger.Info("Successfully connected to PostgreSQL database")
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed icons: %w", err)
+	}
+	defer rows.Close()
 
-	return &DB{DB: db}, nil
+	var icons []*domain.FeedIcon
+	for rows.Next() {
+		icon, err := scanFeedIcon(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan feed icon: %w", err)
+		}
+		icons = append(icons, icon)
+	}
+
+	return icons, rows.Err()
 }
 
-// CreateFeed создает новую RSS ленту в базе данных
-func (db *DB) CreateFeed(name, url string) (*domain.Feed, error) {
-	uuid, _err := utils.NewUUID()
-	if _err != nil {
-		return nil, _err
+// API keys methods
+
+// apiKeyColumns перечисляет колонки ключа API в порядке, ожидаемом scanAPIKey
+const apiKeyColumns = `id, name, prefix, key_hash, created_at, last_used_at, revoked_at, fever_hash`
+
+// scanAPIKey читает ключ API из строки результата запроса
+func scanAPIKey(row rowScanner) (*domain.APIKey, error) {
+	key := &domain.APIKey{}
+	var id string
+	var lastUsedAt, revokedAt sql.NullTime
+	var feverHash sql.NullString
+	if err := row.Scan(&id, &key.Name, &key.Prefix, &key.Hash, &key.CreatedAt, &lastUsedAt, &revokedAt, &feverHash); err != nil {
+		return nil, err
+	}
+	key.FeverHash = feverHash.String
+
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
 	}
 
-	feed := &domain.Feed{
-		ID:        uuid,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-		Name:      name,
-		URL:       url,
+	var err error
+	key.ID, err = utils.ParseUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf("UUID error: %v", err)
 	}
 
-	// SQL запрос для вставки новой ленты
+	return key, nil
+}
+
+// CreateAPIKey сохраняет новый ключ API
+func (db *DB) CreateAPIKey(ctx context.Context, key *domain.APIKey) error {
 	query := `
-		INSERT INTO feeds (id, created_at, updated_at, name, url)
-		VALUES ($1, $2, $3, $4, $5)`
+		INSERT INTO api_keys (id, name, prefix, key_hash, fever_hash)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+		RETURNING created_at`
 
-	_, err := db.Exec(query, feed.ID.String(), feed.CreatedAt, feed.UpdatedAt, feed.Name, feed.URL)
+	err := db.QueryRowContext(ctx, query, key.ID.String(), key.Name, key.Prefix, key.Hash, key.FeverHash).Scan(&key.CreatedAt)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create feed: %w", err)
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: %s", domain.ErrDuplicateAPIKey, key.Name)
+		}
+		return fmt.Errorf("failed to create api key: %w", err)
 	}
 
-	logger.Info("Created new feed: %s (%s)", name, url)
-	return feed, nil
+	return nil
 }
 
-// GetFeedByName получает ленту по имени
-func (db *DB) GetFeedByName(name string) (*domain.Feed, error) {
-	feed := &domain.Feed{}
+// GetAPIKeys получает все ключи API, включая отозванные
+func (db *DB) GetAPIKeys(ctx context.Context) ([]*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY created_at`
 
-	query := `
-		SELECT id, created_at, updated_at, name, url 
-		FROM feeds 
-		WHERE name = $1`
-	var idFeed string
-	err := db.QueryRow(query, name).
-		Scan(&idFeed, &feed.CreatedAt, &feed.UpdatedAt, &feed.Name, &feed.URL)
+	rows, err := db.QueryContext(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("%v", err)
+		return nil, fmt.Errorf("failed to get api keys: %w", err)
 	}
+	defer rows.Close()
 
-	feed.ID, err = utils.ParseUUID(idFeed)
+	var keys []*domain.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// GetAPIKeyByHash получает действующий (не отозванный) ключ API по хешу
+func (db *DB) GetAPIKeyByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1 AND revoked_at IS NULL`
+
+	key, err := scanAPIKey(db.QueryRowContext(ctx, query, hash))
 	if err != nil {
-		return nil, fmt.Errorf("UUID error: %v", err)
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
 	}
 
+	return key, nil
+}
+
+// GetAPIKeyByFeverHash получает действующий ключ API по api_key протокола Fever
+func (db *DB) GetAPIKeyByFeverHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE fever_hash = $1 AND revoked_at IS NULL`
+
+	key, err := scanAPIKey(db.QueryRowContext(ctx, query, hash))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("feed not found: %s", name)
+			return nil, domain.ErrAPIKeyNotFound
 		}
-		return nil, fmt.Errorf("failed to get feed: %w", err)
+		return nil, fmt.Errorf("failed to get api key: %w", err)
 	}
 
-	return feed, nil
+	return key, nil
 }
 
-// GetAllFeeds получает все ленты, опционально ограничивая количество
-func (db *DB) GetAllFeeds(limit int) ([]*domain.Feed, error) {
-	var query string
-	var args []interface{}
+// TouchAPIKey обновляет время последнего использования ключа
+func (db *DB) TouchAPIKey(ctx context.Context, id utils.UUID) error {
+	query := `UPDATE api_keys SET last_used_at = NOW() WHERE id = $1`
 
-	if limit > 0 {
-		// С ограничением количества, сортируем по дате создания (новые сначала)
-		query = `
-			SELECT id, created_at, updated_at, name, url 
-			FROM feeds 
-			ORDER BY created_at DESC 
-			LIMIT $1`
-		args = append(args, limit)
-	} else {
-		// Без ограничений
-		query = `
-			SELECT id, created_at, updated_at, name, url 
-			FROM feeds 
-			ORDER BY created_at DESC`
+	if _, err := db.ExecContext(ctx, query, id.String()); err != nil {
+		return fmt.Errorf("failed to update api key usage: %w", err)
 	}
 
-	rows, err := db.Query(query, args...)
+	return nil
+}
+
+// RevokeAPIKey отзывает действующий ключ API по имени
+func (db *DB) RevokeAPIKey(ctx context.Context, name string) error {
+	query := `UPDATE api_keys SET revoked_at = NOW() WHERE name = $1 AND revoked_at IS NULL`
+
+	result, err := db.ExecContext(ctx, query, name)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get feeds: %w", err)
+		return fmt.Errorf("failed to revoke api key: %w", err)
 	}
-	defer rows.Close()
 
-	var feeds []*domain.Feed
-	var idFeed string
-	for rows.Next() {
-		feed := &domain.Feed{}
-		err := rows.Scan(&idFeed, &feed.CreatedAt, &feed.UpdatedAt, &feed.Name, &feed.URL)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan feed: %w", err)
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrAPIKeyNotFound, name)
+	}
+
+	logger.Info("Revoked API key: %s", name)
+	return nil
+}
+
+// Smart feeds methods
+
+// smartFeedColumns перечисляет колонки смарт-ленты в порядке, ожидаемом scanSmartFeed
+const smartFeedColumns = `id, name, query, created_at`
+
+// scanSmartFeed читает смарт-ленту из строки результата запроса
+func scanSmartFeed(row rowScanner) (*domain.SmartFeed, error) {
+	smartFeed := &domain.SmartFeed{}
+	var id string
+	if err := row.Scan(&id, &smartFeed.Name, &smartFeed.Query, &smartFeed.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	var err error
+	smartFeed.ID, err = utils.ParseUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf("UUID error: %v", err)
+	}
+
+	return smartFeed, nil
+}
+
+// CreateSmartFeed сохраняет новую смарт-ленту
+func (db *DB) CreateSmartFeed(ctx context.Context, smartFeed *domain.SmartFeed) error {
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return err
+	}
+	smartFeed.ID = uuid
+	smartFeed.CreatedAt = time.Now()
+
+	query := `INSERT INTO smart_feeds (id, name, query, created_at) VALUES ($1, $2, $3, $4)`
+
+	_, err = db.ExecContext(ctx, query, smartFeed.ID.String(), smartFeed.Name, smartFeed.Query, smartFeed.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: %s", domain.ErrDuplicateSmartFeed, smartFeed.Name)
 		}
-		feed.ID, err = utils.ParseUUID(idFeed)
-		if err != nil {
-			return nil, fmt.Errorf("failed to UIID feed: %w", err)
+		return fmt.Errorf("failed to create smart feed: %w", err)
+	}
+
+	logger.Info("Created smart feed: %s (%s)", smartFeed.Name, smartFeed.Query)
+	return nil
+}
+
+// GetSmartFeedByName получает смарт-ленту по имени
+func (db *DB) GetSmartFeedByName(ctx context.Context, name string) (*domain.SmartFeed, error) {
+	query := `SELECT ` + smartFeedColumns + ` FROM smart_feeds WHERE name = $1`
+
+	smartFeed, err := scanSmartFeed(db.QueryRowContext(ctx, query, name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: %s", domain.ErrSmartFeedNotFound, name)
 		}
-		feeds = append(feeds, feed)
+		return nil, fmt.Errorf("failed to get smart feed: %w", err)
 	}
 
-	return feeds, nil
+	return smartFeed, nil
 }
 
-// GetOldestFeeds получает N самых устаревших лент для обновления
-func (db *DB) GetOldestFeeds(limit int) ([]*domain.Feed, error) {
-	query := `
-		SELECT id, created_at, updated_at, name, url 
-		FROM feeds 
-		ORDER BY updated_at ASC 
-		LIMIT $1`
+// GetSmartFeeds получает все смарт-ленты, отсортированные по имени
+func (db *DB) GetSmartFeeds(ctx context.Context) ([]*domain.SmartFeed, error) {
+	query := `SELECT ` + smartFeedColumns + ` FROM smart_feeds ORDER BY name`
 
-	rows, err := db.Query(query, limit)
+	rows, err := db.QueryContext(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get oldest feeds: %w", err)
+		return nil, fmt.Errorf("failed to get smart feeds: %w", err)
 	}
 	defer rows.Close()
 
-	var feeds []*domain.Feed
-	var idFeed string
+	var smartFeeds []*domain.SmartFeed
 	for rows.Next() {
-		feed := &domain.Feed{}
-		err := rows.Scan(&idFeed, &feed.CreatedAt, &feed.UpdatedAt, &feed.Name, &feed.URL)
+		smartFeed, err := scanSmartFeed(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan feed: %w", err)
+			return nil, fmt.Errorf("failed to scan smart feed: %w", err)
 		}
+		smartFeeds = append(smartFeeds, smartFeed)
+	}
 
-		feed.ID, _ = utils.ParseUUID(idFeed)
+	return smartFeeds, rows.Err()
+}
 
-		feeds = append(feeds, feed)
+// DeleteSmartFeed удаляет смарт-ленту по имени; статьи лент не затрагиваются
+func (db *DB) DeleteSmartFeed(ctx context.Context, name string) error {
+	result, err := db.ExecContext(ctx, `DELETE FROM smart_feeds WHERE name = $1`, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete smart feed: %w", err)
 	}
 
-	return feeds, nil
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrSmartFeedNotFound, name)
+	}
+
+	logger.Info("Deleted smart feed: %s", name)
+	return nil
 }
 
-// UpdateFeedTimestamp обновляет время последнего обновления ленты
-func (db *DB) UpdateFeedTimestamp(feedID utils.UUID) error {
-	query := `UPDATE feeds SET updated_at = $1 WHERE id = $2`
+// Notification rules methods
 
-	_, err := db.Exec(query, time.Now(), feedID.String())
+// CreateNotificationRule сохраняет новое правило уведомлений
+func (db *DB) CreateNotificationRule(ctx context.Context, rule *domain.NotificationRule) error {
+	uuid, err := utils.NewUUID()
 	if err != nil {
-		return fmt.Errorf("failed to update feed timestamp: %w", err)
+		return err
+	}
+	rule.ID = uuid
+	rule.CreatedAt = time.Now()
+
+	query := `INSERT INTO notification_rules (id, name, query, expression, channel, created_at) VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err = db.ExecContext(ctx, query, rule.ID.String(), rule.Name, rule.Query, rule.Expression, rule.Channel, rule.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: %s", domain.ErrDuplicateRule, rule.Name)
+		}
+		return fmt.Errorf("failed to create notification rule: %w", err)
 	}
 
+	logger.Info("Created notification rule: %s (%s -> %s)", rule.Name, rule.Condition(), rule.Channel)
 	return nil
 }
 
-// DeleteFeed удаляет ленту по имени
-func (db *DB) DeleteFeed(name string) error {
-	// Сначала проверяем, существует ли лента
-	_, err := db.GetFeedByName(name)
+// GetNotificationRules получает все правила уведомлений, отсортированные по имени
+func (db *DB) GetNotificationRules(ctx context.Context) ([]*domain.NotificationRule, error) {
+	rows, err := db.QueryContext(ctx, `SELECT id, name, query, expression, channel, created_at FROM notification_rules ORDER BY name`)
 	if err != nil {
-		return err // Лента не найдена или другая ошибка
+		return nil, fmt.Errorf("failed to get notification rules: %w", err)
 	}
+	defer rows.Close()
 
-	query := `DELETE FROM feeds WHERE name = $1`
+	var rules []*domain.NotificationRule
+	for rows.Next() {
+		rule := &domain.NotificationRule{}
+		var id string
+		if err := rows.Scan(&id, &rule.Name, &rule.Query, &rule.Expression, &rule.Channel, &rule.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan notification rule: %w", err)
+		}
+		if rule.ID, err = utils.ParseUUID(id); err != nil {
+			return nil, fmt.Errorf("UUID error: %v", err)
+		}
+		rules = append(rules, rule)
+	}
 
-	result, err := db.Exec(query, name)
+	return rules, rows.Err()
+}
+
+// DeleteNotificationRule удаляет правило уведомлений по имени
+func (db *DB) DeleteNotificationRule(ctx context.Context, name string) error {
+	result, err := db.ExecContext(ctx, `DELETE FROM notification_rules WHERE name = $1`, name)
 	if err != nil {
-		return fmt.Errorf("failed to delete feed: %w", err)
+		return fmt.Errorf("failed to delete notification rule: %w", err)
 	}
 
-	// Проверяем, что что-то было удалено
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		return fmt.Errorf("no feed was deleted")
+		return fmt.Errorf("%w: %s", domain.ErrRuleNotFound, name)
 	}
 
-	logger.Info("Deleted feed: %s", name)
+	logger.Info("Deleted notification rule: %s", name)
 	return nil
 }
 
-// CreateArticle создает новую статью в базе данных
-func (db *DB) CreateArticle(article *domain.Article) error {
-	// Генерируем ID если его нет
-	if article.ID.String() == "" {
-		if uuid, err := utils.NewUUID(); err != nil {
-			return fmt.Errorf("UUID error")
-		} else {
-			article.ID = uuid
-		}
+// Filter rules methods
+
+// CreateFilterRule сохраняет новое правило фильтра
+func (db *DB) CreateFilterRule(ctx context.Context, rule *domain.FilterRule) error {
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return err
 	}
+	rule.ID = uuid
+	rule.CreatedAt = time.Now()
 
-	// Устанавливаем текущее время если не установлено
-	if article.CreatedAt.IsZero() {
-		article.CreatedAt = time.Now()
+	query := `INSERT INTO filter_rules (id, name, expression, action, created_at) VALUES ($1, $2, $3, $4, $5)`
+
+	_, err = db.ExecContext(ctx, query, rule.ID.String(), rule.Name, rule.Expression, string(rule.Action), rule.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: %s", domain.ErrDuplicateFilter, rule.Name)
+		}
+		return fmt.Errorf("failed to create filter rule: %w", err)
 	}
-	if article.UpdatedAt.IsZero() {
-		article.UpdatedAt = time.Now()
+
+	logger.Info("Created filter rule: %s (%s %s)", rule.Name, rule.Action, rule.Expression)
+	return nil
+}
+
+// GetFilterRules получает все правила фильтров, отсортированные по имени
+func (db *DB) GetFilterRules(ctx context.Context) ([]*domain.FilterRule, error) {
+	rows, err := db.QueryContext(ctx, `SELECT id, name, expression, action, created_at FROM filter_rules ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get filter rules: %w", err)
 	}
+	defer rows.Close()
 
-	query := `
-		INSERT INTO articles (id, created_at, updated_at, title, link, published_at, description, feed_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		ON CONFLICT (link) DO NOTHING` // Игнорируем дубликаты по URL
+	var rules []*domain.FilterRule
+	for rows.Next() {
+		rule := &domain.FilterRule{}
+		var id, action string
+		if err := rows.Scan(&id, &rule.Name, &rule.Expression, &action, &rule.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan filter rule: %w", err)
+		}
+		if rule.ID, err = utils.ParseUUID(id); err != nil {
+			return nil, fmt.Errorf("UUID error: %v", err)
+		}
+		rule.Action = domain.FilterAction(action)
+		rules = append(rules, rule)
+	}
 
-	_, err := db.Exec(query,
-		article.ID.String(), article.CreatedAt, article.UpdatedAt,
-		article.Title, article.Link, article.PublishedAt,
-		article.Description, article.FeedID.String())
+	return rules, rows.Err()
+}
 
+// DeleteFilterRule удаляет правило фильтра по имени
+func (db *DB) DeleteFilterRule(ctx context.Context, name string) error {
+	result, err := db.ExecContext(ctx, `DELETE FROM filter_rules WHERE name = $1`, name)
 	if err != nil {
-		return fmt.Errorf("failed to create article: %w", err)
+		return fmt.Errorf("failed to delete filter rule: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrFilterNotFound, name)
 	}
 
+	logger.Info("Deleted filter rule: %s", name)
 	return nil
 }
 
-// GetArticlesByFeedName получает статьи для конкретной ленты по имени
-func (db *DB) GetArticlesByFeedName(feedName string, limit int) ([]*domain.Article, error) {
-	if limit <= 0 {
-		limit = 3 // Значение по умолчанию
+// GetSmartFeedArticles возвращает страницу статей всех лент, подходящих под запрос,
+// от новых к старым; after - курсор последней статьи предыдущей страницы, limit <= 0 - без ограничения
+func (db *DB) GetSmartFeedArticles(ctx context.Context, query domain.QueryNode, after *domain.PageCursor, limit int) ([]*domain.DigestEntry, error) {
+	var afterTime interface{}
+	var afterID interface{}
+	if after != nil {
+		afterTime, afterID = after.Time, after.ID.String()
 	}
 
-	query := `
-		SELECT a.id, a.created_at, a.updated_at, a.title, a.link, a.published_at, a.description, a.feed_id
+	// LIMIT NULL в PostgreSQL означает отсутствие ограничения
+	var limitArg interface{}
+	if limit > 0 {
+		limitArg = limit
+	}
+
+	args := []interface{}{afterTime, afterID, limitArg}
+	condition := smartQuerySQL(query, &args)
+
+	sqlQuery := `
+		SELECT ` + prefixColumns("a", articleColumns) + `, f.name, f.tags, COALESCE(f.folder, '')
 		FROM articles a
 		JOIN feeds f ON a.feed_id = f.id
-		WHERE f.name = $1
-		ORDER BY a.published_at DESC
-		LIMIT $2`
+		WHERE ` + condition + `
+			AND ($1::timestamptz IS NULL OR (a.published_at, a.id) < ($1, $2::uuid))
+		ORDER BY a.published_at DESC, a.id DESC
+		LIMIT $3`
 
-	rows, err := db.Query(query, feedName, limit)
+	rows, err := db.QueryContext(ctx, sqlQuery, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get articles: %w", err)
+		return nil, fmt.Errorf("failed to get smart feed articles: %w", err)
 	}
 	defer rows.Close()
 
-	var articles []*domain.Article
-	var articleID string
-	var feedID string
-
+	var entries []*domain.DigestEntry
 	for rows.Next() {
-		article := &domain.Article{}
-		err := rows.Scan(
-			&articleID, &article.CreatedAt, &article.UpdatedAt,
-			&article.Title, &article.Link, &article.PublishedAt,
-			&article.Description, &feedID,
-		)
+		entry := &domain.DigestEntry{}
+		article, err := scanArticle(rows, &entry.FeedName, pq.Array(&entry.FeedTags), &entry.FeedFolder)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan article: %w", err)
 		}
 
-		article.ID, err = utils.ParseUUID(articleID)
-		if err != nil {
-			return nil, fmt.Errorf("failed parsing article ID: %w", err)
+		entry.Article = article
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read articles: %w", err)
+	}
+
+	articles := make([]*domain.Article, 0, len(entries))
+	for _, entry := range entries {
+		articles = append(articles, entry.Article)
+	}
+	return entries, db.attachMedia(ctx, articles)
+}
+
+// Fetch runs methods
+
+// SaveFetchRun сохраняет итоги цикла получения лент
+func (db *DB) SaveFetchRun(ctx context.Context, run *domain.FetchRun) error {
+	feedErrors := run.Errors
+	if feedErrors == nil {
+		feedErrors = []domain.FeedError{} // Пустой массив вместо null в JSONB
+	}
+	errorsJSON, err := json.Marshal(feedErrors)
+	if err != nil {
+		return fmt.Errorf("failed to encode fetch run errors: %w", err)
+	}
+
+	query := `
+		INSERT INTO fetch_runs (instance, started_at, finished_at, feeds, new_articles, duplicates, updated,
+			failed, timed_out, skipped, bytes, errors, queue_wait_avg_ms, queue_wait_max_ms)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id`
+
+	var id string
+	err = db.QueryRowContext(ctx, query, run.Instance, run.StartedAt, run.FinishedAt, run.Feeds, run.NewArticles, run.Duplicates,
+		run.Updated, run.Failed, run.TimedOut, run.Skipped, run.Bytes, errorsJSON,
+		run.QueueWaitAvg.Milliseconds(), run.QueueWaitMax.Milliseconds()).Scan(&id)
+	if err != nil {
+		return fmt.Errorf("failed to save fetch run: %w", err)
+	}
+
+	run.ID, err = utils.ParseUUID(id)
+	if err != nil {
+		return fmt.Errorf("UUID error: %v", err)
+	}
+	return nil
+}
+
+// GetFetchRuns получает последние limit циклов, от новых к старым (limit <= 0 - все)
+func (db *DB) GetFetchRuns(ctx context.Context, limit int) ([]*domain.FetchRun, error) {
+	query := `
+		SELECT id, instance, started_at, finished_at, feeds, new_articles, duplicates, updated,
+			failed, timed_out, skipped, bytes, errors, queue_wait_avg_ms, queue_wait_max_ms
+		FROM fetch_runs
+		ORDER BY started_at DESC
+		LIMIT $1`
+
+	var limitArg interface{}
+	if limit > 0 {
+		limitArg = limit
+	}
+
+	rows, err := db.QueryContext(ctx, query, limitArg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fetch runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []*domain.FetchRun
+	for rows.Next() {
+		run := &domain.FetchRun{}
+		var id string
+		var errorsJSON []byte
+		var waitAvgMs, waitMaxMs int64
+		if err := rows.Scan(&id, &run.Instance, &run.StartedAt, &run.FinishedAt, &run.Feeds, &run.NewArticles,
+			&run.Duplicates, &run.Updated, &run.Failed, &run.TimedOut, &run.Skipped, &run.Bytes, &errorsJSON,
+			&waitAvgMs, &waitMaxMs); err != nil {
+			return nil, fmt.Errorf("failed to scan fetch run: %w", err)
 		}
+		run.QueueWaitAvg = time.Duration(waitAvgMs) * time.Millisecond
+		run.QueueWaitMax = time.Duration(waitMaxMs) * time.Millisecond
 
-		article.FeedID, err = utils.ParseUUID(feedID)
+		run.ID, err = utils.ParseUUID(id)
 		if err != nil {
-			return nil, fmt.Errorf("failed parsing feed ID: %w", err)
+			return nil, fmt.Errorf("UUID error: %v", err)
+		}
+		if err := json.Unmarshal(errorsJSON, &run.Errors); err != nil {
+			return nil, fmt.Errorf("failed to decode fetch run errors: %w", err)
 		}
+		runs = append(runs, run)
+	}
 
-		articles = append(articles, article)
+	return runs, rows.Err()
+}
+
+// Traffic methods
+
+// AddFeedTraffic добавляет bytes к трафику ленты за день at (по местному времени)
+func (db *DB) AddFeedTraffic(ctx context.Context, feedID utils.UUID, at time.Time, bytes int64) error {
+	query := `
+		INSERT INTO feed_traffic (feed_id, day, bytes)
+		VALUES ($1, $2::date, $3)
+		ON CONFLICT (feed_id, day)
+		DO UPDATE SET bytes = feed_traffic.bytes + EXCLUDED.bytes`
+
+	if _, err := db.ExecContext(ctx, query, feedID.String(), at.Format("2006-01-02"), bytes); err != nil {
+		return fmt.Errorf("failed to save feed traffic: %w", err)
 	}
+	return nil
+}
 
-	return articles, nil
+// GetTrafficSince возвращает трафик всех лент, включая удаленные, начиная с дня since
+func (db *DB) GetTrafficSince(ctx context.Context, since time.Time) (int64, error) {
+	var total int64
+	err := db.QueryRowContext(ctx, `SELECT COALESCE(SUM(bytes), 0) FROM feed_traffic WHERE day >= $1::date`,
+		since.Format("2006-01-02")).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get traffic: %w", err)
+	}
+	return total, nil
 }
 
-// ArticleExists проверяет, существует ли статья с данным URL
-func (db *DB) ArticleExists(link string) (bool, error) {
-	var exists bool
-	query := `SELECT EXISTS(SELECT 1 FROM articles WHERE link = $1)`
+// GetFeedTraffic возвращает трафик каждой ленты начиная с дня since по именам лент
+func (db *DB) GetFeedTraffic(ctx context.Context, since time.Time) (map[string]int64, error) {
+	query := `
+		SELECT f.name, SUM(t.bytes)
+		FROM feed_traffic t
+		JOIN feeds f ON t.feed_id = f.id
+		WHERE t.day >= $1::date
+		GROUP BY f.name`
 
-	err := db.QueryRow(query, link).Scan(&exists)
+	rows, err := db.QueryContext(ctx, query, since.Format("2006-01-02"))
 	if err != nil {
-		return false, fmt.Errorf("failed to check article existence: %w", err)
+		return nil, fmt.Errorf("failed to get feed traffic: %w", err)
 	}
+	defer rows.Close()
 
-	return exists, nil
+	traffic := make(map[string]int64)
+	for rows.Next() {
+		var name string
+		var bytes int64
+		if err := rows.Scan(&name, &bytes); err != nil {
+			return nil, fmt.Errorf("failed to scan feed traffic: %w", err)
+		}
+		traffic[name] = bytes
+	}
+	return traffic, rows.Err()
 }
 
 // Aggregator settings methods
 
 // SetAggregatorSetting сохраняет настройку агрегатора
-func (db *DB) SetAggregatorSetting(key, value string) error {
+func (db *DB) SetAggregatorSetting(ctx context.Context, key, value string) error {
 	query := `
 		INSERT INTO aggregator (key, value) 
 		VALUES ($1, $2)
 		ON CONFLICT (key) 
 		DO UPDATE SET value = EXCLUDED.value`
 
-	_, err := db.Exec(query, key, value)
+	_, err := db.ExecContext(ctx, query, key, value)
 	if err != nil {
 		return fmt.Errorf("failed to set aggregator setting: %w", err)
 	}
@@ -332,11 +2417,11 @@ func (db *DB) SetAggregatorSetting(key, value string) error {
 }
 
 // GetAggregatorSetting получает настройку агрегатора
-func (db *DB) GetAggregatorSetting(key string) (string, error) {
+func (db *DB) GetAggregatorSetting(ctx context.Context, key string) (string, error) {
 	var value string
 	query := `SELECT value FROM aggregator WHERE key = $1`
 
-	err := db.QueryRow(query, key).Scan(&value)
+	err := db.QueryRowContext(ctx, query, key).Scan(&value)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return "", fmt.Errorf("setting not found: %s", key)
@@ -347,14 +2432,77 @@ func (db *DB) GetAggregatorSetting(key string) (string, error) {
 	return value, nil
 }
 
+// settingsChannel канал LISTEN/NOTIFY, в который публикуются изменения настроек агрегатора
+const settingsChannel = "rsshub_settings"
+
+// Параметры переподключения и проверки соединения LISTEN
+const (
+	listenMinReconnect = time.Second
+	listenMaxReconnect = time.Minute
+	listenPingInterval = 90 * time.Second
+)
+
+// NotifySettingsChanged публикует изменение настроек в канал settingsChannel
+func (db *DB) NotifySettingsChanged(ctx context.Context) error {
+	if _, err := db.ExecContext(ctx, `SELECT pg_notify($1, '')`, settingsChannel); err != nil {
+		return fmt.Errorf("failed to notify settings change: %w", err)
+	}
+	return nil
+}
+
+// ListenSettingsChanges слушает канал settingsChannel на отдельном соединении, которое
+// переподключается при обрыве. Уведомления, отправленные во время обрыва, теряются, поэтому
+// после переподключения в канал тоже приходит значение
+func (db *DB) ListenSettingsChanges(ctx context.Context) (<-chan struct{}, error) {
+	listener := pq.NewListener(db.dsn, listenMinReconnect, listenMaxReconnect, func(event pq.ListenerEventType, err error) {
+		switch event {
+		case pq.ListenerEventDisconnected:
+			logger.Warn("Settings listener disconnected: %v", err)
+		case pq.ListenerEventConnectionAttemptFailed:
+			logger.Warn("Settings listener failed to reconnect: %v", err)
+		case pq.ListenerEventReconnected:
+			logger.Info("Settings listener reconnected")
+		}
+	})
+	if err := listener.Listen(settingsChannel); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to listen for settings changes: %w", err)
+	}
+
+	changes := make(chan struct{}, 1)
+	go func() {
+		defer listener.Close()
+
+		ping := time.NewTicker(listenPingInterval)
+		defer ping.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-listener.Notify:
+				// nil после переподключения: изменения за время обрыва могли быть пропущены
+				select {
+				case changes <- struct{}{}:
+				default:
+				}
+			case <-ping.C:
+				// Обрыв соединения без трафика иначе не заметить
+				go listener.Ping()
+			}
+		}
+	}()
+	return changes, nil
+}
+
 // TryLock пытается получить блокировку в базе данных
-func (db *DB) TryLock(lockName string) (bool, error) {
+func (db *DB) TryLock(ctx context.Context, lockName string) (bool, error) {
 	query := `
 		INSERT INTO aggregator (key, value) 
 		VALUES ($1, 'locked')
 		ON CONFLICT (key) DO NOTHING`
 
-	result, err := db.Exec(query, lockName)
+	result, err := db.ExecContext(ctx, query, lockName)
 	if err != nil {
 		return false, fmt.Errorf("failed to acquire lock: %w", err)
 	}
@@ -368,10 +2516,10 @@ func (db *DB) TryLock(lockName string) (bool, error) {
 }
 
 // ReleaseLock освобождает блокировку в базе данных
-func (db *DB) ReleaseLock(lockName string) error {
+func (db *DB) ReleaseLock(ctx context.Context, lockName string) error {
 	query := `DELETE FROM aggregator WHERE key = $1`
 
-	_, err := db.Exec(query, lockName)
+	_, err := db.ExecContext(ctx, query, lockName)
 	if err != nil {
 		return fmt.Errorf("failed to release lock: %w", err)
 	}
@@ -0,0 +1,185 @@
+// internal/adapter/storage/maintenance.go
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"rsshub/internal/core/domain"
+
+	"github.com/lib/pq"
+)
+
+// pruneBatchSize сколько строк удаляется одним запросом: короткие запросы не держат блокировки
+// долго и не раздувают WAL, пока агрегатор продолжает сохранять статьи
+const pruneBatchSize = 10000
+
+// unusedIndexMinSize с какого размера неиспользуемый индекс попадает в подсказки
+const unusedIndexMinSize = 1 << 20
+
+// pruneStep удаление строк одной таблицы по условию
+type pruneStep struct {
+	table string
+	where string // Условие отбора строк; псевдоним таблицы - t
+	args  []interface{}
+	count *int64
+}
+
+// Prune удаляет осиротевшие строки, затем данные старше сроков хранения. Строки удаляются
+// частями по pruneBatchSize; прерванное удаление оставляет уже удаленные части удаленными
+func (db *DB) Prune(ctx context.Context, policy domain.RetentionPolicy, dryRun bool) (*domain.PruneReport, error) {
+	report := &domain.PruneReport{}
+	now := time.Now()
+
+	var orphanMedia, orphanVersions int64
+	steps := []pruneStep{
+		{table: "articles", where: `NOT EXISTS (SELECT 1 FROM feeds f WHERE f.id = t.feed_id)`, count: &report.OrphanArticles},
+		{table: "article_media", where: `NOT EXISTS (SELECT 1 FROM articles a WHERE a.id = t.article_id)`, count: &orphanMedia},
+		{table: "article_versions", where: `NOT EXISTS (SELECT 1 FROM articles a WHERE a.id = t.article_id)`, count: &orphanVersions},
+	}
+	if policy.Articles > 0 {
+		// Самые новые статьи ленты остаются: лента еще может отдавать их, и без статьи с тем же
+		// ключом уникальности они были бы сохранены снова как новые
+		before := now.Add(-policy.Articles)
+		where := `t.saved_at IS NULL AND t.published_at < $1 AND t.created_at < $1`
+		args := []interface{}{before}
+		if policy.KeepPerFeed > 0 {
+			where += ` AND EXISTS (
+				SELECT 1 FROM articles n
+				WHERE n.feed_id = t.feed_id AND (n.published_at, n.id) > (t.published_at, t.id)
+				OFFSET $2 LIMIT 1)`
+			args = append(args, policy.KeepPerFeed-1)
+		}
+		steps = append(steps, pruneStep{table: "articles", where: where, args: args, count: &report.Articles})
+	}
+	if policy.FetchRuns > 0 {
+		steps = append(steps, pruneStep{table: "fetch_runs", where: `t.started_at < $1`,
+			args: []interface{}{now.Add(-policy.FetchRuns)}, count: &report.FetchRuns})
+	}
+	if policy.Traffic > 0 {
+		steps = append(steps, pruneStep{table: "feed_traffic", where: `t.day < $1::date`,
+			args: []interface{}{now.Add(-policy.Traffic)}, count: &report.Traffic})
+	}
+
+	for _, step := range steps {
+		if err := db.pruneRows(ctx, step, dryRun); err != nil {
+			return report, err
+		}
+	}
+	report.OrphanRows = orphanMedia + orphanVersions
+	return report, nil
+}
+
+// pruneRows удаляет строки шага частями или, при dryRun, считает их
+func (db *DB) pruneRows(ctx context.Context, step pruneStep, dryRun bool) error {
+	if dryRun {
+		query := fmt.Sprintf(`SELECT COUNT(*) FROM %s t WHERE %s`, step.table, step.where)
+		if err := db.DB.QueryRowContext(ctx, query, step.args...).Scan(step.count); err != nil {
+			return fmt.Errorf("failed to count %s to prune: %w", step.table, err)
+		}
+		return nil
+	}
+
+	query := fmt.Sprintf(`DELETE FROM %s WHERE ctid = ANY(ARRAY(SELECT t.ctid FROM %s t WHERE %s LIMIT $%d))`,
+		step.table, step.table, step.where, len(step.args)+1)
+	args := append(step.args[:len(step.args):len(step.args)], pruneBatchSize)
+	for {
+		result, err := db.DB.ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to prune %s: %w", step.table, err)
+		}
+		deleted, _ := result.RowsAffected()
+		*step.count += deleted
+		if deleted < pruneBatchSize {
+			return nil
+		}
+	}
+}
+
+// TableStats возвращает размер, оценку количества живых и мертвых строк и время последнего
+// VACUUM таблиц схемы rsshub
+func (db *DB) TableStats(ctx context.Context) ([]domain.TableStats, error) {
+	query := `
+		SELECT relname, pg_total_relation_size(relid), pg_indexes_size(relid), n_live_tup, n_dead_tup,
+			GREATEST(last_vacuum, last_autovacuum)
+		FROM pg_stat_user_tables
+		WHERE schemaname = current_schema()
+		ORDER BY pg_total_relation_size(relid) DESC, relname`
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []domain.TableStats
+	for rows.Next() {
+		var s domain.TableStats
+		var lastVacuum sql.NullTime
+		if err := rows.Scan(&s.Name, &s.TotalBytes, &s.IndexBytes, &s.LiveRows, &s.DeadRows, &lastVacuum); err != nil {
+			return nil, fmt.Errorf("failed to scan table stats: %w", err)
+		}
+		if lastVacuum.Valid {
+			s.LastVacuum = &lastVacuum.Time
+		}
+		stats = append(stats, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read table stats: %w", err)
+	}
+	return stats, nil
+}
+
+// IndexHints находит невалидные индексы (прерванный CREATE INDEX CONCURRENTLY) и большие
+// индексы, которые ни разу не использовались с последнего сброса статистики
+func (db *DB) IndexHints(ctx context.Context) ([]domain.IndexHint, error) {
+	query := `
+		SELECT s.relname, s.indexrelname, pg_relation_size(s.indexrelid), i.indisvalid
+		FROM pg_stat_user_indexes s
+		JOIN pg_index i ON i.indexrelid = s.indexrelid
+		WHERE s.schemaname = current_schema()
+			AND (NOT i.indisvalid
+				OR (s.idx_scan = 0 AND NOT i.indisunique AND NOT i.indisprimary AND pg_relation_size(s.indexrelid) >= $1))
+		ORDER BY pg_relation_size(s.indexrelid) DESC, s.indexrelname`
+
+	rows, err := db.QueryContext(ctx, query, unusedIndexMinSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get index stats: %w", err)
+	}
+	defer rows.Close()
+
+	var hints []domain.IndexHint
+	for rows.Next() {
+		var h domain.IndexHint
+		var valid bool
+		if err := rows.Scan(&h.Table, &h.Index, &h.Bytes, &valid); err != nil {
+			return nil, fmt.Errorf("failed to scan index stats: %w", err)
+		}
+		if valid {
+			h.Hint = "never used since statistics were reset; drop it if no query needs it"
+		} else {
+			h.Hint = "invalid after an interrupted build; rebuild it with REINDEX INDEX CONCURRENTLY " + pq.QuoteIdentifier(h.Index)
+		}
+		hints = append(hints, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read index stats: %w", err)
+	}
+	return hints, nil
+}
+
+// Vacuum выполняет VACUUM (ANALYZE) или VACUUM (FULL, ANALYZE) каждой таблицы по очереди
+func (db *DB) Vacuum(ctx context.Context, tables []string, full bool) error {
+	options := "ANALYZE"
+	if full {
+		options = "FULL, ANALYZE"
+	}
+	for _, table := range tables {
+		if _, err := db.DB.ExecContext(ctx, `VACUUM (`+options+`) `+pq.QuoteIdentifier(table)); err != nil {
+			return fmt.Errorf("failed to vacuum %s: %w", table, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,470 @@
+// internal/adapter/storage/memory/dryrun.go
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/utils"
+)
+
+// Проверяем на этапе компиляции, что DryRun реализует репозиторий
+var _ port.FeedArticleRepository = (*DryRun)(nil)
+
+// DryRun читает данные из основного репозитория, а все записи оставляет в памяти.
+// Позволяет выполнить полный цикл получения лент, ничего не изменив в базе данных
+type DryRun struct {
+	base port.FeedArticleRepository
+
+	mu       sync.Mutex
+	articles map[string]*domain.Article // Статьи, которые были бы вставлены, по ключу уникальности
+	settings map[string]string
+}
+
+// NewDryRun создает обертку над репозиторием, которая не выполняет запись
+func NewDryRun(base port.FeedArticleRepository) *DryRun {
+	return &DryRun{
+		base:     base,
+		articles: make(map[string]*domain.Article),
+		settings: make(map[string]string),
+	}
+}
+
+// PendingArticles возвращает статьи, которые были бы сохранены
+func (d *DryRun) PendingArticles() []*domain.Article {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	articles := make([]*domain.Article, 0, len(d.articles))
+	for _, article := range d.articles {
+		articles = append(articles, copyArticle(article))
+	}
+	return articles
+}
+
+// WithinTransaction выполняет fn над той же оберткой: записи dry-run и так не доходят до базы данных
+func (d *DryRun) WithinTransaction(ctx context.Context, fn func(repo port.FeedArticleRepository) error) error {
+	return fn(d)
+}
+
+// CreateFeed в режиме dry-run недоступен
+func (d *DryRun) CreateFeed(ctx context.Context, feed *domain.Feed) error {
+	return fmt.Errorf("cannot create feed in dry-run mode")
+}
+
+// GetFeedByName читает ленту из основного репозитория
+func (d *DryRun) GetFeedByName(ctx context.Context, name string) (*domain.Feed, error) {
+	return d.base.GetFeedByName(ctx, name)
+}
+
+// GetFeedByURL читает ленту из основного репозитория
+func (d *DryRun) GetFeedByURL(ctx context.Context, url string) (*domain.Feed, error) {
+	return d.base.GetFeedByURL(ctx, url)
+}
+
+// GetFolders читает папки из основного репозитория
+func (d *DryRun) GetFolders(ctx context.Context) ([]string, error) {
+	return d.base.GetFolders(ctx)
+}
+
+// GetFeedByID читает ленту из основного репозитория
+func (d *DryRun) GetFeedByID(ctx context.Context, id utils.UUID) (*domain.Feed, error) {
+	return d.base.GetFeedByID(ctx, id)
+}
+
+// GetAllFeeds читает ленты из основного репозитория
+func (d *DryRun) GetAllFeeds(ctx context.Context, limit int) ([]*domain.Feed, error) {
+	return d.base.GetAllFeeds(ctx, limit)
+}
+
+// GetFeedsPage читает страницу лент из основного репозитория
+func (d *DryRun) GetFeedsPage(ctx context.Context, after *domain.PageCursor, limit int) ([]*domain.Feed, error) {
+	return d.base.GetFeedsPage(ctx, after, limit)
+}
+
+// GetOldestFeeds читает ленты из основного репозитория
+func (d *DryRun) GetOldestFeeds(ctx context.Context, limit int, staleAfter time.Duration) ([]*domain.Feed, error) {
+	return d.base.GetOldestFeeds(ctx, limit, staleAfter)
+}
+
+// ClaimFeeds возвращает ленты, которые были бы зарезервированы, не резервируя их
+func (d *DryRun) ClaimFeeds(ctx context.Context, owner string, limit int, lease, staleAfter time.Duration) ([]*domain.Feed, error) {
+	return d.base.GetOldestFeeds(ctx, limit, staleAfter)
+}
+
+// ReleaseFeedClaim ничего не делает: резервирование не выполнялось
+func (d *DryRun) ReleaseFeedClaim(ctx context.Context, feedID utils.UUID) error {
+	return nil
+}
+
+// ClaimFeed ничего не резервирует: лента просто будет обработана
+func (d *DryRun) ClaimFeed(ctx context.Context, feedID utils.UUID, owner string, lease time.Duration) (bool, error) {
+	return true, nil
+}
+
+// UpdateFeedTimestamp ничего не делает в режиме dry-run
+func (d *DryRun) UpdateFeedTimestamp(ctx context.Context, feedID utils.UUID) error {
+	return nil
+}
+
+// SetFeedFetchState ничего не делает в режиме dry-run
+func (d *DryRun) SetFeedFetchState(ctx context.Context, feedID utils.UUID, failures int, activeURL string) error {
+	return nil
+}
+
+// SetFeedCache ничего не делает в режиме dry-run: следующий запрос останется прежним
+func (d *DryRun) SetFeedCache(ctx context.Context, feedID utils.UUID, cache domain.CacheValidators) error {
+	return nil
+}
+
+// SetFeedNextRun ничего не делает в режиме dry-run: расписание лент не меняется
+func (d *DryRun) SetFeedNextRun(ctx context.Context, feedID utils.UUID, next *time.Time) error {
+	return nil
+}
+
+// SetFeedLastError ничего не делает в режиме dry-run
+func (d *DryRun) SetFeedLastError(ctx context.Context, feedID utils.UUID, message string) error {
+	return nil
+}
+
+// DisableFeed ничего не делает в режиме dry-run: лента остается включенной
+func (d *DryRun) DisableFeed(ctx context.Context, feedID utils.UUID, reason string) error {
+	return nil
+}
+
+// UpdateFeed в режиме dry-run недоступен
+func (d *DryRun) UpdateFeed(ctx context.Context, feed *domain.Feed) error {
+	return fmt.Errorf("cannot update feed in dry-run mode")
+}
+
+// DeleteFeed в режиме dry-run недоступен
+func (d *DryRun) DeleteFeed(ctx context.Context, name string) error {
+	return fmt.Errorf("cannot delete feed in dry-run mode")
+}
+
+// DeleteFeeds в режиме dry-run недоступен
+func (d *DryRun) DeleteFeeds(ctx context.Context, names []string) error {
+	return fmt.Errorf("cannot delete feeds in dry-run mode")
+}
+
+// CreateArticle запоминает статью в памяти вместо записи в БД. Обновление уже запомненной
+// статьи, как и UpdateArticleContent, только сообщается
+func (d *DryRun) CreateArticle(ctx context.Context, article *domain.Article, onConflict domain.ConflictMode) (domain.InsertResult, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := article.DedupKey
+	if key == "" {
+		key = domain.ArticleDedupKey(domain.DedupByLink, article.FeedID, article.GUID, article.Link)
+	}
+	if pending, ok := d.articles[key]; ok {
+		if onConflict == domain.ConflictUpdate && pending.FeedID == article.FeedID && pending.ContentHash != article.ContentHash {
+			return domain.ArticleUpdated, nil
+		}
+		return domain.ArticleSkipped, nil
+	}
+
+	// ID и время назначаются так же, как при записи в БД, чтобы вызывающий код не зависел от режима
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return "", err
+	}
+	article.ID = uuid
+	article.CreatedAt = time.Now()
+	article.UpdatedAt = article.CreatedAt
+	d.articles[key] = copyArticle(article)
+	return domain.ArticleInserted, nil
+}
+
+// RestoreArticle не выполняется: восстановление из резервной копии не бывает пробным
+func (d *DryRun) RestoreArticle(ctx context.Context, article *domain.Article) (domain.InsertResult, error) {
+	return "", fmt.Errorf("cannot restore articles in dry-run mode")
+}
+
+// GetArticlesByFeedName читает статьи из основного репозитория
+func (d *DryRun) GetArticlesByFeedName(ctx context.Context, feedName string, limit int) ([]*domain.Article, error) {
+	return d.base.GetArticlesByFeedName(ctx, feedName, limit)
+}
+
+// GetArticlesPage читает страницу статей из основного репозитория
+func (d *DryRun) GetArticlesPage(ctx context.Context, feedName string, after *domain.PageCursor, limit int) ([]*domain.Article, error) {
+	return d.base.GetArticlesPage(ctx, feedName, after, limit)
+}
+
+// GetUpdatedArticlesPage читает страницу измененных статей из основного репозитория
+func (d *DryRun) GetUpdatedArticlesPage(ctx context.Context, feedName string, after *domain.PageCursor, limit int) ([]*domain.Article, error) {
+	return d.base.GetUpdatedArticlesPage(ctx, feedName, after, limit)
+}
+
+// GetArticleByKey учитывает и сохраненные, и "вставленные" в этом запуске статьи
+func (d *DryRun) GetArticleByKey(ctx context.Context, feedID utils.UUID, dedupKey, link string) (*domain.Article, error) {
+	d.mu.Lock()
+	pending, ok := d.articles[dedupKey]
+	d.mu.Unlock()
+
+	if ok {
+		return copyArticle(pending), nil
+	}
+	return d.base.GetArticleByKey(ctx, feedID, dedupKey, link)
+}
+
+// UpdateArticleContent ничего не делает в режиме dry-run
+func (d *DryRun) UpdateArticleContent(ctx context.Context, article *domain.Article) error {
+	return nil
+}
+
+// GetRelatedArticles ищет похожие статьи в основном репозитории
+func (d *DryRun) GetRelatedArticles(ctx context.Context, articleID utils.UUID, limit int) ([]*domain.DigestEntry, error) {
+	return d.base.GetRelatedArticles(ctx, articleID, limit)
+}
+
+// GetArticlesByLink читает статьи из основного репозитория
+func (d *DryRun) GetArticlesByLink(ctx context.Context, link string) ([]*domain.DigestEntry, error) {
+	return d.base.GetArticlesByLink(ctx, link)
+}
+
+// GetArticleVersions читает версии статьи из основного репозитория
+func (d *DryRun) GetArticleVersions(ctx context.Context, articleID utils.UUID) ([]*domain.ArticleVersion, error) {
+	return d.base.GetArticleVersions(ctx, articleID)
+}
+
+// GetArticlesSince читает статьи из основного репозитория
+func (d *DryRun) GetArticlesSince(ctx context.Context, since time.Time, limit int) ([]*domain.DigestEntry, error) {
+	return d.base.GetArticlesSince(ctx, since, limit)
+}
+
+// FindArticles читает статьи из основного репозитория
+func (d *DryRun) FindArticles(ctx context.Context, filter domain.ArticleFilter) ([]*domain.Article, error) {
+	return d.base.FindArticles(ctx, filter)
+}
+
+// GetFeedStats читает статистику из основного репозитория
+func (d *DryRun) GetFeedStats(ctx context.Context, feedName string) ([]*domain.FeedStats, error) {
+	return d.base.GetFeedStats(ctx, feedName)
+}
+
+// GetFeedArticleCounts читает количество статей из основного репозитория
+func (d *DryRun) GetFeedArticleCounts(ctx context.Context) (map[utils.UUID]domain.ArticleCounts, error) {
+	return d.base.GetFeedArticleCounts(ctx)
+}
+
+// TrimFeedArticles ничего не удаляет в режиме dry-run
+func (d *DryRun) TrimFeedArticles(ctx context.Context, feedID utils.UUID, keep int) (int64, error) {
+	return 0, nil
+}
+
+// MarkArticleRead ничего не делает в режиме dry-run
+func (d *DryRun) MarkArticleRead(ctx context.Context, articleID utils.UUID) error {
+	return nil
+}
+
+// GetArticlesBySeq читает статьи из основного репозитория
+func (d *DryRun) GetArticlesBySeq(ctx context.Context, filter domain.ArticleSeqFilter) ([]*domain.Article, error) {
+	return d.base.GetArticlesBySeq(ctx, filter)
+}
+
+// GetArticleSeqs читает номера статей из основного репозитория
+func (d *DryRun) GetArticleSeqs(ctx context.Context, state domain.ArticleState) ([]int64, error) {
+	return d.base.GetArticleSeqs(ctx, state)
+}
+
+// MarkArticlesBySeq ничего не делает в режиме dry-run
+func (d *DryRun) MarkArticlesBySeq(ctx context.Context, seqs []int64, mark domain.ArticleMark) error {
+	return nil
+}
+
+// MarkFeedsReadBefore ничего не делает в режиме dry-run
+func (d *DryRun) MarkFeedsReadBefore(ctx context.Context, feedIDs []utils.UUID, before time.Time) error {
+	return nil
+}
+
+// SaveWebSubSubscription в режиме dry-run недоступен
+func (d *DryRun) SaveWebSubSubscription(ctx context.Context, sub *domain.WebSubSubscription) error {
+	return fmt.Errorf("cannot save websub subscription in dry-run mode")
+}
+
+// GetWebSubSubscription читает подписку из основного репозитория
+func (d *DryRun) GetWebSubSubscription(ctx context.Context, feedID utils.UUID) (*domain.WebSubSubscription, error) {
+	return d.base.GetWebSubSubscription(ctx, feedID)
+}
+
+// GetWebSubSubscriptions читает подписки из основного репозитория
+func (d *DryRun) GetWebSubSubscriptions(ctx context.Context) ([]*domain.WebSubSubscription, error) {
+	return d.base.GetWebSubSubscriptions(ctx)
+}
+
+// DeleteWebSubSubscription в режиме dry-run недоступен
+func (d *DryRun) DeleteWebSubSubscription(ctx context.Context, feedID utils.UUID) error {
+	return fmt.Errorf("cannot delete websub subscription in dry-run mode")
+}
+
+// SaveFeedIcon ничего не делает в режиме dry-run
+func (d *DryRun) SaveFeedIcon(ctx context.Context, icon *domain.FeedIcon) error {
+	return nil
+}
+
+// GetFeedIcon читает иконку из основного репозитория
+func (d *DryRun) GetFeedIcon(ctx context.Context, feedID utils.UUID) (*domain.FeedIcon, error) {
+	return d.base.GetFeedIcon(ctx, feedID)
+}
+
+// GetFeedIcons читает иконки из основного репозитория
+func (d *DryRun) GetFeedIcons(ctx context.Context) ([]*domain.FeedIcon, error) {
+	return d.base.GetFeedIcons(ctx)
+}
+
+// CreateAPIKey в режиме dry-run недоступен
+func (d *DryRun) CreateAPIKey(ctx context.Context, key *domain.APIKey) error {
+	return fmt.Errorf("cannot create api key in dry-run mode")
+}
+
+// GetAPIKeys читает ключи API из основного репозитория
+func (d *DryRun) GetAPIKeys(ctx context.Context) ([]*domain.APIKey, error) {
+	return d.base.GetAPIKeys(ctx)
+}
+
+// GetAPIKeyByHash читает ключ API из основного репозитория
+func (d *DryRun) GetAPIKeyByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	return d.base.GetAPIKeyByHash(ctx, hash)
+}
+
+// GetAPIKeyByFeverHash читает ключ API из основного репозитория
+func (d *DryRun) GetAPIKeyByFeverHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	return d.base.GetAPIKeyByFeverHash(ctx, hash)
+}
+
+// TouchAPIKey ничего не делает в режиме dry-run
+func (d *DryRun) TouchAPIKey(ctx context.Context, id utils.UUID) error {
+	return nil
+}
+
+// RevokeAPIKey в режиме dry-run недоступен
+func (d *DryRun) RevokeAPIKey(ctx context.Context, name string) error {
+	return fmt.Errorf("cannot revoke api key in dry-run mode")
+}
+
+// CreateSmartFeed в режиме dry-run недоступен
+func (d *DryRun) CreateSmartFeed(ctx context.Context, smartFeed *domain.SmartFeed) error {
+	return fmt.Errorf("cannot create smart feed in dry-run mode")
+}
+
+// GetSmartFeedByName читает смарт-ленту из основного репозитория
+func (d *DryRun) GetSmartFeedByName(ctx context.Context, name string) (*domain.SmartFeed, error) {
+	return d.base.GetSmartFeedByName(ctx, name)
+}
+
+// GetSmartFeeds читает смарт-ленты из основного репозитория
+func (d *DryRun) GetSmartFeeds(ctx context.Context) ([]*domain.SmartFeed, error) {
+	return d.base.GetSmartFeeds(ctx)
+}
+
+// DeleteSmartFeed в режиме dry-run недоступен
+func (d *DryRun) DeleteSmartFeed(ctx context.Context, name string) error {
+	return fmt.Errorf("cannot delete smart feed in dry-run mode")
+}
+
+// GetSmartFeedArticles читает статьи смарт-ленты из основного репозитория
+func (d *DryRun) GetSmartFeedArticles(ctx context.Context, query domain.QueryNode, after *domain.PageCursor, limit int) ([]*domain.DigestEntry, error) {
+	return d.base.GetSmartFeedArticles(ctx, query, after, limit)
+}
+
+// CreateNotificationRule в режиме dry-run недоступен
+func (d *DryRun) CreateNotificationRule(ctx context.Context, rule *domain.NotificationRule) error {
+	return fmt.Errorf("cannot create notification rule in dry-run mode")
+}
+
+// GetNotificationRules читает правила уведомлений из основного репозитория
+func (d *DryRun) GetNotificationRules(ctx context.Context) ([]*domain.NotificationRule, error) {
+	return d.base.GetNotificationRules(ctx)
+}
+
+// DeleteNotificationRule в режиме dry-run недоступен
+func (d *DryRun) DeleteNotificationRule(ctx context.Context, name string) error {
+	return fmt.Errorf("cannot delete notification rule in dry-run mode")
+}
+
+// CreateFilterRule в режиме dry-run недоступен
+func (d *DryRun) CreateFilterRule(ctx context.Context, rule *domain.FilterRule) error {
+	return fmt.Errorf("cannot create filter rule in dry-run mode")
+}
+
+// GetFilterRules читает правила фильтров из основного репозитория: пробный цикл отбрасывает
+// те же элементы, что и настоящий
+func (d *DryRun) GetFilterRules(ctx context.Context) ([]*domain.FilterRule, error) {
+	return d.base.GetFilterRules(ctx)
+}
+
+// DeleteFilterRule в режиме dry-run недоступен
+func (d *DryRun) DeleteFilterRule(ctx context.Context, name string) error {
+	return fmt.Errorf("cannot delete filter rule in dry-run mode")
+}
+
+// SaveFetchRun ничего не делает в режиме dry-run: история циклов не изменяется
+func (d *DryRun) SaveFetchRun(ctx context.Context, run *domain.FetchRun) error {
+	return nil
+}
+
+// GetFetchRuns читает историю циклов из основного репозитория
+func (d *DryRun) GetFetchRuns(ctx context.Context, limit int) ([]*domain.FetchRun, error) {
+	return d.base.GetFetchRuns(ctx, limit)
+}
+
+// AddFeedTraffic ничего не делает в режиме dry-run: трафик пробного запуска не учитывается в лимите
+func (d *DryRun) AddFeedTraffic(ctx context.Context, feedID utils.UUID, at time.Time, bytes int64) error {
+	return nil
+}
+
+// GetTrafficSince читает трафик из основного репозитория
+func (d *DryRun) GetTrafficSince(ctx context.Context, since time.Time) (int64, error) {
+	return d.base.GetTrafficSince(ctx, since)
+}
+
+// GetFeedTraffic читает трафик лент из основного репозитория
+func (d *DryRun) GetFeedTraffic(ctx context.Context, since time.Time) (map[string]int64, error) {
+	return d.base.GetFeedTraffic(ctx, since)
+}
+
+// SetAggregatorSetting запоминает настройку в памяти
+func (d *DryRun) SetAggregatorSetting(ctx context.Context, key, value string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.settings[key] = value
+	return nil
+}
+
+// GetAggregatorSetting читает настройку из памяти или из основного репозитория
+func (d *DryRun) GetAggregatorSetting(ctx context.Context, key string) (string, error) {
+	d.mu.Lock()
+	value, ok := d.settings[key]
+	d.mu.Unlock()
+
+	if ok {
+		return value, nil
+	}
+	return d.base.GetAggregatorSetting(ctx, key)
+}
+
+// NotifySettingsChanged ничего не делает: настройки dry-run не видны запущенным агрегаторам
+func (d *DryRun) NotifySettingsChanged(ctx context.Context) error {
+	return nil
+}
+
+// ListenSettingsChanges слушает изменения настроек основного репозитория
+func (d *DryRun) ListenSettingsChanges(ctx context.Context) (<-chan struct{}, error) {
+	return d.base.ListenSettingsChanges(ctx)
+}
+
+// TryLock всегда успешен: блокировки в режиме dry-run не нужны
+func (d *DryRun) TryLock(ctx context.Context, lockName string) (bool, error) {
+	return true, nil
+}
+
+// ReleaseLock ничего не делает в режиме dry-run
+func (d *DryRun) ReleaseLock(ctx context.Context, lockName string) error {
+	return nil
+}
@@ -0,0 +1,1803 @@
+// internal/adapter/storage/memory/memory.go
+package memory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/urlnorm"
+	"rsshub/internal/platform/utils"
+)
+
+// Проверяем на этапе компиляции, что Store реализует репозиторий
+var _ port.FeedArticleRepository = (*Store)(nil)
+
+// Store хранит ленты, статьи и настройки агрегатора в памяти процесса.
+// Используется в тестах и в режимах, которые не должны ничего записывать в БД.
+// Все методы возвращают копии, поэтому вызывающий код не может изменить хранилище напрямую
+type Store struct {
+	mu       sync.RWMutex
+	txMu     sync.Mutex // Сериализует транзакции, чтобы откат одной не отменял изменения другой
+	feeds    map[utils.UUID]*domain.Feed
+	claims   map[utils.UUID]claim
+	articles map[utils.UUID]*domain.Article
+	versions map[utils.UUID][]*domain.ArticleVersion // Версии содержимого статей по ID статьи
+	settings map[string]string
+	websub   map[utils.UUID]*domain.WebSubSubscription
+	icons    map[utils.UUID]*domain.FeedIcon
+	apiKeys  map[utils.UUID]*domain.APIKey
+	smart    map[string]*domain.SmartFeed        // Смарт-ленты по имени
+	rules    map[string]*domain.NotificationRule // Правила уведомлений по имени
+	filters  map[string]*domain.FilterRule       // Правила фильтров по имени
+	runs     []*domain.FetchRun                  // История циклов в порядке сохранения
+	traffic  []trafficRecord                     // Трафик лент по дням
+	folders  map[string]bool                     // Пути папок, включая родительские
+
+	feedSeq    int64 // Последний выданный номер ленты (как BIGSERIAL в PostgreSQL)
+	articleSeq int64 // Последний выданный номер статьи
+
+	listenMu  sync.Mutex
+	listeners map[chan struct{}]struct{} // Подписчики на изменения настроек (см. ListenSettingsChanges)
+}
+
+// claim резервирование ленты экземпляром агрегатора
+type claim struct {
+	owner string
+	until time.Time
+}
+
+// trafficRecord трафик ленты за день; у удаленной ленты feedID пустой, трафик остается в лимите
+type trafficRecord struct {
+	feedID utils.UUID
+	day    string // Дата в формате 2006-01-02 по местному времени
+	bytes  int64
+}
+
+// New создает пустое хранилище в памяти
+func New() *Store {
+	return &Store{
+		feeds:    make(map[utils.UUID]*domain.Feed),
+		claims:   make(map[utils.UUID]claim),
+		articles: make(map[utils.UUID]*domain.Article),
+		versions: make(map[utils.UUID][]*domain.ArticleVersion),
+		settings: make(map[string]string),
+		websub:   make(map[utils.UUID]*domain.WebSubSubscription),
+		icons:    make(map[utils.UUID]*domain.FeedIcon),
+		apiKeys:  make(map[utils.UUID]*domain.APIKey),
+		smart:    make(map[string]*domain.SmartFeed),
+		rules:    make(map[string]*domain.NotificationRule),
+		filters:  make(map[string]*domain.FilterRule),
+		folders:  make(map[string]bool),
+
+		listeners: make(map[chan struct{}]struct{}),
+	}
+}
+
+// WithinTransaction выполняет fn над хранилищем и при ошибке или панике fn восстанавливает
+// состояние, бывшее до ее вызова. Транзакции выполняются по очереди, но изоляции от остальных
+// вызовов нет: они видят изменения fn сразу
+func (s *Store) WithinTransaction(ctx context.Context, fn func(repo port.FeedArticleRepository) error) error {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	saved := s.snapshot()
+	defer func() {
+		if p := recover(); p != nil {
+			s.restore(saved)
+			panic(p)
+		}
+	}()
+
+	if err := fn(s); err != nil {
+		s.restore(saved)
+		return err
+	}
+	return nil
+}
+
+// snapshot возвращает независимую копию данных хранилища
+func (s *Store) snapshot() *Store {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c := New()
+	for id, feed := range s.feeds {
+		c.feeds[id] = copyFeed(feed)
+	}
+	for id, cl := range s.claims {
+		c.claims[id] = cl
+	}
+	for id, article := range s.articles {
+		c.articles[id] = copyArticle(article)
+	}
+	for id, versions := range s.versions {
+		c.versions[id] = append([]*domain.ArticleVersion(nil), versions...)
+	}
+	for key, value := range s.settings {
+		c.settings[key] = value
+	}
+	for id, sub := range s.websub {
+		c.websub[id] = copyWebSubSubscription(sub)
+	}
+	for id, icon := range s.icons {
+		c.icons[id] = copyFeedIcon(icon)
+	}
+	for id, key := range s.apiKeys {
+		c.apiKeys[id] = copyAPIKey(key)
+	}
+	// Смарт-ленты и правила заменяются целиком, а не меняются на месте
+	for name, smartFeed := range s.smart {
+		c.smart[name] = smartFeed
+	}
+	for name, rule := range s.rules {
+		c.rules[name] = rule
+	}
+	for name, rule := range s.filters {
+		c.filters[name] = rule
+	}
+	for _, run := range s.runs {
+		c.runs = append(c.runs, copyFetchRun(run))
+	}
+	c.traffic = append([]trafficRecord(nil), s.traffic...)
+	for path := range s.folders {
+		c.folders[path] = true
+	}
+	c.feedSeq, c.articleSeq = s.feedSeq, s.articleSeq
+	return c
+}
+
+// restore заменяет данные хранилища снимком
+func (s *Store) restore(saved *Store) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.feeds, s.claims, s.articles, s.versions = saved.feeds, saved.claims, saved.articles, saved.versions
+	s.settings, s.websub, s.icons, s.apiKeys = saved.settings, saved.websub, saved.icons, saved.apiKeys
+	s.smart, s.rules, s.runs, s.traffic = saved.smart, saved.rules, saved.runs, saved.traffic
+	s.filters, s.folders = saved.filters, saved.folders
+	s.feedSeq, s.articleSeq = saved.feedSeq, saved.articleSeq
+}
+
+// CreateFeed добавляет ленту, проверяя уникальность имени
+func (s *Store) CreateFeed(ctx context.Context, feed *domain.Feed) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findFeedByName(feed.Name) != nil {
+		return fmt.Errorf("%w: %s", domain.ErrDuplicateFeed, feed.Name)
+	}
+
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return err
+	}
+	feed.ID = uuid
+	feed.CreatedAt = time.Now()
+	feed.UpdatedAt = feed.CreatedAt
+	s.feedSeq++
+	feed.Seq = s.feedSeq
+
+	s.feeds[feed.ID] = copyFeed(feed)
+	s.addFolder(feed.Folder)
+	return nil
+}
+
+// GetFeedByName возвращает ленту по имени
+func (s *Store) GetFeedByName(ctx context.Context, name string) (*domain.Feed, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	feed := s.findFeedByName(name)
+	if feed == nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrFeedNotFound, name)
+	}
+	return copyFeed(feed), nil
+}
+
+// GetFeedByURL возвращает добавленную первой ленту с тем же нормализованным URL
+func (s *Store) GetFeedByURL(ctx context.Context, url string) (*domain.Feed, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key := urlnorm.Key(url)
+	for _, feed := range s.sortedFeeds(func(a, b *domain.Feed) bool { return newerFirst(b.CreatedAt, b.ID, a.CreatedAt, a.ID) }) {
+		if urlnorm.Key(feed.URL) == key {
+			return feed, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", domain.ErrFeedNotFound, url)
+}
+
+// GetFeedByID возвращает ленту по ID
+func (s *Store) GetFeedByID(ctx context.Context, id utils.UUID) (*domain.Feed, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	feed, ok := s.feeds[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domain.ErrFeedNotFound, id)
+	}
+	return copyFeed(feed), nil
+}
+
+// GetAllFeeds возвращает ленты от новых к старым, опционально ограничивая количество
+func (s *Store) GetAllFeeds(ctx context.Context, limit int) ([]*domain.Feed, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	feeds := s.sortedFeeds(func(a, b *domain.Feed) bool {
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+	return limitFeeds(feeds, limit), nil
+}
+
+// GetFeedsPage возвращает страницу лент (новые сначала) после курсора after
+func (s *Store) GetFeedsPage(ctx context.Context, after *domain.PageCursor, limit int) ([]*domain.Feed, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var page []*domain.Feed
+	for _, feed := range s.sortedFeeds(func(a, b *domain.Feed) bool { return newerFirst(a.CreatedAt, a.ID, b.CreatedAt, b.ID) }) {
+		if after == nil || newerFirst(after.Time, after.ID, feed.CreatedAt, feed.ID) {
+			page = append(page, feed)
+		}
+	}
+	return limitFeeds(page, limit), nil
+}
+
+// GetOldestFeeds возвращает самые устаревшие включенные ленты с учетом приоритета,
+// которые пора получать по их интервалу или staleAfter
+func (s *Store) GetOldestFeeds(ctx context.Context, limit int, staleAfter time.Duration) ([]*domain.Feed, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return limitFeeds(staleFeeds(s.sortedFeeds(dueOrder), time.Now(), staleAfter), limit), nil
+}
+
+// ClaimFeeds резервирует свободные включенные ленты за владельцем на время аренды
+func (s *Store) ClaimFeeds(ctx context.Context, owner string, limit int, lease, staleAfter time.Duration) ([]*domain.Feed, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var claimed []*domain.Feed
+	for _, feed := range staleFeeds(s.sortedFeeds(dueOrder), now, staleAfter) {
+		if limit > 0 && len(claimed) >= limit {
+			break
+		}
+		if c, ok := s.claims[feed.ID]; ok && c.until.After(now) {
+			continue
+		}
+		if feed.NextRunAt != nil && feed.NextRunAt.After(now) {
+			continue
+		}
+		s.claims[feed.ID] = claim{owner: owner, until: now.Add(lease)}
+		claimed = append(claimed, feed)
+	}
+	return claimed, nil
+}
+
+// ClaimFeed резервирует ленту за владельцем, если она еще не зарезервирована
+func (s *Store) ClaimFeed(ctx context.Context, feedID utils.UUID, owner string, lease time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if c, ok := s.claims[feedID]; ok && c.until.After(now) {
+		return false, nil
+	}
+	if _, ok := s.feeds[feedID]; !ok {
+		return false, nil
+	}
+	s.claims[feedID] = claim{owner: owner, until: now.Add(lease)}
+	return true, nil
+}
+
+// ReleaseFeedClaim снимает резервирование ленты
+func (s *Store) ReleaseFeedClaim(ctx context.Context, feedID utils.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.claims, feedID)
+	return nil
+}
+
+// UpdateFeedTimestamp обновляет время получения ленты и снимает резервирование
+func (s *Store) UpdateFeedTimestamp(ctx context.Context, feedID utils.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if feed, ok := s.feeds[feedID]; ok {
+		feed.UpdatedAt = time.Now()
+	}
+	delete(s.claims, feedID)
+	return nil
+}
+
+// SetFeedCache сохраняет валидаторы кеша последнего ответа ленты
+func (s *Store) SetFeedCache(ctx context.Context, feedID utils.UUID, cache domain.CacheValidators) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if feed, ok := s.feeds[feedID]; ok {
+		feed.Cache = cache
+	}
+	return nil
+}
+
+// UpdateFeed сохраняет изменяемые поля ленты, не трогая время получения
+func (s *Store) UpdateFeed(ctx context.Context, feed *domain.Feed) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.feeds[feed.ID]
+	if !ok {
+		return fmt.Errorf("%w: %s", domain.ErrFeedNotFound, feed.Name)
+	}
+	if other := s.findFeedByName(feed.Name); other != nil && other.ID != feed.ID {
+		return fmt.Errorf("%w: %s", domain.ErrDuplicateFeed, feed.Name)
+	}
+
+	updated := copyFeed(feed)
+	updated.CreatedAt = stored.CreatedAt
+	updated.UpdatedAt = stored.UpdatedAt
+	updated.FetchFailures = stored.FetchFailures
+	updated.ActiveURL = stored.ActiveURL
+	updated.LastError = stored.LastError
+	updated.LastErrorAt = stored.LastErrorAt
+	updated.FailingSince = stored.FailingSince
+	updated.DisabledReason = stored.DisabledReason
+	updated.DisabledAt = stored.DisabledAt
+	updated.Cache = domain.CacheValidators{}
+	if updated.URL == stored.URL {
+		updated.Cache = stored.Cache
+	}
+	if updated.Enabled {
+		// Включение ленты сбрасывает причину отключения и серию неудач
+		if !stored.Enabled {
+			updated.FailingSince = nil
+		}
+		updated.DisabledReason = ""
+		updated.DisabledAt = nil
+	}
+	updated.Seq = stored.Seq
+	s.feeds[feed.ID] = updated
+	s.addFolder(updated.Folder)
+	return nil
+}
+
+// addFolder запоминает папку и все ее родительские папки; вызывается под s.mu
+func (s *Store) addFolder(path string) {
+	for _, ancestor := range domain.FolderAncestors(path) {
+		s.folders[ancestor] = true
+	}
+}
+
+// GetFolders возвращает пути всех папок по алфавиту
+func (s *Store) GetFolders(ctx context.Context) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	folders := make([]string, 0, len(s.folders))
+	for path := range s.folders {
+		folders = append(folders, path)
+	}
+	sort.Strings(folders)
+	return folders, nil
+}
+
+// SetFeedFetchState сохраняет число неудач основного URL подряд и зеркало, с которого получена лента
+func (s *Store) SetFeedFetchState(ctx context.Context, feedID utils.UUID, failures int, activeURL string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if feed, ok := s.feeds[feedID]; ok {
+		feed.FetchFailures = failures
+		feed.ActiveURL = activeURL
+	}
+	return nil
+}
+
+// SetFeedNextRun сохраняет время следующего запуска ленты по расписанию (nil - без расписания)
+func (s *Store) SetFeedNextRun(ctx context.Context, feedID utils.UUID, next *time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if feed, ok := s.feeds[feedID]; ok {
+		feed.NextRunAt = nil
+		if next != nil {
+			t := *next
+			feed.NextRunAt = &t
+		}
+	}
+	return nil
+}
+
+// SetFeedLastError сохраняет последнюю ошибку обработки ленты; пустое сообщение ее сбрасывает
+func (s *Store) SetFeedLastError(ctx context.Context, feedID utils.UUID, message string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if feed, ok := s.feeds[feedID]; ok {
+		feed.LastError = message
+		feed.LastErrorAt = nil
+		if message == "" {
+			feed.FailingSince = nil
+			return nil
+		}
+		now := time.Now()
+		feed.LastErrorAt = &now
+		if feed.FailingSince == nil {
+			feed.FailingSince = &now
+		}
+	}
+	return nil
+}
+
+// DisableFeed отключает мертвую ленту, сохраняя причину и время отключения
+func (s *Store) DisableFeed(ctx context.Context, feedID utils.UUID, reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	feed, ok := s.feeds[feedID]
+	if !ok {
+		return fmt.Errorf("%w: %s", domain.ErrFeedNotFound, feedID)
+	}
+	now := time.Now()
+	feed.Enabled = false
+	feed.DisabledReason = reason
+	feed.DisabledAt = &now
+	return nil
+}
+
+// DeleteFeed удаляет ленту и ее статьи
+func (s *Store) DeleteFeed(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	feed := s.findFeedByName(name)
+	if feed == nil {
+		return fmt.Errorf("%w: %s", domain.ErrFeedNotFound, name)
+	}
+
+	s.deleteFeed(feed)
+	return nil
+}
+
+// DeleteFeeds удаляет несколько лент: если какой-то ленты нет, не удаляется ни одна
+func (s *Store) DeleteFeeds(ctx context.Context, names []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	feeds := make([]*domain.Feed, 0, len(names))
+	for _, name := range names {
+		feed := s.findFeedByName(name)
+		if feed == nil {
+			return fmt.Errorf("%w: %s", domain.ErrFeedNotFound, name)
+		}
+		feeds = append(feeds, feed)
+	}
+
+	for _, feed := range feeds {
+		s.deleteFeed(feed)
+	}
+	return nil
+}
+
+// deleteFeed удаляет ленту вместе с ее статьями, заявкой, подпиской и иконкой, отвязывая
+// от нее трафик; вызывается под s.mu
+func (s *Store) deleteFeed(feed *domain.Feed) {
+	delete(s.feeds, feed.ID)
+	delete(s.claims, feed.ID)
+	delete(s.websub, feed.ID)
+	delete(s.icons, feed.ID)
+	for i := range s.traffic {
+		if s.traffic[i].feedID == feed.ID {
+			s.traffic[i].feedID = utils.UUID{}
+		}
+	}
+	for id, article := range s.articles {
+		if article.FeedID == feed.ID {
+			delete(s.articles, id)
+			delete(s.versions, id)
+		}
+	}
+}
+
+// CreateArticle добавляет статью, назначая ей ID, время создания и номер; уже сохраненная статья
+// обновляется или пропускается по onConflict, как в PostgreSQL хранилище
+func (s *Store) CreateArticle(ctx context.Context, article *domain.Article, onConflict domain.ConflictMode) (domain.InsertResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.feeds[article.FeedID]; !ok {
+		return "", fmt.Errorf("failed to create article: feed %s does not exist", article.FeedID)
+	}
+	if article.DedupKey == "" {
+		article.DedupKey = domain.ArticleDedupKey(domain.DedupByLink, article.FeedID, article.GUID, article.Link)
+	}
+	if stored := s.findArticle(article.FeedID, article.DedupKey, ""); stored != nil {
+		if onConflict != domain.ConflictUpdate || stored.FeedID != article.FeedID || stored.ContentHash == article.ContentHash {
+			return domain.ArticleSkipped, nil
+		}
+
+		now := time.Now()
+		if stored.ContentHash != "" {
+			stored.ModifiedAt = &now
+		}
+		stored.Title = article.Title
+		stored.Description = article.Description
+		stored.ContentHash = article.ContentHash
+		stored.UpdatedAt = now
+		s.addVersion(stored)
+
+		article.ID, article.Seq = stored.ID, stored.Seq
+		article.CreatedAt, article.UpdatedAt, article.ModifiedAt = stored.CreatedAt, stored.UpdatedAt, stored.ModifiedAt
+		return domain.ArticleUpdated, nil
+	}
+
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return "", err
+	}
+	article.ID = uuid
+	article.CreatedAt = time.Now()
+	article.UpdatedAt = article.CreatedAt
+	s.articleSeq++
+	article.Seq = s.articleSeq
+
+	s.articles[article.ID] = copyArticle(article)
+	s.addVersion(article)
+	return domain.ArticleInserted, nil
+}
+
+// RestoreArticle сохраняет статью из резервной копии с ее временем сохранения и состоянием
+func (s *Store) RestoreArticle(ctx context.Context, article *domain.Article) (domain.InsertResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.feeds[article.FeedID]; !ok {
+		return "", fmt.Errorf("failed to restore article: feed %s does not exist", article.FeedID)
+	}
+	if article.DedupKey == "" {
+		article.DedupKey = domain.ArticleDedupKey(domain.DedupByLink, article.FeedID, article.GUID, article.Link)
+	}
+	if s.findArticle(article.FeedID, article.DedupKey, "") != nil {
+		return domain.ArticleSkipped, nil
+	}
+
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return "", err
+	}
+	article.ID = uuid
+	if article.CreatedAt.IsZero() {
+		article.CreatedAt = time.Now()
+	}
+	if article.UpdatedAt.IsZero() {
+		article.UpdatedAt = article.CreatedAt
+	}
+	s.articleSeq++
+	article.Seq = s.articleSeq
+
+	s.articles[article.ID] = copyArticle(article)
+	s.addVersion(article)
+	return domain.ArticleInserted, nil
+}
+
+// addVersion сохраняет содержимое статьи как ее версию, если версии с таким хешем еще нет
+func (s *Store) addVersion(article *domain.Article) {
+	if article.ContentHash == "" {
+		return
+	}
+	for _, v := range s.versions[article.ID] {
+		if v.ContentHash == article.ContentHash {
+			return
+		}
+	}
+	s.versions[article.ID] = append(s.versions[article.ID], &domain.ArticleVersion{
+		ArticleID:   article.ID,
+		ContentHash: article.ContentHash,
+		Title:       article.Title,
+		Description: article.Description,
+		CreatedAt:   time.Now(),
+	})
+}
+
+// GetArticlesByFeedName возвращает последние статьи ленты
+func (s *Store) GetArticlesByFeedName(ctx context.Context, feedName string, limit int) ([]*domain.Article, error) {
+	if limit <= 0 {
+		limit = 3 // Значение по умолчанию, как в PostgreSQL хранилище
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	feed := s.findFeedByName(feedName)
+	if feed == nil {
+		return nil, nil
+	}
+
+	var articles []*domain.Article
+	for _, article := range s.articles {
+		if article.FeedID == feed.ID {
+			articles = append(articles, copyArticle(article))
+		}
+	}
+	sort.Slice(articles, func(i, j int) bool {
+		return articles[i].PublishedAt.After(articles[j].PublishedAt)
+	})
+
+	if len(articles) > limit {
+		articles = articles[:limit]
+	}
+	return articles, nil
+}
+
+// GetArticlesPage возвращает страницу статей ленты (новые сначала) после курсора after
+func (s *Store) GetArticlesPage(ctx context.Context, feedName string, after *domain.PageCursor, limit int) ([]*domain.Article, error) {
+	return s.articlesPage(feedName, after, limit, false)
+}
+
+// GetUpdatedArticlesPage возвращает страницу статей ленты, измененных лентой после сохранения
+func (s *Store) GetUpdatedArticlesPage(ctx context.Context, feedName string, after *domain.PageCursor, limit int) ([]*domain.Article, error) {
+	return s.articlesPage(feedName, after, limit, true)
+}
+
+// articlesPage выбирает страницу статей ленты; onlyModified оставляет только измененные
+func (s *Store) articlesPage(feedName string, after *domain.PageCursor, limit int, onlyModified bool) ([]*domain.Article, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	feed := s.findFeedByName(feedName)
+	if feed == nil {
+		return nil, nil
+	}
+
+	var articles []*domain.Article
+	for _, article := range s.articles {
+		if article.FeedID != feed.ID || (onlyModified && article.ModifiedAt == nil) {
+			continue
+		}
+		if after != nil && !newerFirst(after.Time, after.ID, article.PublishedAt, article.ID) {
+			continue
+		}
+		articles = append(articles, copyArticle(article))
+	}
+	sort.Slice(articles, func(i, j int) bool {
+		return newerFirst(articles[i].PublishedAt, articles[i].ID, articles[j].PublishedAt, articles[j].ID)
+	})
+
+	if limit > 0 && len(articles) > limit {
+		articles = articles[:limit]
+	}
+	return articles, nil
+}
+
+// GetArticleByKey ищет статью по ключу уникальности или ссылке; совпадение по ключу важнее
+func (s *Store) GetArticleByKey(ctx context.Context, feedID utils.UUID, dedupKey, link string) (*domain.Article, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	article := s.findArticle(feedID, dedupKey, "")
+	if article == nil {
+		article = s.findArticle(feedID, dedupKey, link)
+	}
+	if article == nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrArticleNotFound, link)
+	}
+	return copyArticle(article), nil
+}
+
+// UpdateArticleContent сохраняет измененное лентой содержимое статьи и обновляет UpdatedAt
+func (s *Store) UpdateArticleContent(ctx context.Context, article *domain.Article) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.articles[article.ID]
+	if !ok {
+		return fmt.Errorf("%w: %s", domain.ErrArticleNotFound, article.ID)
+	}
+
+	article.UpdatedAt = time.Now()
+	stored.Title = article.Title
+	stored.Description = article.Description
+	stored.ContentHash = article.ContentHash
+	stored.UpdatedAt = article.UpdatedAt
+	stored.ModifiedAt = nil
+	if article.ModifiedAt != nil {
+		modifiedAt := *article.ModifiedAt
+		stored.ModifiedAt = &modifiedAt
+	}
+	s.addVersion(stored)
+	return nil
+}
+
+// GetArticlesByLink возвращает статьи всех лент с этой ссылкой, новые первыми
+func (s *Store) GetArticlesByLink(ctx context.Context, link string) ([]*domain.DigestEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var entries []*domain.DigestEntry
+	for _, article := range s.articles {
+		feed, ok := s.feeds[article.FeedID]
+		if !ok || article.Link != link {
+			continue
+		}
+		entries = append(entries, &domain.DigestEntry{
+			Article:    copyArticle(article),
+			FeedName:   feed.Name,
+			FeedTags:   append([]string(nil), feed.Tags...),
+			FeedFolder: feed.Folder,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Article.CreatedAt.After(entries[j].Article.CreatedAt)
+	})
+	return entries, nil
+}
+
+// GetRelatedArticles ищет статьи, похожие на articleID: за каждое слово заголовка статьи,
+// найденное в заголовке другой статьи, начисляется 2 балла, в ее описании - 1
+func (s *Store) GetRelatedArticles(ctx context.Context, articleID utils.UUID, limit int) ([]*domain.DigestEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	source, ok := s.articles[articleID]
+	if !ok {
+		return nil, domain.ErrArticleNotFound
+	}
+	queryTerms := make(map[string]bool)
+	for _, term := range domain.Terms(source.Title) {
+		queryTerms[term] = true
+	}
+
+	type scored struct {
+		entry *domain.DigestEntry
+		score int
+	}
+	var results []scored
+	for id, article := range s.articles {
+		feed, ok := s.feeds[article.FeedID]
+		if id == articleID || !ok {
+			continue
+		}
+
+		score := 0
+		inTitle := make(map[string]bool)
+		for _, term := range domain.Terms(article.Title) {
+			if queryTerms[term] && !inTitle[term] {
+				inTitle[term] = true
+				score += 2
+			}
+		}
+		inDescription := make(map[string]bool)
+		for _, term := range domain.Terms(article.Description) {
+			if queryTerms[term] && !inTitle[term] && !inDescription[term] {
+				inDescription[term] = true
+				score++
+			}
+		}
+		if score == 0 {
+			continue
+		}
+
+		results = append(results, scored{score: score, entry: &domain.DigestEntry{
+			Article:    copyArticle(article),
+			FeedName:   feed.Name,
+			FeedTags:   append([]string(nil), feed.Tags...),
+			FeedFolder: feed.Folder,
+		}})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].score != results[j].score {
+			return results[i].score > results[j].score
+		}
+		return results[i].entry.Article.PublishedAt.After(results[j].entry.Article.PublishedAt)
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	entries := make([]*domain.DigestEntry, 0, len(results))
+	for _, r := range results {
+		entries = append(entries, r.entry)
+	}
+	return entries, nil
+}
+
+// GetArticleVersions возвращает версии содержимого статьи в порядке получения
+func (s *Store) GetArticleVersions(ctx context.Context, articleID utils.UUID) ([]*domain.ArticleVersion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	versions := make([]*domain.ArticleVersion, 0, len(s.versions[articleID]))
+	for _, v := range s.versions[articleID] {
+		c := *v
+		versions = append(versions, &c)
+	}
+	return versions, nil
+}
+
+// GetArticlesSince возвращает статьи, добавленные начиная с since, вместе с данными их лент
+func (s *Store) GetArticlesSince(ctx context.Context, since time.Time, limit int) ([]*domain.DigestEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var entries []*domain.DigestEntry
+	for _, article := range s.articles {
+		if article.CreatedAt.Before(since) {
+			continue
+		}
+		feed, ok := s.feeds[article.FeedID]
+		if !ok {
+			continue
+		}
+		entries = append(entries, &domain.DigestEntry{
+			Article:    copyArticle(article),
+			FeedName:   feed.Name,
+			FeedTags:   append([]string(nil), feed.Tags...),
+			FeedFolder: feed.Folder,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Article.CreatedAt.After(entries[j].Article.CreatedAt)
+	})
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+// FindArticles возвращает статьи по фильтру в хронологическом порядке
+func (s *Store) FindArticles(ctx context.Context, filter domain.ArticleFilter) ([]*domain.Article, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var articles []*domain.Article
+	for _, article := range s.articles {
+		if filter.FeedName != "" {
+			if feed, ok := s.feeds[article.FeedID]; !ok || feed.Name != filter.FeedName {
+				continue
+			}
+		}
+		if !filter.Since.IsZero() && article.PublishedAt.Before(filter.Since) {
+			continue
+		}
+		articles = append(articles, copyArticle(article))
+	}
+	sort.Slice(articles, func(i, j int) bool {
+		return articles[i].PublishedAt.Before(articles[j].PublishedAt)
+	})
+
+	if filter.Limit > 0 && len(articles) > filter.Limit {
+		articles = articles[:filter.Limit]
+	}
+	return articles, nil
+}
+
+// GetFeedStats считает статистику статей по ленте feedName или по всем лентам (пустое имя)
+func (s *Store) GetFeedStats(ctx context.Context, feedName string) ([]*domain.FeedStats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	byFeed := make(map[utils.UUID]*domain.FeedStats)
+	for _, feed := range s.feeds {
+		if feedName == "" || feed.Name == feedName {
+			byFeed[feed.ID] = &domain.FeedStats{FeedName: feed.Name, LastFetchedAt: feed.UpdatedAt}
+		}
+	}
+
+	for _, article := range s.articles {
+		stats, ok := byFeed[article.FeedID]
+		if !ok {
+			continue
+		}
+
+		published := article.PublishedAt
+		stats.TotalArticles++
+		if stats.FirstArticleAt == nil || published.Before(*stats.FirstArticleAt) {
+			stats.FirstArticleAt = &published
+		}
+		if stats.LastArticleAt == nil || published.After(*stats.LastArticleAt) {
+			stats.LastArticleAt = &published
+		}
+
+		age := now.Sub(published)
+		switch {
+		case age <= 24*time.Hour:
+			stats.LastDay++
+			stats.LastWeek++
+		case age <= 7*24*time.Hour:
+			stats.LastWeek++
+		case age <= 14*24*time.Hour:
+			stats.PrevWeek++
+		}
+	}
+
+	result := make([]*domain.FeedStats, 0, len(byFeed))
+	for _, stats := range byFeed {
+		result = append(result, stats)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].FeedName < result[j].FeedName })
+
+	return result, nil
+}
+
+// GetFeedArticleCounts считает все и непрочитанные статьи каждой ленты
+func (s *Store) GetFeedArticleCounts(ctx context.Context) (map[utils.UUID]domain.ArticleCounts, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[utils.UUID]domain.ArticleCounts)
+	for _, article := range s.articles {
+		if _, ok := s.feeds[article.FeedID]; !ok {
+			continue
+		}
+		c := counts[article.FeedID]
+		c.Total++
+		if article.ReadAt == nil {
+			c.Unread++
+		}
+		counts[article.FeedID] = c
+	}
+	return counts, nil
+}
+
+// TrimFeedArticles удаляет статьи ленты старше keep самых новых, кроме статей в избранном
+func (s *Store) TrimFeedArticles(ctx context.Context, feedID utils.UUID, keep int) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var articles []*domain.Article
+	for _, article := range s.articles {
+		if article.FeedID == feedID {
+			articles = append(articles, article)
+		}
+	}
+	if len(articles) <= keep {
+		return 0, nil
+	}
+	sort.Slice(articles, func(i, j int) bool {
+		return newerFirst(articles[i].PublishedAt, articles[i].ID, articles[j].PublishedAt, articles[j].ID)
+	})
+
+	var deleted int64
+	for _, article := range articles[keep:] {
+		if article.SavedAt == nil {
+			delete(s.articles, article.ID)
+			delete(s.versions, article.ID)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// MarkArticleRead отмечает статью прочитанной
+func (s *Store) MarkArticleRead(ctx context.Context, articleID utils.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if article, ok := s.articles[articleID]; ok && article.ReadAt == nil {
+		now := time.Now()
+		article.ReadAt = &now
+	}
+	return nil
+}
+
+// GetArticlesBySeq выбирает статьи по номерам: после SinceSeq по возрастанию, до MaxSeq по убыванию
+// или перечисленные в Seqs
+func (s *Store) GetArticlesBySeq(ctx context.Context, filter domain.ArticleSeqFilter) ([]*domain.Article, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	wanted := make(map[int64]bool, len(filter.Seqs))
+	for _, seq := range filter.Seqs {
+		wanted[seq] = true
+	}
+
+	var articles []*domain.Article
+	for _, article := range s.articles {
+		switch {
+		case len(filter.Seqs) > 0:
+			if !wanted[article.Seq] {
+				continue
+			}
+		case filter.MaxSeq > 0:
+			if article.Seq >= filter.MaxSeq {
+				continue
+			}
+		default:
+			if article.Seq <= filter.SinceSeq {
+				continue
+			}
+		}
+		articles = append(articles, copyArticle(article))
+	}
+
+	descending := len(filter.Seqs) == 0 && filter.MaxSeq > 0
+	sort.Slice(articles, func(i, j int) bool {
+		if descending {
+			return articles[i].Seq > articles[j].Seq
+		}
+		return articles[i].Seq < articles[j].Seq
+	})
+
+	if filter.Limit > 0 && len(articles) > filter.Limit {
+		articles = articles[:filter.Limit]
+	}
+	return articles, nil
+}
+
+// GetArticleSeqs возвращает номера непрочитанных или избранных статей по возрастанию
+func (s *Store) GetArticleSeqs(ctx context.Context, state domain.ArticleState) ([]int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var seqs []int64
+	for _, article := range s.articles {
+		switch state {
+		case domain.ArticleUnread:
+			if article.ReadAt != nil {
+				continue
+			}
+		case domain.ArticleSaved:
+			if article.SavedAt == nil {
+				continue
+			}
+		default:
+			return nil, fmt.Errorf("unknown article state: %s", state)
+		}
+		seqs = append(seqs, article.Seq)
+	}
+
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	return seqs, nil
+}
+
+// MarkArticlesBySeq меняет состояние статей с перечисленными номерами
+func (s *Store) MarkArticlesBySeq(ctx context.Context, seqs []int64, mark domain.ArticleMark) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wanted := make(map[int64]bool, len(seqs))
+	for _, seq := range seqs {
+		wanted[seq] = true
+	}
+
+	now := time.Now()
+	for _, article := range s.articles {
+		if !wanted[article.Seq] {
+			continue
+		}
+		switch mark {
+		case domain.MarkRead:
+			if article.ReadAt == nil {
+				readAt := now
+				article.ReadAt = &readAt
+			}
+		case domain.MarkUnread:
+			article.ReadAt = nil
+		case domain.MarkSaved:
+			if article.SavedAt == nil {
+				savedAt := now
+				article.SavedAt = &savedAt
+			}
+		case domain.MarkUnsaved:
+			article.SavedAt = nil
+		default:
+			return fmt.Errorf("unknown article mark: %s", mark)
+		}
+	}
+	return nil
+}
+
+// MarkFeedsReadBefore отмечает прочитанными статьи лент, сохраненные не позже before
+func (s *Store) MarkFeedsReadBefore(ctx context.Context, feedIDs []utils.UUID, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wanted := make(map[utils.UUID]bool, len(feedIDs))
+	for _, id := range feedIDs {
+		wanted[id] = true
+	}
+
+	now := time.Now()
+	for _, article := range s.articles {
+		if wanted[article.FeedID] && article.ReadAt == nil && !article.CreatedAt.After(before) {
+			readAt := now
+			article.ReadAt = &readAt
+		}
+	}
+	return nil
+}
+
+// SaveWebSubSubscription создает или обновляет подписку ленты
+func (s *Store) SaveWebSubSubscription(ctx context.Context, sub *domain.WebSubSubscription) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.feeds[sub.FeedID]; !ok {
+		return fmt.Errorf("failed to save websub subscription: feed %s does not exist", sub.FeedID)
+	}
+
+	saved := copyWebSubSubscription(sub)
+	saved.UpdatedAt = time.Now()
+	if stored, ok := s.websub[sub.FeedID]; ok {
+		saved.CreatedAt = stored.CreatedAt
+	} else {
+		saved.CreatedAt = saved.UpdatedAt
+	}
+	s.websub[sub.FeedID] = saved
+	return nil
+}
+
+// GetWebSubSubscription возвращает подписку ленты
+func (s *Store) GetWebSubSubscription(ctx context.Context, feedID utils.UUID) (*domain.WebSubSubscription, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sub, ok := s.websub[feedID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domain.ErrSubscriptionNotFound, feedID)
+	}
+	return copyWebSubSubscription(sub), nil
+}
+
+// GetWebSubSubscriptions возвращает все подписки в порядке создания
+func (s *Store) GetWebSubSubscriptions(ctx context.Context) ([]*domain.WebSubSubscription, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	subs := make([]*domain.WebSubSubscription, 0, len(s.websub))
+	for _, sub := range s.websub {
+		subs = append(subs, copyWebSubSubscription(sub))
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].CreatedAt.Before(subs[j].CreatedAt) })
+	return subs, nil
+}
+
+// DeleteWebSubSubscription удаляет подписку ленты
+func (s *Store) DeleteWebSubSubscription(ctx context.Context, feedID utils.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.websub, feedID)
+	return nil
+}
+
+// SaveFeedIcon создает или обновляет иконку ленты
+func (s *Store) SaveFeedIcon(ctx context.Context, icon *domain.FeedIcon) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.feeds[icon.FeedID]; !ok {
+		return fmt.Errorf("failed to save feed icon: feed %s does not exist", icon.FeedID)
+	}
+
+	s.icons[icon.FeedID] = copyFeedIcon(icon)
+	return nil
+}
+
+// GetFeedIcon возвращает иконку ленты
+func (s *Store) GetFeedIcon(ctx context.Context, feedID utils.UUID) (*domain.FeedIcon, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	icon, ok := s.icons[feedID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domain.ErrIconNotFound, feedID)
+	}
+	return copyFeedIcon(icon), nil
+}
+
+// GetFeedIcons возвращает все иконки с изображением
+func (s *Store) GetFeedIcons(ctx context.Context) ([]*domain.FeedIcon, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	icons := make([]*domain.FeedIcon, 0, len(s.icons))
+	for _, icon := range s.icons {
+		if len(icon.Data) > 0 {
+			icons = append(icons, copyFeedIcon(icon))
+		}
+	}
+	return icons, nil
+}
+
+// CreateAPIKey сохраняет новый ключ API; имя уникально среди действующих ключей
+func (s *Store) CreateAPIKey(ctx context.Context, key *domain.APIKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, stored := range s.apiKeys {
+		if stored.RevokedAt == nil && stored.Name == key.Name {
+			return fmt.Errorf("%w: %s", domain.ErrDuplicateAPIKey, key.Name)
+		}
+	}
+
+	key.CreatedAt = time.Now()
+	s.apiKeys[key.ID] = copyAPIKey(key)
+	return nil
+}
+
+// GetAPIKeys возвращает все ключи API в порядке создания
+func (s *Store) GetAPIKeys(ctx context.Context) ([]*domain.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]*domain.APIKey, 0, len(s.apiKeys))
+	for _, key := range s.apiKeys {
+		keys = append(keys, copyAPIKey(key))
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].CreatedAt.Before(keys[j].CreatedAt) })
+	return keys, nil
+}
+
+// GetAPIKeyByHash возвращает действующий ключ API по хешу
+func (s *Store) GetAPIKeyByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, key := range s.apiKeys {
+		if key.RevokedAt == nil && key.Hash == hash {
+			return copyAPIKey(key), nil
+		}
+	}
+	return nil, domain.ErrAPIKeyNotFound
+}
+
+// GetAPIKeyByFeverHash возвращает действующий ключ API по api_key протокола Fever
+func (s *Store) GetAPIKeyByFeverHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, key := range s.apiKeys {
+		if key.RevokedAt == nil && key.FeverHash != "" && key.FeverHash == hash {
+			return copyAPIKey(key), nil
+		}
+	}
+	return nil, domain.ErrAPIKeyNotFound
+}
+
+// TouchAPIKey обновляет время последнего использования ключа
+func (s *Store) TouchAPIKey(ctx context.Context, id utils.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key, ok := s.apiKeys[id]; ok {
+		now := time.Now()
+		key.LastUsedAt = &now
+	}
+	return nil
+}
+
+// RevokeAPIKey отзывает действующий ключ API по имени
+func (s *Store) RevokeAPIKey(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range s.apiKeys {
+		if key.RevokedAt == nil && key.Name == name {
+			now := time.Now()
+			key.RevokedAt = &now
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", domain.ErrAPIKeyNotFound, name)
+}
+
+// CreateSmartFeed сохраняет новую смарт-ленту
+func (s *Store) CreateSmartFeed(ctx context.Context, smartFeed *domain.SmartFeed) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.smart[smartFeed.Name]; ok {
+		return fmt.Errorf("%w: %s", domain.ErrDuplicateSmartFeed, smartFeed.Name)
+	}
+
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return err
+	}
+	smartFeed.ID = uuid
+	smartFeed.CreatedAt = time.Now()
+
+	c := *smartFeed
+	s.smart[smartFeed.Name] = &c
+	return nil
+}
+
+// GetSmartFeedByName возвращает смарт-ленту по имени
+func (s *Store) GetSmartFeedByName(ctx context.Context, name string) (*domain.SmartFeed, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	smartFeed, ok := s.smart[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domain.ErrSmartFeedNotFound, name)
+	}
+	c := *smartFeed
+	return &c, nil
+}
+
+// GetSmartFeeds возвращает все смарт-ленты, отсортированные по имени
+func (s *Store) GetSmartFeeds(ctx context.Context) ([]*domain.SmartFeed, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	smartFeeds := make([]*domain.SmartFeed, 0, len(s.smart))
+	for _, smartFeed := range s.smart {
+		c := *smartFeed
+		smartFeeds = append(smartFeeds, &c)
+	}
+	sort.Slice(smartFeeds, func(i, j int) bool { return smartFeeds[i].Name < smartFeeds[j].Name })
+	return smartFeeds, nil
+}
+
+// DeleteSmartFeed удаляет смарт-ленту по имени
+func (s *Store) DeleteSmartFeed(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.smart[name]; !ok {
+		return fmt.Errorf("%w: %s", domain.ErrSmartFeedNotFound, name)
+	}
+	delete(s.smart, name)
+	return nil
+}
+
+// CreateNotificationRule сохраняет новое правило уведомлений
+func (s *Store) CreateNotificationRule(ctx context.Context, rule *domain.NotificationRule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.rules[rule.Name]; ok {
+		return fmt.Errorf("%w: %s", domain.ErrDuplicateRule, rule.Name)
+	}
+
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return err
+	}
+	rule.ID = uuid
+	rule.CreatedAt = time.Now()
+
+	c := *rule
+	s.rules[rule.Name] = &c
+	return nil
+}
+
+// GetNotificationRules возвращает все правила уведомлений, отсортированные по имени
+func (s *Store) GetNotificationRules(ctx context.Context) ([]*domain.NotificationRule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rules := make([]*domain.NotificationRule, 0, len(s.rules))
+	for _, rule := range s.rules {
+		c := *rule
+		rules = append(rules, &c)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+	return rules, nil
+}
+
+// DeleteNotificationRule удаляет правило уведомлений по имени
+func (s *Store) DeleteNotificationRule(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.rules[name]; !ok {
+		return fmt.Errorf("%w: %s", domain.ErrRuleNotFound, name)
+	}
+	delete(s.rules, name)
+	return nil
+}
+
+// CreateFilterRule сохраняет новое правило фильтра
+func (s *Store) CreateFilterRule(ctx context.Context, rule *domain.FilterRule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.filters[rule.Name]; ok {
+		return fmt.Errorf("%w: %s", domain.ErrDuplicateFilter, rule.Name)
+	}
+
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return err
+	}
+	rule.ID = uuid
+	rule.CreatedAt = time.Now()
+
+	c := *rule
+	s.filters[rule.Name] = &c
+	return nil
+}
+
+// GetFilterRules возвращает все правила фильтров, отсортированные по имени
+func (s *Store) GetFilterRules(ctx context.Context) ([]*domain.FilterRule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rules := make([]*domain.FilterRule, 0, len(s.filters))
+	for _, rule := range s.filters {
+		c := *rule
+		rules = append(rules, &c)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+	return rules, nil
+}
+
+// DeleteFilterRule удаляет правило фильтра по имени
+func (s *Store) DeleteFilterRule(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.filters[name]; !ok {
+		return fmt.Errorf("%w: %s", domain.ErrFilterNotFound, name)
+	}
+	delete(s.filters, name)
+	return nil
+}
+
+// GetSmartFeedArticles возвращает страницу статей, подходящих под запрос, от новых к старым
+func (s *Store) GetSmartFeedArticles(ctx context.Context, query domain.QueryNode, after *domain.PageCursor, limit int) ([]*domain.DigestEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var entries []*domain.DigestEntry
+	for _, article := range s.articles {
+		if after != nil && !newerFirst(after.Time, after.ID, article.PublishedAt, article.ID) {
+			continue
+		}
+		feed, ok := s.feeds[article.FeedID]
+		if !ok {
+			continue
+		}
+
+		entry := &domain.DigestEntry{Article: article, FeedName: feed.Name, FeedTags: feed.Tags, FeedFolder: feed.Folder}
+		if !query.Match(entry) {
+			continue
+		}
+		entries = append(entries, &domain.DigestEntry{
+			Article:    copyArticle(article),
+			FeedName:   feed.Name,
+			FeedTags:   append([]string(nil), feed.Tags...),
+			FeedFolder: feed.Folder,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i].Article, entries[j].Article
+		return newerFirst(a.PublishedAt, a.ID, b.PublishedAt, b.ID)
+	})
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+// SaveFetchRun сохраняет итоги цикла получения лент
+func (s *Store) SaveFetchRun(ctx context.Context, run *domain.FetchRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return err
+	}
+	run.ID = uuid
+	s.runs = append(s.runs, copyFetchRun(run))
+	return nil
+}
+
+// GetFetchRuns возвращает последние limit циклов, от новых к старым (limit <= 0 - все)
+func (s *Store) GetFetchRuns(ctx context.Context, limit int) ([]*domain.FetchRun, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	runs := make([]*domain.FetchRun, 0, len(s.runs))
+	for _, run := range s.runs {
+		runs = append(runs, copyFetchRun(run))
+	}
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].StartedAt.After(runs[j].StartedAt) })
+
+	if limit > 0 && len(runs) > limit {
+		runs = runs[:limit]
+	}
+	return runs, nil
+}
+
+// AddFeedTraffic добавляет bytes к трафику ленты за день at (по местному времени)
+func (s *Store) AddFeedTraffic(ctx context.Context, feedID utils.UUID, at time.Time, bytes int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	day := at.Format("2006-01-02")
+	for i := range s.traffic {
+		if s.traffic[i].feedID == feedID && s.traffic[i].day == day {
+			s.traffic[i].bytes += bytes
+			return nil
+		}
+	}
+	s.traffic = append(s.traffic, trafficRecord{feedID: feedID, day: day, bytes: bytes})
+	return nil
+}
+
+// GetTrafficSince возвращает трафик всех лент, включая удаленные, начиная с дня since
+func (s *Store) GetTrafficSince(ctx context.Context, since time.Time) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	from := since.Format("2006-01-02")
+	var total int64
+	for _, record := range s.traffic {
+		if record.day >= from {
+			total += record.bytes
+		}
+	}
+	return total, nil
+}
+
+// GetFeedTraffic возвращает трафик каждой ленты начиная с дня since по именам лент
+func (s *Store) GetFeedTraffic(ctx context.Context, since time.Time) (map[string]int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	from := since.Format("2006-01-02")
+	traffic := make(map[string]int64)
+	for _, record := range s.traffic {
+		feed, ok := s.feeds[record.feedID]
+		if ok && record.day >= from {
+			traffic[feed.Name] += record.bytes
+		}
+	}
+	return traffic, nil
+}
+
+// SetAggregatorSetting сохраняет настройку агрегатора
+func (s *Store) SetAggregatorSetting(ctx context.Context, key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.settings[key] = value
+	return nil
+}
+
+// GetAggregatorSetting возвращает настройку агрегатора
+func (s *Store) GetAggregatorSetting(ctx context.Context, key string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	value, ok := s.settings[key]
+	if !ok {
+		return "", fmt.Errorf("setting not found: %s", key)
+	}
+	return value, nil
+}
+
+// NotifySettingsChanged сразу уведомляет подписчиков, в том числе внутри транзакции
+func (s *Store) NotifySettingsChanged(ctx context.Context) error {
+	s.listenMu.Lock()
+	defer s.listenMu.Unlock()
+
+	for changes := range s.listeners {
+		select {
+		case changes <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// ListenSettingsChanges подписывается на изменения настроек в этом процессе до отмены ctx
+func (s *Store) ListenSettingsChanges(ctx context.Context) (<-chan struct{}, error) {
+	changes := make(chan struct{}, 1)
+
+	s.listenMu.Lock()
+	s.listeners[changes] = struct{}{}
+	s.listenMu.Unlock()
+
+	context.AfterFunc(ctx, func() {
+		s.listenMu.Lock()
+		delete(s.listeners, changes)
+		s.listenMu.Unlock()
+	})
+	return changes, nil
+}
+
+// TryLock получает именованную блокировку, если она свободна
+func (s *Store) TryLock(ctx context.Context, lockName string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, locked := s.settings[lockName]; locked {
+		return false, nil
+	}
+	s.settings[lockName] = "locked"
+	return true, nil
+}
+
+// ReleaseLock освобождает именованную блокировку
+func (s *Store) ReleaseLock(ctx context.Context, lockName string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.settings, lockName)
+	return nil
+}
+
+// findFeedByName ищет ленту по имени (вызывается под блокировкой)
+func (s *Store) findFeedByName(name string) *domain.Feed {
+	for _, feed := range s.feeds {
+		if feed.Name == name {
+			return feed
+		}
+	}
+	return nil
+}
+
+// findArticle ищет статью по ключу уникальности или по ссылке среди статей ленты feedID
+// с ключом по ссылке, как GetArticleByKey в PostgreSQL (вызывается под блокировкой)
+func (s *Store) findArticle(feedID utils.UUID, dedupKey, link string) *domain.Article {
+	for _, article := range s.articles {
+		if article.DedupKey == dedupKey || (link != "" && article.FeedID == feedID && article.Link == link &&
+			strings.HasPrefix(article.DedupKey, "link:")) {
+			return article
+		}
+	}
+	return nil
+}
+
+// sortedFeeds возвращает копии всех лент в указанном порядке (вызывается под блокировкой)
+func (s *Store) sortedFeeds(less func(a, b *domain.Feed) bool) []*domain.Feed {
+	feeds := make([]*domain.Feed, 0, len(s.feeds))
+	for _, feed := range s.feeds {
+		feeds = append(feeds, copyFeed(feed))
+	}
+	sort.Slice(feeds, func(i, j int) bool { return less(feeds[i], feeds[j]) })
+	return feeds
+}
+
+// dueOrder порядок обработки лент: сначала высокий приоритет, затем самые устаревшие
+func dueOrder(a, b *domain.Feed) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	return a.UpdatedAt.Before(b.UpdatedAt)
+}
+
+// newerFirst порядок keyset пагинации: по убыванию времени, затем по убыванию ID, как в PostgreSQL
+func newerFirst(aTime time.Time, aID utils.UUID, bTime time.Time, bID utils.UUID) bool {
+	if !aTime.Equal(bTime) {
+		return aTime.After(bTime)
+	}
+	return bytes.Compare(aID[:], bID[:]) > 0
+}
+
+// enabledFeeds оставляет только включенные ленты
+func enabledFeeds(feeds []*domain.Feed) []*domain.Feed {
+	result := feeds[:0]
+	for _, feed := range feeds {
+		if feed.Enabled {
+			result = append(result, feed)
+		}
+	}
+	return result
+}
+
+// staleFeeds оставляет включенные ленты, которые пора получать (см. domain.Feed.IsStale)
+func staleFeeds(feeds []*domain.Feed, now time.Time, staleAfter time.Duration) []*domain.Feed {
+	result := feeds[:0]
+	for _, feed := range enabledFeeds(feeds) {
+		if feed.IsStale(now, staleAfter) {
+			result = append(result, feed)
+		}
+	}
+	return result
+}
+
+// limitFeeds обрезает список до limit элементов (limit <= 0 - без ограничений)
+func limitFeeds(feeds []*domain.Feed, limit int) []*domain.Feed {
+	if limit > 0 && len(feeds) > limit {
+		return feeds[:limit]
+	}
+	return feeds
+}
+
+// copyFeed создает независимую копию ленты
+func copyFeed(feed *domain.Feed) *domain.Feed {
+	c := *feed
+	if feed.Headers != nil {
+		c.Headers = make(map[string]string, len(feed.Headers))
+		for k, v := range feed.Headers {
+			c.Headers[k] = v
+		}
+	}
+	if feed.Auth != nil {
+		auth := *feed.Auth
+		c.Auth = &auth
+	}
+	c.Tags = append([]string(nil), feed.Tags...)
+	c.Mirrors = append([]string(nil), feed.Mirrors...)
+	c.Languages = append([]string(nil), feed.Languages...)
+	return &c
+}
+
+// copyFetchRun создает независимую копию записи о цикле
+func copyFetchRun(run *domain.FetchRun) *domain.FetchRun {
+	c := *run
+	c.Errors = append([]domain.FeedError(nil), run.Errors...)
+	return &c
+}
+
+// copyArticle создает независимую копию статьи
+func copyArticle(article *domain.Article) *domain.Article {
+	c := *article
+	if article.ReadAt != nil {
+		readAt := *article.ReadAt
+		c.ReadAt = &readAt
+	}
+	if article.ModifiedAt != nil {
+		modifiedAt := *article.ModifiedAt
+		c.ModifiedAt = &modifiedAt
+	}
+	if article.Podcast != nil {
+		podcast := *article.Podcast
+		c.Podcast = &podcast
+	}
+	if article.Media != nil {
+		c.Media = append([]domain.MediaItem(nil), article.Media...)
+	}
+	if article.SavedAt != nil {
+		savedAt := *article.SavedAt
+		c.SavedAt = &savedAt
+	}
+	return &c
+}
+
+// copyWebSubSubscription создает независимую копию подписки
+func copyWebSubSubscription(sub *domain.WebSubSubscription) *domain.WebSubSubscription {
+	c := *sub
+	if sub.ExpiresAt != nil {
+		expiresAt := *sub.ExpiresAt
+		c.ExpiresAt = &expiresAt
+	}
+	return &c
+}
+
+// copyFeedIcon создает независимую копию иконки
+func copyFeedIcon(icon *domain.FeedIcon) *domain.FeedIcon {
+	c := *icon
+	c.Data = append([]byte(nil), icon.Data...)
+	if icon.FetchedAt != nil {
+		fetchedAt := *icon.FetchedAt
+		c.FetchedAt = &fetchedAt
+	}
+	return &c
+}
+
+// copyAPIKey создает независимую копию ключа API
+func copyAPIKey(key *domain.APIKey) *domain.APIKey {
+	c := *key
+	if key.LastUsedAt != nil {
+		lastUsedAt := *key.LastUsedAt
+		c.LastUsedAt = &lastUsedAt
+	}
+	if key.RevokedAt != nil {
+		revokedAt := *key.RevokedAt
+		c.RevokedAt = &revokedAt
+	}
+	return &c
+}
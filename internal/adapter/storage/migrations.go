@@ -2,109 +2,276 @@
 package storage
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"rsshub/internal/core/domain"
 	"rsshub/internal/platform/logger"
+	"rsshub/migrations"
 )
 
-// RunMigrations запускает все миграции базы данных
+// migrationLockID ключ advisory блокировки, чтобы миграции не выполнялись
+// одновременно несколькими экземплярами приложения
+const migrationLockID = 727_001
+
+// Migration описывает одну версию схемы базы данных
+type Migration struct {
+	Version int    // Номер версии (префикс имени файла)
+	Name    string // Описание миграции из имени файла
+	Up      string // SQL для применения
+	Down    string // SQL для отката
+}
+
+// LoadMigrations читает миграции из файловой системы и сортирует их по версии.
+// Ожидаются пары файлов NNN_name.up.sql / NNN_name.down.sql
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		fileName := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(fileName, ".sql") {
+			continue
+		}
+
+		base := strings.TrimSuffix(fileName, ".sql")
+		var direction string
+		switch {
+		case strings.HasSuffix(base, ".up"):
+			direction = "up"
+		case strings.HasSuffix(base, ".down"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("migration %s must end with .up.sql or .down.sql", fileName)
+		}
+		base = strings.TrimSuffix(base, "."+direction)
+
+		versionStr, name, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %s must be named NNN_name", fileName)
+		}
+		version, err := strconv.Atoi(versionStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", fileName, err)
+		}
+
+		content, err := fs.ReadFile(fsys, fileName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", fileName, err)
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("migration version %d is used by both %s and %s", version, m.Name, name)
+		}
+
+		if direction == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	result := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %03d_%s has no up script", m.Version, m.Name)
+		}
+		result = append(result, *m)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+
+	return result, nil
+}
+
+// RunMigrations применяет все еще не примененные миграции базы данных
 func (db *DB) RunMigrations() error {
 	logger.Info("Running database migrations...")
 
-	// Создаем расширение для UUID
-	if err := db.createUUIDExtension(); err != nil {
-		return fmt.Errorf("failed to create UUID extension: %w", err)
+	all, err := LoadMigrations(migrations.FS)
+	if err != nil {
+		return err
 	}
 
-	// Создаем таблицу feeds
-	if err := db.createFeedsTable(); err != nil {
-		return fmt.Errorf("failed to create feeds table: %w", err)
-	}
+	applied := 0
+	err = db.withMigrationLock(func(conn *sql.Conn) error {
+		done, err := appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range all {
+			if _, ok := done[m.Version]; ok {
+				continue
+			}
 
-	// Создаем таблицу articles
-	if err := db.createArticlesTable(); err != nil {
-		return fmt.Errorf("failed to create articles table: %w", err)
+			logger.Info("Applying migration %03d_%s", m.Version, m.Name)
+			if err := runMigrationStep(conn, m.Up, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, m.Version, m.Name); err != nil {
+				return fmt.Errorf("migration %03d_%s failed: %w", m.Version, m.Name, err)
+			}
+			applied++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	// Создаем таблицу aggregator
-	if err := db.createAggregatorTable(); err != nil {
-		return fmt.Errorf("failed to create aggregator table: %w", err)
+	if applied == 0 {
+		logger.Info("Database schema is up to date")
+		return nil
 	}
 
-	logger.Success("Database migrations completed successfully")
+	logger.Success("Database migrations completed successfully (%d applied)", applied)
 	return nil
 }
 
-// createUUIDExtension создает расширение для работы с UUID
-func (db *DB) createUUIDExtension() error {
-	query := `CREATE EXTENSION IF NOT EXISTS "uuid-ossp";`
+// RollbackMigrations откатывает последние steps примененных миграций
+func (db *DB) RollbackMigrations(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("number of steps must be positive")
+	}
+
+	all, err := LoadMigrations(migrations.FS)
+	if err != nil {
+		return err
+	}
 
-	_, err := db.Exec(query)
-	return err
+	return db.withMigrationLock(func(conn *sql.Conn) error {
+		done, err := appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(all) - 1; i >= 0 && steps > 0; i-- {
+			m := all[i]
+			if _, ok := done[m.Version]; !ok {
+				continue
+			}
+			if m.Down == "" {
+				return fmt.Errorf("migration %03d_%s has no down script", m.Version, m.Name)
+			}
+
+			logger.Info("Rolling back migration %03d_%s", m.Version, m.Name)
+			if err := runMigrationStep(conn, m.Down, `DELETE FROM schema_migrations WHERE version = $1`, m.Version); err != nil {
+				return fmt.Errorf("rollback of %03d_%s failed: %w", m.Version, m.Name, err)
+			}
+			logger.Success("Rolled back migration %03d_%s", m.Version, m.Name)
+			steps--
+		}
+		return nil
+	})
 }
 
-// createFeedsTable создает таблицу feeds
-func (db *DB) createFeedsTable() error {
-	query := `
-		CREATE TABLE IF NOT EXISTS feeds (
-			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-			updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
-			name TEXT NOT NULL UNIQUE,
-			url TEXT NOT NULL
-		);
-
-		-- Создаем индексы если они не существуют
-		CREATE INDEX IF NOT EXISTS idx_feeds_name ON feeds(name);
-		CREATE INDEX IF NOT EXISTS idx_feeds_updated_at ON feeds(updated_at);
-	`
-
-	_, err := db.Exec(query)
-	return err
+// MigrationsStatus возвращает список всех миграций с отметкой о применении
+func (db *DB) MigrationsStatus() ([]domain.MigrationStatus, error) {
+	all, err := LoadMigrations(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []domain.MigrationStatus
+	err = db.withMigrationLock(func(conn *sql.Conn) error {
+		done, err := appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range all {
+			status := domain.MigrationStatus{Version: m.Version, Name: m.Name}
+			if appliedAt, ok := done[m.Version]; ok {
+				status.AppliedAt = &appliedAt
+			}
+			result = append(result, status)
+		}
+		return nil
+	})
+
+	return result, err
 }
 
-// createArticlesTable создает таблицу articles
-func (db *DB) createArticlesTable() error {
+// withMigrationLock выполняет fn на выделенном соединении под advisory блокировкой
+func (db *DB) withMigrationLock(fn func(conn *sql.Conn) error) error {
+	ctx := context.Background()
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, migrationLockID); err != nil {
+			logger.Warn("Failed to release migration lock: %v", err)
+		}
+	}()
+
+	// Таблица с историей примененных миграций
 	query := `
-		CREATE TABLE IF NOT EXISTS articles (
-			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-			updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
-			title TEXT NOT NULL,
-			link TEXT NOT NULL,
-			published_at TIMESTAMP,
-			description TEXT,
-			feed_id UUID NOT NULL,
-			
-			-- Ограничения
-			FOREIGN KEY (feed_id) REFERENCES feeds(id) ON DELETE CASCADE,
-			UNIQUE(link)
-		);
-
-		-- Создаем индексы если они не существуют
-		CREATE INDEX IF NOT EXISTS idx_articles_feed_id ON articles(feed_id);
-		CREATE INDEX IF NOT EXISTS idx_articles_published_at ON articles(published_at DESC);
-		CREATE INDEX IF NOT EXISTS idx_articles_feed_published ON articles(feed_id, published_at DESC);
-	`
-
-	_, err := db.Exec(query)
-	return err
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)`
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	return fn(conn)
 }
 
-// createAggregatorTable создает таблицу для настроек агрегатора и блокировок
-func (db *DB) createAggregatorTable() error {
-	query := `
-		CREATE TABLE IF NOT EXISTS aggregator (
-			id SERIAL PRIMARY KEY,
-			key TEXT UNIQUE NOT NULL,
-			value TEXT NOT NULL,
-			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-			updated_at TIMESTAMP NOT NULL DEFAULT NOW()
-		);
-
-		-- Индексы
-		CREATE INDEX IF NOT EXISTS idx_aggregator_key ON aggregator(key);
-	`
-
-	_, err := db.Exec(query)
-	return err
+// appliedVersions возвращает версии примененных миграций и время их применения
+func appliedVersions(conn *sql.Conn) (map[int]time.Time, error) {
+	rows, err := conn.QueryContext(context.Background(), `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	done := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan applied migration: %w", err)
+		}
+		done[version] = appliedAt
+	}
+
+	return done, rows.Err()
+}
+
+// runMigrationStep выполняет SQL миграции и запись в schema_migrations в одной транзакции
+func runMigrationStep(conn *sql.Conn, script, record string, recordArgs ...interface{}) error {
+	ctx := context.Background()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, record, recordArgs...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
@@ -0,0 +1,58 @@
+// internal/adapter/storage/smartquery.go
+package storage
+
+import (
+	"strconv"
+	"strings"
+
+	"rsshub/internal/core/domain"
+)
+
+// likeEscaper экранирует спецсимволы шаблона LIKE (обратная косая черта - escape по умолчанию)
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// smartQuerySQL переводит запрос смарт-ленты в условие WHERE по таблицам articles a и feeds f.
+// Значения условий добавляются в args как параметры запроса, в SQL попадают только их номера
+func smartQuerySQL(node domain.QueryNode, args *[]interface{}) string {
+	switch q := node.(type) {
+	case *domain.QueryAnd:
+		return "(" + smartQuerySQL(q.Left, args) + " AND " + smartQuerySQL(q.Right, args) + ")"
+	case *domain.QueryOr:
+		return "(" + smartQuerySQL(q.Left, args) + " OR " + smartQuerySQL(q.Right, args) + ")"
+	case *domain.QueryNot:
+		return "NOT " + smartQuerySQL(q.Node, args)
+	case *domain.QueryTerm:
+		return smartTermSQL(q, args)
+	default:
+		return "FALSE"
+	}
+}
+
+// smartTermSQL переводит одно условие запроса; семантика совпадает с domain.QueryTerm.Match
+func smartTermSQL(term *domain.QueryTerm, args *[]interface{}) string {
+	param := func(value interface{}) string {
+		*args = append(*args, value)
+		return "$" + strconv.Itoa(len(*args))
+	}
+
+	switch term.Field {
+	case domain.QueryFieldFeed:
+		return "LOWER(f.name) = LOWER(" + param(term.Value) + ")"
+	case domain.QueryFieldTag:
+		return "LOWER(" + param(term.Value) + ") = ANY(f.tags)"
+	case domain.QueryFieldLanguage:
+		return "a.lang = LOWER(" + param(term.Value) + ")"
+	}
+
+	pattern := param("%" + likeEscaper.Replace(term.Value) + "%")
+	switch term.Field {
+	case domain.QueryFieldTitle:
+		return "a.title ILIKE " + pattern
+	case domain.QueryFieldDescription:
+		return "a.description ILIKE " + pattern
+	case domain.QueryFieldLink:
+		return "a.link ILIKE " + pattern
+	default:
+		return "(a.title ILIKE " + pattern + " OR a.description ILIKE " + pattern + ")"
+	}
+}
@@ -0,0 +1,207 @@
+// internal/adapter/tts/tts.go
+package tts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strings"
+	"time"
+
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/config"
+)
+
+// Проверяем на этапе компиляции, что синтезаторы реализуют озвучивание текста
+var (
+	_ port.SpeechSynthesizer = (*Command)(nil)
+	_ port.SpeechSynthesizer = (*OpenAI)(nil)
+)
+
+const (
+	// commandTimeout сколько ждать озвучивания одного текста внешней командой
+	commandTimeout = 10 * time.Minute
+
+	// requestTimeout таймаут запроса к API синтеза речи
+	requestTimeout = 2 * time.Minute
+
+	// maxInputLength ограничение API OpenAI на длину текста одного запроса (в символах)
+	maxInputLength = 4096
+)
+
+// New создает синтезатор речи согласно CLI_APP_TTS_BACKEND
+func New(cfg *config.TTSConfig) (port.SpeechSynthesizer, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Backend)) {
+	case "command":
+		if strings.TrimSpace(cfg.Command) == "" {
+			return nil, fmt.Errorf("TTS command is not configured (CLI_APP_TTS_COMMAND)")
+		}
+		return &Command{command: cfg.Command}, nil
+	case "openai":
+		if u, err := url.Parse(cfg.URL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return nil, fmt.Errorf("invalid TTS API URL %q", cfg.URL)
+		}
+		if cfg.APIKey == "" {
+			return nil, fmt.Errorf("TTS API key is not configured (CLI_APP_TTS_API_KEY)")
+		}
+		return &OpenAI{
+			client: &http.Client{Timeout: requestTimeout},
+			url:    cfg.URL,
+			apiKey: cfg.APIKey,
+			model:  cfg.Model,
+			voice:  cfg.Voice,
+		}, nil
+	case "":
+		return nil, fmt.Errorf("TTS backend is not configured (CLI_APP_TTS_BACKEND: command or openai)")
+	default:
+		return nil, fmt.Errorf("unknown TTS backend %q (expected command or openai)", cfg.Backend)
+	}
+}
+
+// Command озвучивает текст внешней программой (например, piper или espeak-ng с lame),
+// запущенной через sh -c: текст передается в stdin, MP3 читается из stdout
+type Command struct {
+	command string
+}
+
+// Synthesize озвучивает текст и записывает MP3 в w
+func (c *Command) Synthesize(ctx context.Context, text string, w io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", c.command)
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("TTS command failed: %w: %s", err, message)
+		}
+		return fmt.Errorf("TTS command failed: %w", err)
+	}
+	return nil
+}
+
+// OpenAI озвучивает текст через API, совместимый с OpenAI /v1/audio/speech.
+// Длинный текст разбивается на части по предложениям, MP3 частей записываются подряд
+type OpenAI struct {
+	client *http.Client
+	url    string
+	apiKey string
+	model  string
+	voice  string
+}
+
+// Synthesize озвучивает текст и записывает MP3 в w
+func (o *OpenAI) Synthesize(ctx context.Context, text string, w io.Writer) error {
+	chunks := splitText(text, maxInputLength)
+	for i, chunk := range chunks {
+		if err := o.synthesize(ctx, chunk, w); err != nil {
+			return fmt.Errorf("TTS part %d of %d: %w", i+1, len(chunks), err)
+		}
+	}
+	return nil
+}
+
+// synthesize озвучивает одну часть текста
+func (o *OpenAI) synthesize(ctx context.Context, text string, w io.Writer) error {
+	payload, err := json.Marshal(map[string]string{
+		"model":           o.model,
+		"voice":           o.voice,
+		"input":           text,
+		"response_format": "mp3",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode TTS request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+o.apiKey)
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("TTS API error (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("failed to read TTS audio: %w", err)
+	}
+	return nil
+}
+
+// splitText разбивает текст на части не длиннее max символов, по возможности по границам
+// абзацев и предложений
+func splitText(text string, max int) []string {
+	var chunks []string
+	var current strings.Builder
+	length := 0
+
+	flush := func() {
+		if s := strings.TrimSpace(current.String()); s != "" {
+			chunks = append(chunks, s)
+		}
+		current.Reset()
+		length = 0
+	}
+
+	for _, sentence := range sentences(text) {
+		size := len([]rune(sentence))
+		if length > 0 && length+size > max {
+			flush()
+		}
+		// Предложение длиннее лимита режется по символам
+		for runes := []rune(sentence); len(runes) > max; runes = []rune(sentence) {
+			chunks = append(chunks, string(runes[:max]))
+			sentence = string(runes[max:])
+			size = len(runes) - max
+		}
+		current.WriteString(sentence)
+		length += size
+	}
+	flush()
+	return chunks
+}
+
+// sentences разбивает текст на предложения, сохраняя завершающие пробелы и переводы строк
+func sentences(text string) []string {
+	var result []string
+	start := 0
+	runes := []rune(text)
+	for i, r := range runes {
+		if (r == '.' || r == '!' || r == '?' || r == '\n') && (i+1 == len(runes) || runes[i+1] == ' ' || runes[i+1] == '\n') {
+			end := i + 1
+			for end < len(runes) && (runes[end] == ' ' || runes[end] == '\n') {
+				end++
+			}
+			if end > start {
+				result = append(result, string(runes[start:end]))
+				start = end
+			}
+		}
+	}
+	if start < len(runes) {
+		result = append(result, string(runes[start:]))
+	}
+	return result
+}
@@ -0,0 +1,108 @@
+// internal/adapter/websub/handler.go
+package websub
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/utils"
+)
+
+// maxContentSize максимальный размер содержимого ленты, доставляемого хабом
+const maxContentSize = 10 << 20
+
+// Handler обрабатывает запросы хабов к callback адресам вида <prefix><feed id>:
+// GET - подтверждение подписки, POST - доставка нового содержимого ленты
+type Handler struct {
+	receiver port.WebSubReceiver
+	prefix   string
+}
+
+// NewHandler создает обработчик callback запросов; prefix - путь, под которым он
+// зарегистрирован, например /websub/
+func NewHandler(receiver port.WebSubReceiver, prefix string) *Handler {
+	return &Handler{receiver: receiver, prefix: prefix}
+}
+
+// ServeHTTP реализует http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	feedID, err := utils.ParseUUID(strings.TrimPrefix(r.URL.Path, h.prefix))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.verify(w, r, feedID)
+	case http.MethodPost:
+		h.deliver(w, r, feedID)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// verify отвечает на проверку намерения: при согласии возвращает hub.challenge
+func (h *Handler) verify(w http.ResponseWriter, r *http.Request, feedID utils.UUID) {
+	ctx := r.Context()
+	query := r.URL.Query()
+	mode := query.Get("hub.mode")
+	topic := query.Get("hub.topic")
+
+	if mode == "denied" {
+		if err := h.receiver.Deny(ctx, feedID, topic, query.Get("hub.reason")); err != nil {
+			logger.Warn("WebSub: ignoring denial for feed %s: %v", feedID, err)
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	challenge := query.Get("hub.challenge")
+	if challenge == "" {
+		http.Error(w, "hub.challenge is required", http.StatusBadRequest)
+		return
+	}
+
+	leaseSeconds, _ := strconv.Atoi(query.Get("hub.lease_seconds"))
+	if err := h.receiver.VerifyIntent(ctx, feedID, mode, topic, leaseSeconds); err != nil {
+		logger.Warn("WebSub: rejected %s request for feed %s: %v", mode, feedID, err)
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	io.WriteString(w, challenge)
+}
+
+// deliver принимает новое содержимое ленты от хаба
+func (h *Handler) deliver(w http.ResponseWriter, r *http.Request, feedID utils.UUID) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxContentSize))
+	if err != nil {
+		http.Error(w, "content is too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	_, err = h.receiver.Deliver(r.Context(), feedID, body, r.Header.Get("X-Hub-Signature"))
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusNoContent)
+	case errors.Is(err, domain.ErrSubscriptionNotFound), errors.Is(err, domain.ErrFeedNotFound):
+		// 410 сообщает хабу, что подписка больше не нужна
+		http.Error(w, "subscription not found", http.StatusGone)
+	case errors.Is(err, domain.ErrInvalidSignature):
+		// По спецификации содержимое с неверной подписью игнорируется, но запрос подтверждается
+		logger.Warn("WebSub: %v", err)
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		logger.Error("WebSub: failed to process content: %v", err)
+		http.Error(w, "failed to process content", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,87 @@
+// internal/adapter/websub/hub.go
+package websub
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/netguard"
+)
+
+// Проверяем на этапе компиляции, что Hub реализует порт
+var _ port.WebSubHub = (*Hub)(nil)
+
+// Hub отправляет запросы подписки WebSub хабам
+type Hub struct {
+	client    *http.Client
+	userAgent string
+}
+
+// NewHub создает клиент WebSub хабов. Адрес хаба берется из ленты, поэтому соединения
+// проверяются guard так же, как запросы к самим лентам
+func NewHub(timeout time.Duration, userAgent string, guard *netguard.Guard) *Hub {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	guard.SecureTransport(transport, nil)
+
+	return &Hub{
+		client:    &http.Client{Timeout: timeout, Transport: transport},
+		userAgent: userAgent,
+	}
+}
+
+// Subscribe просит хаб доставлять обновления темы на callback адрес
+func (h *Hub) Subscribe(sub *domain.WebSubSubscription, callbackURL string) error {
+	form := url.Values{
+		"hub.mode":     {"subscribe"},
+		"hub.topic":    {sub.Topic},
+		"hub.callback": {callbackURL},
+		"hub.secret":   {sub.Secret},
+	}
+	if sub.LeaseSeconds > 0 {
+		form.Set("hub.lease_seconds", strconv.Itoa(sub.LeaseSeconds))
+	}
+	return h.send(sub.HubURL, form)
+}
+
+// Unsubscribe просит хаб прекратить доставку обновлений темы
+func (h *Hub) Unsubscribe(sub *domain.WebSubSubscription, callbackURL string) error {
+	form := url.Values{
+		"hub.mode":     {"unsubscribe"},
+		"hub.topic":    {sub.Topic},
+		"hub.callback": {callbackURL},
+	}
+	return h.send(sub.HubURL, form)
+}
+
+// send отправляет запрос хабу. Хаб отвечает 202 Accepted и подтверждает намерение
+// отдельным запросом к callback адресу
+func (h *Hub) send(hubURL string, form url.Values) error {
+	req, err := http.NewRequest(http.MethodPost, hubURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to build hub request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if h.userAgent != "" {
+		req.Header.Set("User-Agent", h.userAgent)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("hub request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("hub returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+// internal/core/domain/apikey.go
+package domain
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"rsshub/internal/platform/utils"
+)
+
+// APIKeyPrefix префикс всех ключей API, чтобы их было легко узнать в конфигурации и логах
+const APIKeyPrefix = "rsh_"
+
+// APIKey ключ доступа к HTTP API. Сам ключ не хранится, только его SHA-256 хеш
+type APIKey struct {
+	ID         utils.UUID
+	Name       string     // Человекочитаемое имя (для кого или чего выдан ключ)
+	Prefix     string     // Начало ключа, чтобы отличать ключи в списке
+	Hash       string     // SHA-256 ключа в hex
+	FeverHash  string     // MD5 "имя:ключ" для Fever API (пусто - ключ создан до поддержки Fever)
+	CreatedAt  time.Time  // Время создания
+	LastUsedAt *time.Time // Время последнего использования (nil - не использовался)
+	RevokedAt  *time.Time // Время отзыва (nil - ключ действует)
+}
+
+// NewAPIKey генерирует новый ключ и возвращает его запись и сам ключ, который
+// показывается пользователю один раз
+func NewAPIKey(name string) (*APIKey, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	secret := APIKeyPrefix + hex.EncodeToString(b)
+
+	id, err := utils.NewUUID()
+	if err != nil {
+		return nil, "", err
+	}
+
+	key := &APIKey{
+		ID:        id,
+		Name:      name,
+		Prefix:    secret[:len(APIKeyPrefix)+8],
+		Hash:      HashAPIKey(secret),
+		FeverHash: FeverAPIKey(name, secret),
+	}
+	return key, secret, nil
+}
+
+// HashAPIKey возвращает хеш ключа для хранения и поиска. Ключи случайные и длинные,
+// поэтому медленная хеш-функция для паролей не нужна
+func HashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// FeverAPIKey возвращает api_key протокола Fever: MD5 строки "email:password" в hex.
+// В Fever клиенте email - имя ключа, пароль - сам ключ
+func FeverAPIKey(email, password string) string {
+	sum := md5.Sum([]byte(email + ":" + password))
+	return hex.EncodeToString(sum[:])
+}
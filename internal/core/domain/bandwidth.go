@@ -0,0 +1,69 @@
+// internal/core/domain/bandwidth.go
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// byteUnits множители единиц размера (двоичные: 1 KB = 1024 байта)
+var byteUnits = map[string]int64{
+	"":   1,
+	"B":  1,
+	"KB": 1 << 10, "KIB": 1 << 10,
+	"MB": 1 << 20, "MIB": 1 << 20,
+	"GB": 1 << 30, "GIB": 1 << 30,
+	"TB": 1 << 40, "TIB": 1 << 40,
+}
+
+// ParseByteSize разбирает размер вида "500MB", "1.5GB" или "1024" (байты)
+func ParseByteSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i < 0 {
+		i = len(s)
+	}
+
+	number, unit := s[:i], strings.ToUpper(strings.TrimSpace(s[i:]))
+	value, err := strconv.ParseFloat(number, 64)
+	multiplier, ok := byteUnits[unit]
+	if err != nil || !ok || value < 0 {
+		return 0, fmt.Errorf("invalid size %q (expected a number with B, KB, MB, GB or TB, e.g. 500MB)", s)
+	}
+	return int64(value * float64(multiplier)), nil
+}
+
+// FormatBytes форматирует размер в байтах для вывода: "512 B", "1.5 MB"
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, suffix := float64(n)/unit, "KB"
+	for _, next := range []string{"MB", "GB", "TB"} {
+		if value < unit {
+			break
+		}
+		value, suffix = value/unit, next
+	}
+	return fmt.Sprintf("%.1f %s", value, suffix)
+}
+
+// BandwidthBudget лимиты трафика получения лент (0 - без ограничения)
+type BandwidthBudget struct {
+	Daily   int64 // Байт в сутки (с полуночи по местному времени)
+	Monthly int64 // Байт в календарный месяц
+}
+
+// Enabled проверяет, что задан хотя бы один лимит
+func (b BandwidthBudget) Enabled() bool {
+	return b.Daily > 0 || b.Monthly > 0
+}
+
+// TrafficPeriods возвращает начало текущих суток и месяца, за которые считается трафик
+func TrafficPeriods(now time.Time) (day, month time.Time) {
+	y, m, d := now.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, now.Location()), time.Date(y, m, 1, 0, 0, 0, 0, now.Location())
+}
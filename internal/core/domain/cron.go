@@ -0,0 +1,184 @@
+// internal/core/domain/cron.go
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchLimit насколько далеко вперед ищется следующий запуск по расписанию
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// cronDescriptors сокращения стандартных расписаний
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronField диапазон и имена значений поля расписания
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronMonthNames = map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}
+	cronWeekdayNames = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+
+	cronFields = [5]cronField{
+		{name: "minute", min: 0, max: 59},
+		{name: "hour", min: 0, max: 23},
+		{name: "day of month", min: 1, max: 31},
+		{name: "month", min: 1, max: 12, names: cronMonthNames},
+		{name: "day of week", min: 0, max: 7, names: cronWeekdayNames}, // 7 - тоже воскресенье
+	}
+)
+
+// CronSchedule расписание в формате cron из пяти полей: минута, час, день месяца, месяц,
+// день недели. Время считается в местном часовом поясе
+type CronSchedule struct {
+	expr                                string
+	minutes, hours, days, months, wdays uint64 // Битовые маски допустимых значений
+	anyDay, anyWeekday                  bool   // Поле дня месяца или недели равно *
+}
+
+// ParseCron разбирает выражение cron: "0 9 * * 1-5" (по будням в 9:00), "*/30 8-20 * * *",
+// списки, диапазоны, шаги, имена месяцев и дней (jan, mon-fri) и сокращения @daily, @hourly и т.п.
+func ParseCron(expr string) (*CronSchedule, error) {
+	expr = strings.Join(strings.Fields(expr), " ")
+	spec := expr
+	if descriptor, ok := cronDescriptors[strings.ToLower(expr)]; ok {
+		spec = descriptor
+	}
+
+	parts := strings.Fields(spec)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("invalid schedule %q: expected 5 fields (minute hour day-of-month month day-of-week) or @daily, @hourly, @weekly, @monthly", expr)
+	}
+
+	s := &CronSchedule{expr: expr, anyDay: parts[2] == "*", anyWeekday: parts[4] == "*"}
+	masks := []*uint64{&s.minutes, &s.hours, &s.days, &s.months, &s.wdays}
+	for i, part := range parts {
+		mask, err := parseCronField(part, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", expr, err)
+		}
+		*masks[i] = mask
+	}
+	// Воскресенье может быть задано как 0 или 7
+	if s.wdays&(1<<7) != 0 {
+		s.wdays |= 1
+	}
+
+	if s.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("invalid schedule %q: it never matches", expr)
+	}
+	return s, nil
+}
+
+// parseCronField разбирает поле расписания в битовую маску значений
+func parseCronField(s string, field cronField) (uint64, error) {
+	var mask uint64
+	for _, item := range strings.Split(s, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepPart, field.name)
+			}
+		}
+
+		low, high := field.min, field.max
+		if rangePart != "*" {
+			lowPart, highPart, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = cronValue(lowPart, field); err != nil {
+				return 0, err
+			}
+			high = low
+			if isRange {
+				if high, err = cronValue(highPart, field); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				high = field.max // "5/15" - с 5 до конца диапазона
+			}
+			if high < low {
+				return 0, fmt.Errorf("invalid range %q in %s field", rangePart, field.name)
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+	return mask, nil
+}
+
+// cronValue разбирает значение поля: число или имя месяца/дня недели
+func cronValue(s string, field cronField) (int, error) {
+	if v, ok := field.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < field.min || v > field.max {
+		return 0, fmt.Errorf("invalid value %q in %s field (expected %d-%d)", s, field.name, field.min, field.max)
+	}
+	return v, nil
+}
+
+// Next возвращает первый момент расписания строго после after (с точностью до минуты);
+// нулевое время, если такого момента нет
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.Add(cronSearchLimit)
+
+	for t.Before(limit) {
+		y, m, d := t.Date()
+		switch {
+		case s.months&(1<<uint(m)) == 0:
+			t = time.Date(y, m+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.dayMatches(t):
+			t = time.Date(y, m, d+1, 0, 0, 0, 0, t.Location())
+		case s.hours&(1<<uint(t.Hour())) == 0:
+			t = time.Date(y, m, d, t.Hour()+1, 0, 0, 0, t.Location())
+		case s.minutes&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches проверяет день месяца и день недели. Как в cron, если оба поля ограничены,
+// достаточно совпадения одного из них
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	day := s.days&(1<<uint(t.Day())) != 0
+	weekday := s.wdays&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekday
+	case s.anyWeekday:
+		return day
+	default:
+		return day || weekday
+	}
+}
+
+// String возвращает выражение расписания
+func (s *CronSchedule) String() string {
+	return s.expr
+}
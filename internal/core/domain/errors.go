@@ -0,0 +1,33 @@
+// internal/core/domain/errors.go
+package domain
+
+import "errors"
+
+// Ошибки репозитория. Хранилища оборачивают их через %w с подробностями,
+// вызывающий код проверяет их через errors.Is
+var (
+	ErrFeedNotFound     = errors.New("feed not found")
+	ErrDuplicateFeed    = errors.New("feed already exists")
+	ErrDuplicateFeedURL = errors.New("feed URL is already added")
+	ErrArticleNotFound  = errors.New("article not found")
+
+	// ErrFeedGone лента удалена источником навсегда (HTTP 410 Gone)
+	ErrFeedGone = errors.New("feed is gone (HTTP 410)")
+
+	ErrSubscriptionNotFound = errors.New("websub subscription not found")
+	ErrInvalidSignature     = errors.New("invalid websub signature")
+
+	ErrAPIKeyNotFound  = errors.New("api key not found")
+	ErrDuplicateAPIKey = errors.New("api key already exists")
+
+	ErrSmartFeedNotFound  = errors.New("smart feed not found")
+	ErrDuplicateSmartFeed = errors.New("smart feed already exists")
+
+	ErrIconNotFound = errors.New("feed icon not found")
+
+	ErrRuleNotFound  = errors.New("notification rule not found")
+	ErrDuplicateRule = errors.New("notification rule already exists")
+
+	ErrFilterNotFound  = errors.New("filter rule not found")
+	ErrDuplicateFilter = errors.New("filter rule already exists")
+)
@@ -0,0 +1,25 @@
+// internal/core/domain/events.go
+package domain
+
+// ArticleEventFilter ленты и теги, новые статьи которых нужны подписчику; пустой набор -
+// без ограничения
+type ArticleEventFilter struct {
+	Feeds map[string]bool
+	Tags  map[string]bool
+}
+
+// Match сообщает, нужна ли статья подписчику: лента из списка и хотя бы один тег из списка
+func (f ArticleEventFilter) Match(e *DigestEntry) bool {
+	if len(f.Feeds) > 0 && !f.Feeds[e.FeedName] {
+		return false
+	}
+	if len(f.Tags) == 0 {
+		return true
+	}
+	for _, tag := range e.FeedTags {
+		if f.Tags[tag] {
+			return true
+		}
+	}
+	return false
+}
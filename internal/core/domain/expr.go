@@ -0,0 +1,576 @@
+// internal/core/domain/expr.go
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expression разобранное выражение правила фильтра или уведомлений, например
+// feed.tag == "security" && contains(title, "CVE"). Синтаксис:
+//
+//	title, description, link, guid, language   поля статьи (строки)
+//	feed.name, feed.folder                     имя и папка ленты (строки)
+//	feed.tags (или feed.tag)                   теги ленты (список)
+//	"text", 'text', 42, true, ["a", "b"]       строка, число, логическое значение, список строк
+//	==, !=, <, <=, >, >=                       сравнение; список == строка - строка есть в списке
+//	x in list, x in "text"                     строка в списке или подстрока
+//	!, &&, ||, ( )                             логика и группировка
+//	contains(s, x), startsWith(s, x), endsWith(s, x)
+//	matches(s, "regexp"), lower(s), len(s)     регулярное выражение RE2 и длина строки или списка
+//
+// Строки сравниваются без учета регистра; регистр в matches задается флагом (?i).
+// Типы проверяются при разборе, поэтому разобранное выражение вычисляется без ошибок
+type Expression struct {
+	source string
+	root   exprNode
+}
+
+// ParseExpression разбирает выражение, результат которого - логическое значение
+func ParseExpression(s string) (*Expression, error) {
+	tokens, err := tokenizeExpr(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("expression is empty")
+	}
+
+	p := &exprParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %s in expression", p.peek())
+	}
+	if root.typ() != exprBool {
+		return nil, fmt.Errorf("expression must be a condition, got %s", root.typ())
+	}
+	return &Expression{source: strings.TrimSpace(s), root: root}, nil
+}
+
+// Match проверяет, подходит ли статья (вместе с данными ее ленты) под выражение
+func (e *Expression) Match(entry *DigestEntry) bool {
+	return e.root.eval(entry).(bool)
+}
+
+// String возвращает исходный текст выражения
+func (e *Expression) String() string {
+	return e.source
+}
+
+// exprType тип значения выражения
+type exprType int
+
+const (
+	exprString exprType = iota
+	exprNumber
+	exprBool
+	exprList
+)
+
+func (t exprType) String() string {
+	switch t {
+	case exprString:
+		return "string"
+	case exprNumber:
+		return "number"
+	case exprBool:
+		return "bool"
+	default:
+		return "list"
+	}
+}
+
+// exprNode узел выражения. eval возвращает string, float64, bool или []string согласно typ
+type exprNode interface {
+	typ() exprType
+	eval(entry *DigestEntry) interface{}
+}
+
+// exprLiteral константа
+type exprLiteral struct {
+	t     exprType
+	value interface{}
+}
+
+func (n *exprLiteral) typ() exprType                    { return n.t }
+func (n *exprLiteral) eval(*DigestEntry) interface{}    { return n.value }
+func (n *exprField) typ() exprType                      { return n.t }
+func (n *exprField) eval(e *DigestEntry) interface{}    { return n.get(e) }
+func (n *exprNot) typ() exprType                        { return exprBool }
+func (n *exprNot) eval(e *DigestEntry) interface{}      { return !n.node.eval(e).(bool) }
+func (n *exprLogic) typ() exprType                      { return exprBool }
+func (n *exprCompare) typ() exprType                    { return exprBool }
+func (n *exprCall) typ() exprType                       { return n.fn.result }
+func (n *exprCall) eval(e *DigestEntry) interface{}     { return n.fn.call(n, e) }
+func (n *exprListNode) typ() exprType                   { return exprList }
+func (n *exprListNode) eval(e *DigestEntry) interface{} { return evalList(n.items, e) }
+
+// exprField поле статьи или ленты
+type exprField struct {
+	t   exprType
+	get func(e *DigestEntry) interface{}
+}
+
+// exprFields поля, доступные в выражениях
+var exprFields = map[string]*exprField{
+	"title":       {exprString, func(e *DigestEntry) interface{} { return e.Article.Title }},
+	"description": {exprString, func(e *DigestEntry) interface{} { return e.Article.Description }},
+	"link":        {exprString, func(e *DigestEntry) interface{} { return e.Article.Link }},
+	"guid":        {exprString, func(e *DigestEntry) interface{} { return e.Article.GUID }},
+	"language":    {exprString, func(e *DigestEntry) interface{} { return e.Article.Language }},
+	"feed.name":   {exprString, func(e *DigestEntry) interface{} { return e.FeedName }},
+	"feed.folder": {exprString, func(e *DigestEntry) interface{} { return e.FeedFolder }},
+	"feed.tags":   {exprList, func(e *DigestEntry) interface{} { return e.FeedTags }},
+	"feed.tag":    {exprList, func(e *DigestEntry) interface{} { return e.FeedTags }},
+}
+
+// exprNot логическое отрицание
+type exprNot struct{ node exprNode }
+
+// exprLogic && или ||; правая часть вычисляется только при необходимости
+type exprLogic struct {
+	and         bool
+	left, right exprNode
+}
+
+func (n *exprLogic) eval(e *DigestEntry) interface{} {
+	left := n.left.eval(e).(bool)
+	if n.and != left {
+		return left
+	}
+	return n.right.eval(e).(bool)
+}
+
+// exprCompare сравнение или in
+type exprCompare struct {
+	op          string
+	left, right exprNode
+}
+
+func (n *exprCompare) eval(e *DigestEntry) interface{} {
+	left, right := n.left.eval(e), n.right.eval(e)
+
+	switch n.op {
+	case "in":
+		if list, ok := right.([]string); ok {
+			return listContains(list, left.(string))
+		}
+		return containsFold(right.(string), left.(string))
+	case "==", "!=":
+		equal := false
+		switch {
+		case n.left.typ() == exprList:
+			equal = listContains(left.([]string), right.(string))
+		case n.right.typ() == exprList:
+			equal = listContains(right.([]string), left.(string))
+		case n.left.typ() == exprString:
+			equal = strings.EqualFold(left.(string), right.(string))
+		default:
+			equal = left == right
+		}
+		return equal == (n.op == "==")
+	}
+
+	l, r := left.(float64), right.(float64)
+	switch n.op {
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	default:
+		return l >= r
+	}
+}
+
+// checkCompare проверяет типы операндов сравнения
+func checkCompare(op string, left, right exprNode) error {
+	l, r := left.typ(), right.typ()
+	switch op {
+	case "in":
+		if l == exprString && (r == exprList || r == exprString) {
+			return nil
+		}
+	case "==", "!=":
+		if l == r && l != exprList || l == exprList && r == exprString || l == exprString && r == exprList {
+			return nil
+		}
+	default:
+		if l == exprNumber && r == exprNumber {
+			return nil
+		}
+	}
+	return fmt.Errorf("operator %s cannot compare %s and %s", op, l, r)
+}
+
+// exprListNode список строк
+type exprListNode struct{ items []exprNode }
+
+func evalList(items []exprNode, e *DigestEntry) []string {
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		list = append(list, item.eval(e).(string))
+	}
+	return list
+}
+
+// exprCall вызов функции
+type exprCall struct {
+	fn   *exprFunc
+	args []exprNode
+	re   *regexp.Regexp // Разобранное регулярное выражение matches
+}
+
+// exprFunc функция выражений: типы аргументов и результата
+type exprFunc struct {
+	args   [][]exprType // Допустимые типы каждого аргумента
+	result exprType
+	call   func(n *exprCall, e *DigestEntry) interface{}
+}
+
+var (
+	stringArg     = []exprType{exprString}
+	stringListArg = []exprType{exprString, exprList}
+)
+
+// exprFuncs функции, доступные в выражениях
+var exprFuncs = map[string]*exprFunc{
+	"contains": {[][]exprType{stringListArg, stringArg}, exprBool, func(n *exprCall, e *DigestEntry) interface{} {
+		value := n.args[1].eval(e).(string)
+		if list, ok := n.args[0].eval(e).([]string); ok {
+			return listContains(list, value)
+		}
+		return containsFold(n.args[0].eval(e).(string), value)
+	}},
+	"startsWith": {[][]exprType{stringArg, stringArg}, exprBool, func(n *exprCall, e *DigestEntry) interface{} {
+		return strings.HasPrefix(strings.ToLower(n.args[0].eval(e).(string)), strings.ToLower(n.args[1].eval(e).(string)))
+	}},
+	"endsWith": {[][]exprType{stringArg, stringArg}, exprBool, func(n *exprCall, e *DigestEntry) interface{} {
+		return strings.HasSuffix(strings.ToLower(n.args[0].eval(e).(string)), strings.ToLower(n.args[1].eval(e).(string)))
+	}},
+	"matches": {[][]exprType{stringArg, stringArg}, exprBool, func(n *exprCall, e *DigestEntry) interface{} {
+		return n.re.MatchString(n.args[0].eval(e).(string))
+	}},
+	"lower": {[][]exprType{stringArg}, exprString, func(n *exprCall, e *DigestEntry) interface{} {
+		return strings.ToLower(n.args[0].eval(e).(string))
+	}},
+	"len": {[][]exprType{stringListArg}, exprNumber, func(n *exprCall, e *DigestEntry) interface{} {
+		if list, ok := n.args[0].eval(e).([]string); ok {
+			return float64(len(list))
+		}
+		return float64(len([]rune(n.args[0].eval(e).(string))))
+	}},
+}
+
+// newExprCall проверяет аргументы функции name и создает ее вызов
+func newExprCall(name string, args []exprNode) (exprNode, error) {
+	fn, ok := exprFuncs[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %s", name)
+	}
+	if len(args) != len(fn.args) {
+		return nil, fmt.Errorf("function %s expects %d arguments, got %d", name, len(fn.args), len(args))
+	}
+	for i, arg := range args {
+		if !hasType(fn.args[i], arg.typ()) {
+			return nil, fmt.Errorf("argument %d of %s cannot be %s", i+1, name, arg.typ())
+		}
+	}
+
+	call := &exprCall{fn: fn, args: args}
+	if name == "matches" {
+		pattern, ok := args[1].(*exprLiteral)
+		if !ok {
+			return nil, fmt.Errorf("pattern of matches must be a string literal")
+		}
+		re, err := regexp.Compile(pattern.value.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern of matches: %w", err)
+		}
+		call.re = re
+	}
+	return call, nil
+}
+
+func hasType(types []exprType, t exprType) bool {
+	for _, allowed := range types {
+		if allowed == t {
+			return true
+		}
+	}
+	return false
+}
+
+func listContains(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(s, sub string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(sub))
+}
+
+// exprToken лексема выражения
+type exprToken struct {
+	kind byte // 'i' - имя, 's' - строка, 'n' - число, 'o' - оператор или скобка
+	text string
+}
+
+func (t exprToken) is(op string) bool { return t.kind == 'o' && t.text == op }
+
+func (t exprToken) String() string { return fmt.Sprintf("%q", t.text) }
+
+// exprOperators операторы и скобки; двухсимвольные проверяются первыми
+var exprOperators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")", "[", "]", ","}
+
+// tokenizeExpr разбивает выражение на имена, строки, числа и операторы
+func tokenizeExpr(s string) ([]exprToken, error) {
+	var tokens []exprToken
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			var text strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				// \" и \\ - экранирование; остальные \ сохраняются, например \d в matches
+				if runes[j] == '\\' && j+1 < len(runes) && (runes[j+1] == r || runes[j+1] == '\\') {
+					j++
+				}
+				text.WriteRune(runes[j])
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("unterminated string in expression")
+			}
+			tokens = append(tokens, exprToken{kind: 's', text: text.String()})
+			i = j + 1
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, exprToken{kind: 'n', text: string(runes[i:j])})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, exprToken{kind: 'i', text: string(runes[i:j])})
+			i = j
+		default:
+			op := ""
+			for _, candidate := range exprOperators {
+				if strings.HasPrefix(string(runes[i:]), candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q in expression", r)
+			}
+			tokens = append(tokens, exprToken{kind: 'o', text: op})
+			i += len([]rune(op))
+		}
+	}
+	return tokens, nil
+}
+
+// exprParser рекурсивный спуск по лексемам выражения
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+}
+
+func (p *exprParser) done() bool { return p.pos >= len(p.tokens) }
+
+func (p *exprParser) peek() exprToken { return p.tokens[p.pos] }
+
+// accept пропускает оператор op, если он следующий
+func (p *exprParser) accept(op string) bool {
+	if !p.done() && p.peek().is(op) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// expect требует оператор op
+func (p *exprParser) expect(op string) error {
+	if p.accept(op) {
+		return nil
+	}
+	if p.done() {
+		return fmt.Errorf("missing %q at end of expression", op)
+	}
+	return fmt.Errorf("expected %q, got %s", op, p.peek())
+}
+
+// parseOr: and ("||" and)*
+func (p *exprParser) parseOr() (exprNode, error) {
+	return p.parseLogic("||", p.parseAnd)
+}
+
+// parseAnd: not ("&&" not)*
+func (p *exprParser) parseAnd() (exprNode, error) {
+	return p.parseLogic("&&", p.parseNot)
+}
+
+// parseLogic разбирает цепочку операндов логического оператора op
+func (p *exprParser) parseLogic(op string, operand func() (exprNode, error)) (exprNode, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept(op) {
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		if left.typ() != exprBool || right.typ() != exprBool {
+			return nil, fmt.Errorf("operator %s needs conditions, got %s and %s", op, left.typ(), right.typ())
+		}
+		left = &exprLogic{and: op == "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseNot: "!" not | compare
+func (p *exprParser) parseNot() (exprNode, error) {
+	if !p.accept("!") {
+		return p.parseCompare()
+	}
+	node, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	if node.typ() != exprBool {
+		return nil, fmt.Errorf("operator ! needs a condition, got %s", node.typ())
+	}
+	return &exprNot{node: node}, nil
+}
+
+// parseCompare: primary [op primary]
+func (p *exprParser) parseCompare() (exprNode, error) {
+	left, err := p.parsePrimary()
+	if err != nil || p.done() {
+		return left, err
+	}
+
+	op := ""
+	switch t := p.peek(); {
+	case t.kind == 'i' && t.text == "in":
+		op = "in"
+	case t.kind == 'o' && strings.ContainsAny(t.text, "=<>"):
+		op = t.text
+	default:
+		return left, nil
+	}
+	p.pos++
+
+	right, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCompare(op, left, right); err != nil {
+		return nil, err
+	}
+	return &exprCompare{op: op, left: left, right: right}, nil
+}
+
+// parsePrimary: "(" or ")" | "[" [strings] "]" | string | number | true | false | field | name "(" args ")"
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	if p.done() {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	t := p.peek()
+	p.pos++
+
+	switch {
+	case t.is("("):
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return node, p.expect(")")
+	case t.is("["):
+		return p.parseList()
+	case t.kind == 's':
+		return &exprLiteral{t: exprString, value: t.text}, nil
+	case t.kind == 'n':
+		n, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", t)
+		}
+		return &exprLiteral{t: exprNumber, value: n}, nil
+	case t.kind == 'i' && (t.text == "true" || t.text == "false"):
+		return &exprLiteral{t: exprBool, value: t.text == "true"}, nil
+	case t.kind == 'i' && p.accept("("):
+		args, err := p.parseArgs()
+		if err != nil {
+			return nil, err
+		}
+		return newExprCall(t.text, args)
+	case t.kind == 'i':
+		field, ok := exprFields[strings.ToLower(t.text)]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %s (expected title, description, link, guid, language, feed.name, feed.folder or feed.tags)", t.text)
+		}
+		return field, nil
+	default:
+		return nil, fmt.Errorf("unexpected %s in expression", t)
+	}
+}
+
+// parseArgs разбирает аргументы функции после "("
+func (p *exprParser) parseArgs() ([]exprNode, error) {
+	var args []exprNode
+	if p.accept(")") {
+		return args, nil
+	}
+	for {
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if !p.accept(",") {
+			return args, p.expect(")")
+		}
+	}
+}
+
+// parseList разбирает список строк после "["
+func (p *exprParser) parseList() (exprNode, error) {
+	list := &exprListNode{}
+	if p.accept("]") {
+		return list, nil
+	}
+	for {
+		item, err := p.parseCompare()
+		if err != nil {
+			return nil, err
+		}
+		if item.typ() != exprString {
+			return nil, fmt.Errorf("list items must be strings, got %s", item.typ())
+		}
+		list.items = append(list.items, item)
+		if !p.accept(",") {
+			return list, p.expect("]")
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// internal/core/domain/fever.go
+package domain
+
+import "fmt"
+
+// ArticleSeqFilter выборка статей по целочисленным номерам (Fever API).
+// Заполняется одно из условий: SinceSeq, MaxSeq или Seqs; без условий - статьи с начала
+type ArticleSeqFilter struct {
+	SinceSeq int64   // Статьи с номером больше SinceSeq, по возрастанию
+	MaxSeq   int64   // Статьи с номером меньше MaxSeq, по убыванию
+	Seqs     []int64 // Статьи с перечисленными номерами
+	Limit    int     // Максимум статей
+}
+
+// ArticleState состояние статьи, по которому выбираются номера статей
+type ArticleState string
+
+const (
+	ArticleUnread ArticleState = "unread" // Не прочитана
+	ArticleSaved  ArticleState = "saved"  // В избранном
+)
+
+// ArticleMark изменение состояния статьи
+type ArticleMark string
+
+const (
+	MarkRead    ArticleMark = "read"
+	MarkUnread  ArticleMark = "unread"
+	MarkSaved   ArticleMark = "saved"
+	MarkUnsaved ArticleMark = "unsaved"
+)
+
+// ParseArticleMark преобразует текстовое название в изменение состояния статьи
+func ParseArticleMark(s string) (ArticleMark, error) {
+	switch mark := ArticleMark(s); mark {
+	case MarkRead, MarkUnread, MarkSaved, MarkUnsaved:
+		return mark, nil
+	default:
+		return "", fmt.Errorf("unknown article mark: %s (expected read, unread, saved or unsaved)", s)
+	}
+}
@@ -0,0 +1,59 @@
+// internal/core/domain/folder.go
+package domain
+
+import (
+	"strings"
+)
+
+// FolderSeparator разделитель уровней в пути папки: "News/Tech/Go"
+const FolderSeparator = "/"
+
+// ParseFolder приводит путь папки к каноническому виду: обрезает пробелы вокруг уровней
+// и удаляет пустые уровни, поэтому " News / Tech/ " и "/News/Tech" - одна папка "News/Tech".
+// Регистр сохраняется, как в OPML; пустая строка - лента без папки
+func ParseFolder(s string) string {
+	parts := strings.Split(s, FolderSeparator)
+	levels := parts[:0]
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			levels = append(levels, part)
+		}
+	}
+	return strings.Join(levels, FolderSeparator)
+}
+
+// FolderAncestors возвращает путь папки и все родительские папки, начиная с верхнего уровня:
+// "News/Tech/Go" - "News", "News/Tech", "News/Tech/Go"
+func FolderAncestors(path string) []string {
+	if path == "" {
+		return nil
+	}
+	levels := strings.Split(path, FolderSeparator)
+	ancestors := make([]string, len(levels))
+	for i := range levels {
+		ancestors[i] = strings.Join(levels[:i+1], FolderSeparator)
+	}
+	return ancestors
+}
+
+// InFolder сообщает, находится ли папка path в папке folder или в одной из ее вложенных папок.
+// Папки сравниваются без учета регистра
+func InFolder(path, folder string) bool {
+	if folder == "" {
+		return true
+	}
+	if len(path) < len(folder) || !strings.EqualFold(path[:len(folder)], folder) {
+		return false
+	}
+	return len(path) == len(folder) || strings.HasPrefix(path[len(folder):], FolderSeparator)
+}
+
+// FolderDepth возвращает уровень вложенности папки: 0 - папка верхнего уровня
+func FolderDepth(path string) int {
+	return strings.Count(path, FolderSeparator)
+}
+
+// FolderName возвращает имя папки без родительских папок: "Go" для "News/Tech/Go"
+func FolderName(path string) string {
+	return path[strings.LastIndex(path, FolderSeparator)+1:]
+}
@@ -0,0 +1,192 @@
+// internal/core/domain/language.go
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// minLanguageLetters минимум букв в тексте, с которого язык определяется
+const minLanguageLetters = 8
+
+// stopWords частые служебные слова языков с латиницей, по которым они различаются
+var stopWords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "for", "on", "with", "that", "this", "are", "was", "from", "by", "at", "be", "have", "you", "it", "how", "what", "new"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "mit", "den", "von", "zu", "ein", "eine", "für", "auf", "im", "dem", "des", "sich", "auch", "wie", "wird"},
+	"fr": {"le", "les", "et", "des", "est", "une", "du", "pour", "dans", "qui", "sur", "pas", "au", "avec", "ce", "par", "plus", "aux", "sont", "cette"},
+	"es": {"el", "los", "las", "y", "que", "es", "por", "una", "del", "con", "para", "se", "su", "al", "como", "más", "pero", "sus", "está"},
+	"it": {"il", "di", "che", "è", "per", "non", "sono", "gli", "dei", "nel", "della", "alla", "anche", "questo", "una", "delle", "più", "ha"},
+	"pt": {"os", "do", "da", "em", "um", "uma", "não", "para", "com", "dos", "das", "no", "na", "é", "ao", "mais", "foi", "pelo", "seu"},
+	"nl": {"het", "een", "en", "van", "dat", "op", "te", "niet", "met", "voor", "zijn", "er", "ook", "aan", "als", "bij", "door", "wordt", "naar"},
+}
+
+// stopWordLanguages обратный индекс stopWords: слово -> языки
+var stopWordLanguages = func() map[string][]string {
+	index := make(map[string][]string)
+	for lang, words := range stopWords {
+		for _, word := range words {
+			index[word] = append(index[word], lang)
+		}
+	}
+	return index
+}()
+
+// DetectLanguage определяет язык текста (код ISO 639-1) по алфавиту, а для латиницы - по частым
+// служебным словам. Возвращает "", если текст слишком короткий или язык не удалось определить
+func DetectLanguage(text string) string {
+	text = stripTags(text)
+
+	scripts := make(map[string]int)
+	letters := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		scripts[letterScript(r)]++
+	}
+	if letters < minLanguageLetters {
+		return ""
+	}
+
+	// Кана встречается только в японском, даже если иероглифов больше
+	if scripts["kana"] > 0 {
+		return "ja"
+	}
+
+	script, count := "", 0
+	for name, n := range scripts {
+		if n > count || (n == count && name < script) {
+			script, count = name, n
+		}
+	}
+
+	switch script {
+	case "latin":
+		return latinLanguage(text)
+	case "cyrillic":
+		// Буквы і, ї, є, ґ есть в украинском, но не в русском
+		if strings.ContainsAny(strings.ToLower(text), "іїєґ") {
+			return "uk"
+		}
+		return "ru"
+	case "han":
+		return "zh"
+	case "hangul":
+		return "ko"
+	case "greek":
+		return "el"
+	case "arabic":
+		return "ar"
+	case "hebrew":
+		return "he"
+	case "thai":
+		return "th"
+	case "devanagari":
+		return "hi"
+	default:
+		return ""
+	}
+}
+
+// letterScript возвращает название алфавита буквы
+func letterScript(r rune) string {
+	switch {
+	case unicode.Is(unicode.Latin, r):
+		return "latin"
+	case unicode.Is(unicode.Cyrillic, r):
+		return "cyrillic"
+	case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+		return "kana"
+	case unicode.Is(unicode.Han, r):
+		return "han"
+	case unicode.Is(unicode.Hangul, r):
+		return "hangul"
+	case unicode.Is(unicode.Greek, r):
+		return "greek"
+	case unicode.Is(unicode.Arabic, r):
+		return "arabic"
+	case unicode.Is(unicode.Hebrew, r):
+		return "hebrew"
+	case unicode.Is(unicode.Thai, r):
+		return "thai"
+	case unicode.Is(unicode.Devanagari, r):
+		return "devanagari"
+	default:
+		return "other"
+	}
+}
+
+// latinLanguage выбирает язык с латиницей, служебных слов которого в тексте больше всего.
+// При равенстве язык считается неопределенным
+func latinLanguage(text string) string {
+	scores := make(map[string]int)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) })
+	for _, word := range words {
+		for _, lang := range stopWordLanguages[word] {
+			scores[lang]++
+		}
+	}
+
+	best, bestScore, tie := "", 0, false
+	for lang, score := range scores {
+		switch {
+		case score > bestScore:
+			best, bestScore, tie = lang, score, false
+		case score == bestScore:
+			tie = true
+		}
+	}
+	if tie {
+		return ""
+	}
+	return best
+}
+
+// stripTags убирает HTML теги из описания, чтобы имена тегов и атрибутов не влияли на результат
+func stripTags(s string) string {
+	if !strings.Contains(s, "<") {
+		return s
+	}
+
+	var b strings.Builder
+	inTag := false
+	for _, r := range s {
+		switch {
+		case r == '<':
+			inTag = true
+		case r == '>' && inTag:
+			inTag = false
+			b.WriteByte(' ')
+		case !inTag:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// ParseLanguages разбирает список кодов языков через запятую (например, "en,ru")
+func ParseLanguages(s string) ([]string, error) {
+	languages := ParseTags(s)
+	for _, lang := range languages {
+		if len(lang) < 2 || len(lang) > 3 || strings.IndexFunc(lang, func(r rune) bool { return r < 'a' || r > 'z' }) >= 0 {
+			return nil, fmt.Errorf("invalid language code %q (expected ISO 639-1 code like en or ru)", lang)
+		}
+	}
+	return languages, nil
+}
+
+// AcceptsLanguage проверяет, ожидается ли статья на языке lang в ленте. Статьи, язык которых
+// не определен, принимаются всегда
+func (f *Feed) AcceptsLanguage(lang string) bool {
+	if len(f.Languages) == 0 || lang == "" {
+		return true
+	}
+	for _, expected := range f.Languages {
+		if expected == lang {
+			return true
+		}
+	}
+	return false
+}
@@ -1,8 +1,15 @@
 package domain
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"strings"
 	"time"
 
+	"rsshub/internal/platform/urlnorm"
 	"rsshub/internal/platform/utils"
 )
 
@@ -13,32 +20,371 @@ type Feed struct {
 	UpdatedAt time.Time  `json:"updated_at"` // Время последнего обновления
 	Name      string     `json:"name"`       // Человекочитаемое имя ленты
 	URL       string     `json:"url"`        // URL для получения RSS данных
+
+	// Сетевые настройки ленты
+	ProxyURL    string        `json:"proxy_url,omitempty"`    // Индивидуальный прокси (http, https, socks5)
+	TLSInsecure bool          `json:"tls_insecure,omitempty"` // Не проверять TLS сертификат (внутренние ленты)
+	Timeout     time.Duration `json:"timeout,omitempty"`      // Таймаут получения ленты (0 - глобальный)
+
+	// Дополнительные HTTP заголовки запроса (User-Agent, Cookie, авторизация и т.п.)
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Учетные данные для приватных лент (хранятся в БД в зашифрованном виде)
+	Auth *FeedAuth `json:"-"`
+
+	Priority FeedPriority `json:"priority"`       // Приоритет получения ленты в цикле
+	Tags     []string     `json:"tags,omitempty"` // Теги для группировки и фильтрации лент
+	Enabled  bool         `json:"enabled"`        // Отключенные ленты не получаются агрегатором
+
+	// Путь папки ленты, например "News/Tech/Go" (пусто - лента вне папок)
+	Folder string `json:"folder,omitempty"`
+
+	// Ожидаемые языки статей (коды ISO 639-1); статьи на других языках не сохраняются (пусто - любые)
+	Languages []string `json:"languages,omitempty"`
+
+	// Расписание получения в формате cron (пусто - в каждом цикле) и время следующего запуска по нему
+	Schedule  string     `json:"schedule,omitempty"`
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+
+	// Как часто получать ленту (0 - когда она устарела по интервалу агрегатора, см. IsStale)
+	Interval time.Duration `json:"interval,omitempty"`
+
+	// Зеркала: запасные URL той же ленты для ненадежных или заблокированных в регионе источников
+	Mirrors       []string `json:"mirrors,omitempty"`
+	FetchFailures int      `json:"fetch_failures,omitempty"` // Неудачи получения с основного URL подряд
+	ActiveURL     string   `json:"active_url,omitempty"`     // Зеркало, с которого лента получена последний раз (пусто - основной URL)
+
+	// Последняя ошибка обработки ленты; пусто - последняя обработка успешна
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+
+	// Начало непрерывной серии неудач обработки (nil - последняя обработка успешна)
+	FailingSince *time.Time `json:"failing_since,omitempty"`
+
+	// Почему агрегатор отключил мертвую ленту и когда (пусто - лента не отключалась автоматически)
+	DisabledReason string     `json:"disabled_reason,omitempty"`
+	DisabledAt     *time.Time `json:"disabled_at,omitempty"`
+
+	// Валидаторы кеша последнего ответа основного URL для условного GET
+	Cache CacheValidators `json:"-"`
+
+	Seq int64 `json:"-"` // Целочисленный номер ленты для Fever API
+}
+
+// CacheValidators валидаторы кеша HTTP ответа ленты (заголовки ETag и Last-Modified). Повторный
+// запрос с ними условный: если документ не изменился, сервер отвечает 304 Not Modified без тела
+type CacheValidators struct {
+	ETag         string
+	LastModified string
+}
+
+// IsZero сообщает, что валидаторов нет и запрос будет безусловным
+func (v CacheValidators) IsZero() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
+
+// IsStale сообщает, что ленту пора получать: она еще не получалась (время обновления совпадает
+// со временем создания) или с последнего получения прошел ее интервал, а для ленты без своего
+// интервала - staleAfter
+func (f *Feed) IsStale(now time.Time, staleAfter time.Duration) bool {
+	if f.Interval > 0 {
+		staleAfter = f.Interval
+	}
+	return f.UpdatedAt.Equal(f.CreatedAt) || !f.UpdatedAt.After(now.Add(-staleAfter))
+}
+
+// ParseTags разбирает список тегов через запятую: обрезает пробелы,
+// приводит к нижнему регистру и удаляет пустые значения и дубликаты
+func ParseTags(s string) []string {
+	tags := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, tag := range strings.Split(s, ",") {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// FeedPriority определяет порядок и очередь, в которой лента обрабатывается воркерами
+type FeedPriority int
+
+const (
+	PriorityLow    FeedPriority = iota // Обрабатывается в последнюю очередь
+	PriorityNormal                     // Приоритет по умолчанию
+	PriorityHigh                       // Обрабатывается первой в каждом цикле
+)
+
+// String возвращает текстовое название приоритета
+func (p FeedPriority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityHigh:
+		return "high"
+	default:
+		return "normal"
+	}
+}
+
+// ParseFeedPriority преобразует текстовое название в приоритет
+func ParseFeedPriority(s string) (FeedPriority, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "low":
+		return PriorityLow, nil
+	case "normal", "":
+		return PriorityNormal, nil
+	case "high":
+		return PriorityHigh, nil
+	default:
+		return PriorityNormal, fmt.Errorf("invalid priority: %s (expected high, normal or low)", s)
+	}
+}
+
+// MarshalJSON сериализует приоритет в виде строки
+func (p FeedPriority) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + p.String() + `"`), nil
+}
+
+// FeedAuth содержит учетные данные для доступа к ленте
+type FeedAuth struct {
+	Username    string `json:"username,omitempty"`     // Имя пользователя для HTTP Basic
+	Password    string `json:"password,omitempty"`     // Пароль для HTTP Basic
+	BearerToken string `json:"bearer_token,omitempty"` // Токен для заголовка Authorization: Bearer
+}
+
+// IsZero проверяет, что учетные данные не заданы
+func (a *FeedAuth) IsZero() bool {
+	return a == nil || (a.Username == "" && a.Password == "" && a.BearerToken == "")
 }
 
 // Article представляет статью в базе данных
 type Article struct {
-	ID          utils.UUID `json:"id"`           // Уникальный идентификатор
-	CreatedAt   time.Time  `json:"created_at"`   // Время создания записи
-	UpdatedAt   time.Time  `json:"updated_at"`   // Время последнего обновления
-	Title       string     `json:"title"`        // Заголовок статьи
-	Link        string     `json:"link"`         // URL статьи
-	PublishedAt time.Time  `json:"published_at"` // Дата публикации из RSS
-	Description string     `json:"description"`  // Описание статьи
-	FeedID      utils.UUID `json:"feed_id"`      // ID ленты, к которой принадлежит статья
+	ID          utils.UUID   `json:"id"`                    // Уникальный идентификатор
+	CreatedAt   time.Time    `json:"created_at"`            // Время создания записи
+	UpdatedAt   time.Time    `json:"updated_at"`            // Время последнего обновления
+	Title       string       `json:"title"`                 // Заголовок статьи
+	Link        string       `json:"link"`                  // URL статьи
+	PublishedAt time.Time    `json:"published_at"`          // Дата публикации из RSS
+	Description string       `json:"description"`           // Описание статьи
+	FeedID      utils.UUID   `json:"feed_id"`               // ID ленты, к которой принадлежит статья
+	ReadAt      *time.Time   `json:"read_at"`               // Время прочтения (nil - не прочитана)
+	GUID        string       `json:"guid,omitempty"`        // Идентификатор элемента из RSS (<guid>)
+	DedupKey    string       `json:"-"`                     // Ключ уникальности статьи (см. ArticleDedupKey)
+	ContentHash string       `json:"-"`                     // Хеш заголовка и описания (см. ArticleContentHash)
+	ModifiedAt  *time.Time   `json:"modified_at,omitempty"` // Когда лента последний раз изменила статью (nil - не меняла)
+	Podcast     *PodcastInfo `json:"podcast,omitempty"`     // Метаданные эпизода подкаста (nil - не подкаст)
+	Media       []MediaItem  `json:"media,omitempty"`       // Вложения Media RSS: изображения, видео, миниатюры
+	SavedAt     *time.Time   `json:"saved_at,omitempty"`    // Время добавления в избранное (nil - не в избранном)
+	Seq         int64        `json:"-"`                     // Целочисленный номер статьи для Fever API
+	Language    string       `json:"language,omitempty"`    // Язык статьи (ISO 639-1), определенный при сохранении
+}
+
+// DedupMode способ определения дубликатов статей
+type DedupMode string
+
+const (
+	DedupByGUID DedupMode = "guid" // GUID элемента, если он есть, иначе ссылка
+	DedupByLink DedupMode = "link" // Только ссылка
+)
+
+// ParseDedupMode преобразует текстовое название в способ определения дубликатов
+func ParseDedupMode(s string) (DedupMode, error) {
+	switch DedupMode(strings.ToLower(strings.TrimSpace(s))) {
+	case DedupByGUID, "":
+		return DedupByGUID, nil
+	case DedupByLink:
+		return DedupByLink, nil
+	default:
+		return DedupByGUID, fmt.Errorf("invalid dedup key: %s (expected guid or link)", s)
+	}
+}
+
+// ConflictMode что делать с элементом ленты, статья которого уже сохранена (тот же ключ уникальности)
+type ConflictMode string
+
+const (
+	ConflictUpdate ConflictMode = "update" // Обновить заголовок и описание, если лента их изменила
+	ConflictSkip   ConflictMode = "skip"   // Оставить сохраненную статью без изменений
+)
+
+// ParseConflictMode преобразует текстовое название в способ обработки сохраненных статей
+func ParseConflictMode(s string) (ConflictMode, error) {
+	switch ConflictMode(strings.ToLower(strings.TrimSpace(s))) {
+	case ConflictUpdate, "":
+		return ConflictUpdate, nil
+	case ConflictSkip:
+		return ConflictSkip, nil
+	default:
+		return ConflictUpdate, fmt.Errorf("invalid article conflict mode: %s (expected update or skip)", s)
+	}
+}
+
+// InsertResult итог сохранения статьи хранилищем
+type InsertResult string
+
+const (
+	ArticleInserted InsertResult = "inserted" // Статья добавлена
+	ArticleSkipped  InsertResult = "skipped"  // Статья уже сохранена и не изменилась
+	ArticleUpdated  InsertResult = "updated"  // Статья уже сохранена, ее содержимое обновлено
+)
+
+// CrossFeedDedup что делать со статьей, ссылка которой уже сохранена в другой ленте
+type CrossFeedDedup string
+
+const (
+	CrossFeedKeep CrossFeedDedup = "keep" // Сохранить статью и в этой ленте
+	CrossFeedSkip CrossFeedDedup = "skip" // Пропустить как дубликат
+)
+
+// ParseCrossFeedDedup преобразует текстовое название в политику дубликатов между лентами
+func ParseCrossFeedDedup(s string) (CrossFeedDedup, error) {
+	switch CrossFeedDedup(strings.ToLower(strings.TrimSpace(s))) {
+	case CrossFeedKeep, "":
+		return CrossFeedKeep, nil
+	case CrossFeedSkip:
+		return CrossFeedSkip, nil
+	default:
+		return CrossFeedKeep, fmt.Errorf("invalid cross-feed dedup mode: %s (expected keep or skip)", s)
+	}
+}
+
+// ArticleDedupKey возвращает ключ уникальности статьи. Статья уникальна в пределах своей
+// ленты: одна и та же ссылка может быть сохранена в нескольких лентах (см. CrossFeedDedup),
+// поэтому оба ключа включают ID ленты. Ссылка сравнивается после нормализации
+// (см. urlnorm.Normalize), чтобы метки аналитики и порядок параметров не создавали дубликатов
+func ArticleDedupKey(mode DedupMode, feedID utils.UUID, guid, link string) string {
+	if mode == DedupByGUID && guid != "" {
+		return "guid:" + feedID.String() + ":" + guid
+	}
+	return "link:" + feedID.String() + ":" + urlnorm.Normalize(link)
+}
+
+// ArticleContentHash возвращает хеш содержимого статьи, по которому определяется,
+// что лента опубликовала элемент повторно с измененным заголовком или описанием
+func ArticleContentHash(title, description string) string {
+	sum := sha256.Sum256([]byte(title + "\x00" + description))
+	return hex.EncodeToString(sum[:])
+}
+
+// ArticleVersion редакция содержимого статьи, полученная от ленты
+type ArticleVersion struct {
+	ArticleID   utils.UUID
+	ContentHash string // См. ArticleContentHash
+	Title       string
+	Description string
+	CreatedAt   time.Time // Когда версия впервые получена
+}
+
+// ArticleFilter условия выборки статей
+type ArticleFilter struct {
+	FeedName string    // Имя ленты (пусто - все ленты)
+	Since    time.Time // Опубликованы не раньше (нулевое значение - без ограничения)
+	Limit    int       // Максимум статей (<= 0 - без ограничения)
+}
+
+// PageCursor позиция в списке для keyset пагинации: ключ сортировки и ID последнего
+// показанного элемента (published_at для статей, created_at для лент)
+type PageCursor struct {
+	Time time.Time
+	ID   utils.UUID
+}
+
+// Encode возвращает курсор в виде непрозрачной строки для передачи в --after
+func (c *PageCursor) Encode() string {
+	raw := c.Time.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParsePageCursor разбирает строку, полученную из PageCursor.Encode
+func ParsePageCursor(s string) (*PageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %s", s)
+	}
+
+	timePart, idPart, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, fmt.Errorf("invalid cursor: %s", s)
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, timePart)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %s", s)
+	}
+	id, err := utils.ParseUUID(idPart)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %s", s)
+	}
+
+	return &PageCursor{Time: t, ID: id}, nil
 }
 
 // RSSFeed представляет структуру RSS XML документа
 // Используется для парсинга XML ответов от RSS серверов
 type RSSFeed struct {
+	XMLName xml.Name   // Корневой элемент: rss или feed (Atom)
 	Channel RSSChannel `xml:"channel"` // Основной канал с информацией о ленте
+
+	// Документ Atom (RFC 4287): корневой <feed> с элементами <entry> вместо <channel>
+	AtomTitle   string      `xml:"http://www.w3.org/2005/Atom title"`
+	AtomLinks   []AtomLink  `xml:"http://www.w3.org/2005/Atom link"`
+	AtomEntries []AtomEntry `xml:"http://www.w3.org/2005/Atom entry"`
+	AtomIcon    string      `xml:"http://www.w3.org/2005/Atom icon"`
+	AtomLogo    string      `xml:"http://www.w3.org/2005/Atom logo"`
+}
+
+// IsAtom проверяет, что документ - лента Atom
+func (f *RSSFeed) IsAtom() bool {
+	return f.XMLName.Space == AtomNamespace && f.XMLName.Local == "feed"
 }
 
+// AtomNamespace пространство имен XML документов Atom
+const AtomNamespace = "http://www.w3.org/2005/Atom"
+
 // RSSChannel содержит метаданные канала и список элементов
 type RSSChannel struct {
-	Title       string    `xml:"title"`       // Название канала
-	Link        string    `xml:"link"`        // Ссылка на сайт
-	Description string    `xml:"description"` // Описание канала
-	Items       []RSSItem `xml:"item"`        // Список статей/элементов
+	Title string `xml:"title"` // Название канала
+	// Ссылки <atom:link> (hub, self). Поле должно идти раньше Link: encoding/xml
+	// сопоставляет "link" без пространства имен с элементами из любого пространства
+	AtomLinks   []AtomLink `xml:"http://www.w3.org/2005/Atom link"`
+	Link        string     `xml:"link"`        // Ссылка на сайт
+	Description string     `xml:"description"` // Описание канала
+	Items       []RSSItem  `xml:"item"`        // Список статей/элементов
+
+	// Картинка канала; itunes:image по той же причине объявлена раньше image
+	ITunesImage ITunesImage `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+	Image       RSSImage    `xml:"image"`
+}
+
+// RSSImage элемент <image> канала RSS
+type RSSImage struct {
+	URL string `xml:"url"`
+}
+
+// AtomLink элемент <atom:link rel="..." href="..."/> в канале RSS или ленте Atom
+type AtomLink struct {
+	Rel  string `xml:"rel,attr"`
+	Href string `xml:"href,attr"`
+}
+
+// AtomEntry запись ленты Atom. Поля yt:* и media:group заполняются в лентах YouTube
+type AtomEntry struct {
+	ID        string     `xml:"http://www.w3.org/2005/Atom id"`
+	Title     string     `xml:"http://www.w3.org/2005/Atom title"`
+	Links     []AtomLink `xml:"http://www.w3.org/2005/Atom link"`
+	Published string     `xml:"http://www.w3.org/2005/Atom published"`
+	Updated   string     `xml:"http://www.w3.org/2005/Atom updated"`
+	Summary   string     `xml:"http://www.w3.org/2005/Atom summary"`
+	Content   string     `xml:"http://www.w3.org/2005/Atom content"`
+
+	VideoID         string           `xml:"http://www.youtube.com/xml/schemas/2015 videoId"`
+	MediaContents   []MediaContent   `xml:"http://search.yahoo.com/mrss/ content"`
+	MediaThumbnails []MediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	MediaGroups     []MediaGroup     `xml:"http://search.yahoo.com/mrss/ group"`
 }
 
 // RSSItem представляет отдельную статью в RSS ленте
@@ -47,6 +393,79 @@ type RSSItem struct {
 	Link        string `xml:"link"`        // Ссылка на статью
 	Description string `xml:"description"` // Описание/краткое содержание
 	PubDate     string `xml:"pubDate"`     // Дата публикации в RSS формате
+	GUID        string `xml:"guid"`        // Постоянный идентификатор элемента
+
+	// Метаданные эпизода подкаста (пространство имен iTunes)
+	ITunesAuthor   string      `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd author"`
+	ITunesDuration string      `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+	ITunesImage    ITunesImage `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+	ITunesEpisode  string      `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd episode"`
+
+	// Вложения Media RSS: отдельные элементы и сгруппированные в media:group
+	MediaContents   []MediaContent   `xml:"http://search.yahoo.com/mrss/ content"`
+	MediaThumbnails []MediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	MediaGroups     []MediaGroup     `xml:"http://search.yahoo.com/mrss/ group"`
+}
+
+// MediaContent элемент <media:content url="..." type="..." medium="..."/>. Размеры
+// читаются строками: некорректное значение не должно ломать разбор всей ленты
+type MediaContent struct {
+	URL        string           `xml:"url,attr"`
+	Type       string           `xml:"type,attr"`
+	Medium     string           `xml:"medium,attr"`
+	Width      string           `xml:"width,attr"`
+	Height     string           `xml:"height,attr"`
+	Thumbnails []MediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+// MediaThumbnail элемент <media:thumbnail url="..." width="..." height="..."/>
+type MediaThumbnail struct {
+	URL    string `xml:"url,attr"`
+	Width  string `xml:"width,attr"`
+	Height string `xml:"height,attr"`
+}
+
+// MediaGroup элемент <media:group> с вариантами одного вложения
+type MediaGroup struct {
+	Description string           `xml:"http://search.yahoo.com/mrss/ description"`
+	Contents    []MediaContent   `xml:"http://search.yahoo.com/mrss/ content"`
+	Thumbnails  []MediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+// MediaKind вид вложения статьи
+type MediaKind string
+
+const (
+	MediaKindContent   MediaKind = "content"   // Само вложение (media:content)
+	MediaKindThumbnail MediaKind = "thumbnail" // Миниатюра (media:thumbnail)
+)
+
+// MediaItem вложение статьи из Media RSS
+type MediaItem struct {
+	Kind   MediaKind `json:"kind"`
+	URL    string    `json:"url"`
+	Type   string    `json:"type,omitempty"`   // MIME тип, например video/mp4
+	Medium string    `json:"medium,omitempty"` // image, video, audio, document или executable
+	Width  int       `json:"width,omitempty"`
+	Height int       `json:"height,omitempty"`
+}
+
+// ITunesImage элемент <itunes:image href="..."/>
+type ITunesImage struct {
+	Href string `xml:"href,attr"`
+}
+
+// PodcastInfo метаданные эпизода подкаста из пространства имен iTunes
+type PodcastInfo struct {
+	Author   string `json:"author,omitempty"`   // Автор эпизода (itunes:author)
+	Duration int    `json:"duration,omitempty"` // Длительность в секундах (itunes:duration)
+	Image    string `json:"image,omitempty"`    // Обложка эпизода (itunes:image)
+	Episode  int    `json:"episode,omitempty"`  // Номер эпизода (itunes:episode)
+}
+
+// IsZero проверяет, что метаданных подкаста нет
+func (p *PodcastInfo) IsZero() bool {
+	return p == nil || *p == PodcastInfo{}
 }
 
 // ParsedRSSFeed представляет распарсенную RSS ленту с преобразованными данными
@@ -55,12 +474,427 @@ type ParsedRSSFeed struct {
 	Link        string          // Ссылка на сайт
 	Description string          // Описание канала
 	Items       []ParsedRSSItem // Список обработанных статей
+	HubURL      string          // WebSub хаб ленты (rel="hub"), пусто - push не поддерживается
+	SelfURL     string          // Канонический URL ленты (rel="self") - тема подписки WebSub
+	MovedTo     string          // Новый URL ленты, если она перемещена навсегда (301/308)
+	PrevArchive string          // Предыдущая страница архива ленты (RFC 5005 rel="prev-archive" или max_id у Mastodon)
+	ImageURL    string          // Картинка канала (image, itunes:image, Atom icon или logo)
+	Bytes       int64           // Размер ответа в байтах до распаковки (трафик получения ленты)
+	Cache       CacheValidators // Валидаторы кеша ответа для следующего условного запроса
+	NotModified bool            // Лента ответила 304 Not Modified: документ не изменился, элементов нет
 }
 
 // ParsedRSSItem представляет обработанную статью с корректно распарсенной датой
 type ParsedRSSItem struct {
-	Title       string    // Заголовок статьи
-	Link        string    // Ссылка на статью
-	Description string    // Описание статьи
-	PublishedAt time.Time // Дата публикации как time.Time
+	Title       string       // Заголовок статьи
+	Link        string       // Ссылка на статью
+	Description string       // Описание статьи
+	PublishedAt time.Time    // Дата публикации как time.Time
+	GUID        string       // Идентификатор элемента (может быть пустым)
+	Podcast     *PodcastInfo // Метаданные эпизода подкаста (nil - не подкаст)
+	Media       []MediaItem  // Вложения Media RSS
+}
+
+// FeedIcon иконка ленты для клиентов: favicon сайта или картинка канала. Неудачная попытка
+// тоже сохраняется (без данных), чтобы не запрашивать иконку в каждом цикле
+type FeedIcon struct {
+	FeedID      utils.UUID
+	URL         string     // Адрес, с которого получена иконка
+	ContentType string     // MIME тип изображения
+	Data        []byte     // Изображение; пусто - иконку пока не удалось получить
+	FetchedAt   *time.Time // Когда получено изображение
+	CheckedAt   time.Time  // Последняя попытка получения
+	LastError   string     // Ошибка последней попытки; пусто - попытка успешна
+}
+
+// Due проверяет, пора ли снова запрашивать иконку: имеющуюся - раз в refresh,
+// а если получить ее не удалось - раз в retry
+func (i *FeedIcon) Due(now time.Time, refresh, retry time.Duration) bool {
+	if len(i.Data) == 0 {
+		return now.Sub(i.CheckedAt) >= retry
+	}
+	return now.Sub(i.CheckedAt) >= refresh
+}
+
+// WebSubSubscription подписка ленты на push уведомления WebSub хаба
+type WebSubSubscription struct {
+	FeedID       utils.UUID
+	HubURL       string     // Адрес хаба
+	Topic        string     // URL темы, на которую оформлена подписка
+	Secret       string     // Секрет для проверки подписи содержимого (X-Hub-Signature)
+	LeaseSeconds int        // Срок подписки, подтвержденный хабом
+	ExpiresAt    *time.Time // Окончание подписки (nil - хаб еще не подтвердил подписку)
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Active проверяет, что подписка подтверждена хабом и еще не истекла
+func (s *WebSubSubscription) Active(now time.Time) bool {
+	return s.ExpiresAt != nil && now.Before(*s.ExpiresAt)
+}
+
+// MigrationStatus описывает версию схемы БД и факт ее применения
+type MigrationStatus struct {
+	Version   int        // Номер версии миграции
+	Name      string     // Описание миграции
+	AppliedAt *time.Time // Время применения (nil - не применена)
+}
+
+// RetentionPolicy сроки хранения данных для обслуживания хранилища (0 - хранить всегда)
+type RetentionPolicy struct {
+	Articles    time.Duration // Статьи, опубликованные и сохраненные раньше; избранные не удаляются
+	KeepPerFeed int           // Сколько самых новых статей каждой ленты не удалять, даже если они старше Articles
+	FetchRuns   time.Duration // История циклов получения лент
+	Traffic     time.Duration // Суточный учет трафика лент
+}
+
+// PruneReport сколько строк удалило (или удалило бы) обслуживание хранилища
+type PruneReport struct {
+	Articles       int64 // Статьи старше срока хранения
+	FetchRuns      int64 // Циклы получения старше срока хранения
+	Traffic        int64 // Дни учета трафика старше срока хранения
+	OrphanArticles int64 // Статьи, лент которых уже нет
+	OrphanRows     int64 // Вложения и версии статей, которых уже нет
+}
+
+// Total возвращает количество всех удаленных строк
+func (r *PruneReport) Total() int64 {
+	return r.Articles + r.FetchRuns + r.Traffic + r.OrphanArticles + r.OrphanRows
+}
+
+// TableStats размер и состояние таблицы хранилища
+type TableStats struct {
+	Name       string
+	TotalBytes int64      // Размер таблицы вместе с индексами и TOAST
+	IndexBytes int64      // Размер индексов таблицы
+	LiveRows   int64      // Оценка количества строк
+	DeadRows   int64      // Удаленные и измененные строки, место которых еще не освобождено VACUUM
+	LastVacuum *time.Time // Последний VACUUM или autovacuum (nil - не выполнялся)
+}
+
+// DeadRatio доля мертвых строк таблицы (0 - пустая таблица)
+func (s TableStats) DeadRatio() float64 {
+	if s.LiveRows+s.DeadRows == 0 {
+		return 0
+	}
+	return float64(s.DeadRows) / float64(s.LiveRows+s.DeadRows)
+}
+
+// IndexHint подсказка по обслуживанию индекса
+type IndexHint struct {
+	Table string
+	Index string
+	Bytes int64  // Размер индекса
+	Hint  string // Что стоит сделать с индексом
+}
+
+// SaveReport итог сохранения элементов одной ленты
+type SaveReport struct {
+	Articles    []*Article // Добавленные статьи
+	Duplicates  int        // Элементов, статьи которых уже сохранены и не изменились
+	Updated     int        // Сохраненных статей, содержимое которых обновлено
+	NotModified bool       // Лента не изменилась с прошлого получения (304), элементы не обрабатывались
+}
+
+// FeedResult результат обработки одной ленты в цикле получения
+type FeedResult struct {
+	FeedName    string        // Имя ленты
+	NewArticles int           // Количество новых статей
+	Articles    []*Article    // Добавленные статьи
+	Duplicates  int           // Элементов, статьи которых уже сохранены и не изменились
+	Updated     int           // Сохраненных статей, содержимое которых обновлено
+	NotModified bool          // Лента ответила 304 Not Modified
+	Skipped     bool          // Лента не обработана (воркеры заняты или остановка)
+	TimedOut    bool          // Обработка отменена, так как заняла больше допустимого времени
+	Bytes       int64         // Загружено байт
+	QueueWait   time.Duration // Сколько лента ждала свободного воркера
+	Err         error         // Ошибка получения или сохранения
+}
+
+// CycleReport сводка по одному циклу получения лент
+type CycleReport struct {
+	StartedAt  time.Time    // Начало цикла
+	FinishedAt time.Time    // Окончание цикла
+	Feeds      []FeedResult // Результаты по каждой ленте
+}
+
+// NewArticles возвращает общее количество новых статей за цикл
+func (r *CycleReport) NewArticles() int {
+	total := 0
+	for _, f := range r.Feeds {
+		total += f.NewArticles
+	}
+	return total
+}
+
+// Duplicates возвращает общее количество элементов, статьи которых уже были сохранены
+func (r *CycleReport) Duplicates() int {
+	total := 0
+	for _, f := range r.Feeds {
+		total += f.Duplicates
+	}
+	return total
+}
+
+// Updated возвращает общее количество сохраненных статей, обновленных за цикл
+func (r *CycleReport) Updated() int {
+	total := 0
+	for _, f := range r.Feeds {
+		total += f.Updated
+	}
+	return total
+}
+
+// Failed возвращает количество лент, обработанных с ошибкой
+func (r *CycleReport) Failed() int {
+	failed := 0
+	for _, f := range r.Feeds {
+		if f.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+// TimedOut возвращает количество лент, обработка которых отменена по таймауту
+func (r *CycleReport) TimedOut() int {
+	timedOut := 0
+	for _, f := range r.Feeds {
+		if f.TimedOut {
+			timedOut++
+		}
+	}
+	return timedOut
+}
+
+// Bytes возвращает общий объем данных, загруженных за цикл
+func (r *CycleReport) Bytes() int64 {
+	var total int64
+	for _, f := range r.Feeds {
+		total += f.Bytes
+	}
+	return total
+}
+
+// Skipped возвращает количество пропущенных лент
+func (r *CycleReport) Skipped() int {
+	skipped := 0
+	for _, f := range r.Feeds {
+		if f.Skipped {
+			skipped++
+		}
+	}
+	return skipped
+}
+
+// QueueWait возвращает среднее и максимальное время, которое ленты цикла ждали свободного воркера
+func (r *CycleReport) QueueWait() (avg, longest time.Duration) {
+	if len(r.Feeds) == 0 {
+		return 0, 0
+	}
+	var total time.Duration
+	for _, f := range r.Feeds {
+		total += f.QueueWait
+		longest = max(longest, f.QueueWait)
+	}
+	return total / time.Duration(len(r.Feeds)), longest
+}
+
+// FetchRun сохраненная история одного цикла получения лент
+type FetchRun struct {
+	ID          utils.UUID  `json:"id"`
+	Instance    string      `json:"instance"`     // Экземпляр агрегатора, выполнивший цикл
+	StartedAt   time.Time   `json:"started_at"`   // Начало цикла
+	FinishedAt  time.Time   `json:"finished_at"`  // Окончание цикла
+	Feeds       int         `json:"feeds"`        // Обработано лент
+	NewArticles int         `json:"new_articles"` // Добавлено новых статей
+	Duplicates  int         `json:"duplicates"`   // Элементов, статьи которых уже сохранены
+	Updated     int         `json:"updated"`      // Сохраненных статей, обновленных лентами
+	Failed      int         `json:"failed"`       // Лент с ошибкой (включая таймауты)
+	TimedOut    int         `json:"timed_out"`    // Лент, отмененных по таймауту
+	Skipped     int         `json:"skipped"`      // Пропущенных лент
+	Bytes       int64       `json:"bytes"`        // Загружено байт
+	Errors      []FeedError `json:"errors"`       // Ошибки по лентам
+
+	QueueWaitAvg time.Duration `json:"queue_wait_avg"` // Сколько в среднем лента ждала свободного воркера
+	QueueWaitMax time.Duration `json:"queue_wait_max"` // Самое долгое ожидание свободного воркера
+}
+
+// FeedError ошибка получения одной ленты в цикле
+type FeedError struct {
+	FeedName string `json:"feed"`
+	Error    string `json:"error"`
+}
+
+// Duration возвращает длительность цикла
+func (r *FetchRun) Duration() time.Duration {
+	return r.FinishedAt.Sub(r.StartedAt)
+}
+
+// NewFetchRun формирует запись истории по отчету о цикле
+func NewFetchRun(instance string, report *CycleReport) *FetchRun {
+	run := &FetchRun{
+		Instance:    instance,
+		StartedAt:   report.StartedAt,
+		FinishedAt:  report.FinishedAt,
+		Feeds:       len(report.Feeds),
+		NewArticles: report.NewArticles(),
+		Duplicates:  report.Duplicates(),
+		Updated:     report.Updated(),
+		Failed:      report.Failed(),
+		TimedOut:    report.TimedOut(),
+		Skipped:     report.Skipped(),
+		Bytes:       report.Bytes(),
+	}
+	run.QueueWaitAvg, run.QueueWaitMax = report.QueueWait()
+	for _, f := range report.Feeds {
+		if f.Err != nil {
+			run.Errors = append(run.Errors, FeedError{FeedName: f.FeedName, Error: f.Err.Error()})
+		}
+	}
+	return run
+}
+
+// BackfillReport итог импорта архива ленты
+type BackfillReport struct {
+	Pages       int // Обработано страниц архива
+	Items       int // Всего элементов на этих страницах
+	NewArticles int // Добавлено новых статей
+	Duplicates  int // Элементов, статьи которых уже были сохранены
+}
+
+// Subscription подписка, импортируемая из другого агрегатора (Miniflux, FreshRSS)
+type Subscription struct {
+	Title      string
+	FeedURL    string
+	Categories []string // Категории или метки подписки; становятся тегами ленты
+	Folder     string   // Путь папки подписки (вложенные outline в OPML)
+}
+
+// ImportedEntry статья другого агрегатора вместе с ее состоянием
+type ImportedEntry struct {
+	FeedURL     string // URL ленты, к которой относится статья
+	Title       string
+	Link        string
+	Description string
+	PublishedAt time.Time
+	Read        bool
+	Starred     bool
+}
+
+// ImportReport результат импорта подписок и состояния статей
+type ImportReport struct {
+	Feeds         int // Добавлено лент
+	ExistingFeeds int // Ленты с тем же URL уже были
+	Articles      int // Добавлено статей
+	Read          int // Статей отмечено прочитанными
+	Starred       int // Статей добавлено в избранное
+}
+
+// Backup ленты со всеми настройками и правила фильтров для переноса без потерь (rsshub export)
+type Backup struct {
+	Feeds   []*Feed
+	Filters []*FilterRule
+}
+
+// RestoreReport результат восстановления лент, правил фильтров и статей из резервной копии
+type RestoreReport struct {
+	Feeds            int // Добавлено лент
+	ExistingFeeds    int // Ленты с тем же именем или URL уже были
+	Filters          int // Добавлено правил фильтров
+	ExistingFilters  int // Правила с тем же именем уже были
+	Articles         int // Добавлено статей
+	ExistingArticles int // Статьи с тем же ключом уникальности уже были
+	SkippedArticles  int // Статьи лент, которых нет в базе (лента с тем же URL есть под другим именем)
+}
+
+// DiscoveredFeed лента, найденная на сайте командой discover, и результат ее проверки
+type DiscoveredFeed struct {
+	SiteURL  string // Сайт из списка, на котором найдена лента
+	URL      string // URL ленты (пусто, если на сайте не нашлось ни одной)
+	Title    string
+	Items    int    // Статей в ленте при проверке
+	Existing string // Имя уже добавленной ленты с тем же URL
+	Added    string // Имя, под которым лента добавлена
+	Error    string // Почему сайт или лента не прошли проверку
+}
+
+// DigestEntry статья для дайджеста вместе с данными ее ленты
+type DigestEntry struct {
+	Article    *Article
+	FeedName   string   // Имя ленты статьи
+	FeedTags   []string // Теги ленты статьи
+	FeedFolder string   // Путь папки ленты статьи
+	Score      float64  // Оценка статьи конвейером обработки (0 - не оценивалась)
+}
+
+// DigestGroup группа статей дайджеста (по ленте или по тегу)
+type DigestGroup struct {
+	Name    string
+	Entries []*DigestEntry
+}
+
+// Digest дайджест новых статей за период
+type Digest struct {
+	From   time.Time     // Начало периода
+	To     time.Time     // Конец периода
+	Groups []DigestGroup // Статьи, сгруппированные по лентам или тегам
+}
+
+// Total возвращает количество статей в дайджесте (без учета повторов в разных группах)
+func (d *Digest) Total() int {
+	seen := make(map[string]struct{})
+	for _, g := range d.Groups {
+		for _, e := range g.Entries {
+			seen[e.Article.Link] = struct{}{}
+		}
+	}
+	return len(seen)
+}
+
+// ArticleCounts количество статей ленты для вывода в list
+type ArticleCounts struct {
+	Total  int // Всего статей
+	Unread int // Непрочитанных статей
+}
+
+// FeedStats агрегированная статистика статей ленты
+type FeedStats struct {
+	FeedName       string
+	TotalArticles  int        // Всего статей
+	FirstArticleAt *time.Time // Дата публикации самой старой статьи (nil - статей нет)
+	LastArticleAt  *time.Time // Дата публикации самой новой статьи
+	LastDay        int        // Статей за последние сутки
+	LastWeek       int        // Статей за последние 7 дней
+	PrevWeek       int        // Статей за предыдущие 7 дней (8-14 дней назад)
+	LastFetchedAt  time.Time  // Время последнего получения ленты
+}
+
+// AvgInterval возвращает средний интервал между публикациями (0, если статей меньше двух)
+func (s *FeedStats) AvgInterval() time.Duration {
+	if s.TotalArticles < 2 || s.FirstArticleAt == nil || s.LastArticleAt == nil {
+		return 0
+	}
+	return s.LastArticleAt.Sub(*s.FirstArticleAt) / time.Duration(s.TotalArticles-1)
+}
+
+// PerDay возвращает среднее количество статей в день за всю историю ленты
+func (s *FeedStats) PerDay(now time.Time) float64 {
+	if s.TotalArticles == 0 || s.FirstArticleAt == nil {
+		return 0
+	}
+	days := now.Sub(*s.FirstArticleAt).Hours() / 24
+	if days < 1 {
+		days = 1
+	}
+	return float64(s.TotalArticles) / days
+}
+
+// WeeklyGrowth возвращает изменение количества статей за последнюю неделю относительно
+// предыдущей в процентах; ok = false, если на предыдущей неделе статей не было
+func (s *FeedStats) WeeklyGrowth() (percent float64, ok bool) {
+	if s.PrevWeek == 0 {
+		return 0, false
+	}
+	return float64(s.LastWeek-s.PrevWeek) / float64(s.PrevWeek) * 100, true
 }
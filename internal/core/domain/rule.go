@@ -0,0 +1,67 @@
+// internal/core/domain/rule.go
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"rsshub/internal/platform/utils"
+)
+
+// NotificationRule правило уведомлений: новые статьи, подходящие под запрос, отправляются в канал.
+// Канал, для которого есть правила, получает только подходящие хотя бы под одно из них статьи;
+// канал без правил получает все новые статьи
+type NotificationRule struct {
+	ID         utils.UUID `json:"id"`
+	Name       string     `json:"name"`
+	Query      string     `json:"query,omitempty"`      // Запрос в синтаксисе ParseSmartQuery
+	Expression string     `json:"expression,omitempty"` // Выражение ParseExpression вместо запроса
+	Channel    string     `json:"channel"`              // Имя канала уведомлений, например telegram
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// Condition возвращает условие правила: выражение, если оно задано, иначе запрос
+func (r *NotificationRule) Condition() string {
+	if r.Expression != "" {
+		return r.Expression
+	}
+	return r.Query
+}
+
+// ParseCondition разбирает условие правила
+func (r *NotificationRule) ParseCondition() (QueryNode, error) {
+	if r.Expression != "" {
+		return ParseExpression(r.Expression)
+	}
+	return ParseSmartQuery(r.Query)
+}
+
+// FilterAction что правило фильтра делает с подходящими элементами лент
+type FilterAction string
+
+const (
+	FilterDrop FilterAction = "drop" // Отбросить элемент
+	FilterKeep FilterAction = "keep" // Сохранить только элементы, подходящие под правила keep
+)
+
+// ParseFilterAction разбирает действие правила фильтра
+func ParseFilterAction(s string) (FilterAction, error) {
+	switch action := FilterAction(strings.ToLower(strings.TrimSpace(s))); action {
+	case FilterDrop, FilterKeep:
+		return action, nil
+	default:
+		return "", fmt.Errorf("unknown filter action %q (expected drop or keep)", s)
+	}
+}
+
+// FilterRule правило фильтра элементов лент при сохранении: элемент, подходящий под правило drop,
+// не сохраняется; если есть правила keep, сохраняются только элементы, подходящие хотя бы под одно
+// из них. Уже сохраненные статьи правила не меняют
+type FilterRule struct {
+	ID         utils.UUID   `json:"id"`
+	Name       string       `json:"name"`
+	Expression string       `json:"expression"` // Выражение в синтаксисе ParseExpression
+	Action     FilterAction `json:"action"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
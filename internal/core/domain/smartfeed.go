@@ -0,0 +1,302 @@
+// internal/core/domain/smartfeed.go
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"rsshub/internal/platform/utils"
+)
+
+// SmartFeed сохраненный поиск: виртуальная лента из статей всех лент, подходящих под запрос
+type SmartFeed struct {
+	ID        utils.UUID `json:"id"`
+	Name      string     `json:"name"`       // Имя, по которому смарт-лента открывается как обычная
+	Query     string     `json:"query"`      // Запрос в синтаксисе ParseSmartQuery
+	CreatedAt time.Time  `json:"created_at"` // Время создания
+}
+
+// Поля, по которым ищут условия запроса
+const (
+	QueryFieldText        = "text"        // Заголовок или описание (условие без поля)
+	QueryFieldTitle       = "title"       // Заголовок статьи
+	QueryFieldDescription = "description" // Описание статьи
+	QueryFieldLink        = "link"        // Ссылка на статью
+	QueryFieldFeed        = "feed"        // Имя ленты (точное совпадение)
+	QueryFieldTag         = "tag"         // Тег ленты (точное совпадение)
+	QueryFieldLanguage    = "lang"        // Язык статьи (точное совпадение кода)
+)
+
+// queryFieldAliases допустимые имена полей в запросе
+var queryFieldAliases = map[string]string{
+	"text":        QueryFieldText,
+	"title":       QueryFieldTitle,
+	"desc":        QueryFieldDescription,
+	"description": QueryFieldDescription,
+	"link":        QueryFieldLink,
+	"url":         QueryFieldLink,
+	"feed":        QueryFieldFeed,
+	"tag":         QueryFieldTag,
+	"lang":        QueryFieldLanguage,
+	"language":    QueryFieldLanguage,
+}
+
+// QueryNode узел разобранного запроса смарт-ленты
+type QueryNode interface {
+	// Match проверяет, подходит ли статья (вместе с именем и тегами ее ленты) под условие
+	Match(entry *DigestEntry) bool
+	// String возвращает запрос в каноническом виде
+	String() string
+}
+
+// QueryAnd выполняется, когда выполнены оба условия
+type QueryAnd struct{ Left, Right QueryNode }
+
+// QueryOr выполняется, когда выполнено хотя бы одно из условий
+type QueryOr struct{ Left, Right QueryNode }
+
+// QueryNot выполняется, когда условие не выполнено
+type QueryNot struct{ Node QueryNode }
+
+// QueryTerm условие на одно поле. Текстовые поля сравниваются по вхождению подстроки,
+// feed и tag - на точное совпадение; регистр не учитывается
+type QueryTerm struct {
+	Field string
+	Value string
+}
+
+func (q *QueryAnd) Match(entry *DigestEntry) bool { return q.Left.Match(entry) && q.Right.Match(entry) }
+func (q *QueryOr) Match(entry *DigestEntry) bool  { return q.Left.Match(entry) || q.Right.Match(entry) }
+func (q *QueryNot) Match(entry *DigestEntry) bool { return !q.Node.Match(entry) }
+
+func (q *QueryAnd) String() string { return "(" + q.Left.String() + " AND " + q.Right.String() + ")" }
+func (q *QueryOr) String() string  { return "(" + q.Left.String() + " OR " + q.Right.String() + ")" }
+func (q *QueryNot) String() string { return "NOT " + q.Node.String() }
+
+func (q *QueryTerm) String() string { return fmt.Sprintf("%s:%q", q.Field, q.Value) }
+
+// Match проверяет условие на статье
+func (q *QueryTerm) Match(entry *DigestEntry) bool {
+	value := strings.ToLower(q.Value)
+	contains := func(s string) bool { return strings.Contains(strings.ToLower(s), value) }
+
+	switch q.Field {
+	case QueryFieldTitle:
+		return contains(entry.Article.Title)
+	case QueryFieldDescription:
+		return contains(entry.Article.Description)
+	case QueryFieldLink:
+		return contains(entry.Article.Link)
+	case QueryFieldFeed:
+		return strings.EqualFold(entry.FeedName, q.Value)
+	case QueryFieldTag:
+		for _, tag := range entry.FeedTags {
+			if strings.EqualFold(tag, q.Value) {
+				return true
+			}
+		}
+		return false
+	case QueryFieldLanguage:
+		return strings.EqualFold(entry.Article.Language, q.Value)
+	default:
+		return contains(entry.Article.Title) || contains(entry.Article.Description)
+	}
+}
+
+// ParseSmartQuery разбирает запрос смарт-ленты. Синтаксис:
+//
+//	go                      слово в заголовке или описании
+//	"release notes"         фраза в заголовке или описании
+//	title:go, desc:go       слово в заголовке или описании статьи
+//	link:github.com         подстрока ссылки
+//	feed:hn, tag:golang     лента с именем или тегом
+//	lang:en                 статья на языке (код ISO 639-1)
+//	a AND b, a b            оба условия
+//	a OR b                  хотя бы одно
+//	NOT a, -a               условие не выполнено
+//	(a OR b) AND c          группировка
+//
+// AND связывает сильнее OR; операторы записываются заглавными буквами
+func ParseSmartQuery(s string) (QueryNode, error) {
+	tokens, err := tokenizeQuery(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("query is empty")
+	}
+
+	p := &queryParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %s in query", p.peek())
+	}
+	return node, nil
+}
+
+// queryToken лексема запроса; quoted - фраза в кавычках (никогда не оператор)
+type queryToken struct {
+	text   string
+	quoted bool
+}
+
+func (t queryToken) is(op string) bool { return !t.quoted && t.text == op }
+
+func (t queryToken) String() string { return fmt.Sprintf("%q", t.text) }
+
+// tokenizeQuery разбивает запрос на слова, фразы в кавычках, скобки и знак отрицания.
+// Поле с фразой (title:"a b") возвращается двумя лексемами: "title:" и фразой
+func tokenizeQuery(s string) ([]queryToken, error) {
+	var tokens []queryToken
+	runes := []rune(s)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			tokens = append(tokens, queryToken{text: string(r)})
+			i++
+		case r == '-' && (i == 0 || unicode.IsSpace(runes[i-1]) || runes[i-1] == '('):
+			tokens = append(tokens, queryToken{text: "-"})
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated quote in query")
+			}
+			tokens = append(tokens, queryToken{text: string(runes[i+1 : end]), quoted: true})
+			i = end + 1
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' && runes[i] != '"' {
+				i++
+				// Поле перед фразой в кавычках: title:"release notes"
+				if runes[i-1] == ':' && i < len(runes) && runes[i] == '"' {
+					break
+				}
+			}
+			tokens = append(tokens, queryToken{text: string(runes[start:i])})
+		}
+	}
+
+	return tokens, nil
+}
+
+// queryParser рекурсивный спуск по лексемам запроса
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+func (p *queryParser) done() bool { return p.pos >= len(p.tokens) }
+
+func (p *queryParser) peek() queryToken { return p.tokens[p.pos] }
+
+// parseOr: and ("OR" and)*
+func (p *queryParser) parseOr() (QueryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for !p.done() && p.peek().is("OR") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &QueryOr{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseAnd: not (["AND"] not)*
+func (p *queryParser) parseAnd() (QueryNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for !p.done() {
+		next := p.peek()
+		if next.is("OR") || next.is(")") {
+			break
+		}
+		if next.is("AND") {
+			p.pos++
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &QueryAnd{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseNot: ("NOT" | "-") not | primary
+func (p *queryParser) parseNot() (QueryNode, error) {
+	if p.done() {
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+	if next := p.peek(); next.is("NOT") || next.is("-") {
+		p.pos++
+		node, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &QueryNot{Node: node}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary: "(" or ")" | [field ":"] value
+func (p *queryParser) parsePrimary() (QueryNode, error) {
+	token := p.peek()
+	p.pos++
+
+	switch {
+	case token.is("("):
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.done() || !p.peek().is(")") {
+			return nil, fmt.Errorf("missing closing parenthesis in query")
+		}
+		p.pos++
+		return node, nil
+	case token.is(")"), token.is("AND"), token.is("OR"):
+		return nil, fmt.Errorf("unexpected %s in query", token)
+	case token.quoted:
+		return &QueryTerm{Field: QueryFieldText, Value: token.text}, nil
+	}
+
+	name, value, ok := strings.Cut(token.text, ":")
+	if !ok {
+		return &QueryTerm{Field: QueryFieldText, Value: token.text}, nil
+	}
+
+	field, known := queryFieldAliases[strings.ToLower(name)]
+	if !known {
+		// Двоеточие внутри слова (например, время 10:00) - обычный текст
+		return &QueryTerm{Field: QueryFieldText, Value: token.text}, nil
+	}
+
+	if value == "" {
+		if p.done() || !p.peek().quoted {
+			return nil, fmt.Errorf("%s: requires a value", name)
+		}
+		value = p.peek().text
+		p.pos++
+	}
+	return &QueryTerm{Field: field, Value: value}, nil
+}
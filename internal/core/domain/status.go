@@ -0,0 +1,38 @@
+// internal/core/domain/status.go
+package domain
+
+import (
+	"time"
+)
+
+// AggregatorStatus текущее состояние запущенного агрегатора, которое он отдает через сокет
+// управления (rsshub status --live). В отличие от настроек в БД, это значения, с которыми
+// процесс работает прямо сейчас
+type AggregatorStatus struct {
+	Instance     string           `json:"instance"`             // Экземпляр агрегатора (хост и PID)
+	StartedAt    time.Time        `json:"started_at"`           // Запуск фонового процесса
+	Interval     time.Duration    `json:"interval"`             // Интервал между циклами
+	Workers      int              `json:"workers"`              // Запущено воркеров
+	InFlight     int              `json:"in_flight"`            // Лент, которые обрабатываются сейчас
+	Queued       int              `json:"queued"`               // Заданий, ожидающих свободного воркера
+	QueueWait    time.Duration    `json:"queue_wait"`           // Сколько ждет самое старое задание в очереди
+	CycleRunning bool             `json:"cycle_running"`        // Выполняется цикл получения
+	LastCycle    *FetchRun        `json:"last_cycle,omitempty"` // Последний завершенный цикл (nil - циклов еще не было)
+	Activity     []WorkerActivity `json:"workers_activity"`     // Состояние каждого воркера по номерам
+}
+
+// WorkerActivity чем занят воркер пула
+type WorkerActivity struct {
+	ID           int           `json:"id"`
+	Feed         string        `json:"feed,omitempty"`          // Обрабатываемая лента (пусто - воркер свободен)
+	Since        time.Time     `json:"since"`                   // Начало текущего задания или простоя
+	TimedOut     bool          `json:"timed_out,omitempty"`     // Задание отменено по таймауту, воркер ждет его завершения
+	Jobs         int           `json:"jobs"`                    // Обработано лент с запуска воркера
+	LastFeed     string        `json:"last_feed,omitempty"`     // Последняя обработанная лента
+	LastDuration time.Duration `json:"last_duration,omitempty"` // Время обработки последней ленты
+}
+
+// Busy сообщает, обрабатывает ли воркер ленту
+func (w *WorkerActivity) Busy() bool {
+	return w.Feed != ""
+}
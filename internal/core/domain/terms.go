@@ -0,0 +1,87 @@
+// internal/core/domain/terms.go
+package domain
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// minTermLength минимальная длина значимого слова (в символах)
+const minTermLength = 3
+
+// Terms возвращает значимые слова текста в нижнем регистре и в порядке следования: без HTML
+// разметки, слов короче minTermLength и частых служебных слов. Используется для сравнения
+// статей между собой, когда полнотекстовый поиск БД недоступен, и для анализа трендов
+func Terms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(stripTags(text)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := words[:0]
+	for _, word := range words {
+		if len([]rune(word)) < minTermLength || len(stopWordLanguages[word]) > 0 {
+			continue
+		}
+		terms = append(terms, word)
+	}
+	return terms
+}
+
+// TermTrend сколько статей упоминают слово в заголовке за период и за такой же период до него
+type TermTrend struct {
+	Term     string
+	Current  int
+	Previous int
+}
+
+// Growth во сколько раз выросло число упоминаний (со сглаживанием, чтобы новое слово
+// не получало бесконечный рост)
+func (t TermTrend) Growth() float64 {
+	return float64(t.Current+1) / float64(t.Previous+1)
+}
+
+// RisingTerms сравнивает слова заголовков текущего и предыдущего периодов и возвращает до limit
+// слов, упоминания которых выросли сильнее всего. Слово учитывается один раз на заголовок;
+// слова, упомянутые в текущем периоде реже minCount раз, пропускаются как шум
+func RisingTerms(current, previous []string, minCount, limit int) []TermTrend {
+	currentCounts := termCounts(current)
+	previousCounts := termCounts(previous)
+
+	var trends []TermTrend
+	for term, count := range currentCounts {
+		if count < minCount || count <= previousCounts[term] {
+			continue
+		}
+		trends = append(trends, TermTrend{Term: term, Current: count, Previous: previousCounts[term]})
+	}
+
+	sort.Slice(trends, func(i, j int) bool {
+		if gi, gj := trends[i].Growth(), trends[j].Growth(); gi != gj {
+			return gi > gj
+		}
+		if trends[i].Current != trends[j].Current {
+			return trends[i].Current > trends[j].Current
+		}
+		return trends[i].Term < trends[j].Term
+	})
+	if limit > 0 && len(trends) > limit {
+		trends = trends[:limit]
+	}
+	return trends
+}
+
+// termCounts считает, в скольких заголовках встречается каждое слово
+func termCounts(titles []string) map[string]int {
+	counts := make(map[string]int)
+	for _, title := range titles {
+		seen := make(map[string]bool)
+		for _, term := range Terms(title) {
+			if !seen[term] {
+				seen[term] = true
+				counts[term]++
+			}
+		}
+	}
+	return counts
+}
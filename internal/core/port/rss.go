@@ -3,6 +3,7 @@ package port
 
 import (
 	"context"
+	"io"
 	"rsshub/internal/core/domain"
 	"rsshub/internal/platform/utils"
 	"time"
@@ -10,35 +11,291 @@ import (
 
 // FeedRepository defines storage operations
 type FeedArticleRepository interface {
-	CreateFeed(name, url string) (*domain.Feed, error)
-	GetFeedByName(name string) (*domain.Feed, error)
-	GetAllFeeds(limit int) ([]*domain.Feed, error)
-	GetOldestFeeds(limit int) ([]*domain.Feed, error)
-	UpdateFeedTimestamp(feedID utils.UUID) error
-	DeleteFeed(name string) error
-	CreateArticle(article *domain.Article) error
-	GetArticlesByFeedName(feedName string, limit int) ([]*domain.Article, error)
-	ArticleExists(link string) (bool, error)
+	// WithinTransaction выполняет fn как единицу работы: изменения через переданный репозиторий
+	// сохраняются вместе при успехе fn и откатываются, если fn вернула ошибку или запаниковала
+	WithinTransaction(ctx context.Context, fn func(repo FeedArticleRepository) error) error
+
+	CreateFeed(ctx context.Context, feed *domain.Feed) error
+	GetFeedByName(ctx context.Context, name string) (*domain.Feed, error)
+	// GetFeedByURL находит ленту с тем же URL после нормализации (см. urlnorm.Key);
+	// из нескольких таких лент возвращается добавленная первой
+	GetFeedByURL(ctx context.Context, url string) (*domain.Feed, error)
+	GetFeedByID(ctx context.Context, id utils.UUID) (*domain.Feed, error)
+	GetAllFeeds(ctx context.Context, limit int) ([]*domain.Feed, error)
+	GetFeedsPage(ctx context.Context, after *domain.PageCursor, limit int) ([]*domain.Feed, error)
+	// GetFolders возвращает пути всех папок по алфавиту. Папки создаются вместе с родительскими
+	// при сохранении ленты с папкой (CreateFeed, UpdateFeed) и остаются, когда в них нет лент
+	GetFolders(ctx context.Context) ([]string, error)
+	// GetOldestFeeds возвращает до limit (<= 0 - все) самых устаревших включенных лент, которые
+	// пора получать: с последнего получения прошел интервал ленты, а для ленты без интервала -
+	// staleAfter (0 - в любой момент); см. domain.Feed.IsStale
+	GetOldestFeeds(ctx context.Context, limit int, staleAfter time.Duration) ([]*domain.Feed, error)
+	UpdateFeedTimestamp(ctx context.Context, feedID utils.UUID) error
+	// SetFeedCache сохраняет валидаторы кеша последнего ответа ленты для условного GET
+	SetFeedCache(ctx context.Context, feedID utils.UUID, cache domain.CacheValidators) error
+	// SetFeedFetchState сохраняет число неудач основного URL подряд и зеркало, с которого получена лента
+	SetFeedFetchState(ctx context.Context, feedID utils.UUID, failures int, activeURL string) error
+	// SetFeedNextRun сохраняет время следующего запуска ленты по расписанию (nil - без расписания);
+	// до этого времени лента не резервируется
+	SetFeedNextRun(ctx context.Context, feedID utils.UUID, next *time.Time) error
+	// SetFeedLastError сохраняет последнюю ошибку обработки ленты; пустое сообщение ее сбрасывает
+	SetFeedLastError(ctx context.Context, feedID utils.UUID, message string) error
+	// DisableFeed отключает мертвую ленту, сохраняя причину; включение ленты (UpdateFeed) сбрасывает
+	// причину и серию неудач
+	DisableFeed(ctx context.Context, feedID utils.UUID, reason string) error
+
+	// Feed claims: atomically reserve due feeds for one aggregator instance.
+	// Недавно полученные ленты пропускаются по staleAfter, как в GetOldestFeeds
+	ClaimFeeds(ctx context.Context, owner string, limit int, lease, staleAfter time.Duration) ([]*domain.Feed, error)
+	ReleaseFeedClaim(ctx context.Context, feedID utils.UUID) error
+	// ClaimFeed резервирует одну ленту независимо от того, пора ли ее получать; false - лента
+	// уже зарезервирована (ее обрабатывает воркер)
+	ClaimFeed(ctx context.Context, feedID utils.UUID, owner string, lease time.Duration) (bool, error)
+
+	UpdateFeed(ctx context.Context, feed *domain.Feed) error
+	DeleteFeed(ctx context.Context, name string) error
+	// DeleteFeeds удаляет несколько лент в одной транзакции: все или ни одной
+	DeleteFeeds(ctx context.Context, names []string) error
+	// CreateArticle сохраняет статью. Если статья с тем же ключом уникальности уже есть, при
+	// domain.ConflictUpdate обновляется ее содержимое (только статья той же ленты с другим хешем),
+	// иначе она не меняется; результат сообщает, что произошло. Для добавленной и обновленной
+	// статьи в article записываются ID, номер и время сохраненной строки
+	CreateArticle(ctx context.Context, article *domain.Article, onConflict domain.ConflictMode) (domain.InsertResult, error)
+	// RestoreArticle сохраняет статью из резервной копии вместе с ее временем сохранения, прочтения,
+	// добавления в избранное и изменения; статья с тем же ключом уникальности не меняется (ArticleSkipped)
+	RestoreArticle(ctx context.Context, article *domain.Article) (domain.InsertResult, error)
+	GetArticlesByFeedName(ctx context.Context, feedName string, limit int) ([]*domain.Article, error)
+	GetArticlesPage(ctx context.Context, feedName string, after *domain.PageCursor, limit int) ([]*domain.Article, error)
+	// GetUpdatedArticlesPage как GetArticlesPage, но только статьи, измененные лентой после сохранения
+	GetUpdatedArticlesPage(ctx context.Context, feedName string, after *domain.PageCursor, limit int) ([]*domain.Article, error)
+	// GetArticleByKey ищет статью по ключу уникальности или по ссылке среди статей ленты feedID
+	// с ключом по ссылке (сохраненных без GUID)
+	// (domain.ErrArticleNotFound, если нет)
+	GetArticleByKey(ctx context.Context, feedID utils.UUID, dedupKey, link string) (*domain.Article, error)
+	// UpdateArticleContent сохраняет новые заголовок, описание, хеш содержимого и ModifiedAt статьи.
+	// CreateArticle и UpdateArticleContent записывают содержимое статьи и в ее версии
+	UpdateArticleContent(ctx context.Context, article *domain.Article) error
+	// GetArticlesByLink возвращает статьи всех лент с этой ссылкой вместе с данными лент
+	GetArticlesByLink(ctx context.Context, link string) ([]*domain.DigestEntry, error)
+	// GetRelatedArticles возвращает до limit статей, похожих на статью articleID по словам заголовка,
+	// от самых похожих, вместе с данными лент
+	GetRelatedArticles(ctx context.Context, articleID utils.UUID, limit int) ([]*domain.DigestEntry, error)
+	// GetArticleVersions возвращает версии содержимого статьи от первой полученной к последней
+	GetArticleVersions(ctx context.Context, articleID utils.UUID) ([]*domain.ArticleVersion, error)
+	GetArticlesSince(ctx context.Context, since time.Time, limit int) ([]*domain.DigestEntry, error)
+	FindArticles(ctx context.Context, filter domain.ArticleFilter) ([]*domain.Article, error)
+	MarkArticleRead(ctx context.Context, articleID utils.UUID) error
+	// GetArticlesBySeq выбирает статьи по целочисленным номерам (Fever API)
+	GetArticlesBySeq(ctx context.Context, filter domain.ArticleSeqFilter) ([]*domain.Article, error)
+	// GetArticleSeqs возвращает номера всех непрочитанных или избранных статей по возрастанию
+	GetArticleSeqs(ctx context.Context, state domain.ArticleState) ([]int64, error)
+	// MarkArticlesBySeq меняет состояние статей с перечисленными номерами
+	MarkArticlesBySeq(ctx context.Context, seqs []int64, mark domain.ArticleMark) error
+	// MarkFeedsReadBefore отмечает прочитанными статьи лент, сохраненные не позже before
+	MarkFeedsReadBefore(ctx context.Context, feedIDs []utils.UUID, before time.Time) error
+	GetFeedStats(ctx context.Context, feedName string) ([]*domain.FeedStats, error)
+	// GetFeedArticleCounts возвращает количество всех и непрочитанных статей каждой ленты по ID
+	// ленты одним запросом; лент без статей в результате может не быть
+	GetFeedArticleCounts(ctx context.Context) (map[utils.UUID]domain.ArticleCounts, error)
+	// TrimFeedArticles удаляет статьи ленты старше keep самых новых, кроме статей в избранном,
+	// и возвращает количество удаленных статей
+	TrimFeedArticles(ctx context.Context, feedID utils.UUID, keep int) (int64, error)
+
+	// Traffic: bytes downloaded from feeds per day
+	// AddFeedTraffic добавляет bytes к трафику ленты за день at (по местному времени)
+	AddFeedTraffic(ctx context.Context, feedID utils.UUID, at time.Time, bytes int64) error
+	// GetTrafficSince возвращает трафик всех лент, включая удаленные, начиная с дня since
+	GetTrafficSince(ctx context.Context, since time.Time) (int64, error)
+	// GetFeedTraffic возвращает трафик каждой ленты начиная с дня since по именам лент
+	GetFeedTraffic(ctx context.Context, since time.Time) (map[string]int64, error)
+
+	// WebSub subscriptions
+	SaveWebSubSubscription(ctx context.Context, sub *domain.WebSubSubscription) error
+	GetWebSubSubscription(ctx context.Context, feedID utils.UUID) (*domain.WebSubSubscription, error)
+	GetWebSubSubscriptions(ctx context.Context) ([]*domain.WebSubSubscription, error)
+	DeleteWebSubSubscription(ctx context.Context, feedID utils.UUID) error
+
+	// Feed icons: cached favicons or channel images
+	SaveFeedIcon(ctx context.Context, icon *domain.FeedIcon) error
+	// GetFeedIcon получает иконку ленты (domain.ErrIconNotFound, если ее еще не запрашивали)
+	GetFeedIcon(ctx context.Context, feedID utils.UUID) (*domain.FeedIcon, error)
+	// GetFeedIcons возвращает все полученные иконки (без неудачных попыток)
+	GetFeedIcons(ctx context.Context) ([]*domain.FeedIcon, error)
+
+	// API keys
+	CreateAPIKey(ctx context.Context, key *domain.APIKey) error
+	GetAPIKeys(ctx context.Context) ([]*domain.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, hash string) (*domain.APIKey, error)
+	// GetAPIKeyByFeverHash получает действующий ключ по api_key протокола Fever
+	GetAPIKeyByFeverHash(ctx context.Context, hash string) (*domain.APIKey, error)
+	TouchAPIKey(ctx context.Context, id utils.UUID) error
+	RevokeAPIKey(ctx context.Context, name string) error
+
+	// Smart feeds: saved searches across all feeds
+	CreateSmartFeed(ctx context.Context, smartFeed *domain.SmartFeed) error
+	GetSmartFeedByName(ctx context.Context, name string) (*domain.SmartFeed, error)
+	GetSmartFeeds(ctx context.Context) ([]*domain.SmartFeed, error)
+	DeleteSmartFeed(ctx context.Context, name string) error
+	GetSmartFeedArticles(ctx context.Context, query domain.QueryNode, after *domain.PageCursor, limit int) ([]*domain.DigestEntry, error)
+
+	// Notification rules: route matching new articles to notification channels
+	CreateNotificationRule(ctx context.Context, rule *domain.NotificationRule) error
+	// GetNotificationRules возвращает все правила уведомлений, отсортированные по имени
+	GetNotificationRules(ctx context.Context) ([]*domain.NotificationRule, error)
+	DeleteNotificationRule(ctx context.Context, name string) error
+
+	// Filter rules: drop or keep feed items matching an expression before they are saved
+	CreateFilterRule(ctx context.Context, rule *domain.FilterRule) error
+	// GetFilterRules возвращает все правила фильтров, отсортированные по имени
+	GetFilterRules(ctx context.Context) ([]*domain.FilterRule, error)
+	DeleteFilterRule(ctx context.Context, name string) error
+
+	// Fetch runs: history of aggregation cycles
+	SaveFetchRun(ctx context.Context, run *domain.FetchRun) error
+	GetFetchRuns(ctx context.Context, limit int) ([]*domain.FetchRun, error)
 
 	// Aggregator settings
-	SetAggregatorSetting(key, value string) error
-	GetAggregatorSetting(key string) (string, error)
+	SetAggregatorSetting(ctx context.Context, key, value string) error
+	GetAggregatorSetting(ctx context.Context, key string) (string, error)
+	// NotifySettingsChanged сообщает запущенным агрегаторам, что интервал или количество воркеров
+	// изменились; внутри транзакции уведомление доставляется после ее фиксации
+	NotifySettingsChanged(ctx context.Context) error
+	// ListenSettingsChanges подписывается на уведомления NotifySettingsChanged до отмены ctx.
+	// Несколько уведомлений подряд могут прийти в канал одним значением
+	ListenSettingsChanges(ctx context.Context) (<-chan struct{}, error)
 
 	// Database locking
-	TryLock(lockName string) (bool, error)
-	ReleaseLock(lockName string) error
+	TryLock(ctx context.Context, lockName string) (bool, error)
+	ReleaseLock(ctx context.Context, lockName string) error
+}
+
+// Migrator управляет версиями схемы базы данных
+type Migrator interface {
+	RunMigrations() error
+	RollbackMigrations(steps int) error
+	MigrationsStatus() ([]domain.MigrationStatus, error)
+}
+
+// Maintainer обслуживает хранилище: удаляет устаревшие и осиротевшие данные и сообщает
+// о размере и состоянии таблиц (rsshub maintenance)
+type Maintainer interface {
+	// Prune удаляет данные старше сроков policy и строки без ленты или статьи; dryRun только считает их
+	Prune(ctx context.Context, policy domain.RetentionPolicy, dryRun bool) (*domain.PruneReport, error)
+	// TableStats возвращает таблицы хранилища от самых больших к самым маленьким
+	TableStats(ctx context.Context) ([]domain.TableStats, error)
+	IndexHints(ctx context.Context) ([]domain.IndexHint, error)
+	// Vacuum освобождает место удаленных строк и обновляет статистику планировщика; full
+	// возвращает место операционной системе, но блокирует таблицы на время перезаписи
+	Vacuum(ctx context.Context, tables []string, full bool) error
 }
 
 type Parser interface {
-	FetchAndParse(url string) (*domain.ParsedRSSFeed, error)
-	ValidateRSSURL(url string) error
+	FetchAndParse(ctx context.Context, feed *domain.Feed) (*domain.ParsedRSSFeed, error)
+	// Parse разбирает уже полученный документ ленты (например, доставленный WebSub хабом)
+	Parse(r io.Reader) (*domain.ParsedRSSFeed, error)
+	ValidateFeed(ctx context.Context, feed *domain.Feed) error
+}
+
+// ConditionalFetcher получает ленту условным запросом с ее валидаторами кеша (feed.Cache).
+// Если документ не изменился, возвращает ленту без элементов с NotModified
+type ConditionalFetcher interface {
+	FetchIfModified(ctx context.Context, feed *domain.Feed) (*domain.ParsedRSSFeed, error)
+}
+
+// FeedURLResolver раскрывает сокращения вида "reddit:r/golang" в URL ленты;
+// обычные URL возвращаются без изменений
+type FeedURLResolver interface {
+	ResolveFeedURL(ctx context.Context, raw string) (string, error)
+}
+
+// IconFetcher получает иконку ленты: favicon сайта или картинку канала
+type IconFetcher interface {
+	FetchIcon(ctx context.Context, feed *domain.Feed, parsed *domain.ParsedRSSFeed) (*domain.FeedIcon, error)
+}
+
+// EnclosureFetcher загружает вложения статей (эпизоды подкастов, видео) не больше limit байт
+type EnclosureFetcher interface {
+	FetchEnclosure(ctx context.Context, feed *domain.Feed, url string, w io.Writer, limit int64) (string, error)
+}
+
+// FeedDiscoverer находит ленты, объявленные на странице сайта (<link rel="alternate">)
+type FeedDiscoverer interface {
+	DiscoverFeeds(ctx context.Context, siteURL string) ([]string, error)
 }
 
 type Aggregator interface {
 	Start(ctx context.Context) error
 	Stop() error
 	IsRunning() bool
+	Status() *domain.AggregatorStatus
 	SetInterval(newInterval time.Duration) error
 	Resize(newWorkersCount int) error
-	LoadSettingsFromDB() error
+	SetFollowPermanent(follow bool)
+	SetMaxItemsPerFeed(limit int)
+	LoadSettingsFromDB(ctx context.Context) error
+	RunOnce(ctx context.Context) (*domain.CycleReport, error)
+	// Refresh сразу получает одну ленту; force - даже если ее еще рано получать, без валидаторов кеша
+	Refresh(ctx context.Context, feedID utils.UUID, force bool) (*domain.FeedResult, error)
+	Ingest(ctx context.Context, feed *domain.Feed, parsed *domain.ParsedRSSFeed) []*domain.Article
+	Backfill(ctx context.Context, feed *domain.Feed, maxNew int) (*domain.BackfillReport, error)
+	RefreshIcon(ctx context.Context, feed *domain.Feed) (*domain.FeedIcon, error)
+	Import(ctx context.Context, source SubscriptionSource, withState bool, maxEntries int) (*domain.ImportReport, error)
+	Restore(ctx context.Context, backup *domain.Backup) (*domain.RestoreReport, error)
+	// RestoreArticles сохраняет статьи резервной копии в ленты с теми же именами и дополняет report
+	RestoreArticles(ctx context.Context, entries []*domain.DigestEntry, report *domain.RestoreReport) error
+	Discover(ctx context.Context, sites []string, add bool, tags []string) ([]*domain.DiscoveredFeed, error)
+}
+
+// SubscriptionSource другой агрегатор, из которого импортируются подписки и состояние статей
+type SubscriptionSource interface {
+	Subscriptions(ctx context.Context) ([]domain.Subscription, error)
+	// Entries возвращает прочитанные и избранные статьи, не больше limit каждого вида
+	Entries(ctx context.Context, limit int) ([]domain.ImportedEntry, error)
+}
+
+// DigestSender отправляет дайджест новых статей
+type DigestSender interface {
+	SendDigest(digest *domain.Digest) error
+}
+
+// Notifier получает статьи, добавленные за один цикл получения лент
+type Notifier interface {
+	Notify(ctx context.Context, entries []*domain.DigestEntry) error
+}
+
+// ArticleArchive сохраняет новые статьи вне БД (например, файлами на диске)
+type ArticleArchive interface {
+	Archive(ctx context.Context, entries []*domain.DigestEntry) error
+}
+
+// SpeechSynthesizer озвучивает текст и записывает аудио в формате MP3 в w
+type SpeechSynthesizer interface {
+	Synthesize(ctx context.Context, text string, w io.Writer) error
+}
+
+// WebSubHub отправляет запросы подписки и отписки WebSub хабам
+type WebSubHub interface {
+	Subscribe(sub *domain.WebSubSubscription, callbackURL string) error
+	Unsubscribe(sub *domain.WebSubSubscription, callbackURL string) error
+}
+
+// WebSubReceiver обрабатывает запросы хаба к callback адресу ленты
+type WebSubReceiver interface {
+	// VerifyIntent подтверждает запрос хаба на подписку или отписку
+	VerifyIntent(ctx context.Context, feedID utils.UUID, mode, topic string, leaseSeconds int) error
+	// Deny обрабатывает отказ хаба в подписке
+	Deny(ctx context.Context, feedID utils.UUID, topic, reason string) error
+	// Deliver проверяет подпись и сохраняет статьи из доставленного содержимого ленты
+	Deliver(ctx context.Context, feedID utils.UUID, body []byte, signature string) (int, error)
+}
+
+// ArticleEventSource рассылает подписчикам новые сохраненные статьи
+type ArticleEventSource interface {
+	// Subscribe подписывает на новые статьи, подходящие под filter. Канал закрывается после
+	// отписки, при остановке рассылки и если подписчик не успевает читать; после остановки
+	// канал nil
+	Subscribe(filter domain.ArticleEventFilter) (<-chan *domain.DigestEntry, func())
+	// ArticlesAfter возвращает до limit статей с номером больше after вместе с номером
+	// последней прочитанной статьи
+	ArticlesAfter(ctx context.Context, after int64, limit int) ([]*domain.DigestEntry, int64, error)
 }
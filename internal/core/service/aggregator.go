@@ -3,7 +3,11 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"os"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -11,10 +15,14 @@ import (
 	"rsshub/internal/core/domain"
 	"rsshub/internal/core/port"
 	"rsshub/internal/platform/logger"
-
+	"rsshub/internal/platform/pool"
 	"rsshub/internal/platform/utils"
 )
 
+// claimLease время аренды ленты экземпляром агрегатора; по истечении лента
+// снова доступна другим экземплярам (например, если процесс аварийно завершился)
+const claimLease = 5 * time.Minute
+
 // Aggregator управляет фоновым процессом получения RSS лент
 type Aggregator struct {
 	db     port.FeedArticleRepository // База данных
@@ -30,9 +38,21 @@ type Aggregator struct {
 	cancel context.CancelFunc // Функция отмены контекста
 	ticker *time.Ticker       // Таймер для периодических запусков
 
-	// Каналы для координации воркеров
-	jobs     chan *domain.Feed // Канал заданий для воркеров
-	workerWg sync.WaitGroup    // WaitGroup для ожидания завершения воркеров
+	// Монитор настроек: как часто перечитывать настройки без уведомления (0 - только по
+	// уведомлениям) и его таймер
+	settingsCheck  time.Duration
+	settingsTicker *time.Ticker
+
+	// Цикл агрегации и монитор настроек; Stop ждет их завершения
+	background sync.WaitGroup
+
+	// Пул воркеров с очередями заданий по приоритетам и занятость его воркеров
+	pool    *pool.Pool
+	workers *workerTracker
+
+	// Запуск фонового процесса и итоги последнего цикла для rsshub status --live
+	startedAt time.Time
+	lastCycle *domain.FetchRun
 
 	// Состояние
 	isRunning bool         // Флаг запущенного состояния
@@ -40,27 +60,272 @@ type Aggregator struct {
 
 	// Менеджер настроек
 	manager *AggregatorManager
+
+	// Идентификатор экземпляра для резервирования лент
+	instanceID string
+
+	// Расписание тихих часов, когда циклы получения пропускаются
+	quietHours *QuietHours
+
+	// Получатели уведомлений о новых статьях после каждого цикла
+	notifiers map[string]port.Notifier // Каналы уведомлений по имени
+
+	// Архив, в который записываются все новые статьи (nil - архив отключен)
+	archive port.ArticleArchive
+
+	// Способ определения дубликатов статей, что делать с уже сохраненными статьями и со
+	// статьями, ссылка которых уже сохранена в другой ленте
+	dedupMode      domain.DedupMode
+	conflictMode   domain.ConflictMode
+	crossFeedDedup domain.CrossFeedDedup
+
+	// Обновлять URL ленты, перемещенной навсегда (301/308)
+	followPermanent bool
+
+	// Максимум элементов одной ленты, обрабатываемых за цикл (0 - без ограничения)
+	maxItemsPerFeed int
+
+	// Максимум статей, хранимых для одной ленты (0 - без ограничения)
+	maxArticlesPerFeed int
+
+	// Максимальное время обработки одной ленты (0 - без ограничения)
+	maxJobDuration time.Duration
+
+	// Защита от одновременного выполнения циклов и поведение при наложении
+	cycles  *cycleGuard
+	overlap CycleOverlap
+
+	// Поведение при заполненной очереди и ленты, пропущенные в прошлых циклах
+	queueFull QueueFullMode
+	skippedMu sync.Mutex
+	skipped   map[utils.UUID]struct{}
+
+	// Неудач основного URL подряд, после которых лента получается с зеркал (0 - не использовать)
+	mirrorAfter int
+
+	// Сколько лента может непрерывно завершаться ошибкой до автоматического отключения (0 - не отключать)
+	deadAfter time.Duration
+
+	// Как часто обновляются иконки лент (0 - не запрашивать)
+	iconRefresh time.Duration
+
+	// Лимиты трафика, после превышения которых циклы получения приостанавливаются
+	budget domain.BandwidthBudget
+
+	// Планировщик лент с расписанием cron
+	scheduler *Scheduler
+
+	// Разобранные запросы правил уведомлений
+	ruleQueries ruleQueries
+
+	// Конвейер обработки элементов лент
+	pipeline *Pipeline
 }
 
 // New создает новый агрегатор
 func New(db port.FeedArticleRepository, parser port.Parser, defaultInterval time.Duration, defaultWorkers int) *Aggregator {
-	return &Aggregator{
-		db:           db,
-		parser:       parser,
-		interval:     defaultInterval,
-		workersCount: defaultWorkers,
-		isRunning:    false,
-		manager:      NewAggregatorManager(db),
+	a := &Aggregator{
+		db:              db,
+		parser:          parser,
+		interval:        defaultInterval,
+		workersCount:    defaultWorkers,
+		isRunning:       false,
+		manager:         NewAggregatorManager(db),
+		instanceID:      newInstanceID(),
+		dedupMode:       domain.DedupByGUID,
+		conflictMode:    domain.ConflictUpdate,
+		crossFeedDedup:  domain.CrossFeedKeep,
+		followPermanent: true,
+		cycles:          newCycleGuard(),
+		overlap:         OverlapSkip,
+		queueFull:       QueueFullBlock,
+		skipped:         make(map[utils.UUID]struct{}),
+		mirrorAfter:     3,
+		deadAfter:       14 * 24 * time.Hour,
+		iconRefresh:     7 * 24 * time.Hour,
+		settingsCheck:   time.Minute,
+		scheduler:       NewScheduler(),
+	}
+	a.pipeline = a.newAggregatorPipeline()
+	return a
+}
+
+// newInstanceID формирует идентификатор экземпляра агрегатора: хост и PID процесса
+func newInstanceID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// SetQuietHours задает тихие часы, в которые агрегатор не получает ленты
+func (a *Aggregator) SetQuietHours(q *QuietHours) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.quietHours = q
+}
+
+// SetDedupMode задает, по какому ключу определяются дубликаты статей
+func (a *Aggregator) SetDedupMode(mode domain.DedupMode) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.dedupMode = mode
+}
+
+// SetConflictMode задает, обновлять ли сохраненные статьи, содержимое которых изменила лента
+func (a *Aggregator) SetConflictMode(mode domain.ConflictMode) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.conflictMode = mode
+}
+
+// SetCrossFeedDedup задает, сохранять ли статью, ссылка которой уже сохранена в другой ленте
+func (a *Aggregator) SetCrossFeedDedup(mode domain.CrossFeedDedup) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.crossFeedDedup = mode
+}
+
+// SetFollowPermanent задает, нужно ли сохранять новый URL ленты после постоянного редиректа
+func (a *Aggregator) SetFollowPermanent(follow bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.followPermanent = follow
+}
+
+// SetMaxItemsPerFeed ограничивает количество элементов ленты, обрабатываемых за цикл
+// (0 - без ограничения), чтобы первая загрузка большой ленты не занимала воркер надолго
+func (a *Aggregator) SetMaxItemsPerFeed(limit int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.maxItemsPerFeed = limit
+}
+
+// SetMaxArticlesPerFeed ограничивает количество хранимых статей одной ленты (0 - без ограничения).
+// После каждого цикла самые старые статьи лент сверх лимита удаляются, кроме статей в избранном,
+// чтобы одна лента с огромным потоком статей не занимала все хранилище
+func (a *Aggregator) SetMaxArticlesPerFeed(limit int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.maxArticlesPerFeed = limit
+}
+
+// SetMaxJobDuration задает максимальное время обработки одной ленты (0 - без ограничения).
+// Задание, которое не уложилось в срок, отменяется и отмечается в отчете о цикле
+func (a *Aggregator) SetMaxJobDuration(d time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.maxJobDuration = d
+	if a.pool != nil {
+		a.pool.SetTimeout(d)
+	}
+}
+
+// SetSettingsCheckInterval задает, как часто монитор настроек перечитывает интервал и количество
+// воркеров из базы данных на случай пропущенного уведомления (0 - только по уведомлениям)
+func (a *Aggregator) SetSettingsCheckInterval(d time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.settingsCheck = d
+	if a.settingsTicker != nil {
+		resetTicker(a.settingsTicker, d)
+	}
+}
+
+// resetTicker меняет период тикера; d <= 0 останавливает его
+func resetTicker(t *time.Ticker, d time.Duration) {
+	if d <= 0 {
+		t.Stop()
+		return
+	}
+	t.Reset(d)
+}
+
+// SetCycleOverlap задает, что делать с циклом, если предыдущий еще не завершен:
+// пропустить (OverlapSkip) или запустить сразу после него (OverlapQueue)
+func (a *Aggregator) SetCycleOverlap(mode CycleOverlap) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.overlap = mode
+}
+
+// SetQueueFullMode задает, ждет ли лента свободного воркера сколько потребуется (QueueFullBlock)
+// или пропускается до следующего цикла, если ее не взяли за интервал агрегатора (QueueFullSkip)
+func (a *Aggregator) SetQueueFullMode(mode QueueFullMode) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.queueFull = mode
+}
+
+// CycleStats возвращает счетчики запущенных, пропущенных и отложенных циклов
+func (a *Aggregator) CycleStats() CycleStats {
+	return a.cycles.stats()
+}
+
+// Pipeline возвращает конвейер обработки элементов лент, в котором можно регистрировать
+// собственные шаги этапов
+func (a *Aggregator) Pipeline() *Pipeline {
+	return a.pipeline
+}
+
+// AddNotifier добавляет канал уведомлений о новых статьях; имя канала указывается в правилах уведомлений
+func (a *Aggregator) AddNotifier(channel string, n port.Notifier) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.notifiers == nil {
+		a.notifiers = make(map[string]port.Notifier)
+	}
+	a.notifiers[channel] = n
+}
+
+// SetNotifiers заменяет каналы уведомлений (например, после перезагрузки конфигурации)
+func (a *Aggregator) SetNotifiers(notifiers map[string]port.Notifier) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.notifiers = make(map[string]port.Notifier, len(notifiers))
+	for channel, n := range notifiers {
+		a.notifiers[channel] = n
 	}
 }
 
+// SetDefaults задает интервал и количество воркеров по умолчанию. Значения, сохраненные
+// командами set-interval и set-workers, имеют приоритет; запущенному агрегатору изменения
+// применяются на лету
+func (a *Aggregator) SetDefaults(ctx context.Context, interval time.Duration, workers int) error {
+	if intervalStr, err := a.db.GetAggregatorSetting(ctx, "interval"); err == nil {
+		if stored, err := time.ParseDuration(intervalStr); err == nil {
+			interval = stored
+		}
+	}
+	if workersStr, err := a.db.GetAggregatorSetting(ctx, "workers"); err == nil {
+		if stored, err := strconv.Atoi(workersStr); err == nil && stored > 0 {
+			workers = stored
+		}
+	}
+
+	if !a.IsRunning() {
+		a.mu.Lock()
+		a.interval = interval
+		a.mu.Unlock()
+		return a.Resize(workers)
+	}
+
+	if err := a.SetInterval(interval); err != nil {
+		return err
+	}
+	return a.Resize(workers)
+}
+
 // LoadSettingsFromDB загружает настройки агрегатора из базы данных
-func (a *Aggregator) LoadSettingsFromDB() error {
+func (a *Aggregator) LoadSettingsFromDB(ctx context.Context) error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
 	// Загружаем интервал
-	if intervalStr, err := a.db.GetAggregatorSetting("interval"); err == nil {
+	if intervalStr, err := a.db.GetAggregatorSetting(ctx, "interval"); err == nil {
 		if interval, err := time.ParseDuration(intervalStr); err == nil {
 			a.interval = interval
 			logger.Info("Loaded interval from database: %v", interval)
@@ -68,7 +333,7 @@ func (a *Aggregator) LoadSettingsFromDB() error {
 	}
 
 	// Загружаем количество воркеров
-	if workersStr, err := a.db.GetAggregatorSetting("workers"); err == nil {
+	if workersStr, err := a.db.GetAggregatorSetting(ctx, "workers"); err == nil {
 		if workers, err := strconv.Atoi(workersStr); err == nil && workers > 0 {
 			a.workersCount = workers
 			logger.Info("Loaded workers count from database: %d", workers)
@@ -89,25 +354,19 @@ func (a *Aggregator) Start(ctx context.Context) error {
 	}
 
 	// Загружаем настройки из базы данных
-	if err := a.LoadSettingsFromDB(); err != nil {
+	if err := a.LoadSettingsFromDB(ctx); err != nil {
 		logger.Warn("Failed to load settings from database: %v", err)
 	}
 
 	// Создаем контекст для управления жизненным циклом
 	a.ctx, a.cancel = context.WithCancel(ctx)
 
-	// Инициализируем каналы и воркеры
+	// Запускаем пул воркеров с общей очередью заданий
 	a.mu.RLock()
 	workersCount := a.workersCount
 	a.mu.RUnlock()
 
-	a.jobs = make(chan *domain.Feed, workersCount*2) // Буферизированный канал
-
-	// Запускаем воркеров
-	for i := 0; i < workersCount; i++ {
-		a.workerWg.Add(1)
-		go a.worker(i + 1)
-	}
+	a.startPool(workersCount * 2)
 
 	// Создаем и запускаем тикер
 	a.mu.RLock()
@@ -116,24 +375,54 @@ func (a *Aggregator) Start(ctx context.Context) error {
 
 	a.ticker = time.NewTicker(interval)
 	a.isRunning = true
+	a.mu.Lock()
+	a.startedAt = time.Now()
+	a.mu.Unlock()
+	a.writeHeartbeat(a.ctx)
+	a.writeCycleStats(a.ctx)
 
 	logger.Success("The background process for fetching feeds has started (interval = %v, workers = %d)",
 		interval, workersCount)
+	logger.Debug("Ingest pipeline: %s", a.pipeline)
 
-	// Запускаем основной цикл агрегации
-	go a.aggregationLoop()
+	// Запускаем основной цикл агрегации и монитор настроек; Stop ждет их завершения
+	a.mu.Lock()
+	a.settingsTicker = time.NewTicker(time.Hour)
+	resetTicker(a.settingsTicker, a.settingsCheck)
+	settingsTicker, settingsCheck := a.settingsTicker, a.settingsCheck
+	a.mu.Unlock()
 
-	// Запускаем мониторинг изменений настроек
-	go a.manager.StartMonitoring(a.ctx, a)
+	a.background.Add(2)
+	go func() {
+		defer a.background.Done()
+		a.aggregationLoop(a.ctx)
+	}()
+	go func() {
+		defer a.background.Done()
+		a.manager.StartMonitoring(a.ctx, a, settingsTicker.C, settingsCheck)
+	}()
 
 	// Делаем первый запуск сразу, не дожидаясь тикера
-	go a.fetchFeeds()
+	go a.fetchFeeds(a.ctx)
 
 	return nil
 }
 
 // Stop останавливает фоновый процесс gracefully
 func (a *Aggregator) Stop() error {
+	if err := a.stop(); err != nil {
+		return err
+	}
+
+	// Монитор настроек может в этот момент применять настройку и ждать runningMu,
+	// поэтому фоновые горутины ждем после снятия блокировки
+	a.background.Wait()
+	logger.Success("Graceful shutdown: aggregator stopped")
+	return nil
+}
+
+// stop останавливает тикеры и воркеров и отменяет контекст фонового процесса
+func (a *Aggregator) stop() error {
 	a.runningMu.Lock()
 	defer a.runningMu.Unlock()
 
@@ -143,26 +432,32 @@ func (a *Aggregator) Stop() error {
 
 	logger.Info("Stopping background aggregation process...")
 
-	// Останавливаем тикер
+	// Останавливаем тикеры
 	if a.ticker != nil {
 		a.ticker.Stop()
 	}
+	a.mu.Lock()
+	if a.settingsTicker != nil {
+		a.settingsTicker.Stop()
+		a.settingsTicker = nil
+	}
+	a.mu.Unlock()
 
 	// Отменяем контекст
 	if a.cancel != nil {
 		a.cancel()
 	}
 
-	// Закрываем канал заданий
-	if a.jobs != nil {
-		close(a.jobs)
+	// Закрываем очереди и ждем воркеров. Задания, оставшиеся в очереди, после отмены
+	// контекста не выполняются и отмечаются пропущенными, поэтому циклы не ждут их вечно
+	if a.pool != nil {
+		a.pool.Close()
+		a.pool.Wait()
 	}
 
-	// Ждем завершения всех воркеров
-	a.workerWg.Wait()
-
 	a.isRunning = false
-	logger.Success("Graceful shutdown: aggregator stopped")
+	// Контекст агрегатора уже отменен, а отметку об остановке нужно записать
+	a.clearHeartbeat(context.WithoutCancel(a.ctx))
 
 	return nil
 }
@@ -196,10 +491,9 @@ func (a *Aggregator) SetInterval(newInterval time.Duration) error {
 	a.interval = newInterval
 	a.mu.Unlock()
 
-	// Перезапускаем тикер с новым интервалом
+	// Меняем интервал того же тикера: aggregationLoop продолжает ждать его канал
 	if a.ticker != nil {
-		a.ticker.Stop()
-		a.ticker = time.NewTicker(newInterval)
+		a.ticker.Reset(newInterval)
 	}
 
 	logger.Success("Interval of fetching feeds changed from %v to %v (applied dynamically)", oldInterval, newInterval)
@@ -233,16 +527,13 @@ func (a *Aggregator) Resize(newWorkersCount int) error {
 		return nil
 	}
 
-	// Если агрегатор запущен, нужно управлять воркерами
-	if newWorkersCount > a.workersCount {
-		// Добавляем новых воркеров
-		for i := a.workersCount; i < newWorkersCount; i++ {
-			a.workerWg.Add(1)
-			go a.worker(i + 1)
+	// Лишние воркеры дорабатывают текущую ленту и останавливаются
+	if a.pool != nil {
+		if err := a.pool.Resize(newWorkersCount); err != nil {
+			a.mu.Unlock()
+			return err
 		}
 	}
-	// Если нужно уменьшить количество воркеров, они завершатся естественным образом
-	// когда закончатся задания или при следующем shutdown
 
 	a.workersCount = newWorkersCount
 	a.mu.Unlock()
@@ -252,10 +543,11 @@ func (a *Aggregator) Resize(newWorkersCount int) error {
 	return nil
 }
 
-// aggregationLoop запускает основной цикл агрегации
-func (a *Aggregator) aggregationLoop() {
-	settingsTicker := time.NewTicker(10 * time.Second)
-	defer settingsTicker.Stop()
+// aggregationLoop запускает основной цикл агрегации. Изменения настроек применяются
+// отдельно, по уведомлениям (см. AggregatorManager.StartMonitoring)
+func (a *Aggregator) aggregationLoop(ctx context.Context) {
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
 
 	for {
 		select {
@@ -263,133 +555,772 @@ func (a *Aggregator) aggregationLoop() {
 			return
 
 		case <-a.ticker.C:
-			go a.fetchFeeds()
+			go a.fetchFeeds(ctx)
 
-		case <-settingsTicker.C:
-			logger.Info("Checking DB for settings changes...")
-			if err := a.manager.CheckAndApplyChanges(a); err != nil {
-				logger.Error("Failed to apply settings changes: %v", err)
-			}
+		case <-heartbeat.C:
+			a.writeHeartbeat(ctx)
+			a.writeCycleStats(ctx)
 		}
 	}
 }
 
-// fetchFeeds получает устаревшие ленты и распределяет их между воркерами
-func (a *Aggregator) fetchFeeds() {
-	logger.Info("-----------------------------")
-	logger.Info("Starting feeds fetch cycle...")
-	logger.Info("-----------------------------")
-	// Получаем самые устаревшие ленты
+// fetchFeeds запускает очередной цикл получения лент по тикеру
+func (a *Aggregator) fetchFeeds(ctx context.Context) {
+	a.mu.RLock()
+	quietHours := a.quietHours
+	overlap := a.overlap
+	a.mu.RUnlock()
+
+	// В тихие часы цикл пропускается целиком
+	if quietHours.IsQuiet(time.Now()) {
+		logger.Info("Quiet hours (%s), skipping feeds fetch cycle", quietHours)
+		return
+	}
+
+	// После превышения лимита трафика циклы пропускаются до начала следующего периода
+	if err := a.checkBudget(ctx, time.Now()); err != nil {
+		logger.Warn("%v, skipping feeds fetch cycle", err)
+		return
+	}
+
+	// Медленный цикл не должен накладываться на следующий
+	if !a.cycles.acquire(a.ctx, overlap) {
+		return
+	}
+	defer a.cycles.release()
+
+	// Количество воркеров читаем после ожидания: пока цикл был в очереди, оно могло измениться
 	a.mu.RLock()
 	workersCount := a.workersCount
+	interval := a.interval
+	queueFull := a.queueFull
 	a.mu.RUnlock()
 
-	feeds, err := a.db.GetOldestFeeds(workersCount)
+	// В режиме QueueFullSkip лента ждет свободного воркера не дольше интервала, чтобы
+	// перегруженные воркеры не задерживали следующий цикл
+	var maxWait time.Duration
+	if queueFull == QueueFullSkip {
+		maxWait = interval
+	}
+
+	// За один цикл берем не больше лент, чем воркеров. Ленте без своего интервала достаточно
+	// устареть на половину интервала агрегатора: только что полученная (WebSub, fetch --once,
+	// другой экземпляр) не запрашивается повторно, а полученная в прошлом цикле не пропускается
+	// из-за того, что ее обработка заняла часть интервала
+	if feeds := a.claimDueFeeds(ctx, workersCount, interval/2); len(feeds) > 0 {
+		a.runCycle(ctx, feeds, maxWait)
+	}
+}
+
+// claimDueFeeds резервирует до limit устаревших лент (limit <= 0 - все), чтобы другие экземпляры
+// агрегатора их не обрабатывали; staleAfter - сколько должно пройти с получения ленты без своего интервала
+func (a *Aggregator) claimDueFeeds(ctx context.Context, limit int, staleAfter time.Duration) []*domain.Feed {
+	logger.Info("-----------------------------")
+	logger.Info("Starting feeds fetch cycle...")
+	logger.Info("-----------------------------")
+
+	feeds, err := a.db.ClaimFeeds(ctx, a.instanceID, limit, claimLease, staleAfter)
 	if err != nil {
 		logger.Error("Failed to get feeds: %v", err)
-		return
+		return nil
 	}
 
+	// Ленты с расписанием cron, время которых не наступило, ждут своего запуска
+	feeds = a.dueFeeds(ctx, feeds)
 	if len(feeds) == 0 {
 		logger.Info("No feeds to process")
-		return
+		return nil
 	}
 
 	logger.Info("Found %d feeds to process", len(feeds))
+	return feeds
+}
+
+// runCycle распределяет зарезервированные ленты между воркерами и ждет результатов.
+// maxWait - сколько лента может ждать свободного воркера (0 - без ограничения)
+func (a *Aggregator) runCycle(ctx context.Context, feeds []*domain.Feed, maxWait time.Duration) *domain.CycleReport {
+	// Ленты уже отсортированы по приоритету; пропущенные в прошлых циклах отправляем первыми.
+	// Свободные воркеры сами забирают их из общей очереди; если она заполнена, ждем, пока
+	// воркер заберет следующую ленту
+	c := newCycle()
+	for _, j := range a.newJobs(ctx, feeds, c, maxWait) {
+		c.add()
+		if a.ctx.Err() != nil || !a.pool.Submit(a.ctx, j) {
+			// Агрегатор останавливается, лента будет получена в следующий раз
+			j.Done(pool.ErrNotRun)
+		}
+	}
+
+	report := c.wait()
+	avgWait, maxWait := report.QueueWait()
+	logger.Info("Fetch cycle finished in %v: %d feeds, %d new articles (%d duplicates, %d updated), %d failed, %d timed out, %d skipped, %s downloaded, queue wait avg %v, max %v",
+		report.FinishedAt.Sub(report.StartedAt).Round(time.Millisecond), len(report.Feeds),
+		report.NewArticles(), report.Duplicates(), report.Updated(),
+		report.Failed(), report.TimedOut(), report.Skipped(), domain.FormatBytes(report.Bytes()),
+		avgWait.Round(time.Millisecond), maxWait.Round(time.Millisecond))
+
+	a.cycles.record(report)
+	run := domain.NewFetchRun(a.instanceID, report)
+	a.mu.Lock()
+	a.lastCycle = run
+	a.mu.Unlock()
+
+	a.saveRun(ctx, run)
+	a.notifyEntries(a.ctx, c.pendingEntries())
+	a.trimArticles(ctx, report)
+
+	return report
+}
+
+// trimArticles удаляет самые старые статьи лент цикла, у которых после сохранения новых статей
+// их стало больше лимита (см. SetMaxArticlesPerFeed); уведомления к этому моменту уже отправлены
+func (a *Aggregator) trimArticles(ctx context.Context, report *domain.CycleReport) {
+	a.mu.RLock()
+	limit := a.maxArticlesPerFeed
+	a.mu.RUnlock()
+
+	if limit <= 0 || report.NewArticles() == 0 {
+		return
+	}
+
+	counts, err := a.db.GetFeedArticleCounts(ctx)
+	if err != nil {
+		logger.Error("Failed to count articles of feeds: %v", err)
+		return
+	}
+
+	var deleted int64
+	feeds := 0
+	for _, result := range report.Feeds {
+		if len(result.Articles) == 0 {
+			continue
+		}
+		feedID := result.Articles[0].FeedID
+		if counts[feedID].Total <= limit {
+			continue
+		}
+		n, err := a.db.TrimFeedArticles(ctx, feedID, limit)
+		if err != nil {
+			logger.Error("Failed to trim articles of feed %s: %v", result.FeedName, err)
+			continue
+		}
+		if n > 0 {
+			logger.Debug("Trimmed %d oldest articles of feed %s", n, result.FeedName)
+			deleted += n
+			feeds++
+		}
+	}
+	if deleted > 0 {
+		logger.Info("Trimmed %d oldest articles of %d feeds over the limit of %d articles per feed", deleted, feeds, limit)
+	}
+}
+
+// saveRun сохраняет итоги цикла в историю; ошибка сохранения не прерывает работу. Итоги
+// цикла, прерванного остановкой, тоже сохраняются, поэтому отмена ctx на запрос не действует
+func (a *Aggregator) saveRun(ctx context.Context, run *domain.FetchRun) {
+	if err := a.db.SaveFetchRun(context.WithoutCancel(ctx), run); err != nil {
+		logger.Warn("Failed to save fetch run: %v", err)
+	}
+}
+
+// notifyEntries передает статьи каналам уведомлений согласно правилам (см. routeEntries)
+func (a *Aggregator) notifyEntries(ctx context.Context, entries []*domain.DigestEntry) {
+	a.mu.RLock()
+	notifiers := a.notifiers
+	a.mu.RUnlock()
+
+	if len(notifiers) == 0 || len(entries) == 0 {
+		return
+	}
+
+	routed, err := a.routeEntries(ctx, entries)
+	if err != nil {
+		logger.Error("Failed to send notifications: %v", err)
+		return
+	}
+
+	for channel, n := range notifiers {
+		selected, ruled := routed[channel]
+		if !ruled {
+			selected = entries
+		}
+		if len(selected) == 0 {
+			continue
+		}
+		if err := n.Notify(ctx, selected); err != nil {
+			logger.Error("Failed to send notifications to %s: %v", channel, err)
+		}
+	}
+}
 
-	// Отправляем ленты воркерам
+// feedEntries связывает статьи с данными их ленты для уведомлений
+func feedEntries(feed *domain.Feed, articles []*domain.Article) []*domain.DigestEntry {
+	entries := make([]*domain.DigestEntry, 0, len(articles))
+	for _, article := range articles {
+		entries = append(entries, &domain.DigestEntry{Article: article, FeedName: feed.Name, FeedTags: feed.Tags, FeedFolder: feed.Folder})
+	}
+	return entries
+}
+
+// newJobs создает задания цикла. Ленты, пропущенные в прошлых циклах, идут первыми
+// с высоким приоритетом, чтобы их не пропускали снова. maxWait - сколько лента может
+// ждать свободного воркера (0 - без ограничения)
+func (a *Aggregator) newJobs(ctx context.Context, feeds []*domain.Feed, c *cycle, maxWait time.Duration) []*pool.Job {
+	a.skippedMu.Lock()
+	defer a.skippedMu.Unlock()
+
+	var boosted, jobs []*pool.Job
 	for _, feed := range feeds {
-		select {
-		case a.jobs <- feed:
-			// Задание отправлено
-		case <-a.ctx.Done():
-			// Контекст отменен
-			return
+		if _, boost := a.skipped[feed.ID]; boost {
+			boosted = append(boosted, a.newJob(ctx, feed, c, true, maxWait))
+		} else {
+			jobs = append(jobs, a.newJob(ctx, feed, c, false, maxWait))
+		}
+	}
+	return append(boosted, jobs...)
+}
+
+// newJob создает задание обработки ленты. Результат сообщается в цикл ровно один раз:
+// при таймауте сразу, а результат, полученный после этого, пул отбрасывает
+func (a *Aggregator) newJob(ctx context.Context, feed *domain.Feed, c *cycle, boost bool, maxWait time.Duration) *pool.Job {
+	saved := &domain.SaveReport{}
+	var bytes int64
+	j := &pool.Job{
+		Name:     feed.Name,
+		Priority: jobPriority(feed.Priority, boost),
+		MaxWait:  maxWait,
+		Run: func(ctx context.Context, worker int) error {
+			var err error
+			saved, bytes, err = a.processFeed(ctx, worker, feed, false, c)
+			a.clearSkipped(feed)
+			return err
+		},
+	}
+	j.Done = func(err error) {
+		var timeout *pool.TimeoutError
+		var panicked *pool.PanicError
+		switch {
+		case errors.Is(err, pool.ErrExpired):
+			logger.Warn("Workers are busy, skipping feed until next cycle: %s (waited %v)", feed.Name, j.Waited().Round(time.Millisecond))
+			a.skipFeed(ctx, c, feed, j.Waited())
+		case errors.Is(err, pool.ErrNotRun):
+			a.skipFeed(ctx, c, feed, j.Waited())
+		case errors.As(err, &timeout):
+			err = fmt.Errorf("processing timed out after %v", timeout.Timeout)
+			a.releaseClaim(ctx, feed)
+			a.setLastError(ctx, feed, err)
+			c.done(domain.FeedResult{FeedName: feed.Name, TimedOut: true, QueueWait: j.Waited(), Err: err})
+		case errors.As(err, &panicked):
+			// Паника не роняет воркер: лента отмечается неудачной и будет получена в следующем цикле
+			a.releaseClaim(ctx, feed)
+			a.setLastError(ctx, feed, err)
+			c.done(domain.FeedResult{FeedName: feed.Name, QueueWait: j.Waited(), Err: err})
 		default:
-			// Канал заполнен, пропускаем эту ленту
-			logger.Warn("Workers are busy, skipping feed: %s", feed.Name)
+			// Вызывается из воркера после Run, поэтому saved и bytes уже заполнены
+			result := newFeedResult(feed, saved, bytes, err)
+			result.QueueWait = j.Waited()
+			c.done(result)
 		}
 	}
+	return j
 }
 
-// worker обрабатывает ленты из канала заданий
-func (a *Aggregator) worker(id int) {
-	defer a.workerWg.Done()
+// skipFeed отмечает ленту пропущенной в цикле и снимает ее резервирование.
+// В следующем цикле лента будет обработана в первую очередь
+func (a *Aggregator) skipFeed(ctx context.Context, c *cycle, feed *domain.Feed, waited time.Duration) {
+	a.skippedMu.Lock()
+	a.skipped[feed.ID] = struct{}{}
+	a.skippedMu.Unlock()
 
-	logger.Debug("Worker %d started", id)
+	a.releaseClaim(ctx, feed)
+	c.done(domain.FeedResult{FeedName: feed.Name, Skipped: true, QueueWait: waited})
+}
 
-	for {
-		select {
-		case feed, ok := <-a.jobs:
-			if !ok {
-				// Канал закрыт, завершаем воркер
-				logger.Debug("Worker %d stopped (channel closed)", id)
-				return
-			}
+// clearSkipped снимает повышенный приоритет ленты после ее обработки
+func (a *Aggregator) clearSkipped(feed *domain.Feed) {
+	a.skippedMu.Lock()
+	delete(a.skipped, feed.ID)
+	a.skippedMu.Unlock()
+}
 
-			// Обрабатываем ленту
-			a.processFeed(id, feed)
+// setLastError сохраняет ошибку обработки ленты (nil - сбрасывает прежнюю) и отключает ленту,
+// если она мертва (см. checkDeadFeed). Ленту не меняет: при таймауте ее еще может обрабатывать воркер.
+// Ошибка таймаута сохраняется, когда ctx уже отменен, поэтому отмена ctx на запросы не действует
+func (a *Aggregator) setLastError(ctx context.Context, feed *domain.Feed, err error) {
+	message := ""
+	if err != nil {
+		message = err.Error()
+	} else if feed.LastError == "" {
+		return
+	}
 
-		case <-a.ctx.Done():
-			// Контекст отменен, завершаем воркер
-			logger.Debug("Worker %d stopped (context cancelled)", id)
-			return
-		}
+	ctx = context.WithoutCancel(ctx)
+	if err := a.db.SetFeedLastError(ctx, feed.ID, message); err != nil {
+		logger.Warn("Failed to save last error of feed %s: %v", feed.Name, err)
+	}
+	if err != nil {
+		a.checkDeadFeed(ctx, feed, err)
+	}
+}
+
+// releaseClaim снимает резервирование необработанной ленты. Резервирование снимается и после
+// таймаута или остановки, поэтому отмена ctx на запрос не действует
+func (a *Aggregator) releaseClaim(ctx context.Context, feed *domain.Feed) {
+	if err := a.db.ReleaseFeedClaim(context.WithoutCancel(ctx), feed.ID); err != nil {
+		logger.Error("Failed to release claim of feed %s: %v", feed.Name, err)
 	}
 }
 
-// processFeed обрабатывает одну RSS ленту
-func (a *Aggregator) processFeed(workerID int, feed *domain.Feed) {
+// RunOnce выполняет ровно один цикл получения по всем устаревшим лентам и возвращает отчет.
+// Не требует запуска фонового процесса; используется для запуска из cron и в режиме dry-run
+func (a *Aggregator) RunOnce(ctx context.Context) (*domain.CycleReport, error) {
+	a.runningMu.Lock()
+	if a.isRunning {
+		a.runningMu.Unlock()
+		return nil, fmt.Errorf("background process is already running")
+	}
+	a.isRunning = true
+	a.runningMu.Unlock()
+
+	defer func() {
+		a.runningMu.Lock()
+		a.isRunning = false
+		a.runningMu.Unlock()
+	}()
+
+	// Загружаем настройки из базы данных
+	if err := a.LoadSettingsFromDB(ctx); err != nil {
+		logger.Warn("Failed to load settings from database: %v", err)
+	}
+
+	if err := a.checkBudget(ctx, time.Now()); err != nil {
+		return nil, err
+	}
+
+	a.ctx, a.cancel = context.WithCancel(ctx)
+	defer a.cancel()
+
+	// Ленты без своего интервала получаются все, с интервалом - если он прошел
+	feeds := a.claimDueFeeds(ctx, 0, 0)
+	if len(feeds) == 0 {
+		now := time.Now()
+		return &domain.CycleReport{StartedAt: now, FinishedAt: now}, nil
+	}
+
+	// Очередь вмещает все ленты цикла, а ждать воркера они могут сколько потребуется
+	a.startPool(len(feeds))
+
+	report := a.runCycle(ctx, feeds, 0)
+
+	a.pool.Close()
+	// Воркеры с зависшими заданиями не ждем: задания уже отменены и отмечены в отчете
+	if report.TimedOut() == 0 {
+		a.pool.Wait()
+	}
+
+	return report, nil
+}
+
+// startPool запускает пул воркеров с указанной емкостью общей очереди заданий
+func (a *Aggregator) startPool(queueSize int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	workers := newWorkerTracker()
+	a.workers = workers
+	a.pool = pool.New(a.ctx, pool.Options{
+		Workers:   a.workersCount,
+		QueueSize: queueSize,
+		Timeout:   a.maxJobDuration,
+		Hooks: pool.Hooks{
+			OnWorkerStart: func(worker int) {
+				workers.started(worker)
+				logger.Debug("Worker %d started", worker)
+			},
+			OnWorkerStop: func(worker int) {
+				workers.stopped(worker)
+				logger.Debug("Worker %d stopped", worker)
+			},
+			OnJobStart: workers.jobStarted,
+			OnJobFinish: func(worker int, j *pool.Job, elapsed time.Duration, err error) {
+				workers.jobFinished(worker, j, elapsed, err)
+				logJobFinish(worker, j, elapsed, err)
+			},
+			OnJobDiscard: func(worker int, j *pool.Job, _ error) {
+				workers.jobDiscarded(worker)
+				logger.Warn("Worker %d: discarding late result of timed out feed %s", worker, j.Name)
+			},
+		},
+	})
+}
+
+// logJobFinish записывает в лог таймауты и паники заданий и время обработки лент
+func logJobFinish(worker int, j *pool.Job, elapsed time.Duration, err error) {
+	var timeout *pool.TimeoutError
+	var panicked *pool.PanicError
+	switch {
+	case errors.As(err, &timeout):
+		logger.Error("Worker %d: feed %s timed out after %v, job cancelled", worker, j.Name, timeout.Timeout)
+	case errors.As(err, &panicked):
+		logger.Error("Worker %d: processing of feed %s panicked: %v\n%s", worker, j.Name, panicked.Value, panicked.Stack)
+	case errors.Is(err, pool.ErrNotRun):
+	default:
+		logger.Debug("Worker %d: feed %s processed in %v", worker, j.Name, elapsed.Round(time.Millisecond))
+	}
+}
+
+// newFeedResult формирует результат обработки ленты для отчета цикла
+func newFeedResult(feed *domain.Feed, saved *domain.SaveReport, bytes int64, err error) domain.FeedResult {
+	return domain.FeedResult{FeedName: feed.Name, NewArticles: len(saved.Articles), Articles: saved.Articles,
+		Duplicates: saved.Duplicates, Updated: saved.Updated, NotModified: saved.NotModified, Bytes: bytes, Err: err}
+}
+
+// processFeed получает одну RSS ленту, проводит ее элементы через конвейер и возвращает итог
+// сохранения и объем загруженных данных. force - запросить ленту безусловно, без ее валидаторов
+// кеша; уведомления о статьях ленты цикла c откладываются до его завершения (nil - отправляются сразу)
+func (a *Aggregator) processFeed(ctx context.Context, workerID int, feed *domain.Feed, force bool, c *cycle) (*domain.SaveReport, int64, error) {
 	logger.Info("Worker %d processing feed: %s (%s)", workerID, feed.Name, feed.URL)
 
-	// Получаем и парсим RSS ленту
-	parsedFeed, err := a.parser.FetchAndParse(feed.URL)
+	// Получаем и парсим RSS ленту (с зеркал, если основной URL не отвечает)
+	parsedFeed, fetchedURL, err := a.fetchFeed(ctx, feed, force)
 	if err != nil {
 		logger.Error("Worker %d failed to fetch feed %s: %v", workerID, feed.Name, err)
+		a.releaseClaim(ctx, feed)
+		a.setLastError(ctx, feed, err)
+		return &domain.SaveReport{}, 0, err
+	}
+	a.recordTraffic(ctx, feed, parsedFeed.Bytes)
+
+	// Редирект зеркала не меняет основной URL
+	if parsedFeed.MovedTo != "" && fetchedURL == feed.URL {
+		a.updateMovedFeed(ctx, feed, parsedFeed.MovedTo)
+	}
+
+	batch := newIngestBatch(feed, SourceFetch, parsedFeed.Items)
+	batch.cycle = c
+	// Валидаторы кеша сохраняются только для ответа основного URL
+	if fetchedURL == feed.URL {
+		batch.cache = &parsedFeed.Cache
+	}
+
+	// Новые статьи и время получения ленты сохраняются в одной транзакции: если процесс прервется
+	// посреди ленты, она не останется с частью статей и будет обработана заново в следующем цикле
+	if err := a.pipeline.run(ctx, batch); err != nil {
+		logger.Error("Worker %d failed to save feed %s: %v", workerID, feed.Name, err)
+		a.releaseClaim(ctx, feed)
+		a.setLastError(ctx, feed, err)
+		return &domain.SaveReport{}, parsedFeed.Bytes, err
+	}
+
+	// Ответ 304 не содержит документа, по которому ищется иконка
+	saved := batch.Saved
+	saved.NotModified = parsedFeed.NotModified
+	if !saved.NotModified {
+		a.refreshIcon(ctx, feed, parsedFeed)
+	}
+
+	// Обновляем время следующего запуска по расписанию
+	a.scheduleNext(ctx, feed)
+
+	a.setLastError(ctx, feed, nil)
+
+	logger.Success("Worker %d completed feed %s: %d new articles, %d duplicates, %d updated",
+		workerID, feed.Name, len(saved.Articles), saved.Duplicates, saved.Updated)
+	return saved, parsedFeed.Bytes, nil
+}
+
+// updateMovedFeed сохраняет новый URL ленты, которая ответила постоянным редиректом
+func (a *Aggregator) updateMovedFeed(ctx context.Context, feed *domain.Feed, newURL string) {
+	a.mu.RLock()
+	follow := a.followPermanent
+	a.mu.RUnlock()
+
+	if !follow {
+		logger.Warn("Feed %s permanently moved to %s (URL not updated)", feed.Name, newURL)
 		return
 	}
 
-	// Сохраняем новые статьи
-	newArticles := 0
-	for _, item := range parsedFeed.Items {
-		// Проверяем, существует ли уже эта статья
-		exists, err := a.db.ArticleExists(item.Link)
-		if err != nil {
-			logger.Error("Worker %d failed to check article existence: %v", workerID, err)
-			continue
+	oldURL := feed.URL
+	updated := *feed
+	updated.URL = newURL
+	if err := a.db.UpdateFeed(ctx, &updated); err != nil {
+		logger.Error("Failed to update URL of permanently moved feed %s: %v", feed.Name, err)
+		return
+	}
+
+	feed.URL = newURL
+	logger.Warn("Feed %s permanently moved: URL changed from %s to %s", feed.Name, oldURL, newURL)
+}
+
+// limitItems оставляет не больше maxItemsPerFeed самых новых элементов ленты
+func (a *Aggregator) limitItems(feed *domain.Feed, items []*IngestItem) []*IngestItem {
+	a.mu.RLock()
+	limit := a.maxItemsPerFeed
+	a.mu.RUnlock()
+
+	if limit <= 0 || len(items) <= limit {
+		return items
+	}
+
+	newest := make([]*IngestItem, len(items))
+	copy(newest, items)
+	sort.SliceStable(newest, func(i, j int) bool { return newest[i].PublishedAt.After(newest[j].PublishedAt) })
+
+	logger.Warn("Feed %s has %d items, processing the newest %d (use fetch --once --backfill to import all)",
+		feed.Name, len(items), limit)
+	return newest[:limit]
+}
+
+// saveFeed сохраняет новые статьи ленты и обновляет время ее получения в одной транзакции,
+// вместе с валидаторами кеша cache, если они изменились (nil - не менять).
+// Отмена ctx или ошибка поиска дубликата посреди ленты откатывает все ее статьи
+func (a *Aggregator) saveFeed(ctx context.Context, feed *domain.Feed, items []*IngestItem, cache *domain.CacheValidators) (*domain.SaveReport, error) {
+	var saved *domain.SaveReport
+	err := a.db.WithinTransaction(ctx, func(repo port.FeedArticleRepository) error {
+		var err error
+		if saved, err = a.saveArticles(ctx, repo, feed, items, 0); err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := repo.UpdateFeedTimestamp(ctx, feed.ID); err != nil {
+			return fmt.Errorf("failed to update feed timestamp: %w", err)
+		}
+		if cache != nil && *cache != feed.Cache {
+			if err := repo.SetFeedCache(ctx, feed.ID, *cache); err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		feed.Cache = *cache
+	}
+
+	a.archiveArticles(ctx, feed, saved.Articles)
+	return saved, nil
+}
 
-		if exists {
-			// Статья уже существует, пропускаем
+// saveArticles сохраняет новые статьи ленты через repo и возвращает добавленные статьи вместе
+// с количеством пропущенных дубликатов и обновленных статей; добавленная статья элемента
+// записывается в его Article.
+// maxNew ограничивает количество добавляемых статей (0 - без ограничения)
+// Отмена ctx прекращает сохранение; уже сохраненные статьи возвращаются.
+// Ошибка поиска дубликата прекращает сохранение и возвращается: поиск выполняется без точки
+// сохранения, и внутри WithinTransaction после нее транзакция прервана
+func (a *Aggregator) saveArticles(ctx context.Context, repo port.FeedArticleRepository, feed *domain.Feed, items []*IngestItem, maxNew int) (*domain.SaveReport, error) {
+	a.mu.RLock()
+	dedupMode := a.dedupMode
+	conflictMode := a.conflictMode
+	crossFeedDedup := a.crossFeedDedup
+	a.mu.RUnlock()
+
+	saved := &domain.SaveReport{}
+	for _, item := range items {
+		if maxNew > 0 && len(saved.Articles) >= maxNew {
+			break
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		// Проверяем, существует ли уже эта статья в ленте (по GUID, если он есть, или по ссылке)
+		dedupKey := domain.ArticleDedupKey(dedupMode, feed.ID, item.GUID, item.Link)
+		contentHash := domain.ArticleContentHash(item.Title, item.Description)
+		existing, err := repo.GetArticleByKey(ctx, feed.ID, dedupKey, item.Link)
+		if err == nil {
+			// Статья уже существует; если лента изменила ее содержимое - обновляем
+			if conflictMode == domain.ConflictUpdate && a.updateArticle(ctx, repo, feed, existing, item.ParsedRSSItem, contentHash) {
+				saved.Updated++
+			} else {
+				saved.Duplicates++
+			}
 			continue
 		}
-		uuid, err := utils.NewUUID()
-		if err != nil {
-			logger.Error("UUID error: %v", err)
+		if !errors.Is(err, domain.ErrArticleNotFound) {
+			return saved, fmt.Errorf("failed to check article existence: %w", err)
+		}
+
+		if crossFeedDedup == domain.CrossFeedSkip {
+			other, err := a.savedInOtherFeed(ctx, repo, feed, item.Link)
+			if err != nil {
+				return saved, err
+			}
+			if other {
+				saved.Duplicates++
+				continue
+			}
+		}
+
+		// Статьи на языках, которых лента не ожидает, не сохраняются (язык определяет этап enrich)
+		if !feed.AcceptsLanguage(item.Language) {
+			logger.Debug("Skipping article '%s' of feed %s: language %s is not expected", item.Title, feed.Name, item.Language)
 			continue
 		}
-		// Создаем новую статью
+
+		// Создаем новую статью; ID и время создания назначает хранилище
 		article := &domain.Article{
-			ID:          uuid,
 			Title:       item.Title,
 			Link:        item.Link,
 			PublishedAt: item.PublishedAt,
 			Description: item.Description,
 			FeedID:      feed.ID,
+			GUID:        item.GUID,
+			DedupKey:    dedupKey,
+			ContentHash: contentHash,
+			Podcast:     item.Podcast,
+			Media:       item.Media,
+			Language:    item.Language,
 		}
 
-		if err := a.db.CreateArticle(article); err != nil {
-			logger.Error("Worker %d failed to save article '%s': %v", workerID, item.Title, err)
+		// Статью мог успеть сохранить другой воркер или экземпляр: тогда хранилище обновляет
+		// или пропускает ее по conflictMode
+		result, err := repo.CreateArticle(ctx, article, conflictMode)
+		if err != nil {
+			logger.Error("Failed to save article '%s' of feed %s: %v", item.Title, feed.Name, err)
 			continue
 		}
 
-		newArticles++
+		switch result {
+		case domain.ArticleInserted:
+			item.Article = article
+			saved.Articles = append(saved.Articles, article)
+		case domain.ArticleUpdated:
+			saved.Updated++
+		default:
+			saved.Duplicates++
+		}
 	}
 
-	// Обновляем timestamp ленты
-	if err := a.db.UpdateFeedTimestamp(feed.ID); err != nil {
-		logger.Error("Worker %d failed to update feed timestamp: %v", workerID, err)
+	return saved, nil
+}
+
+// savedInOtherFeed сообщает, сохранена ли статья с этой ссылкой в другой ленте
+func (a *Aggregator) savedInOtherFeed(ctx context.Context, repo port.FeedArticleRepository, feed *domain.Feed, link string) (bool, error) {
+	entries, err := repo.GetArticlesByLink(ctx, link)
+	if err != nil {
+		return false, fmt.Errorf("failed to check articles of other feeds: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.Article.FeedID != feed.ID {
+			logger.Debug("Skipping article %s of feed %s: already saved in feed %s", link, feed.Name, entry.FeedName)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// updateArticle сохраняет новые заголовок и описание статьи, если хеш содержимого изменился.
+// Статьи, сохраненные до появления хеша, обновляются без отметки об изменении: неизвестно,
+// менялось ли их содержимое.
+// Статьи другой ленты с той же ссылкой не меняются. Возвращает true, если статья обновлена
+func (a *Aggregator) updateArticle(ctx context.Context, repo port.FeedArticleRepository, feed *domain.Feed, article *domain.Article, item domain.ParsedRSSItem, contentHash string) bool {
+	if article.FeedID != feed.ID || article.ContentHash == contentHash {
+		return false
 	}
 
-	logger.Success("Worker %d completed feed %s: %d new articles", workerID, feed.Name, newArticles)
+	if article.ContentHash != "" {
+		now := time.Now()
+		article.ModifiedAt = &now
+	}
+	article.Title = item.Title
+	article.Description = item.Description
+	article.ContentHash = contentHash
+
+	if err := repo.UpdateArticleContent(ctx, article); err != nil {
+		logger.Error("Failed to update article '%s' of feed %s: %v", item.Title, feed.Name, err)
+		return false
+	}
+	if article.ModifiedAt != nil {
+		logger.Info("Article of feed %s was updated by the feed: %s", feed.Name, item.Title)
+	}
+	return true
+}
+
+// Ingest проводит через конвейер статьи ленты, полученные не циклом агрегатора (например,
+// доставленные WebSub хабом): сохраняет их, обновляет время получения ленты и уведомляет получателей
+func (a *Aggregator) Ingest(ctx context.Context, feed *domain.Feed, parsed *domain.ParsedRSSFeed) []*domain.Article {
+	batch := newIngestBatch(feed, SourcePush, parsed.Items)
+	if err := a.pipeline.run(ctx, batch); err != nil {
+		logger.Error("Failed to save feed %s: %v", feed.Name, err)
+		return nil
+	}
+	return batch.Saved.Articles
+}
+
+// maxBackfillPages защищает от бесконечного обхода архива
+const maxBackfillPages = 1000
+
+// Backfill импортирует исторические статьи ленты, обходя ее архив по ссылкам
+// rel="prev-archive" (RFC 5005), начиная с текущего документа ленты. maxNew ограничивает
+// количество новых статей (0 - без ограничения). Время получения ленты не меняется,
+// поэтому импорт можно запускать параллельно с циклами агрегатора; элементы проходят через
+// тот же конвейер, что и в цикле. Уведомления о статьях архива не отправляются
+func (a *Aggregator) Backfill(ctx context.Context, feed *domain.Feed, maxNew int) (*domain.BackfillReport, error) {
+	report := &domain.BackfillReport{}
+	visited := make(map[string]bool)
+
+	page := *feed
+	for page.URL != "" && !visited[page.URL] && report.Pages < maxBackfillPages {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		visited[page.URL] = true
+
+		parsed, err := a.parser.FetchAndParse(ctx, &page)
+		if err != nil {
+			if report.Pages == 0 {
+				return report, err
+			}
+			// Недоступная страница архива завершает обход, уже импортированное сохраняется
+			logger.Warn("Backfill of feed %s stopped at %s: %v", feed.Name, page.URL, err)
+			break
+		}
+		a.recordTraffic(ctx, feed, parsed.Bytes)
+
+		remaining := 0
+		if maxNew > 0 {
+			remaining = maxNew - report.NewArticles
+		}
+		batch := newIngestBatch(feed, SourceBackfill, parsed.Items)
+		batch.maxNew = remaining
+		if err := a.pipeline.run(ctx, batch); err != nil {
+			return report, err
+		}
+		saved := batch.Saved
+
+		report.Pages++
+		report.Items += len(parsed.Items)
+		report.NewArticles += len(saved.Articles)
+		report.Duplicates += saved.Duplicates + saved.Updated
+		logger.Info("Backfill of feed %s: page %d (%s), %d items, %d new articles, %d duplicates",
+			feed.Name, report.Pages, page.URL, len(parsed.Items), len(saved.Articles), saved.Duplicates+saved.Updated)
+
+		if maxNew > 0 && report.NewArticles >= maxNew {
+			break
+		}
+		page.URL = resolveURL(page.URL, parsed.PrevArchive)
+	}
+
+	return report, nil
+}
+
+// resolveURL возвращает ref относительно base (пусто, если ref пустой или некорректный)
+func resolveURL(base, ref string) string {
+	if ref == "" {
+		return ""
+	}
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return ""
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	return baseURL.ResolveReference(refURL).String()
 }
@@ -0,0 +1,236 @@
+// internal/core/service/aggregator_test.go
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"rsshub/internal/adapter/storage/memory"
+	"rsshub/internal/core/domain"
+)
+
+// newTestAggregator создает агрегатор над хранилищем в памяти с одной лентой
+func newTestAggregator(t *testing.T) (*Aggregator, *memory.Store, *domain.Feed) {
+	t.Helper()
+
+	db := memory.New()
+	feed := &domain.Feed{Name: "test", URL: "https://example.com/feed", Priority: domain.PriorityNormal, Enabled: true, Tags: []string{}}
+	if err := db.CreateFeed(context.Background(), feed); err != nil {
+		t.Fatalf("CreateFeed: %v", err)
+	}
+	return New(db, nil, time.Minute, 1), db, feed
+}
+
+// newItems создает элементы ленты; i-й элемент опубликован на i часов позже первого
+func newItems(items ...domain.ParsedRSSItem) []*IngestItem {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	result := make([]*IngestItem, 0, len(items))
+	for i, item := range items {
+		if item.PublishedAt.IsZero() {
+			item.PublishedAt = base.Add(time.Duration(i) * time.Hour)
+		}
+		result = append(result, &IngestItem{ParsedRSSItem: item})
+	}
+	return result
+}
+
+func TestSaveArticlesDedup(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       domain.DedupMode
+		first      []domain.ParsedRSSItem
+		second     []domain.ParsedRSSItem
+		wantNew    int // Новых статей во втором сохранении
+		wantDup    int // Дубликатов во втором сохранении
+		wantStored int // Статей в хранилище после обоих сохранений
+	}{
+		{
+			name:       "same guid with new link is duplicate",
+			mode:       domain.DedupByGUID,
+			first:      []domain.ParsedRSSItem{{Title: "A", Link: "https://example.com/a", GUID: "a"}},
+			second:     []domain.ParsedRSSItem{{Title: "A", Link: "https://example.com/a?session=2", GUID: "a"}},
+			wantNew:    0,
+			wantDup:    1,
+			wantStored: 1,
+		},
+		{
+			name:       "new guid with new link is new article",
+			mode:       domain.DedupByGUID,
+			first:      []domain.ParsedRSSItem{{Title: "A", Link: "https://example.com/a", GUID: "a1"}},
+			second:     []domain.ParsedRSSItem{{Title: "B", Link: "https://example.com/b", GUID: "a2"}},
+			wantNew:    1,
+			wantDup:    0,
+			wantStored: 2,
+		},
+		{
+			// Лента публикует разные элементы под одной ссылкой ("последний выпуск")
+			name:       "different guid with same link is new article",
+			mode:       domain.DedupByGUID,
+			first:      []domain.ParsedRSSItem{{Title: "A", Link: "https://example.com/latest", GUID: "a1"}},
+			second:     []domain.ParsedRSSItem{{Title: "B", Link: "https://example.com/latest", GUID: "a2"}},
+			wantNew:    1,
+			wantDup:    0,
+			wantStored: 2,
+		},
+		{
+			// Статьи, сохраненные до появления GUID, находятся по ссылке
+			name:       "new guid with same link matches by link",
+			mode:       domain.DedupByGUID,
+			first:      []domain.ParsedRSSItem{{Title: "A", Link: "https://example.com/a"}},
+			second:     []domain.ParsedRSSItem{{Title: "A", Link: "https://example.com/a", GUID: "a"}},
+			wantNew:    0,
+			wantDup:    1,
+			wantStored: 1,
+		},
+		{
+			name:       "link mode ignores guid",
+			mode:       domain.DedupByLink,
+			first:      []domain.ParsedRSSItem{{Title: "A", Link: "https://example.com/a", GUID: "a1"}},
+			second:     []domain.ParsedRSSItem{{Title: "A", Link: "https://example.com/a", GUID: "a2"}},
+			wantNew:    0,
+			wantDup:    1,
+			wantStored: 1,
+		},
+		{
+			name:       "item without guid matches normalized link",
+			mode:       domain.DedupByGUID,
+			first:      []domain.ParsedRSSItem{{Title: "A", Link: "https://example.com/a?id=1"}},
+			second:     []domain.ParsedRSSItem{{Title: "A", Link: "https://EXAMPLE.com:443/a?utm_source=rss&id=1"}},
+			wantNew:    0,
+			wantDup:    1,
+			wantStored: 1,
+		},
+		{
+			name:  "duplicate inside one batch is saved once",
+			mode:  domain.DedupByGUID,
+			first: nil,
+			second: []domain.ParsedRSSItem{
+				{Title: "A", Link: "https://example.com/a", GUID: "a"},
+				{Title: "A", Link: "https://example.com/a", GUID: "a"},
+				{Title: "B", Link: "https://example.com/b", GUID: "b"},
+			},
+			wantNew:    2,
+			wantDup:    1,
+			wantStored: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, db, feed := newTestAggregator(t)
+			a.SetDedupMode(tt.mode)
+			ctx := context.Background()
+
+			mustSave(t, a, db, feed, newItems(tt.first...), 0)
+			saved := mustSave(t, a, db, feed, newItems(tt.second...), 0)
+
+			if len(saved.Articles) != tt.wantNew || saved.Duplicates != tt.wantDup {
+				t.Errorf("second save: %d new, %d duplicates; want %d new, %d duplicates",
+					len(saved.Articles), saved.Duplicates, tt.wantNew, tt.wantDup)
+			}
+			counts, err := db.GetFeedArticleCounts(ctx)
+			if err != nil {
+				t.Fatalf("GetFeedArticleCounts: %v", err)
+			}
+			if got := counts[feed.ID].Total; got != tt.wantStored {
+				t.Errorf("stored %d articles, want %d", got, tt.wantStored)
+			}
+		})
+	}
+}
+
+func TestSaveArticlesConflictMode(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      domain.ConflictMode
+		wantTitle string
+		wantUpd   int
+		wantDup   int
+	}{
+		{"update changes content", domain.ConflictUpdate, "A (fixed)", 1, 0},
+		{"skip keeps content", domain.ConflictSkip, "A", 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, db, feed := newTestAggregator(t)
+			a.SetConflictMode(tt.mode)
+			ctx := context.Background()
+
+			mustSave(t, a, db, feed, newItems(domain.ParsedRSSItem{Title: "A", Link: "https://example.com/a", GUID: "a"}), 0)
+			saved := mustSave(t, a, db, feed, newItems(domain.ParsedRSSItem{Title: "A (fixed)", Link: "https://example.com/a", GUID: "a"}), 0)
+
+			if saved.Updated != tt.wantUpd || saved.Duplicates != tt.wantDup || len(saved.Articles) != 0 {
+				t.Errorf("got %d new, %d updated, %d duplicates; want 0 new, %d updated, %d duplicates",
+					len(saved.Articles), saved.Updated, saved.Duplicates, tt.wantUpd, tt.wantDup)
+			}
+			articles, err := db.GetArticlesByFeedName(ctx, feed.Name, 10)
+			if err != nil {
+				t.Fatalf("GetArticlesByFeedName: %v", err)
+			}
+			if len(articles) != 1 || articles[0].Title != tt.wantTitle {
+				t.Errorf("stored articles %v, want one article titled %q", titles(articles), tt.wantTitle)
+			}
+		})
+	}
+}
+
+func TestSaveArticlesMaxNew(t *testing.T) {
+	a, db, feed := newTestAggregator(t)
+
+	saved := mustSave(t, a, db, feed, newItems(
+		domain.ParsedRSSItem{Title: "A", Link: "https://example.com/a"},
+		domain.ParsedRSSItem{Title: "B", Link: "https://example.com/b"},
+		domain.ParsedRSSItem{Title: "C", Link: "https://example.com/c"},
+	), 2)
+
+	if len(saved.Articles) != 2 {
+		t.Errorf("saved %d articles, want 2", len(saved.Articles))
+	}
+}
+
+func TestTrimArticles(t *testing.T) {
+	a, db, feed := newTestAggregator(t)
+	a.SetMaxArticlesPerFeed(2)
+	ctx := context.Background()
+
+	saved := mustSave(t, a, db, feed, newItems(
+		domain.ParsedRSSItem{Title: "oldest", Link: "https://example.com/1"},
+		domain.ParsedRSSItem{Title: "old", Link: "https://example.com/2"},
+		domain.ParsedRSSItem{Title: "new", Link: "https://example.com/3"},
+		domain.ParsedRSSItem{Title: "newest", Link: "https://example.com/4"},
+	), 0)
+
+	a.trimArticles(ctx, &domain.CycleReport{Feeds: []domain.FeedResult{
+		newFeedResult(feed, saved, 0, nil),
+	}})
+
+	articles, err := db.GetArticlesByFeedName(ctx, feed.Name, 10)
+	if err != nil {
+		t.Fatalf("GetArticlesByFeedName: %v", err)
+	}
+	if got := titles(articles); len(got) != 2 || got[0] != "newest" || got[1] != "new" {
+		t.Errorf("articles after trim %v, want [newest new]", got)
+	}
+}
+
+// mustSave сохраняет элементы через saveArticles и прерывает тест при ошибке
+func mustSave(t *testing.T, a *Aggregator, db *memory.Store, feed *domain.Feed, items []*IngestItem, maxNew int) *domain.SaveReport {
+	t.Helper()
+
+	saved, err := a.saveArticles(context.Background(), db, feed, items, maxNew)
+	if err != nil {
+		t.Fatalf("saveArticles: %v", err)
+	}
+	return saved
+}
+
+// titles возвращает заголовки статей в порядке выборки
+func titles(articles []*domain.Article) []string {
+	result := make([]string, 0, len(articles))
+	for _, a := range articles {
+		result = append(result, a.Title)
+	}
+	return result
+}
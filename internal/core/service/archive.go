@@ -0,0 +1,33 @@
+// internal/core/service/archive.go
+package service
+
+import (
+	"context"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+)
+
+// SetArchive задает архив, в который записываются все новые статьи независимо от правил
+// уведомлений (nil - архив отключен)
+func (a *Aggregator) SetArchive(archive port.ArticleArchive) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.archive = archive
+}
+
+// archiveArticles записывает новые статьи ленты в архив. Ошибка архива не отменяет сохранение
+// статей в БД: она только записывается в лог
+func (a *Aggregator) archiveArticles(ctx context.Context, feed *domain.Feed, articles []*domain.Article) {
+	a.mu.RLock()
+	archive := a.archive
+	a.mu.RUnlock()
+
+	if archive == nil || len(articles) == 0 {
+		return
+	}
+	if err := archive.Archive(ctx, feedEntries(feed, articles)); err != nil {
+		logger.Error("Failed to archive articles of feed %s: %v", feed.Name, err)
+	}
+}
@@ -0,0 +1,70 @@
+// internal/core/service/bandwidth.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/logger"
+)
+
+// ErrBudgetExceeded лимит трафика за сутки или месяц исчерпан
+var ErrBudgetExceeded = errors.New("bandwidth budget exceeded")
+
+// SetBandwidthBudget задает лимиты трафика за сутки и месяц (нулевые значения - без ограничения)
+func (a *Aggregator) SetBandwidthBudget(budget domain.BandwidthBudget) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.budget = budget
+}
+
+// checkBudget проверяет, что лимиты трафика не исчерпаны. Если трафик не удалось прочитать,
+// цикл не останавливается: недоступная статистика не должна останавливать агрегатор
+func (a *Aggregator) checkBudget(ctx context.Context, now time.Time) error {
+	a.mu.RLock()
+	budget := a.budget
+	a.mu.RUnlock()
+
+	if !budget.Enabled() {
+		return nil
+	}
+
+	day, month := domain.TrafficPeriods(now)
+	limits := []struct {
+		period string
+		since  time.Time
+		limit  int64
+	}{
+		{"daily", day, budget.Daily},
+		{"monthly", month, budget.Monthly},
+	}
+	for _, l := range limits {
+		if l.limit <= 0 {
+			continue
+		}
+		used, err := a.db.GetTrafficSince(ctx, l.since)
+		if err != nil {
+			logger.Warn("Failed to check %s bandwidth budget: %v", l.period, err)
+			return nil
+		}
+		if used >= l.limit {
+			return fmt.Errorf("%w: %s downloaded of %s %s budget", ErrBudgetExceeded,
+				domain.FormatBytes(used), domain.FormatBytes(l.limit), l.period)
+		}
+	}
+	return nil
+}
+
+// recordTraffic добавляет загруженные данные к трафику ленты за текущий день;
+// ошибка сохранения не прерывает обработку ленты
+func (a *Aggregator) recordTraffic(ctx context.Context, feed *domain.Feed, bytes int64) {
+	if bytes <= 0 {
+		return
+	}
+	if err := a.db.AddFeedTraffic(ctx, feed.ID, time.Now(), bytes); err != nil {
+		logger.Warn("Failed to save traffic of feed %s: %v", feed.Name, err)
+	}
+}
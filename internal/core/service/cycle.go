@@ -0,0 +1,65 @@
+// internal/core/service/cycle.go
+package service
+
+import (
+	"sync"
+	"time"
+
+	"rsshub/internal/core/domain"
+)
+
+// cycle собирает результаты обработки лент одного цикла получения
+type cycle struct {
+	startedAt time.Time
+	wg        sync.WaitGroup
+
+	mu      sync.Mutex
+	results []domain.FeedResult
+	entries []*domain.DigestEntry // Новые статьи для уведомлений после завершения цикла
+}
+
+// newCycle создает новый цикл
+func newCycle() *cycle {
+	return &cycle{startedAt: time.Now()}
+}
+
+// add регистрирует ленту, результат обработки которой ожидается
+func (c *cycle) add() {
+	c.wg.Add(1)
+}
+
+// done сохраняет результат обработки ленты
+func (c *cycle) done(result domain.FeedResult) {
+	c.mu.Lock()
+	c.results = append(c.results, result)
+	c.mu.Unlock()
+	c.wg.Done()
+}
+
+// notify откладывает уведомления о новых статьях ленты до завершения цикла
+func (c *cycle) notify(entries []*domain.DigestEntry) {
+	c.mu.Lock()
+	c.entries = append(c.entries, entries...)
+	c.mu.Unlock()
+}
+
+// pendingEntries возвращает новые статьи цикла для уведомлений
+func (c *cycle) pendingEntries() []*domain.DigestEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*domain.DigestEntry(nil), c.entries...)
+}
+
+// wait ожидает результаты всех лент цикла и формирует отчет
+func (c *cycle) wait() *domain.CycleReport {
+	c.wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return &domain.CycleReport{
+		StartedAt:  c.startedAt,
+		FinishedAt: time.Now(),
+		Feeds:      append([]domain.FeedResult(nil), c.results...),
+	}
+}
@@ -0,0 +1,76 @@
+// internal/core/service/deadfeeds.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/logger"
+)
+
+// SetDeadFeedAfter задает, сколько лента может непрерывно завершаться ошибкой, прежде чем
+// агрегатор отключит ее как мертвую (0 - не отключать ленты автоматически)
+func (a *Aggregator) SetDeadFeedAfter(d time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.deadAfter = d
+}
+
+// checkDeadFeed отключает ленту, которую источник удалил навсегда (410 Gone) или которая
+// не обрабатывается успешно дольше deadAfter, и сообщает об этом во все каналы уведомлений.
+// feed - состояние ленты до текущей неудачи
+func (a *Aggregator) checkDeadFeed(ctx context.Context, feed *domain.Feed, err error) {
+	a.mu.RLock()
+	after := a.deadAfter
+	a.mu.RUnlock()
+
+	if after <= 0 || !feed.Enabled {
+		return
+	}
+
+	var reason string
+	switch {
+	case errors.Is(err, domain.ErrFeedGone):
+		reason = "the feed returned 410 Gone"
+	case feed.FailingSince != nil && time.Since(*feed.FailingSince) >= after:
+		reason = fmt.Sprintf("failing since %s: %v", feed.FailingSince.Format("2006-01-02 15:04"), err)
+	default:
+		return
+	}
+
+	if err := a.db.DisableFeed(ctx, feed.ID, reason); err != nil {
+		logger.Error("Failed to disable dead feed %s: %v", feed.Name, err)
+		return
+	}
+	logger.Warn("Disabled dead feed %s: %s (re-enable with: rsshub enable --name %s)", feed.Name, reason, feed.Name)
+
+	a.notifyFeedDisabled(feed, reason)
+}
+
+// notifyFeedDisabled сообщает об отключении ленты во все каналы уведомлений, минуя правила:
+// это событие самого агрегатора, а не статья ленты
+func (a *Aggregator) notifyFeedDisabled(feed *domain.Feed, reason string) {
+	a.mu.RLock()
+	notifiers := a.notifiers
+	a.mu.RUnlock()
+
+	entry := &domain.DigestEntry{
+		FeedName:   feed.Name,
+		FeedTags:   feed.Tags,
+		FeedFolder: feed.Folder,
+		Article: &domain.Article{
+			Title:       "Feed disabled: " + feed.Name,
+			Link:        feed.URL,
+			Description: reason + ". Review disabled feeds with: rsshub list --disabled",
+			PublishedAt: time.Now(),
+		},
+	}
+	for channel, n := range notifiers {
+		if err := n.Notify(a.ctx, []*domain.DigestEntry{entry}); err != nil {
+			logger.Error("Failed to send notifications to %s: %v", channel, err)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+// internal/core/service/digest.go
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+)
+
+const (
+	// digestLastSentKey настройка агрегатора со временем последней отправки дайджеста
+	digestLastSentKey = "digest_last_sent"
+
+	// digestCheckInterval как часто проверяется, не пора ли отправить дайджест
+	digestCheckInterval = time.Minute
+
+	// untaggedGroup имя группы для статей лент без тегов
+	untaggedGroup = "untagged"
+
+	// unfiledGroup имя группы для статей лент вне папок
+	unfiledGroup = "unfiled"
+)
+
+// Способы группировки статей в дайджесте
+const (
+	GroupByFeed   = "feed"
+	GroupByTag    = "tag"
+	GroupByFolder = "folder" // По полному пути папки ленты: "News/Tech" и "News/Tech/Go" - разные группы
+)
+
+// Digester собирает дайджест новых статей и отправляет его по расписанию
+type Digester struct {
+	db          port.FeedArticleRepository
+	sender      port.DigestSender
+	schedule    *DigestSchedule
+	groupBy     string
+	maxArticles int
+}
+
+// NewDigester создает сборщик дайджестов. sender и schedule нужны только для отправки;
+// без них дайджест можно собрать для просмотра
+func NewDigester(db port.FeedArticleRepository, sender port.DigestSender, schedule *DigestSchedule, groupBy string, maxArticles int) (*Digester, error) {
+	if groupBy != GroupByFeed && groupBy != GroupByTag && groupBy != GroupByFolder {
+		return nil, fmt.Errorf("invalid digest grouping %q (expected %s, %s or %s)", groupBy, GroupByFeed, GroupByTag, GroupByFolder)
+	}
+
+	return &Digester{
+		db:          db,
+		sender:      sender,
+		schedule:    schedule,
+		groupBy:     groupBy,
+		maxArticles: maxArticles,
+	}, nil
+}
+
+// Build собирает дайджест статей, добавленных начиная с from
+func (d *Digester) Build(ctx context.Context, from time.Time) (*domain.Digest, error) {
+	to := time.Now()
+
+	entries, err := d.db.GetArticlesSince(ctx, from, d.maxArticles)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Digest{From: from, To: to, Groups: d.group(entries)}, nil
+}
+
+// BuildUnread собирает дайджест непрочитанных статей, добавленных начиная с from: в каждой группе
+// остаются perGroup самых новых статей (0 - все)
+func (d *Digester) BuildUnread(ctx context.Context, from time.Time, perGroup int) (*domain.Digest, error) {
+	to := time.Now()
+
+	entries, err := d.db.GetArticlesSince(ctx, from, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	unread := entries[:0]
+	for _, e := range entries {
+		if e.Article.ReadAt == nil {
+			unread = append(unread, e)
+		}
+	}
+
+	groups := d.group(unread)
+	for i := range groups {
+		groupEntries := groups[i].Entries
+		sort.SliceStable(groupEntries, func(a, b int) bool {
+			return groupEntries[a].Article.PublishedAt.After(groupEntries[b].Article.PublishedAt)
+		})
+		if perGroup > 0 && len(groupEntries) > perGroup {
+			groups[i].Entries = groupEntries[:perGroup]
+		}
+	}
+
+	return &domain.Digest{From: from, To: to, Groups: groups}, nil
+}
+
+// group раскладывает статьи по лентам, тегам или папкам; группы упорядочены по имени,
+// поэтому вложенные папки идут сразу за родительской
+func (d *Digester) group(entries []*domain.DigestEntry) []domain.DigestGroup {
+	byName := make(map[string][]*domain.DigestEntry)
+	for _, e := range entries {
+		switch d.groupBy {
+		case GroupByFeed:
+			byName[e.FeedName] = append(byName[e.FeedName], e)
+			continue
+		case GroupByFolder:
+			folder := e.FeedFolder
+			if folder == "" {
+				folder = unfiledGroup
+			}
+			byName[folder] = append(byName[folder], e)
+			continue
+		}
+
+		// Статья ленты с несколькими тегами попадает в каждую из групп
+		if len(e.FeedTags) == 0 {
+			byName[untaggedGroup] = append(byName[untaggedGroup], e)
+		}
+		for _, tag := range e.FeedTags {
+			byName[tag] = append(byName[tag], e)
+		}
+	}
+
+	groups := make([]domain.DigestGroup, 0, len(byName))
+	for name, groupEntries := range byName {
+		groups = append(groups, domain.DigestGroup{Name: name, Entries: groupEntries})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+
+	return groups
+}
+
+// LastSent возвращает время последней отправки дайджеста (нулевое, если не отправлялся)
+func (d *Digester) LastSent(ctx context.Context) time.Time {
+	value, err := d.db.GetAggregatorSetting(ctx, digestLastSentKey)
+	if err != nil {
+		return time.Time{}
+	}
+
+	lastSent, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		logger.Warn("Invalid %s setting %q: %v", digestLastSentKey, value, err)
+		return time.Time{}
+	}
+	return lastSent
+}
+
+// Send собирает дайджест статей с from и отправляет его. Пустой дайджест не отправляется,
+// но время отправки все равно запоминается, чтобы следующий дайджест начинался с этого момента
+func (d *Digester) Send(ctx context.Context, from time.Time) (*domain.Digest, error) {
+	if d.sender == nil {
+		return nil, fmt.Errorf("digest sender is not configured")
+	}
+
+	digest, err := d.Build(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	if digest.Total() > 0 {
+		if err := d.sender.SendDigest(digest); err != nil {
+			return nil, fmt.Errorf("failed to send digest: %w", err)
+		}
+		logger.Success("Digest with %d articles sent", digest.Total())
+	} else {
+		logger.Info("No new articles since %v, digest not sent", from.Format(time.RFC3339))
+	}
+
+	if err := d.db.SetAggregatorSetting(ctx, digestLastSentKey, digest.To.Format(time.RFC3339)); err != nil {
+		logger.Warn("Failed to save digest send time: %v", err)
+	}
+
+	return digest, nil
+}
+
+// Run отправляет дайджесты по расписанию до отмены контекста
+func (d *Digester) Run(ctx context.Context) {
+	if d.schedule == nil || d.sender == nil {
+		logger.Warn("Digest schedule or sender is not configured, digests are disabled")
+		return
+	}
+
+	logger.Info("Email digest scheduled %s", d.schedule)
+
+	ticker := time.NewTicker(digestCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		d.sendIfDue(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendIfDue отправляет дайджест, если с момента прошлой отправки наступило время по расписанию.
+// При первом запуске только запоминается текущее время, чтобы не отправлять всю историю
+func (d *Digester) sendIfDue(ctx context.Context, now time.Time) {
+	lastSent := d.LastSent(ctx)
+	if lastSent.IsZero() {
+		if err := d.db.SetAggregatorSetting(ctx, digestLastSentKey, now.Format(time.RFC3339)); err != nil {
+			logger.Warn("Failed to save digest send time: %v", err)
+		}
+		return
+	}
+
+	if now.Before(d.schedule.Next(lastSent.In(now.Location()))) {
+		return
+	}
+
+	if _, err := d.Send(ctx, lastSent); err != nil {
+		logger.Error("Digest failed: %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+// internal/core/service/discover.go
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/urlnorm"
+)
+
+// Discover ищет ленты на каждом сайте из списка и проверяет их получением. С add валидные
+// ленты, которых еще нет (по нормализованному URL), добавляются с тегами tags и именем из заголовка ленты.
+// Ошибки отдельных сайтов и лент записываются в результат, а не прерывают поиск
+func (a *Aggregator) Discover(ctx context.Context, sites []string, add bool, tags []string) ([]*domain.DiscoveredFeed, error) {
+	discoverer, ok := a.parser.(port.FeedDiscoverer)
+	if !ok {
+		return nil, fmt.Errorf("feed discovery is not supported")
+	}
+
+	feeds, err := a.db.GetAllFeeds(ctx, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feeds: %w", err)
+	}
+	byURL := make(map[string]*domain.Feed, len(feeds))
+	names := make(map[string]bool, len(feeds))
+	for _, feed := range feeds {
+		byURL[urlnorm.Key(feed.URL)] = feed
+		names[feed.Name] = true
+	}
+
+	var results []*domain.DiscoveredFeed
+	seen := make(map[string]bool)
+	for _, site := range sites {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		urls, err := discoverer.DiscoverFeeds(ctx, site)
+		if err == nil && len(urls) == 0 {
+			err = fmt.Errorf("no feeds found")
+		}
+		if err != nil {
+			results = append(results, &domain.DiscoveredFeed{SiteURL: site, Error: err.Error()})
+			continue
+		}
+
+		for _, u := range urls {
+			u = urlnorm.Normalize(u)
+			if seen[u] {
+				continue
+			}
+			seen[u] = true
+
+			result := &domain.DiscoveredFeed{SiteURL: site, URL: u}
+			results = append(results, result)
+			if existing, ok := byURL[urlnorm.Key(u)]; ok {
+				result.Existing = existing.Name
+				continue
+			}
+
+			feed := &domain.Feed{URL: u, Priority: domain.PriorityNormal, Tags: tags, Enabled: true}
+			parsed, err := a.parser.FetchAndParse(ctx, feed)
+			if err != nil {
+				result.Error = err.Error()
+				continue
+			}
+			result.Title, result.Items = parsed.Title, len(parsed.Items)
+			if !add {
+				continue
+			}
+
+			feed.Name = importFeedName(domain.Subscription{Title: parsed.Title, FeedURL: u}, names)
+			if err := a.db.CreateFeed(ctx, feed); err != nil {
+				result.Error = fmt.Sprintf("failed to add feed: %v", err)
+				continue
+			}
+
+			logger.Info("Added discovered feed %s (%s)", feed.Name, feed.URL)
+			result.Added = feed.Name
+			byURL[urlnorm.Key(feed.URL)] = feed
+			names[feed.Name] = true
+		}
+	}
+	return results, nil
+}
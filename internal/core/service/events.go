@@ -0,0 +1,210 @@
+// internal/core/service/events.go
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/utils"
+)
+
+// Проверяем на этапе компиляции, что ArticleEvents рассылает новые статьи
+var _ port.ArticleEventSource = (*ArticleEvents)(nil)
+
+const (
+	// eventsPollInterval как часто рассылка проверяет новые статьи, если интервал не задан
+	eventsPollInterval = 2 * time.Second
+
+	// eventsBatchSize сколько новых статей читается из БД одним запросом
+	eventsBatchSize = 500
+
+	// eventsSubscriberBuffer сколько статей ждут медленного подписчика; переполнение отключает
+	// подписчика, а после переподключения он получает пропущенное по номеру последней статьи
+	eventsSubscriberBuffer = 256
+)
+
+// eventSubscriber подписчик рассылки
+type eventSubscriber struct {
+	filter  domain.ArticleEventFilter
+	entries chan *domain.DigestEntry
+}
+
+// ArticleEvents рассылает новые статьи подписчикам (SSE, gRPC). Статьи сохраняют и другие
+// процессы (fetch, другие экземпляры), поэтому рассылка один раз на всех подписчиков
+// проверяет новые номера статей в БД, а не получает статьи от агрегатора этого процесса
+type ArticleEvents struct {
+	db       port.FeedArticleRepository
+	interval time.Duration
+
+	mu          sync.Mutex
+	subscribers map[*eventSubscriber]struct{}
+	stopped     bool // Run завершился: новые подписчики не принимаются
+}
+
+// NewArticleEvents создает рассылку, проверяющую новые статьи раз в interval
+// (0 - eventsPollInterval); проверка начинается с Run
+func NewArticleEvents(db port.FeedArticleRepository, interval time.Duration) *ArticleEvents {
+	if interval <= 0 {
+		interval = eventsPollInterval
+	}
+	return &ArticleEvents{db: db, interval: interval, subscribers: make(map[*eventSubscriber]struct{})}
+}
+
+// Run проверяет новые статьи и рассылает их до отмены ctx, затем отключает подписчиков
+func (e *ArticleEvents) Run(ctx context.Context) {
+	defer e.stop()
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	// Подписчикам отправляются только статьи, сохраненные после запуска; пропущенные до
+	// переподключения статьи подписчик читает через ArticlesAfter
+	last := int64(-1)
+	for {
+		if last < 0 {
+			seq, err := e.latestSeq(ctx)
+			if err != nil {
+				logger.Error("Events: failed to get latest article: %v", err)
+			} else {
+				last = seq
+			}
+		} else {
+			last = e.poll(ctx, last)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// latestSeq возвращает номер последней сохраненной статьи (0 - статей нет)
+func (e *ArticleEvents) latestSeq(ctx context.Context) (int64, error) {
+	articles, err := e.db.GetArticlesBySeq(ctx, domain.ArticleSeqFilter{MaxSeq: math.MaxInt64, Limit: 1})
+	if err != nil || len(articles) == 0 {
+		return 0, err
+	}
+	return articles[0].Seq, nil
+}
+
+// poll рассылает статьи с номером больше last и возвращает номер последней из них
+func (e *ArticleEvents) poll(ctx context.Context, last int64) int64 {
+	for {
+		entries, next, err := e.ArticlesAfter(ctx, last, eventsBatchSize)
+		if err != nil {
+			logger.Error("Events: failed to get new articles: %v", err)
+			return last
+		}
+		e.publish(entries)
+		if next == last {
+			return last
+		}
+		last = next
+	}
+}
+
+// ArticlesAfter читает до limit статей с номером больше after и возвращает их вместе с
+// данными лент и номером последней прочитанной статьи; статьи удаленных лент пропускаются
+func (e *ArticleEvents) ArticlesAfter(ctx context.Context, after int64, limit int) ([]*domain.DigestEntry, int64, error) {
+	articles, err := e.db.GetArticlesBySeq(ctx, domain.ArticleSeqFilter{SinceSeq: after, Limit: limit})
+	if err != nil || len(articles) == 0 {
+		return nil, after, err
+	}
+
+	feeds, err := e.db.GetAllFeeds(ctx, 0)
+	if err != nil {
+		return nil, after, fmt.Errorf("failed to get feeds: %w", err)
+	}
+	byID := make(map[utils.UUID]*domain.Feed, len(feeds))
+	for _, feed := range feeds {
+		byID[feed.ID] = feed
+	}
+
+	entries := make([]*domain.DigestEntry, 0, len(articles))
+	for _, a := range articles {
+		feed, ok := byID[a.FeedID]
+		if !ok {
+			continue
+		}
+		entries = append(entries, &domain.DigestEntry{
+			Article:    a,
+			FeedName:   feed.Name,
+			FeedTags:   feed.Tags,
+			FeedFolder: feed.Folder,
+		})
+	}
+	return entries, articles[len(articles)-1].Seq, nil
+}
+
+// publish передает статьи подходящим подписчикам; подписчик, который не успевает их читать,
+// отключается, чтобы не задерживать остальных
+func (e *ArticleEvents) publish(entries []*domain.DigestEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for sub := range e.subscribers {
+		for _, entry := range entries {
+			if !sub.filter.Match(entry) {
+				continue
+			}
+			select {
+			case sub.entries <- entry:
+				continue
+			default:
+			}
+			logger.Warn("Events: subscriber is too slow, disconnecting it")
+			delete(e.subscribers, sub)
+			close(sub.entries)
+			break
+		}
+	}
+}
+
+// Subscribe подписывает на новые статьи, подходящие под filter, и возвращает канал статей и
+// функцию отписки; после остановки рассылки канал nil
+func (e *ArticleEvents) Subscribe(filter domain.ArticleEventFilter) (<-chan *domain.DigestEntry, func()) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.stopped {
+		return nil, func() {}
+	}
+	sub := &eventSubscriber{filter: filter, entries: make(chan *domain.DigestEntry, eventsSubscriberBuffer)}
+	e.subscribers[sub] = struct{}{}
+	return sub.entries, func() { e.unsubscribe(sub) }
+}
+
+// unsubscribe удаляет подписчика, если он еще не отключен
+func (e *ArticleEvents) unsubscribe(sub *eventSubscriber) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.subscribers[sub]; ok {
+		delete(e.subscribers, sub)
+		close(sub.entries)
+	}
+}
+
+// stop отключает всех подписчиков, чтобы их запросы завершились вместе с сервером
+func (e *ArticleEvents) stop() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.stopped = true
+	for sub := range e.subscribers {
+		delete(e.subscribers, sub)
+		close(sub.entries)
+	}
+}
@@ -0,0 +1,73 @@
+// internal/core/service/heartbeat.go
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+)
+
+const (
+	// heartbeatKey настройка агрегатора с отметкой о том, что фоновый процесс жив
+	heartbeatKey = "aggregator_heartbeat"
+
+	// heartbeatStopped значение отметки после штатной остановки агрегатора
+	heartbeatStopped = "stopped"
+
+	// heartbeatInterval как часто запущенный агрегатор обновляет отметку
+	heartbeatInterval = 10 * time.Second
+
+	// HeartbeatTimeout после этого срока без отметки агрегатор считается зависшим или упавшим
+	HeartbeatTimeout = 30 * time.Second
+)
+
+// Heartbeat последняя отметка фонового процесса агрегатора
+type Heartbeat struct {
+	Instance string    // Идентификатор экземпляра (хост и PID)
+	At       time.Time // Время отметки
+	Stopped  bool      // Агрегатор остановлен штатно
+}
+
+// Alive проверяет, что агрегатор запущен и отмечался не позже HeartbeatTimeout назад
+func (h *Heartbeat) Alive(now time.Time) bool {
+	return !h.Stopped && now.Sub(h.At) <= HeartbeatTimeout
+}
+
+// ReadHeartbeat возвращает последнюю отметку агрегатора (nil - агрегатор ни разу не запускался)
+func ReadHeartbeat(ctx context.Context, db port.FeedArticleRepository) *Heartbeat {
+	value, err := db.GetAggregatorSetting(ctx, heartbeatKey)
+	if err != nil {
+		return nil
+	}
+	if value == heartbeatStopped {
+		return &Heartbeat{Stopped: true}
+	}
+
+	instance, at, ok := strings.Cut(value, "|")
+	if !ok {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, at)
+	if err != nil {
+		return nil
+	}
+	return &Heartbeat{Instance: instance, At: t}
+}
+
+// writeHeartbeat обновляет отметку запущенного агрегатора
+func (a *Aggregator) writeHeartbeat(ctx context.Context) {
+	value := a.instanceID + "|" + time.Now().Format(time.RFC3339)
+	if err := a.db.SetAggregatorSetting(ctx, heartbeatKey, value); err != nil {
+		logger.Warn("Failed to write aggregator heartbeat: %v", err)
+	}
+}
+
+// clearHeartbeat отмечает штатную остановку агрегатора
+func (a *Aggregator) clearHeartbeat(ctx context.Context) {
+	if err := a.db.SetAggregatorSetting(ctx, heartbeatKey, heartbeatStopped); err != nil {
+		logger.Warn("Failed to clear aggregator heartbeat: %v", err)
+	}
+}
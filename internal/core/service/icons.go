@@ -0,0 +1,101 @@
+// internal/core/service/icons.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+)
+
+// iconRetryInterval через сколько повторяется неудачная попытка получить иконку ленты
+const iconRetryInterval = 24 * time.Hour
+
+// SetIconRefresh задает, как часто обновляются иконки лент (0 - иконки не запрашиваются)
+func (a *Aggregator) SetIconRefresh(d time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.iconRefresh = d
+}
+
+// refreshIcon запрашивает иконку ленты после ее получения, если иконки еще нет или она
+// устарела. Ошибки только записываются в лог: иконка не влияет на результат обработки ленты
+func (a *Aggregator) refreshIcon(ctx context.Context, feed *domain.Feed, parsed *domain.ParsedRSSFeed) {
+	a.mu.RLock()
+	refresh := a.iconRefresh
+	a.mu.RUnlock()
+
+	fetcher, ok := a.parser.(port.IconFetcher)
+	if !ok || refresh <= 0 {
+		return
+	}
+
+	icon, err := a.db.GetFeedIcon(ctx, feed.ID)
+	switch {
+	case err == nil:
+		if !icon.Due(time.Now(), refresh, iconRetryInterval) {
+			return
+		}
+	case errors.Is(err, domain.ErrIconNotFound):
+		icon = nil
+	default:
+		logger.Error("Failed to get icon of feed %s: %v", feed.Name, err)
+		return
+	}
+
+	if _, err := a.updateIcon(ctx, fetcher, feed, parsed, icon); err != nil {
+		logger.Warn("Failed to fetch icon of feed %s: %v", feed.Name, err)
+	}
+}
+
+// updateIcon получает иконку и сохраняет результат. Если получить ее не удалось,
+// прежнее изображение сохраняется, записываются только время и ошибка попытки
+func (a *Aggregator) updateIcon(ctx context.Context, fetcher port.IconFetcher, feed *domain.Feed,
+	parsed *domain.ParsedRSSFeed, previous *domain.FeedIcon) (*domain.FeedIcon, error) {
+	now := time.Now()
+	icon, fetchErr := fetcher.FetchIcon(ctx, feed, parsed)
+	if fetchErr != nil {
+		icon = &domain.FeedIcon{FeedID: feed.ID}
+		if previous != nil {
+			icon = previous
+		}
+		icon.LastError = fetchErr.Error()
+	} else {
+		icon.FetchedAt = &now
+		icon.LastError = ""
+	}
+	icon.CheckedAt = now
+
+	if err := a.db.SaveFeedIcon(ctx, icon); err != nil {
+		return nil, err
+	}
+	if fetchErr != nil {
+		return icon, fetchErr
+	}
+
+	logger.Info("Fetched icon of feed %s: %s (%s, %d bytes)", feed.Name, icon.URL, icon.ContentType, len(icon.Data))
+	return icon, nil
+}
+
+// RefreshIcon сразу получает ленту и ее иконку, не дожидаясь срока обновления
+func (a *Aggregator) RefreshIcon(ctx context.Context, feed *domain.Feed) (*domain.FeedIcon, error) {
+	fetcher, ok := a.parser.(port.IconFetcher)
+	if !ok {
+		return nil, fmt.Errorf("fetching feed icons is not supported")
+	}
+
+	parsed, err := a.parser.FetchAndParse(ctx, feed)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := a.db.GetFeedIcon(ctx, feed.ID)
+	if err != nil && !errors.Is(err, domain.ErrIconNotFound) {
+		return nil, err
+	}
+	return a.updateIcon(ctx, fetcher, feed, parsed, previous)
+}
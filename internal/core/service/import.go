@@ -0,0 +1,306 @@
+// internal/core/service/import.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/urlnorm"
+)
+
+// Import переносит подписки из другого агрегатора: создает ленты, которых еще нет (по нормализованному URL),
+// с тегами из категорий. С withState переносит и прочитанные и избранные статьи, чтобы
+// агрегатор не показал их снова как непрочитанные
+func (a *Aggregator) Import(ctx context.Context, source port.SubscriptionSource, withState bool, maxEntries int) (*domain.ImportReport, error) {
+	report := &domain.ImportReport{}
+
+	subs, err := source.Subscriptions(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to get subscriptions: %w", err)
+	}
+
+	feeds, err := a.db.GetAllFeeds(ctx, 0)
+	if err != nil {
+		return report, fmt.Errorf("failed to get feeds: %w", err)
+	}
+	byURL := make(map[string]*domain.Feed, len(feeds))
+	names := make(map[string]bool, len(feeds))
+	for _, feed := range feeds {
+		byURL[urlnorm.Key(feed.URL)] = feed
+		names[feed.Name] = true
+	}
+
+	for _, sub := range subs {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		if _, ok := byURL[urlnorm.Key(sub.FeedURL)]; ok {
+			report.ExistingFeeds++
+			continue
+		}
+
+		feed := &domain.Feed{
+			Name:     importFeedName(sub, names),
+			URL:      urlnorm.Normalize(sub.FeedURL),
+			Priority: domain.PriorityNormal,
+			Tags:     domain.ParseTags(strings.Join(sub.Categories, ",")),
+			Folder:   domain.ParseFolder(sub.Folder),
+			Enabled:  true,
+		}
+		if err := a.db.CreateFeed(ctx, feed); err != nil {
+			logger.Error("Failed to import feed %s (%s): %v", feed.Name, feed.URL, err)
+			continue
+		}
+
+		logger.Info("Imported feed %s (%s)", feed.Name, feed.URL)
+		byURL[urlnorm.Key(feed.URL)] = feed
+		names[feed.Name] = true
+		report.Feeds++
+	}
+
+	if !withState {
+		return report, nil
+	}
+
+	entries, err := source.Entries(ctx, maxEntries)
+	if err != nil {
+		return report, fmt.Errorf("failed to get articles: %w", err)
+	}
+
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		feed, ok := byURL[urlnorm.Key(entry.FeedURL)]
+		if !ok || entry.Link == "" {
+			continue
+		}
+		if err := a.importEntry(ctx, feed, entry, report); err != nil {
+			logger.Error("Failed to import article '%s' of feed %s: %v", entry.Title, feed.Name, err)
+		}
+	}
+
+	return report, nil
+}
+
+// Restore восстанавливает ленты со всеми настройками и правила фильтров из резервной копии
+// (rsshub export --format json). Ленты с уже занятым именем или тем же нормализованным URL
+// и правила с уже занятым именем пропускаются, поэтому повторное восстановление ничего не дублирует
+func (a *Aggregator) Restore(ctx context.Context, backup *domain.Backup) (*domain.RestoreReport, error) {
+	report := &domain.RestoreReport{}
+
+	feeds, err := a.db.GetAllFeeds(ctx, 0)
+	if err != nil {
+		return report, fmt.Errorf("failed to get feeds: %w", err)
+	}
+	urls := make(map[string]bool, len(feeds))
+	names := make(map[string]bool, len(feeds))
+	for _, feed := range feeds {
+		urls[urlnorm.Key(feed.URL)] = true
+		names[feed.Name] = true
+	}
+
+	for _, feed := range backup.Feeds {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		if names[feed.Name] || urls[urlnorm.Key(feed.URL)] {
+			report.ExistingFeeds++
+			continue
+		}
+
+		// Время следующего запуска по расписанию считается заново: в копии его нет
+		if feed.Schedule != "" {
+			if schedule, err := domain.ParseCron(feed.Schedule); err == nil {
+				next := schedule.Next(time.Now())
+				feed.NextRunAt = &next
+			}
+		}
+		if err := a.db.CreateFeed(ctx, feed); err != nil {
+			logger.Error("Failed to restore feed %s (%s): %v", feed.Name, feed.URL, err)
+			continue
+		}
+		if !feed.Enabled && feed.DisabledReason != "" {
+			if err := a.db.DisableFeed(ctx, feed.ID, feed.DisabledReason); err != nil {
+				logger.Warn("Failed to restore disabled reason of feed %s: %v", feed.Name, err)
+			}
+		}
+
+		logger.Info("Restored feed %s (%s)", feed.Name, feed.URL)
+		urls[urlnorm.Key(feed.URL)] = true
+		names[feed.Name] = true
+		report.Feeds++
+	}
+
+	for _, rule := range backup.Filters {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		err := a.db.CreateFilterRule(ctx, rule)
+		switch {
+		case errors.Is(err, domain.ErrDuplicateFilter):
+			report.ExistingFilters++
+		case err != nil:
+			logger.Error("Failed to restore filter rule %s: %v", rule.Name, err)
+		default:
+			report.Filters++
+		}
+	}
+
+	return report, nil
+}
+
+// RestoreArticles сохраняет статьи полной резервной копии (rsshub backup) в ленты с теми же именами
+// вместе с их состоянием: прочитанностью, избранным и временем сохранения. Ключ уникальности
+// считается заново для новой ленты, поэтому статья, которая уже есть, не дублируется
+func (a *Aggregator) RestoreArticles(ctx context.Context, entries []*domain.DigestEntry, report *domain.RestoreReport) error {
+	a.mu.RLock()
+	dedupMode := a.dedupMode
+	a.mu.RUnlock()
+
+	feeds := make(map[string]*domain.Feed)
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		feed, ok := feeds[entry.FeedName]
+		if !ok {
+			var err error
+			feed, err = a.db.GetFeedByName(ctx, entry.FeedName)
+			if errors.Is(err, domain.ErrFeedNotFound) {
+				logger.Warn("Skipping articles of feed %s: it is not in the database", entry.FeedName)
+			} else if err != nil {
+				return fmt.Errorf("failed to get feed %s: %w", entry.FeedName, err)
+			}
+			feeds[entry.FeedName] = feed
+		}
+		if feed == nil {
+			report.SkippedArticles++
+			continue
+		}
+
+		article := entry.Article
+		article.FeedID = feed.ID
+		article.DedupKey = domain.ArticleDedupKey(dedupMode, feed.ID, article.GUID, article.Link)
+		article.ContentHash = domain.ArticleContentHash(article.Title, article.Description)
+		result, err := a.db.RestoreArticle(ctx, article)
+		if err != nil {
+			return fmt.Errorf("failed to restore article '%s' of feed %s: %w", article.Title, feed.Name, err)
+		}
+		if result == domain.ArticleSkipped {
+			report.ExistingArticles++
+		} else {
+			report.Articles++
+		}
+	}
+	return nil
+}
+
+// importEntry сохраняет статью, если ее еще нет, и переносит ее состояние
+func (a *Aggregator) importEntry(ctx context.Context, feed *domain.Feed, entry domain.ImportedEntry, report *domain.ImportReport) error {
+	a.mu.RLock()
+	dedupMode := a.dedupMode
+	a.mu.RUnlock()
+
+	// GUID другого агрегатора неизвестен, поэтому статья уникальна по ссылке; при получении
+	// ленты агрегатор найдет ее по ссылке и не создаст дубликат
+	dedupKey := domain.ArticleDedupKey(dedupMode, feed.ID, "", entry.Link)
+
+	article, err := a.db.GetArticleByKey(ctx, feed.ID, dedupKey, entry.Link)
+	if errors.Is(err, domain.ErrArticleNotFound) {
+		var created bool
+		article, created, err = a.createImportedArticle(ctx, feed, entry, dedupKey)
+		if created {
+			report.Articles++
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if entry.Read && article.ReadAt == nil {
+		if err := a.db.MarkArticleRead(ctx, article.ID); err != nil {
+			return err
+		}
+		report.Read++
+	}
+	if entry.Starred && article.SavedAt == nil {
+		if err := a.db.MarkArticlesBySeq(ctx, []int64{article.Seq}, domain.MarkSaved); err != nil {
+			return err
+		}
+		report.Starred++
+	}
+	return nil
+}
+
+// createImportedArticle сохраняет статью другого агрегатора в ленту; ID и номер статьи
+// назначает хранилище. Статья, которую успел сохранить цикл получения, не меняется и
+// возвращается с created = false
+func (a *Aggregator) createImportedArticle(ctx context.Context, feed *domain.Feed, entry domain.ImportedEntry, dedupKey string) (*domain.Article, bool, error) {
+	article := &domain.Article{
+		Title:       entry.Title,
+		Link:        entry.Link,
+		PublishedAt: entry.PublishedAt,
+		Description: entry.Description,
+		FeedID:      feed.ID,
+		DedupKey:    dedupKey,
+		ContentHash: domain.ArticleContentHash(entry.Title, entry.Description),
+		Language:    domain.DetectLanguage(entry.Title + "\n" + entry.Description),
+	}
+	result, err := a.db.CreateArticle(ctx, article, domain.ConflictSkip)
+	if err != nil {
+		return nil, false, err
+	}
+	if result == domain.ArticleSkipped {
+		article, err = a.db.GetArticleByKey(ctx, feed.ID, dedupKey, entry.Link)
+		return article, false, err
+	}
+	return article, true, nil
+}
+
+// importFeedName выбирает свободное имя ленты в стиле "tech-crunch": из заголовка подписки
+// или хоста ее URL, при совпадении с уже занятым именем - с номером
+func importFeedName(sub domain.Subscription, taken map[string]bool) string {
+	name := slugify(sub.Title)
+	if name == "" {
+		if u, err := url.Parse(sub.FeedURL); err == nil {
+			name = slugify(strings.TrimPrefix(u.Hostname(), "www."))
+		}
+	}
+	if name == "" {
+		name = "feed"
+	}
+
+	candidate := name
+	for i := 2; taken[candidate]; i++ {
+		candidate = fmt.Sprintf("%s-%d", name, i)
+	}
+	return candidate
+}
+
+// slugify приводит строку к нижнему регистру и заменяет все, кроме букв и цифр, на "-"
+func slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return b.String()
+}
@@ -24,15 +24,15 @@ func NewAggregatorManager(db port.FeedArticleRepository) *AggregatorManager {
 }
 
 // SetInterval устанавливает интервал и уведомляет запущенный агрегатор
-func (m *AggregatorManager) SetInterval(duration time.Duration) error {
+func (m *AggregatorManager) SetInterval(ctx context.Context, duration time.Duration) error {
 	// Сохраняем в базу данных
-	if err := m.db.SetAggregatorSetting("interval", duration.String()); err != nil {
+	if err := m.db.SetAggregatorSetting(ctx, "interval", duration.String()); err != nil {
 		return fmt.Errorf("failed to save interval to database: %w", err)
 	}
 
-	// Устанавливаем флаг для уведомления агрегатора об изменениях
-	if err := m.db.SetAggregatorSetting("settings_changed", "true"); err != nil {
-		logger.Warn("Failed to set settings change flag: %v", err)
+	// Запущенный агрегатор применит настройку, как только получит уведомление
+	if err := m.db.NotifySettingsChanged(ctx); err != nil {
+		logger.Warn("Failed to notify running aggregator: %v", err)
 	}
 
 	logger.Success("Interval set to %v (will be applied to running aggregator)", duration)
@@ -40,47 +40,34 @@ func (m *AggregatorManager) SetInterval(duration time.Duration) error {
 }
 
 // SetWorkers устанавливает количество воркеров и уведомляет запущенный агрегатор
-func (m *AggregatorManager) SetWorkers(count int) error {
+func (m *AggregatorManager) SetWorkers(ctx context.Context, count int) error {
 	// Сохраняем в базу данных
-	if err := m.db.SetAggregatorSetting("workers", strconv.Itoa(count)); err != nil {
+	if err := m.db.SetAggregatorSetting(ctx, "workers", strconv.Itoa(count)); err != nil {
 		return fmt.Errorf("failed to save workers count to database: %w", err)
 	}
 
-	// Устанавливаем флаг для уведомления агрегатора об изменениях
-	if err := m.db.SetAggregatorSetting("settings_changed", "true"); err != nil {
-		logger.Warn("Failed to set settings change flag: %v", err)
+	// Запущенный агрегатор применит настройку, как только получит уведомление
+	if err := m.db.NotifySettingsChanged(ctx); err != nil {
+		logger.Warn("Failed to notify running aggregator: %v", err)
 	}
 
 	logger.Success("Workers count set to %d (will be applied to running aggregator)", count)
 	return nil
 }
 
-// CheckAndApplyChanges проверяет изменения настроек и применяет их к агрегатору
-func (m *AggregatorManager) CheckAndApplyChanges(aggregator port.Aggregator) error {
-	// Проверяем, есть ли изменения настроек
-	changed, err := m.db.GetAggregatorSetting("settings_changed")
-	if err != nil || changed != "true" {
-		return nil // Нет изменений
-	}
-
-	logger.Info("Detected settings changes, applying...")
-
-	// Сбрасываем флаг изменений СНАЧАЛА, чтобы избежать повторного применения
-	if err := m.db.SetAggregatorSetting("settings_changed", "false"); err != nil {
-		logger.Warn("Failed to reset settings change flag: %v", err)
-	}
-
-	// Загружаем новые настройки из базы данных
+// ApplySettings загружает интервал и количество воркеров из базы данных и применяет их к агрегатору.
+// Неизменившиеся настройки агрегатор пропускает сам
+func (m *AggregatorManager) ApplySettings(ctx context.Context, aggregator port.Aggregator) {
 	var newInterval time.Duration
 	var newWorkers int
 
-	if intervalStr, err := m.db.GetAggregatorSetting("interval"); err == nil {
+	if intervalStr, err := m.db.GetAggregatorSetting(ctx, "interval"); err == nil {
 		if interval, err := time.ParseDuration(intervalStr); err == nil {
 			newInterval = interval
 		}
 	}
 
-	if workersStr, err := m.db.GetAggregatorSetting("workers"); err == nil {
+	if workersStr, err := m.db.GetAggregatorSetting(ctx, "workers"); err == nil {
 		if workers, err := strconv.Atoi(workersStr); err == nil && workers > 0 {
 			newWorkers = workers
 		}
@@ -98,26 +85,38 @@ func (m *AggregatorManager) CheckAndApplyChanges(aggregator port.Aggregator) err
 			logger.Error("Failed to apply workers change: %v", err)
 		}
 	}
-
-	return nil
 }
 
-// StartMonitoring запускает мониторинг изменений настроек
-func (m *AggregatorManager) StartMonitoring(ctx context.Context, aggregator port.Aggregator) {
-	ticker := time.NewTicker(10 * time.Second) // Увеличиваем интервал до 10 секунд
-	defer ticker.Stop()
+// StartMonitoring применяет изменения настроек, как только о них приходит уведомление
+// (см. port.FeedArticleRepository.ListenSettingsChanges), и перечитывает их по каждому сигналу
+// recheck на случай пропущенного уведомления. Если подписаться не удалось, настройки применяются
+// только по recheck. Завершается после отмены ctx
+func (m *AggregatorManager) StartMonitoring(ctx context.Context, aggregator port.Aggregator, recheck <-chan time.Time, every time.Duration) {
+	changes, err := m.db.ListenSettingsChanges(ctx)
+	switch {
+	case err == nil && every > 0:
+		logger.Debug("Settings monitoring started (on notifications, re-checking every %v)", every)
+	case err == nil:
+		logger.Debug("Settings monitoring started (on notifications)")
+	case every > 0:
+		logger.Warn("Settings changes will be applied within %v: %v", every, err)
+	default:
+		logger.Error("Settings changes will not be applied until restart: %v", err)
+	}
 
-	logger.Debug("Settings monitoring started (checking every 10 seconds)")
+	// Изменения между загрузкой настроек при запуске и подпиской иначе были бы пропущены
+	m.ApplySettings(ctx, aggregator)
 
 	for {
 		select {
 		case <-ctx.Done():
 			logger.Debug("Settings monitoring stopped")
 			return
-		case <-ticker.C:
-			if err := m.CheckAndApplyChanges(aggregator); err != nil {
-				logger.Error("Error checking settings changes: %v", err)
-			}
+		case <-changes:
+			logger.Info("Detected settings changes, applying...")
+			m.ApplySettings(ctx, aggregator)
+		case <-recheck:
+			m.ApplySettings(ctx, aggregator)
 		}
 	}
 }
@@ -0,0 +1,100 @@
+// internal/core/service/mirrors.go
+package service
+
+import (
+	"context"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+)
+
+// SetMirrorAfterFailures задает, после скольких неудач основного URL подряд лента
+// получается с зеркал (0 - зеркала не используются)
+func (a *Aggregator) SetMirrorAfterFailures(n int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.mirrorAfter = n
+}
+
+// fetchFeed получает ленту с основного URL, а если он не отвечает mirrorAfter раз подряд -
+// с зеркал. Основной URL пробуется в каждом цикле, чтобы вернуться к нему после восстановления.
+// Возвращает URL, с которого получена лента
+func (a *Aggregator) fetchFeed(ctx context.Context, feed *domain.Feed, force bool) (*domain.ParsedRSSFeed, string, error) {
+	parsed, err := a.fetchPrimary(ctx, feed, force)
+	if err == nil {
+		if feed.ActiveURL != "" {
+			logger.Info("Feed %s is available at its primary URL again", feed.Name)
+		}
+		a.setFetchState(ctx, feed, 0, "")
+		return parsed, feed.URL, nil
+	}
+
+	failures := feed.FetchFailures + 1
+	a.mu.RLock()
+	after := a.mirrorAfter
+	a.mu.RUnlock()
+
+	if len(feed.Mirrors) == 0 || after <= 0 || failures < after || ctx.Err() != nil {
+		a.setFetchState(ctx, feed, failures, feed.ActiveURL)
+		return nil, "", err
+	}
+
+	for _, mirror := range mirrorOrder(feed) {
+		logger.Warn("Feed %s failed %d times in a row, trying mirror %s", feed.Name, failures, mirror)
+
+		attempt := *feed
+		attempt.URL = mirror
+		parsed, mirrorErr := a.parser.FetchAndParse(ctx, &attempt)
+		if mirrorErr == nil {
+			logger.Info("Feed %s fetched from mirror %s", feed.Name, mirror)
+			a.setFetchState(ctx, feed, failures, mirror)
+			return parsed, mirror, nil
+		}
+
+		logger.Warn("Mirror %s of feed %s failed: %v", mirror, feed.Name, mirrorErr)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	a.setFetchState(ctx, feed, failures, feed.ActiveURL)
+	return nil, "", err
+}
+
+// fetchPrimary получает ленту с основного URL. Если есть валидаторы кеша, запрос условный, и
+// неизменившаяся лента возвращается с NotModified; force отправляет безусловный запрос.
+// Зеркала всегда запрашиваются безусловно: валидаторы относятся к основному URL
+func (a *Aggregator) fetchPrimary(ctx context.Context, feed *domain.Feed, force bool) (*domain.ParsedRSSFeed, error) {
+	if conditional, ok := a.parser.(port.ConditionalFetcher); ok && !force && !feed.Cache.IsZero() {
+		return conditional.FetchIfModified(ctx, feed)
+	}
+	return a.parser.FetchAndParse(ctx, feed)
+}
+
+// mirrorOrder возвращает зеркала ленты; последнее успешное пробуется первым
+func mirrorOrder(feed *domain.Feed) []string {
+	mirrors := make([]string, 0, len(feed.Mirrors))
+	for _, mirror := range feed.Mirrors {
+		if mirror == feed.ActiveURL {
+			mirrors = append([]string{mirror}, mirrors...)
+		} else {
+			mirrors = append(mirrors, mirror)
+		}
+	}
+	return mirrors
+}
+
+// setFetchState сохраняет состояние получения ленты, если оно изменилось
+func (a *Aggregator) setFetchState(ctx context.Context, feed *domain.Feed, failures int, activeURL string) {
+	if feed.FetchFailures == failures && feed.ActiveURL == activeURL {
+		return
+	}
+
+	if err := a.db.SetFeedFetchState(ctx, feed.ID, failures, activeURL); err != nil {
+		logger.Warn("Failed to save fetch state of feed %s: %v", feed.Name, err)
+		return
+	}
+	feed.FetchFailures = failures
+	feed.ActiveURL = activeURL
+}
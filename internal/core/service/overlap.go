@@ -0,0 +1,149 @@
+// internal/core/service/overlap.go
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+)
+
+// cycleStatsKey настройка агрегатора со счетчиками циклов запущенного процесса
+const cycleStatsKey = "aggregator_cycle_stats"
+
+// CycleOverlap поведение, когда по тикеру пора начинать цикл, а предыдущий еще не завершен
+type CycleOverlap string
+
+const (
+	OverlapSkip  CycleOverlap = "skip"  // Пропустить новый цикл
+	OverlapQueue CycleOverlap = "queue" // Запустить сразу после текущего; в очереди не больше одного цикла
+)
+
+// ParseCycleOverlap разбирает режим наложения циклов
+func ParseCycleOverlap(s string) (CycleOverlap, error) {
+	switch mode := CycleOverlap(strings.ToLower(strings.TrimSpace(s))); mode {
+	case OverlapSkip, OverlapQueue:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown cycle overlap mode %q (expected skip or queue)", s)
+	}
+}
+
+// CycleStats счетчики циклов получения и сохраненных ими статей с момента запуска процесса
+type CycleStats struct {
+	Started int64 `json:"started"` // Запущено циклов
+	Skipped int64 `json:"skipped"` // Пропущено из-за незавершенного предыдущего цикла
+	Queued  int64 `json:"queued"`  // Отложено до завершения предыдущего цикла
+
+	Inserted   int64 `json:"inserted"`   // Добавлено новых статей
+	Duplicates int64 `json:"duplicates"` // Элементов, статьи которых уже были сохранены
+	Updated    int64 `json:"updated"`    // Сохраненных статей, обновленных лентами
+}
+
+// cycleGuard не дает циклам получения выполняться одновременно
+type cycleGuard struct {
+	slot    chan struct{} // Занят, пока выполняется цикл
+	waiting atomic.Bool   // Есть цикл, ожидающий своей очереди
+
+	started atomic.Int64
+	skipped atomic.Int64
+	queued  atomic.Int64
+
+	inserted   atomic.Int64
+	duplicates atomic.Int64
+	updated    atomic.Int64
+}
+
+// newCycleGuard создает защиту от наложения циклов
+func newCycleGuard() *cycleGuard {
+	return &cycleGuard{slot: make(chan struct{}, 1)}
+}
+
+// acquire занимает право на выполнение цикла. Если выполняется другой цикл, в режиме
+// OverlapSkip новый пропускается, в режиме OverlapQueue ждет его завершения (если очередь
+// уже занята, пропускается). Возвращает false, если цикл выполнять не нужно
+func (g *cycleGuard) acquire(ctx context.Context, mode CycleOverlap) bool {
+	select {
+	case g.slot <- struct{}{}:
+		g.started.Add(1)
+		return true
+	default:
+	}
+
+	if mode != OverlapQueue || !g.waiting.CompareAndSwap(false, true) {
+		g.skipped.Add(1)
+		logger.Warn("Previous fetch cycle is still running, skipping this one")
+		return false
+	}
+	defer g.waiting.Store(false)
+
+	g.queued.Add(1)
+	logger.Info("Previous fetch cycle is still running, the next one will start after it")
+
+	select {
+	case g.slot <- struct{}{}:
+		g.started.Add(1)
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// release освобождает право на выполнение цикла
+func (g *cycleGuard) release() {
+	<-g.slot
+}
+
+// running сообщает, выполняется ли сейчас цикл
+func (g *cycleGuard) running() bool {
+	return len(g.slot) > 0
+}
+
+// record добавляет к счетчикам статьи, сохраненные циклом
+func (g *cycleGuard) record(report *domain.CycleReport) {
+	g.inserted.Add(int64(report.NewArticles()))
+	g.duplicates.Add(int64(report.Duplicates()))
+	g.updated.Add(int64(report.Updated()))
+}
+
+// stats возвращает текущие значения счетчиков
+func (g *cycleGuard) stats() CycleStats {
+	return CycleStats{
+		Started:    g.started.Load(),
+		Skipped:    g.skipped.Load(),
+		Queued:     g.queued.Load(),
+		Inserted:   g.inserted.Load(),
+		Duplicates: g.duplicates.Load(),
+		Updated:    g.updated.Load(),
+	}
+}
+
+// writeCycleStats сохраняет счетчики циклов, чтобы их видели другие процессы (rsshub health)
+func (a *Aggregator) writeCycleStats(ctx context.Context) {
+	data, err := json.Marshal(a.cycles.stats())
+	if err != nil {
+		return
+	}
+	if err := a.db.SetAggregatorSetting(ctx, cycleStatsKey, string(data)); err != nil {
+		logger.Warn("Failed to write cycle stats: %v", err)
+	}
+}
+
+// ReadCycleStats возвращает последние сохраненные счетчики циклов (nil - нет данных)
+func ReadCycleStats(ctx context.Context, db port.FeedArticleRepository) *CycleStats {
+	value, err := db.GetAggregatorSetting(ctx, cycleStatsKey)
+	if err != nil {
+		return nil
+	}
+
+	stats := &CycleStats{}
+	if err := json.Unmarshal([]byte(value), stats); err != nil {
+		return nil
+	}
+	return stats
+}
@@ -0,0 +1,260 @@
+// internal/core/service/pipeline.go
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/logger"
+)
+
+// Stage этап конвейера обработки элементов ленты
+type Stage string
+
+const (
+	StageNormalize Stage = "normalize" // Приведение элементов к единому виду
+	StageFilter    Stage = "filter"    // Отбор элементов, которые нужно обработать
+	StageEnrich    Stage = "enrich"    // Дополнение элементов (язык и т.п.)
+	StageScore     Stage = "score"     // Оценка элементов
+	StagePersist   Stage = "persist"   // Сохранение статей
+	StageNotify    Stage = "notify"    // Уведомления о новых статьях
+)
+
+// stages этапы конвейера в порядке выполнения
+var stages = []Stage{StageNormalize, StageFilter, StageEnrich, StageScore, StagePersist, StageNotify}
+
+// ParseStage разбирает название этапа конвейера
+func ParseStage(s string) (Stage, error) {
+	stage := Stage(strings.ToLower(strings.TrimSpace(s)))
+	for _, known := range stages {
+		if stage == known {
+			return stage, nil
+		}
+	}
+	return "", fmt.Errorf("unknown pipeline stage %q (expected normalize, filter, enrich, score, persist or notify)", s)
+}
+
+// IngestSource откуда получены элементы, проходящие через конвейер
+type IngestSource string
+
+const (
+	SourceFetch    IngestSource = "fetch"    // Получены циклом агрегатора или rsshub refresh
+	SourcePush     IngestSource = "push"     // Доставлены WebSub хабом
+	SourceBackfill IngestSource = "backfill" // Импортированы из архива ленты
+)
+
+// IngestItem элемент ленты на конвейере
+type IngestItem struct {
+	domain.ParsedRSSItem
+	Language string          // Язык элемента, определенный на этапе enrich ("" - не определен)
+	Score    float64         // Оценка элемента на этапе score; передается в уведомления
+	Article  *domain.Article // Добавленная статья; nil - элемент не сохранен как новая статья
+}
+
+// IngestBatch элементы одной ленты, проходящие через конвейер. Шаги могут менять Items,
+// в том числе убирать из них элементы (см. Filter)
+type IngestBatch struct {
+	Feed   *domain.Feed
+	Source IngestSource
+	Items  []*IngestItem
+	Saved  *domain.SaveReport // Итог сохранения; заполняется на этапе persist
+
+	cache  *domain.CacheValidators // Валидаторы кеша ответа основного URL (nil - не менять)
+	maxNew int                     // Сколько новых статей можно добавить (0 - без ограничения)
+	cycle  *cycle                  // Цикл, в котором уведомления отправляются общим списком
+}
+
+// newIngestBatch создает партию из разобранных элементов ленты
+func newIngestBatch(feed *domain.Feed, source IngestSource, items []domain.ParsedRSSItem) *IngestBatch {
+	batch := &IngestBatch{Feed: feed, Source: source, Items: make([]*IngestItem, 0, len(items)), Saved: &domain.SaveReport{}}
+	for _, item := range items {
+		batch.Items = append(batch.Items, &IngestItem{ParsedRSSItem: item})
+	}
+	return batch
+}
+
+// Filter оставляет элементы, для которых keep возвращает true
+func (b *IngestBatch) Filter(keep func(item *IngestItem) bool) {
+	kept := b.Items[:0]
+	for _, item := range b.Items {
+		if keep(item) {
+			kept = append(kept, item)
+		}
+	}
+	b.Items = kept
+}
+
+// Entries возвращает добавленные статьи партии с данными ленты для уведомлений
+func (b *IngestBatch) Entries() []*domain.DigestEntry {
+	var entries []*domain.DigestEntry
+	for _, item := range b.Items {
+		if item.Article != nil {
+			entries = append(entries, &domain.DigestEntry{Article: item.Article, FeedName: b.Feed.Name,
+				FeedTags: b.Feed.Tags, FeedFolder: b.Feed.Folder, Score: item.Score})
+		}
+	}
+	return entries
+}
+
+// StepFunc шаг этапа конвейера
+type StepFunc func(ctx context.Context, batch *IngestBatch) error
+
+// pipelineStep зарегистрированный шаг
+type pipelineStep struct {
+	name string
+	run  StepFunc
+}
+
+// Pipeline конвейер обработки элементов лент: этапы выполняются в порядке stages, шаги этапа -
+// в порядке регистрации. Ошибка шага этапа persist прерывает обработку ленты, ошибки шагов
+// остальных этапов только пишутся в лог
+type Pipeline struct {
+	mu    sync.RWMutex
+	steps map[Stage][]pipelineStep
+}
+
+// NewPipeline создает пустой конвейер
+func NewPipeline() *Pipeline {
+	return &Pipeline{steps: make(map[Stage][]pipelineStep)}
+}
+
+// Register добавляет шаг в конец этапа. Имя шага уникально во всем конвейере
+func (p *Pipeline) Register(stage Stage, name string, run StepFunc) error {
+	if _, err := ParseStage(string(stage)); err != nil {
+		return err
+	}
+	if name == "" || run == nil {
+		return fmt.Errorf("pipeline step must have a name and a function")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, steps := range p.steps {
+		for _, step := range steps {
+			if step.name == name {
+				return fmt.Errorf("pipeline step %s is already registered", name)
+			}
+		}
+	}
+	p.steps[stage] = append(p.steps[stage], pipelineStep{name: name, run: run})
+	return nil
+}
+
+// Remove убирает шаг по имени; возвращает false, если такого шага нет
+func (p *Pipeline) Remove(name string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for stage, steps := range p.steps {
+		for i, step := range steps {
+			if step.name == name {
+				p.steps[stage] = append(steps[:i:i], steps[i+1:]...)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// String описывает конвейер: этапы с именами их шагов
+func (p *Pipeline) String() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	parts := make([]string, 0, len(stages))
+	for _, stage := range stages {
+		names := make([]string, 0, len(p.steps[stage]))
+		for _, step := range p.steps[stage] {
+			names = append(names, step.name)
+		}
+		parts = append(parts, fmt.Sprintf("%s [%s]", stage, strings.Join(names, ", ")))
+	}
+	return strings.Join(parts, " -> ")
+}
+
+// run проводит партию через все этапы конвейера. Шаги, зарегистрированные во время обработки,
+// применяются к следующим партиям
+func (p *Pipeline) run(ctx context.Context, batch *IngestBatch) error {
+	p.mu.RLock()
+	steps := make(map[Stage][]pipelineStep, len(p.steps))
+	for stage, registered := range p.steps {
+		steps[stage] = append([]pipelineStep(nil), registered...)
+	}
+	p.mu.RUnlock()
+
+	for _, stage := range stages {
+		for _, step := range steps[stage] {
+			err := step.run(ctx, batch)
+			if err == nil {
+				continue
+			}
+			if stage == StagePersist {
+				return err
+			}
+			logger.Warn("Pipeline step %s/%s failed for feed %s: %v", stage, step.name, batch.Feed.Name, err)
+		}
+	}
+	return nil
+}
+
+// newAggregatorPipeline создает конвейер со встроенными шагами агрегатора: правила
+// фильтров, ограничение количества элементов, определение языка, сохранение статей и уведомления
+func (a *Aggregator) newAggregatorPipeline() *Pipeline {
+	p := NewPipeline()
+	p.steps[StageFilter] = []pipelineStep{{name: "filter-rules", run: a.filterRulesStep}, {name: "max-items", run: a.limitItemsStep}}
+	p.steps[StageEnrich] = []pipelineStep{{name: "language", run: detectLanguageStep}}
+	p.steps[StagePersist] = []pipelineStep{{name: "articles", run: a.persistStep}}
+	p.steps[StageNotify] = []pipelineStep{{name: "notifiers", run: a.notifyStep}}
+	return p
+}
+
+// limitItemsStep оставляет не больше maxItemsPerFeed самых новых элементов полученной ленты
+func (a *Aggregator) limitItemsStep(_ context.Context, batch *IngestBatch) error {
+	if batch.Source == SourceFetch {
+		batch.Items = a.limitItems(batch.Feed, batch.Items)
+	}
+	return nil
+}
+
+// detectLanguageStep определяет язык элементов по заголовку и описанию
+func detectLanguageStep(_ context.Context, batch *IngestBatch) error {
+	for _, item := range batch.Items {
+		item.Language = domain.DetectLanguage(item.Title + "\n" + item.Description)
+	}
+	return nil
+}
+
+// persistStep сохраняет новые статьи. Полученная или доставленная лента сохраняется вместе
+// со временем получения в одной транзакции (см. saveFeed); статьи архива - без него
+func (a *Aggregator) persistStep(ctx context.Context, batch *IngestBatch) error {
+	if batch.Source == SourceBackfill {
+		// Статьи, сохраненные до ошибки, остаются в базе и архивируются
+		saved, err := a.saveArticles(ctx, a.db, batch.Feed, batch.Items, batch.maxNew)
+		batch.Saved = saved
+		a.archiveArticles(ctx, batch.Feed, saved.Articles)
+		return err
+	}
+
+	saved, err := a.saveFeed(ctx, batch.Feed, batch.Items, batch.cache)
+	if err != nil {
+		return err
+	}
+	batch.Saved = saved
+	return nil
+}
+
+// notifyStep передает новые статьи получателям уведомлений. Статьи цикла отправляются общим
+// списком после его завершения, о статьях архива не уведомляется
+func (a *Aggregator) notifyStep(ctx context.Context, batch *IngestBatch) error {
+	entries := batch.Entries()
+	switch {
+	case batch.Source == SourceBackfill || len(entries) == 0:
+	case batch.cycle != nil:
+		batch.cycle.notify(entries)
+	default:
+		a.notifyEntries(ctx, entries)
+	}
+	return nil
+}
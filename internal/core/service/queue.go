@@ -0,0 +1,41 @@
+// internal/core/service/queue.go
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/pool"
+)
+
+// QueueFullMode что делать с лентой, которую долго не берет ни один воркер
+type QueueFullMode string
+
+const (
+	QueueFullBlock QueueFullMode = "block" // Ждать свободного воркера сколько потребуется
+	QueueFullSkip  QueueFullMode = "skip"  // Пропустить ленту до следующего цикла, если ее не взяли за интервал
+)
+
+// ParseQueueFullMode разбирает поведение при занятых воркерах
+func ParseQueueFullMode(s string) (QueueFullMode, error) {
+	switch mode := QueueFullMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case QueueFullBlock, QueueFullSkip:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown queue full mode %q (expected block or skip)", s)
+	}
+}
+
+// jobPriority возвращает приоритет задания ленты: пропущенные в прошлом цикле ленты
+// получают высокий приоритет
+func jobPriority(priority domain.FeedPriority, boost bool) pool.Priority {
+	switch {
+	case boost || priority == domain.PriorityHigh:
+		return pool.High
+	case priority == domain.PriorityLow:
+		return pool.Low
+	default:
+		return pool.Normal
+	}
+}
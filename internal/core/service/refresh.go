@@ -0,0 +1,95 @@
+// internal/core/service/refresh.go
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/utils"
+)
+
+// Refresh сразу получает одну ленту, не дожидаясь цикла (rsshub refresh). Без force лента,
+// которую еще рано получать (см. domain.Feed.IsStale), пропускается с Skipped, а запрос
+// условный; force получает ленту в любом случае безусловным запросом. Ленту, которую сейчас
+// обрабатывает воркер или другой экземпляр, обновить нельзя.
+// Запущенный агрегатор обновляет ленту вызовом через сокет управления; если агрегатор не запущен,
+// Refresh загружает его настройки из БД, как RunOnce
+func (a *Aggregator) Refresh(ctx context.Context, feedID utils.UUID, force bool) (*domain.FeedResult, error) {
+	if !a.IsRunning() {
+		return a.refreshOnce(ctx, feedID, force)
+	}
+	return a.refresh(ctx, feedID, force)
+}
+
+// refreshOnce обновляет ленту без запущенного агрегатора, занимая его на время обновления
+func (a *Aggregator) refreshOnce(ctx context.Context, feedID utils.UUID, force bool) (*domain.FeedResult, error) {
+	a.runningMu.Lock()
+	if a.isRunning {
+		a.runningMu.Unlock()
+		return nil, fmt.Errorf("background process is already running")
+	}
+	a.isRunning = true
+	a.runningMu.Unlock()
+
+	defer func() {
+		a.runningMu.Lock()
+		a.isRunning = false
+		a.runningMu.Unlock()
+	}()
+
+	if err := a.LoadSettingsFromDB(ctx); err != nil {
+		logger.Warn("Failed to load settings from database: %v", err)
+	}
+
+	a.ctx, a.cancel = context.WithCancel(ctx)
+	defer a.cancel()
+
+	return a.refresh(a.ctx, feedID, force)
+}
+
+// refresh резервирует ленту и обрабатывает ее в вызывающей горутине
+func (a *Aggregator) refresh(ctx context.Context, feedID utils.UUID, force bool) (*domain.FeedResult, error) {
+	// Ленту читаем заново: у вызывающего могли остаться устаревшие валидаторы и время получения
+	feed, err := a.db.GetFeedByID(ctx, feedID)
+	if err != nil {
+		return nil, err
+	}
+	if !feed.Enabled {
+		return nil, fmt.Errorf("feed %s is disabled (enable it with: rsshub enable --name %s)", feed.Name, feed.Name)
+	}
+
+	a.mu.RLock()
+	interval := a.interval
+	timeout := a.maxJobDuration
+	a.mu.RUnlock()
+
+	// Тот же порог устаревания, что и в цикле агрегатора (см. fetchFeeds)
+	if !force && !feed.IsStale(time.Now(), interval/2) {
+		logger.Info("Feed %s was fetched %v ago, skipping refresh", feed.Name, time.Since(feed.UpdatedAt).Round(time.Second))
+		return &domain.FeedResult{FeedName: feed.Name, Skipped: true}, nil
+	}
+	if err := a.checkBudget(ctx, time.Now()); err != nil {
+		return nil, err
+	}
+
+	claimed, err := a.db.ClaimFeed(ctx, feed.ID, a.instanceID, claimLease)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, fmt.Errorf("feed %s is being fetched right now", feed.Name)
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	saved, bytes, err := a.processFeed(ctx, 0, feed, force, nil)
+	result := newFeedResult(feed, saved, bytes, err)
+	return &result, nil
+}
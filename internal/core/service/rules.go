@@ -0,0 +1,151 @@
+// internal/core/service/rules.go
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/logger"
+)
+
+// ruleQueries кеш разобранных условий правил уведомлений и фильтров: запросов смарт-лент
+// и выражений по их тексту
+type ruleQueries struct {
+	mu      sync.Mutex
+	queries map[string]domain.QueryNode
+}
+
+// parseRule возвращает разобранное условие правила уведомлений
+func (q *ruleQueries) parseRule(rule *domain.NotificationRule) (domain.QueryNode, error) {
+	if rule.Expression != "" {
+		return q.parseExpression(rule.Expression)
+	}
+	return q.parse("query:"+rule.Query, func() (domain.QueryNode, error) { return domain.ParseSmartQuery(rule.Query) })
+}
+
+// parseExpression возвращает разобранное выражение
+func (q *ruleQueries) parseExpression(expr string) (domain.QueryNode, error) {
+	return q.parse("expr:"+expr, func() (domain.QueryNode, error) { return domain.ParseExpression(expr) })
+}
+
+// parse возвращает условие по ключу, разбирая его только при первом обращении
+func (q *ruleQueries) parse(key string, parse func() (domain.QueryNode, error)) (domain.QueryNode, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if node, ok := q.queries[key]; ok {
+		return node, nil
+	}
+	node, err := parse()
+	if err != nil {
+		return nil, err
+	}
+	if q.queries == nil {
+		q.queries = make(map[string]domain.QueryNode)
+	}
+	q.queries[key] = node
+	return node, nil
+}
+
+// routeEntries распределяет статьи по каналам согласно правилам уведомлений. В результате есть
+// только каналы, для которых заданы правила: каждый получает статьи, подходящие хотя бы под одно
+// его правило, в исходном порядке. Каналы без правил получают все статьи
+func (a *Aggregator) routeEntries(ctx context.Context, entries []*domain.DigestEntry) (map[string][]*domain.DigestEntry, error) {
+	rules, err := a.db.GetNotificationRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification rules: %w", err)
+	}
+
+	routed := make(map[string][]*domain.DigestEntry)
+	matched := make(map[string]map[*domain.DigestEntry]bool)
+	for _, rule := range rules {
+		if _, ok := routed[rule.Channel]; !ok {
+			routed[rule.Channel] = nil
+			matched[rule.Channel] = make(map[*domain.DigestEntry]bool)
+		}
+
+		query, err := a.ruleQueries.parseRule(rule)
+		if err != nil {
+			logger.Warn("Skipping notification rule %s: invalid condition: %v", rule.Name, err)
+			continue
+		}
+		for _, entry := range entries {
+			if !matched[rule.Channel][entry] && query.Match(entry) {
+				logger.Debug("Article '%s' of feed %s matched notification rule %s", entry.Article.Title, entry.FeedName, rule.Name)
+				matched[rule.Channel][entry] = true
+			}
+		}
+	}
+
+	for channel := range routed {
+		for _, entry := range entries {
+			if matched[channel][entry] {
+				routed[channel] = append(routed[channel], entry)
+			}
+		}
+	}
+	return routed, nil
+}
+
+// filterRulesStep отбрасывает элементы ленты по правилам фильтров (см. domain.FilterRule).
+// Выражения видят язык элемента, хотя этап enrich еще не выполнен
+func (a *Aggregator) filterRulesStep(ctx context.Context, batch *IngestBatch) error {
+	rules, err := a.db.GetFilterRules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get filter rules: %w", err)
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	type filter struct {
+		rule *domain.FilterRule
+		cond domain.QueryNode
+	}
+	var drops, keeps []filter
+	for _, rule := range rules {
+		cond, err := a.ruleQueries.parseExpression(rule.Expression)
+		if err != nil {
+			logger.Warn("Skipping filter rule %s: invalid expression: %v", rule.Name, err)
+			continue
+		}
+		if rule.Action == domain.FilterKeep {
+			keeps = append(keeps, filter{rule, cond})
+		} else {
+			drops = append(drops, filter{rule, cond})
+		}
+	}
+
+	batch.Filter(func(item *IngestItem) bool {
+		if item.Language == "" {
+			item.Language = domain.DetectLanguage(item.Title + "\n" + item.Description)
+		}
+		entry := itemEntry(batch.Feed, item)
+		for _, f := range drops {
+			if f.cond.Match(entry) {
+				logger.Debug("Dropping item '%s' of feed %s: matched filter rule %s", item.Title, batch.Feed.Name, f.rule.Name)
+				return false
+			}
+		}
+		if len(keeps) == 0 {
+			return true
+		}
+		for _, f := range keeps {
+			if f.cond.Match(entry) {
+				return true
+			}
+		}
+		logger.Debug("Dropping item '%s' of feed %s: matched no keep filter rule", item.Title, batch.Feed.Name)
+		return false
+	})
+	return nil
+}
+
+// itemEntry представляет еще не сохраненный элемент ленты статьей для проверки условий
+func itemEntry(feed *domain.Feed, item *IngestItem) *domain.DigestEntry {
+	article := &domain.Article{Title: item.Title, Link: item.Link, Description: item.Description, PublishedAt: item.PublishedAt,
+		GUID: item.GUID, FeedID: feed.ID, Language: item.Language, Podcast: item.Podcast, Media: item.Media}
+	return &domain.DigestEntry{Article: article, FeedName: feed.Name, FeedTags: feed.Tags, FeedFolder: feed.Folder, Score: item.Score}
+}
@@ -0,0 +1,165 @@
+// internal/core/service/schedule.go
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// QuietHours описывает ежедневные интервалы времени, когда агрегатор не получает ленты
+type QuietHours struct {
+	windows []timeWindow
+}
+
+// timeWindow интервал внутри суток в минутах от полуночи; может переходить через полночь
+type timeWindow struct {
+	start int
+	end   int
+}
+
+// ParseQuietHours разбирает список интервалов вида "01:00-07:00,13:00-13:30".
+// Пустая строка означает отсутствие тихих часов
+func ParseQuietHours(s string) (*QuietHours, error) {
+	q := &QuietHours{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		from, to, ok := strings.Cut(part, "-")
+		if !ok {
+			return nil, fmt.Errorf("invalid quiet hours window %q (expected HH:MM-HH:MM)", part)
+		}
+
+		start, err := parseClock(from)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseClock(to)
+		if err != nil {
+			return nil, err
+		}
+		if start == end {
+			return nil, fmt.Errorf("quiet hours window %q is empty", part)
+		}
+
+		q.windows = append(q.windows, timeWindow{start: start, end: end})
+	}
+	return q, nil
+}
+
+// parseClock разбирает время суток HH:MM в минуты от полуночи
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q (expected HH:MM)", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// IsQuiet проверяет, попадает ли момент времени (в локальной зоне) в тихие часы
+func (q *QuietHours) IsQuiet(now time.Time) bool {
+	if q == nil {
+		return false
+	}
+
+	minute := now.Hour()*60 + now.Minute()
+	for _, w := range q.windows {
+		if w.start < w.end {
+			if minute >= w.start && minute < w.end {
+				return true
+			}
+		} else if minute >= w.start || minute < w.end {
+			// Интервал переходит через полночь, например 23:00-06:00
+			return true
+		}
+	}
+	return false
+}
+
+// String возвращает интервалы в исходном формате
+func (q *QuietHours) String() string {
+	if q == nil || len(q.windows) == 0 {
+		return "none"
+	}
+	parts := make([]string, len(q.windows))
+	for i, w := range q.windows {
+		parts[i] = fmt.Sprintf("%02d:%02d-%02d:%02d", w.start/60, w.start%60, w.end/60, w.end%60)
+	}
+	return strings.Join(parts, ",")
+}
+
+// DigestSchedule описывает расписание отправки дайджеста: ежедневно или еженедельно в заданное время
+type DigestSchedule struct {
+	weekly  bool
+	weekday time.Weekday
+	at      int // Минуты от полуночи
+}
+
+// ParseDigestSchedule разбирает периодичность (daily, weekly), время HH:MM и день недели для weekly
+func ParseDigestSchedule(period, at, weekday string) (*DigestSchedule, error) {
+	s := &DigestSchedule{}
+
+	switch strings.ToLower(strings.TrimSpace(period)) {
+	case "daily":
+	case "weekly":
+		s.weekly = true
+		day, err := parseWeekday(weekday)
+		if err != nil {
+			return nil, err
+		}
+		s.weekday = day
+	default:
+		return nil, fmt.Errorf("invalid digest schedule %q (expected daily or weekly)", period)
+	}
+
+	minutes, err := parseClock(at)
+	if err != nil {
+		return nil, err
+	}
+	s.at = minutes
+
+	return s, nil
+}
+
+// parseWeekday разбирает день недели по английскому имени, например monday или mon
+func parseWeekday(s string) (time.Weekday, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || (len(name) >= 3 && strings.HasPrefix(full, name)) {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday %q", s)
+}
+
+// Next возвращает ближайший момент отправки строго после after (в зоне after)
+func (s *DigestSchedule) Next(after time.Time) time.Time {
+	y, m, d := after.Date()
+	next := time.Date(y, m, d, s.at/60, s.at%60, 0, 0, after.Location())
+
+	for !next.After(after) || (s.weekly && next.Weekday() != s.weekday) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// Period возвращает длительность периода между отправками
+func (s *DigestSchedule) Period() time.Duration {
+	if s.weekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// String возвращает расписание в читаемом виде
+func (s *DigestSchedule) String() string {
+	clock := fmt.Sprintf("%02d:%02d", s.at/60, s.at%60)
+	if s.weekly {
+		return "weekly on " + s.weekday.String() + " at " + clock
+	}
+	return "daily at " + clock
+}
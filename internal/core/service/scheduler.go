@@ -0,0 +1,105 @@
+// internal/core/service/scheduler.go
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/logger"
+)
+
+// Scheduler решает, пора ли получать ленту с расписанием cron. Ленты без расписания
+// получаются в каждом цикле агрегатора. Разобранные расписания кешируются по выражению
+type Scheduler struct {
+	mu        sync.Mutex
+	schedules map[string]*domain.CronSchedule
+}
+
+// NewScheduler создает планировщик
+func NewScheduler() *Scheduler {
+	return &Scheduler{schedules: make(map[string]*domain.CronSchedule)}
+}
+
+// schedule возвращает разобранное расписание ленты (nil - без расписания или оно некорректно)
+func (s *Scheduler) schedule(feed *domain.Feed) *domain.CronSchedule {
+	if feed.Schedule == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if cron, ok := s.schedules[feed.Schedule]; ok {
+		return cron
+	}
+	cron, err := domain.ParseCron(feed.Schedule)
+	if err != nil {
+		// Некорректное расписание не должно останавливать получение ленты
+		logger.Warn("Feed %s has invalid schedule, fetching it every cycle: %v", feed.Name, err)
+	}
+	s.schedules[feed.Schedule] = cron
+	return cron
+}
+
+// NextRun возвращает время запуска ленты по расписанию после after (nil - лента без расписания)
+func (s *Scheduler) NextRun(feed *domain.Feed, after time.Time) *time.Time {
+	cron := s.schedule(feed)
+	if cron == nil {
+		return nil
+	}
+	next := cron.Next(after)
+	return &next
+}
+
+// Due проверяет, пора ли получать ленту. Если время следующего запуска не сохранено
+// (например, расписание задано без него), оно отсчитывается от последнего получения
+func (s *Scheduler) Due(feed *domain.Feed, now time.Time) bool {
+	if s.schedule(feed) == nil {
+		return true
+	}
+	next := feed.NextRunAt
+	if next == nil {
+		next = s.NextRun(feed, feed.UpdatedAt)
+	}
+	return !next.After(now)
+}
+
+// dueFeeds оставляет ленты, которые пора получать; с остальных снимается резервирование,
+// а время их следующего запуска сохраняется, чтобы они не резервировались до срока
+func (a *Aggregator) dueFeeds(ctx context.Context, feeds []*domain.Feed) []*domain.Feed {
+	now := time.Now()
+	due := feeds[:0]
+	for _, feed := range feeds {
+		if a.scheduler.Due(feed, now) {
+			due = append(due, feed)
+			continue
+		}
+
+		next := feed.NextRunAt
+		if next == nil {
+			next = a.scheduler.NextRun(feed, feed.UpdatedAt)
+			a.setNextRun(ctx, feed, next)
+		}
+		a.releaseClaim(ctx, feed)
+		logger.Debug("Feed %s is not due until %s (schedule %q)", feed.Name, next.Format("2006-01-02 15:04"), feed.Schedule)
+	}
+	return due
+}
+
+// scheduleNext сохраняет время следующего запуска ленты с расписанием после ее получения
+func (a *Aggregator) scheduleNext(ctx context.Context, feed *domain.Feed) {
+	if next := a.scheduler.NextRun(feed, time.Now()); next != nil || feed.NextRunAt != nil {
+		a.setNextRun(ctx, feed, next)
+	}
+}
+
+// setNextRun сохраняет время следующего запуска ленты; ошибка только записывается в лог
+func (a *Aggregator) setNextRun(ctx context.Context, feed *domain.Feed, next *time.Time) {
+	if err := a.db.SetFeedNextRun(ctx, feed.ID, next); err != nil {
+		logger.Warn("Failed to save next run of feed %s: %v", feed.Name, err)
+		return
+	}
+	feed.NextRunAt = next
+}
@@ -0,0 +1,122 @@
+// internal/core/service/status.go
+package service
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/pool"
+)
+
+// workerTracker отслеживает по хукам пула, чем заняты воркеры, для rsshub status --live
+type workerTracker struct {
+	mu      sync.Mutex
+	workers map[int]*domain.WorkerActivity
+}
+
+// newWorkerTracker создает пустой трекер воркеров
+func newWorkerTracker() *workerTracker {
+	return &workerTracker{workers: make(map[int]*domain.WorkerActivity)}
+}
+
+// started отмечает запуск воркера
+func (t *workerTracker) started(worker int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.workers[worker] = &domain.WorkerActivity{ID: worker, Since: time.Now()}
+}
+
+// stopped убирает остановленный воркер
+func (t *workerTracker) stopped(worker int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.workers, worker)
+}
+
+// jobStarted отмечает, что воркер взялся за ленту
+func (t *workerTracker) jobStarted(worker int, j *pool.Job) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if w, ok := t.workers[worker]; ok {
+		w.Feed, w.Since, w.TimedOut = j.Name, time.Now(), false
+	}
+}
+
+// jobFinished отмечает результат задания. Задание, отмененное по таймауту, продолжает занимать
+// воркер, пока не вернется (см. jobDiscarded); не начатое задание состояние воркера не меняет
+func (t *workerTracker) jobFinished(worker int, j *pool.Job, elapsed time.Duration, err error) {
+	if errors.Is(err, pool.ErrNotRun) {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	w, ok := t.workers[worker]
+	if !ok {
+		return
+	}
+	w.Jobs++
+	w.LastFeed, w.LastDuration = j.Name, elapsed
+
+	var timeout *pool.TimeoutError
+	if errors.As(err, &timeout) {
+		w.TimedOut = true
+		return
+	}
+	w.Feed, w.Since = "", time.Now()
+}
+
+// jobDiscarded отмечает, что задание, отмененное по таймауту, наконец вернулось и воркер свободен
+func (t *workerTracker) jobDiscarded(worker int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if w, ok := t.workers[worker]; ok {
+		w.Feed, w.Since, w.TimedOut = "", time.Now(), false
+	}
+}
+
+// snapshot возвращает копию состояния воркеров по возрастанию номеров
+func (t *workerTracker) snapshot() []domain.WorkerActivity {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	activity := make([]domain.WorkerActivity, 0, len(t.workers))
+	for _, w := range t.workers {
+		activity = append(activity, *w)
+	}
+	sort.Slice(activity, func(i, j int) bool { return activity[i].ID < activity[j].ID })
+	return activity
+}
+
+// Status возвращает текущее состояние агрегатора: действующие интервал и количество воркеров,
+// занятость воркеров и итоги последнего цикла
+func (a *Aggregator) Status() *domain.AggregatorStatus {
+	a.mu.RLock()
+	status := &domain.AggregatorStatus{
+		Instance:  a.instanceID,
+		StartedAt: a.startedAt,
+		Interval:  a.interval,
+		LastCycle: a.lastCycle,
+	}
+	p, workers := a.pool, a.workers
+	a.mu.RUnlock()
+
+	status.CycleRunning = a.cycles.running()
+	if p != nil {
+		status.Workers = p.Size()
+		status.Queued = p.Queued()
+		status.QueueWait = p.OldestWait()
+	}
+	if workers != nil {
+		status.Activity = workers.snapshot()
+	}
+	for _, w := range status.Activity {
+		if w.Busy() {
+			status.InFlight++
+		}
+	}
+	return status
+}
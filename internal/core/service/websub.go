@@ -0,0 +1,350 @@
+// internal/core/service/websub.go
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+	"sync"
+	"time"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/core/port"
+	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/utils"
+)
+
+// Проверяем на этапе компиляции, что WebSubSubscriber принимает запросы хабов
+var _ port.WebSubReceiver = (*WebSubSubscriber)(nil)
+
+const (
+	// websubSyncInterval как часто проверяются подписки: новые ленты, продление, отключенные
+	websubSyncInterval = 10 * time.Minute
+
+	// websubPendingRetry через сколько повторить подписку, которую хаб так и не подтвердил
+	websubPendingRetry = 30 * time.Minute
+
+	// websubHubRecheck как часто повторно проверять ленты без хаба или с отказом хаба
+	websubHubRecheck = 24 * time.Hour
+)
+
+// Режимы запросов хаба к callback адресу
+const (
+	WebSubModeSubscribe   = "subscribe"
+	WebSubModeUnsubscribe = "unsubscribe"
+	WebSubModeDenied      = "denied"
+)
+
+// WebSubSubscriber оформляет WebSub подписки для лент с хабом, продлевает их
+// и принимает доставленное хабами содержимое лент
+type WebSubSubscriber struct {
+	db          port.FeedArticleRepository
+	parser      port.Parser
+	hub         port.WebSubHub
+	aggregator  port.Aggregator
+	callbackURL string        // Базовый callback адрес; к нему добавляется ID ленты
+	lease       time.Duration // Запрашиваемый срок подписки
+	renewBefore time.Duration // За сколько до окончания подписка продлевается
+
+	mu      sync.Mutex
+	checked map[utils.UUID]time.Time // Когда лента без подписки последний раз проверялась
+}
+
+// NewWebSubSubscriber создает менеджер WebSub подписок. callbackURL - публичный адрес,
+// по которому хабы обращаются к серверу, например https://rss.example.com/websub/
+func NewWebSubSubscriber(db port.FeedArticleRepository, parser port.Parser, hub port.WebSubHub, agg port.Aggregator,
+	callbackURL string, lease, renewBefore time.Duration) *WebSubSubscriber {
+	return &WebSubSubscriber{
+		db:          db,
+		parser:      parser,
+		hub:         hub,
+		aggregator:  agg,
+		callbackURL: strings.TrimSuffix(callbackURL, "/") + "/",
+		lease:       lease,
+		renewBefore: renewBefore,
+		checked:     make(map[utils.UUID]time.Time),
+	}
+}
+
+// CallbackURL возвращает callback адрес ленты
+func (s *WebSubSubscriber) CallbackURL(feedID utils.UUID) string {
+	return s.callbackURL + feedID.String()
+}
+
+// Run синхронизирует подписки сразу и затем периодически до отмены контекста
+func (s *WebSubSubscriber) Run(ctx context.Context) {
+	ticker := time.NewTicker(websubSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Sync(ctx); err != nil {
+			logger.Error("WebSub sync failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync оформляет подписки для новых лент с хабом, продлевает истекающие
+// и отписывается от отключенных лент
+func (s *WebSubSubscriber) Sync(ctx context.Context) error {
+	feeds, err := s.db.GetAllFeeds(ctx, 0)
+	if err != nil {
+		return err
+	}
+
+	subs, err := s.db.GetWebSubSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+	byFeed := make(map[utils.UUID]*domain.WebSubSubscription, len(subs))
+	for _, sub := range subs {
+		byFeed[sub.FeedID] = sub
+	}
+
+	now := time.Now()
+	for _, feed := range feeds {
+		sub := byFeed[feed.ID]
+
+		switch {
+		case !feed.Enabled:
+			if sub != nil {
+				s.unsubscribe(ctx, feed, sub)
+			}
+		case sub == nil:
+			if s.recentlyChecked(feed.ID, now) {
+				continue
+			}
+			s.subscribeNew(ctx, feed)
+		case sub.ExpiresAt == nil:
+			// Хаб еще не подтвердил подписку; повторяем запрос, если подтверждение не пришло
+			if now.Sub(sub.UpdatedAt) >= websubPendingRetry {
+				s.subscribe(ctx, feed, sub)
+			}
+		case sub.ExpiresAt.Sub(now) <= s.renewBefore:
+			s.subscribe(ctx, feed, sub)
+		}
+	}
+
+	return nil
+}
+
+// recentlyChecked проверяет, что ленту без подписки недавно уже проверяли, и если нет -
+// запоминает время проверки
+func (s *WebSubSubscriber) recentlyChecked(feedID utils.UUID, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if last, ok := s.checked[feedID]; ok && now.Sub(last) < websubHubRecheck {
+		return true
+	}
+	s.checked[feedID] = now
+	return false
+}
+
+// subscribeNew находит хаб ленты и оформляет на нем подписку
+func (s *WebSubSubscriber) subscribeNew(ctx context.Context, feed *domain.Feed) {
+	parsed, err := s.parser.FetchAndParse(ctx, feed)
+	if err != nil {
+		logger.Warn("WebSub: failed to discover hub of feed %s: %v", feed.Name, err)
+		return
+	}
+	if parsed.HubURL == "" {
+		logger.Debug("WebSub: feed %s does not advertise a hub", feed.Name)
+		return
+	}
+
+	topic := parsed.SelfURL
+	if topic == "" {
+		topic = feed.URL
+	}
+
+	secret, err := newWebSubSecret()
+	if err != nil {
+		logger.Error("WebSub: failed to generate secret: %v", err)
+		return
+	}
+
+	s.subscribe(ctx, feed, &domain.WebSubSubscription{FeedID: feed.ID, HubURL: parsed.HubURL, Topic: topic, Secret: secret})
+}
+
+// subscribe отправляет хабу запрос на подписку или ее продление. Подписка сохраняется
+// до запроса, так как хаб может прислать подтверждение раньше, чем ответит на запрос
+func (s *WebSubSubscriber) subscribe(ctx context.Context, feed *domain.Feed, sub *domain.WebSubSubscription) {
+	sub.LeaseSeconds = int(s.lease / time.Second)
+	if err := s.db.SaveWebSubSubscription(ctx, sub); err != nil {
+		logger.Error("WebSub: failed to save subscription of feed %s: %v", feed.Name, err)
+		return
+	}
+
+	if err := s.hub.Subscribe(sub, s.CallbackURL(feed.ID)); err != nil {
+		logger.Error("WebSub: subscription of feed %s at %s failed: %v", feed.Name, sub.HubURL, err)
+		return
+	}
+
+	logger.Info("WebSub: requested subscription of feed %s at %s", feed.Name, sub.HubURL)
+}
+
+// unsubscribe удаляет подписку и просит хаб прекратить доставку
+func (s *WebSubSubscriber) unsubscribe(ctx context.Context, feed *domain.Feed, sub *domain.WebSubSubscription) {
+	// Подписка удаляется заранее: подтверждение отписки принимается только для лент без подписки
+	if err := s.db.DeleteWebSubSubscription(ctx, feed.ID); err != nil {
+		logger.Error("WebSub: failed to delete subscription of feed %s: %v", feed.Name, err)
+		return
+	}
+
+	if err := s.hub.Unsubscribe(sub, s.CallbackURL(feed.ID)); err != nil {
+		logger.Warn("WebSub: unsubscribe of feed %s at %s failed: %v", feed.Name, sub.HubURL, err)
+		return
+	}
+
+	logger.Info("WebSub: unsubscribed disabled feed %s", feed.Name)
+}
+
+// VerifyIntent подтверждает запрос хаба. Подписка подтверждается только для сохраненной
+// подписки с той же темой, отписка - только если подписки на ленту больше нет
+func (s *WebSubSubscriber) VerifyIntent(ctx context.Context, feedID utils.UUID, mode, topic string, leaseSeconds int) error {
+	sub, err := s.db.GetWebSubSubscription(ctx, feedID)
+	if err != nil && !errors.Is(err, domain.ErrSubscriptionNotFound) {
+		return err
+	}
+
+	switch mode {
+	case WebSubModeSubscribe:
+		if sub == nil {
+			return err
+		}
+		if sub.Topic != topic {
+			return fmt.Errorf("topic mismatch for feed %s: %s", feedID, topic)
+		}
+
+		if leaseSeconds <= 0 {
+			leaseSeconds = int(s.lease / time.Second)
+		}
+		expiresAt := time.Now().Add(time.Duration(leaseSeconds) * time.Second)
+		sub.LeaseSeconds = leaseSeconds
+		sub.ExpiresAt = &expiresAt
+		if err := s.db.SaveWebSubSubscription(ctx, sub); err != nil {
+			return err
+		}
+
+		logger.Success("WebSub: subscription to %s verified until %s", topic, expiresAt.Format(time.RFC3339))
+		return nil
+
+	case WebSubModeUnsubscribe:
+		if sub != nil && sub.Topic == topic {
+			return fmt.Errorf("unsubscribe from %s was not requested", topic)
+		}
+		logger.Info("WebSub: unsubscribe from %s verified", topic)
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported hub.mode: %s", mode)
+	}
+}
+
+// Deny удаляет подписку, в которой хаб отказал; повторная попытка будет не раньше websubHubRecheck
+func (s *WebSubSubscriber) Deny(ctx context.Context, feedID utils.UUID, topic, reason string) error {
+	sub, err := s.db.GetWebSubSubscription(ctx, feedID)
+	if err != nil {
+		return err
+	}
+	if sub.Topic != topic {
+		return fmt.Errorf("topic mismatch for feed %s: %s", feedID, topic)
+	}
+
+	if err := s.db.DeleteWebSubSubscription(ctx, feedID); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.checked[feedID] = time.Now()
+	s.mu.Unlock()
+
+	logger.Warn("WebSub: hub denied subscription to %s: %s", topic, reason)
+	return nil
+}
+
+// Deliver проверяет подпись доставленного хабом содержимого и сохраняет новые статьи ленты
+func (s *WebSubSubscriber) Deliver(ctx context.Context, feedID utils.UUID, body []byte, signature string) (int, error) {
+	sub, err := s.db.GetWebSubSubscription(ctx, feedID)
+	if err != nil {
+		return 0, err
+	}
+
+	if !validWebSubSignature(sub.Secret, body, signature) {
+		return 0, fmt.Errorf("%w: feed %s", domain.ErrInvalidSignature, feedID)
+	}
+
+	feed, err := s.db.GetFeedByID(ctx, feedID)
+	if err != nil {
+		return 0, err
+	}
+	if !feed.Enabled {
+		logger.Info("WebSub: ignoring content of disabled feed %s", feed.Name)
+		return 0, nil
+	}
+
+	parsed, err := s.parser.Parse(bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse content of feed %s: %w", feed.Name, err)
+	}
+
+	articles := s.aggregator.Ingest(ctx, feed, parsed)
+	logger.Success("WebSub: received %d items for feed %s: %d new articles", len(parsed.Items), feed.Name, len(articles))
+
+	return len(articles), nil
+}
+
+// validWebSubSignature проверяет заголовок X-Hub-Signature вида "sha256=<hex>"
+func validWebSubSignature(secret string, body []byte, signature string) bool {
+	method, digest, ok := strings.Cut(signature, "=")
+	if !ok {
+		return false
+	}
+
+	var newHash func() hash.Hash
+	switch strings.ToLower(method) {
+	case "sha1":
+		newHash = sha1.New
+	case "sha256":
+		newHash = sha256.New
+	case "sha384":
+		newHash = sha512.New384
+	case "sha512":
+		newHash = sha512.New
+	default:
+		return false
+	}
+
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// newWebSubSecret генерирует случайный секрет подписки
+func newWebSubSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
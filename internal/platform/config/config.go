@@ -2,7 +2,9 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -12,6 +14,40 @@ type Config struct {
 	Database DatabaseConfig
 	// Настройки агрегатора RSS
 	Aggregator AggregatorConfig
+	// Настройки HTTP клиента для получения лент
+	Fetcher FetcherConfig
+	// Настройки email дайджеста
+	Digest DigestConfig
+	// Настройки SMTP сервера для отправки писем
+	SMTP SMTPConfig
+	// Настройки уведомлений в Telegram
+	Telegram TelegramConfig
+	// Настройки уведомлений рабочего стола
+	Desktop DesktopConfig
+	// Настройки уведомлений в Slack
+	Slack SlackConfig
+	// Настройки уведомлений в Discord
+	Discord DiscordConfig
+	// Настройки внешней команды для новых статей
+	Hook HookConfig
+	// Настройки архива статей на диске
+	Archive ArchiveConfig
+	// Настройки S3 совместимого хранилища для rsshub archive
+	S3 S3Config
+	// Сроки хранения данных для rsshub maintenance
+	Retention RetentionConfig
+	// Настройки синтеза речи для аудио-дайджеста
+	TTS TTSConfig
+	// Настройки аудио-дайджеста (rsshub audio-digest)
+	AudioDigest AudioDigestConfig
+	// Настройки HTTP сервера (rsshub serve)
+	Server ServerConfig
+	// Настройки WebSub подписок
+	WebSub WebSubConfig
+	// Ключ шифрования учетных данных лент
+	SecretKey string
+	// Минимальный уровень сообщений лога: debug, info, warn или error
+	LogLevel string
 }
 
 // DatabaseConfig содержит параметры подключения к БД
@@ -27,9 +63,165 @@ type DatabaseConfig struct {
 type AggregatorConfig struct {
 	DefaultInterval time.Duration // Интервал по умолчанию для получения лент
 	DefaultWorkers  int           // Количество воркеров по умолчанию
+	QuietHours      string        // Тихие часы без получения лент, например "01:00-07:00"
+	DedupKey        string        // Ключ дубликатов статей: guid (GUID, иначе ссылка) или link
+	ArticleConflict string        // Уже сохраненная статья, измененная лентой: update (обновить) или skip (оставить)
+	CrossFeedDedup  string        // Статья, ссылка которой уже сохранена в другой ленте: keep (сохранить) или skip (пропустить)
+	MaxItemsPerFeed int           // Максимум элементов ленты, обрабатываемых за цикл (0 - без ограничения)
+	MaxArticles     int           // Максимум хранимых статей одной ленты; лишние старые удаляются после цикла (0 - без ограничения)
+	MaxJobDuration  time.Duration // Максимальное время обработки одной ленты воркером (0 - без ограничения)
+	CycleOverlap    string        // Цикл, начавшийся до завершения предыдущего: skip (пропустить) или queue (выполнить после)
+	QueueFull       string        // Все воркеры заняты: block (ждать воркера) или skip (пропустить ленту, не дождавшуюся воркера за интервал)
+	MirrorAfter     int           // Неудач основного URL ленты подряд, после которых пробуются зеркала (0 - не использовать)
+	IconRefresh     time.Duration // Как часто обновляются иконки лент (0 - не запрашивать иконки)
+	DeadFeedAfter   time.Duration // Сколько лента может непрерывно завершаться ошибкой до отключения (0 - не отключать)
+	BandwidthDaily  string        // Лимит трафика за сутки, например "500MB" (пусто - без ограничения)
+	BandwidthMonth  string        // Лимит трафика за календарный месяц, например "10GB" (пусто - без ограничения)
+	ControlSocket   string        // Unix сокет, через который запущенный fetch отдает состояние для status --live
+	SettingsCheck   time.Duration // Как часто перечитывать set-interval и set-workers без уведомления (0 - только по уведомлениям)
 }
 
-// Load загружает конфигурацию из переменных окружения
+// FetcherConfig содержит настройки HTTP клиента для получения лент
+type FetcherConfig struct {
+	Timeout       time.Duration // Таймаут HTTP запроса
+	HostRateLimit int           // Максимум запросов в минуту к одному хосту (0 - без ограничений)
+	HostRateBurst int           // Допустимый всплеск запросов к одному хосту
+	ProxyURL      string        // Глобальный прокси (http, https, socks5); пусто - из HTTP(S)_PROXY
+	CABundle      string        // Путь к PEM файлу с дополнительными CA сертификатами
+	TLSInsecure   bool          // Отключить проверку TLS сертификатов для всех лент
+	UserAgent     string        // User-Agent по умолчанию для всех запросов
+
+	// Защита от враждебных или сломанных лент (0 - без ограничения)
+	MaxResponseSize int // Максимальный размер распакованного ответа в байтах
+	MaxXMLDepth     int // Максимальная глубина вложенности XML элементов
+	MaxXMLTokens    int // Максимальное количество XML токенов в документе
+
+	// Защита от SSRF: запрет соединений с внутренними сетями по URL из лент
+	BlockPrivateNetworks bool     // Запретить loopback, частные, link-local и служебные адреса
+	AllowedNetworks      []string // CIDR сети или IP, разрешенные несмотря на запрет
+	MaxRedirects         int      // Сколько перенаправлений подряд выполнять; 0 - не следовать ни одному
+
+	// Дополнительные форматы дат публикации (layout Go, например "02.01.2006 15:04"),
+	// которые пробуются после встроенных
+	DateFormats []string
+}
+
+// DigestConfig содержит настройки email дайджеста новых статей
+type DigestConfig struct {
+	Schedule    string // Периодичность: daily или weekly; пусто - дайджест отключен
+	At          string // Время отправки HH:MM (локальное)
+	Weekday     string // День недели для weekly, например monday
+	GroupBy     string // Группировка статей: feed, tag или folder
+	Subject     string // Шаблон темы письма (text/template)
+	Template    string // Путь к шаблону письма; .html - HTML письмо, пусто - встроенный шаблон
+	MaxArticles int    // Максимум статей в одном дайджесте
+}
+
+// SMTPConfig содержит параметры SMTP сервера
+type SMTPConfig struct {
+	Host     string   // Хост SMTP сервера
+	Port     int      // Порт SMTP сервера (STARTTLS используется, если сервер его поддерживает)
+	Username string   // Имя пользователя; пусто - без авторизации
+	Password string   // Пароль
+	From     string   // Адрес отправителя
+	To       []string // Адреса получателей
+}
+
+// TelegramConfig содержит настройки бота для уведомлений о новых статьях
+type TelegramConfig struct {
+	BotToken        string        // Токен бота; пусто - уведомления отключены
+	ChatID          string        // ID чата или @username канала
+	Tags            []string      // Отправлять только статьи лент с этими тегами (пусто - все)
+	Keywords        []string      // Отправлять только статьи с этими словами в заголовке или описании
+	MessageInterval time.Duration // Минимальный интервал между сообщениями (ограничения Telegram)
+	APIURL          string        // Адрес Bot API
+}
+
+// DesktopConfig содержит настройки уведомлений рабочего стола (notify-send, osascript, toast)
+type DesktopConfig struct {
+	Enabled          bool // Показывать новые статьи уведомлениями рабочего стола
+	MaxNotifications int  // Уведомлений за цикл; остальные статьи объединяются в одно
+}
+
+// SlackConfig содержит настройки incoming webhooks Slack для уведомлений о новых статьях
+type SlackConfig struct {
+	WebhookURL      string        // Webhook по умолчанию; пусто и без TagWebhooks - уведомления отключены
+	TagWebhooks     []string      // Маршруты "тег=webhook": статьи лент с тегом уходят в канал этого webhook
+	MessageInterval time.Duration // Минимальный интервал между сообщениями (ограничения Slack)
+}
+
+// DiscordConfig содержит настройки webhook Discord для уведомлений о новых статьях
+type DiscordConfig struct {
+	WebhookURL      string        // Webhook канала; пусто - уведомления отключены
+	MessageInterval time.Duration // Минимальный интервал между сообщениями (ограничения Discord)
+}
+
+// HookConfig настройки внешней команды, которая запускается для новых статей
+type HookConfig struct {
+	Command     string        // Команда оболочки (sh -c), получает статьи в stdin в JSON; пусто - отключена
+	Mode        string        // article - запуск на каждую статью, cycle - один запуск на цикл
+	Timeout     time.Duration // Сколько ждать завершения одного запуска
+	Concurrency int           // Сколько запусков выполняется одновременно
+}
+
+// ArchiveConfig содержит настройки архива, в который каждая новая статья записывается файлом
+type ArchiveConfig struct {
+	Dir    string // Каталог архива; пусто - архив отключен
+	Format string // Формат файлов статей: md или json
+}
+
+// S3Config содержит адрес и учетные данные S3 совместимого хранилища (AWS S3, MinIO)
+type S3Config struct {
+	Endpoint         string // Адрес API хранилища, например https://s3.amazonaws.com или http://minio:9000
+	Region           string // Регион бакета (для MinIO обычно us-east-1)
+	AccessKey        string // Идентификатор ключа доступа
+	SecretKey        string // Секретный ключ доступа
+	PathStyle        bool   // Адресовать бакет в пути (endpoint/bucket), а не в имени хоста (bucket.endpoint)
+	MaxEnclosureSize int    // Максимальный размер загружаемого вложения в байтах
+}
+
+// RetentionConfig содержит сроки хранения данных, которые удаляет rsshub maintenance (0 - хранить всегда)
+type RetentionConfig struct {
+	Articles    time.Duration // Статьи старше этого срока удаляются; избранные не удаляются
+	KeepPerFeed int           // Сколько самых новых статей каждой ленты не удаляется независимо от срока
+	FetchRuns   time.Duration // История циклов получения лент (rsshub runs)
+	Traffic     time.Duration // Суточный учет трафика лент (не меньше месяца для CLI_APP_BANDWIDTH_MONTHLY)
+}
+
+// TTSConfig содержит настройки синтеза речи: внешняя команда или HTTP API, совместимый с OpenAI
+type TTSConfig struct {
+	Backend string // command или openai; пусто - синтез речи не настроен
+	Command string // Команда оболочки (command): текст передается в stdin, MP3 читается из stdout
+	URL     string // Адрес API синтеза речи (openai)
+	APIKey  string // Ключ API (openai)
+	Model   string // Модель синтеза речи (openai)
+	Voice   string // Голос (openai)
+}
+
+// AudioDigestConfig содержит настройки аудио-дайджеста и его ленты подкаста
+type AudioDigestConfig struct {
+	Dir            string // Каталог MP3 файлов и ленты подкаста
+	PublicURL      string // Адрес, по которому каталог опубликован, для ссылок на эпизоды в ленте
+	ArticlesPerTag int    // Сколько непрочитанных статей каждого тега попадает в дайджест
+}
+
+// ServerConfig содержит настройки HTTP сервера
+type ServerConfig struct {
+	Addr      string // Адрес, на котором слушает сервер, например :8080
+	PublicURL string // Публичный адрес сервера, по которому к нему обращаются WebSub хабы
+
+	EventsPoll time.Duration // Как часто поток /events проверяет новые статьи
+
+	GRPCAddr string // Адрес gRPC API, например :9090 (пусто - gRPC отключен)
+}
+
+// WebSubConfig содержит настройки WebSub (PubSubHubbub) подписок
+type WebSubConfig struct {
+	Lease       time.Duration // Запрашиваемый у хаба срок подписки
+	RenewBefore time.Duration // За сколько до окончания подписка продлевается
+}
+
+// Load загружает конфигурацию из переменных окружения (см. LoadFile для файла конфигурации)
 func Load() *Config {
 	return &Config{
 		Database: DatabaseConfig{
@@ -42,7 +234,131 @@ func Load() *Config {
 		Aggregator: AggregatorConfig{
 			DefaultInterval: getEnvDuration("CLI_APP_TIMER_INTERVAL", 3*time.Minute),
 			DefaultWorkers:  getEnvInt("CLI_APP_WORKERS_COUNT", 3),
+			QuietHours:      getEnv("CLI_APP_QUIET_HOURS", ""),
+			DedupKey:        getEnv("CLI_APP_DEDUP_KEY", "guid"),
+			ArticleConflict: getEnv("CLI_APP_ARTICLE_CONFLICT", "update"),
+			CrossFeedDedup:  getEnv("CLI_APP_CROSS_FEED_DEDUP", "keep"),
+			MaxItemsPerFeed: getEnvInt("CLI_APP_MAX_ITEMS_PER_FEED", 100),
+			MaxArticles:     getEnvInt("CLI_APP_MAX_ARTICLES_PER_FEED", 0),
+			MaxJobDuration:  getEnvDuration("CLI_APP_MAX_JOB_DURATION", 5*time.Minute),
+			CycleOverlap:    getEnv("CLI_APP_CYCLE_OVERLAP", "skip"),
+			QueueFull:       getEnv("CLI_APP_QUEUE_FULL", "block"),
+			MirrorAfter:     getEnvInt("CLI_APP_MIRROR_AFTER_FAILURES", 3),
+			IconRefresh:     getEnvDuration("CLI_APP_ICON_REFRESH", 7*24*time.Hour),
+			DeadFeedAfter:   getEnvDuration("CLI_APP_DEAD_FEED_AFTER", 14*24*time.Hour),
+			BandwidthDaily:  getEnv("CLI_APP_BANDWIDTH_DAILY", ""),
+			BandwidthMonth:  getEnv("CLI_APP_BANDWIDTH_MONTHLY", ""),
+			ControlSocket:   getEnv("CLI_APP_CONTROL_SOCKET", filepath.Join(os.TempDir(), "rsshub.sock")),
+			SettingsCheck:   getEnvDuration("CLI_APP_SETTINGS_CHECK_INTERVAL", time.Minute),
+		},
+		Fetcher: FetcherConfig{
+			Timeout:       getEnvDuration("CLI_APP_FETCH_TIMEOUT", 30*time.Second),
+			HostRateLimit: getEnvInt("CLI_APP_HOST_RATE_LIMIT", 0),
+			HostRateBurst: getEnvInt("CLI_APP_HOST_RATE_BURST", 1),
+			ProxyURL:      getEnv("CLI_APP_PROXY_URL", ""),
+			CABundle:      getEnv("CLI_APP_CA_BUNDLE", ""),
+			TLSInsecure:   getEnvBool("CLI_APP_TLS_INSECURE", false),
+			UserAgent:     getEnv("CLI_APP_USER_AGENT", "rsshub/1.0 (+https://github.com/tishmal/RSSHub)"),
+
+			MaxResponseSize: getEnvInt("CLI_APP_MAX_RESPONSE_SIZE", 20<<20),
+			MaxXMLDepth:     getEnvInt("CLI_APP_MAX_XML_DEPTH", 64),
+			MaxXMLTokens:    getEnvInt("CLI_APP_MAX_XML_TOKENS", 2_000_000),
+
+			BlockPrivateNetworks: getEnvBool("CLI_APP_BLOCK_PRIVATE_NETWORKS", true),
+			AllowedNetworks:      getEnvList("CLI_APP_ALLOWED_NETWORKS"),
+			MaxRedirects:         getEnvInt("CLI_APP_MAX_REDIRECTS", 10),
+
+			DateFormats: getEnvSplit("CLI_APP_DATE_FORMATS", ";"),
+		},
+		Digest: DigestConfig{
+			Schedule:    getEnv("CLI_APP_DIGEST_SCHEDULE", ""),
+			At:          getEnv("CLI_APP_DIGEST_TIME", "08:00"),
+			Weekday:     getEnv("CLI_APP_DIGEST_WEEKDAY", "monday"),
+			GroupBy:     getEnv("CLI_APP_DIGEST_GROUP_BY", "feed"),
+			Subject:     getEnv("CLI_APP_DIGEST_SUBJECT", "RSSHub digest: {{.Total}} new articles"),
+			Template:    getEnv("CLI_APP_DIGEST_TEMPLATE", ""),
+			MaxArticles: getEnvInt("CLI_APP_DIGEST_MAX_ARTICLES", 200),
+		},
+		SMTP: SMTPConfig{
+			Host:     getEnv("CLI_APP_SMTP_HOST", ""),
+			Port:     getEnvInt("CLI_APP_SMTP_PORT", 587),
+			Username: getEnv("CLI_APP_SMTP_USERNAME", ""),
+			Password: getEnv("CLI_APP_SMTP_PASSWORD", ""),
+			From:     getEnv("CLI_APP_SMTP_FROM", ""),
+			To:       getEnvList("CLI_APP_SMTP_TO"),
+		},
+		Telegram: TelegramConfig{
+			BotToken:        getEnv("CLI_APP_TELEGRAM_BOT_TOKEN", ""),
+			ChatID:          getEnv("CLI_APP_TELEGRAM_CHAT_ID", ""),
+			Tags:            getEnvList("CLI_APP_TELEGRAM_TAGS"),
+			Keywords:        getEnvList("CLI_APP_TELEGRAM_KEYWORDS"),
+			MessageInterval: getEnvDuration("CLI_APP_TELEGRAM_MESSAGE_INTERVAL", 3*time.Second),
+			APIURL:          getEnv("CLI_APP_TELEGRAM_API_URL", "https://api.telegram.org"),
+		},
+		Desktop: DesktopConfig{
+			Enabled:          getEnvBool("CLI_APP_DESKTOP_NOTIFICATIONS", false),
+			MaxNotifications: getEnvInt("CLI_APP_DESKTOP_MAX_NOTIFICATIONS", 5),
+		},
+		Slack: SlackConfig{
+			WebhookURL:      getEnv("CLI_APP_SLACK_WEBHOOK_URL", ""),
+			TagWebhooks:     getEnvList("CLI_APP_SLACK_TAG_WEBHOOKS"),
+			MessageInterval: getEnvDuration("CLI_APP_SLACK_MESSAGE_INTERVAL", time.Second),
+		},
+		Discord: DiscordConfig{
+			WebhookURL:      getEnv("CLI_APP_DISCORD_WEBHOOK_URL", ""),
+			MessageInterval: getEnvDuration("CLI_APP_DISCORD_MESSAGE_INTERVAL", 500*time.Millisecond),
+		},
+		Hook: HookConfig{
+			Command:     getEnv("CLI_APP_HOOK_COMMAND", ""),
+			Mode:        getEnv("CLI_APP_HOOK_MODE", "article"),
+			Timeout:     getEnvDuration("CLI_APP_HOOK_TIMEOUT", 30*time.Second),
+			Concurrency: getEnvInt("CLI_APP_HOOK_CONCURRENCY", 4),
+		},
+		Archive: ArchiveConfig{
+			Dir:    getEnv("CLI_APP_ARCHIVE_DIR", ""),
+			Format: getEnv("CLI_APP_ARCHIVE_FORMAT", "md"),
+		},
+		S3: S3Config{
+			Endpoint:         getEnv("CLI_APP_S3_ENDPOINT", "https://s3.amazonaws.com"),
+			Region:           getEnv("CLI_APP_S3_REGION", "us-east-1"),
+			AccessKey:        getEnv("CLI_APP_S3_ACCESS_KEY", ""),
+			SecretKey:        getEnv("CLI_APP_S3_SECRET_KEY", ""),
+			PathStyle:        getEnvBool("CLI_APP_S3_PATH_STYLE", false),
+			MaxEnclosureSize: getEnvInt("CLI_APP_S3_MAX_ENCLOSURE_SIZE", 500<<20),
+		},
+		Retention: RetentionConfig{
+			Articles:    getEnvDuration("CLI_APP_ARTICLE_RETENTION", 0),
+			KeepPerFeed: getEnvInt("CLI_APP_RETENTION_KEEP_PER_FEED", 100),
+			FetchRuns:   getEnvDuration("CLI_APP_FETCH_RUNS_RETENTION", 90*24*time.Hour),
+			Traffic:     getEnvDuration("CLI_APP_TRAFFIC_RETENTION", 365*24*time.Hour),
+		},
+		TTS: TTSConfig{
+			Backend: getEnv("CLI_APP_TTS_BACKEND", ""),
+			Command: getEnv("CLI_APP_TTS_COMMAND", ""),
+			URL:     getEnv("CLI_APP_TTS_URL", "https://api.openai.com/v1/audio/speech"),
+			APIKey:  getEnv("CLI_APP_TTS_API_KEY", ""),
+			Model:   getEnv("CLI_APP_TTS_MODEL", "tts-1"),
+			Voice:   getEnv("CLI_APP_TTS_VOICE", "alloy"),
+		},
+		AudioDigest: AudioDigestConfig{
+			Dir:            getEnv("CLI_APP_AUDIO_DIGEST_DIR", "audio-digest"),
+			PublicURL:      getEnv("CLI_APP_AUDIO_DIGEST_URL", ""),
+			ArticlesPerTag: getEnvInt("CLI_APP_AUDIO_DIGEST_ARTICLES", 5),
 		},
+		Server: ServerConfig{
+			Addr:      getEnv("CLI_APP_SERVER_ADDR", ":8080"),
+			PublicURL: getEnv("CLI_APP_PUBLIC_URL", ""),
+
+			EventsPoll: getEnvDuration("CLI_APP_EVENTS_POLL_INTERVAL", 2*time.Second),
+
+			GRPCAddr: getEnv("CLI_APP_GRPC_ADDR", ""),
+		},
+		WebSub: WebSubConfig{
+			Lease:       getEnvDuration("CLI_APP_WEBSUB_LEASE", 10*24*time.Hour),
+			RenewBefore: getEnvDuration("CLI_APP_WEBSUB_RENEW_BEFORE", 24*time.Hour),
+		},
+		SecretKey: getEnv("CLI_APP_SECRET_KEY", ""),
+		LogLevel:  getEnv("CLI_APP_LOG_LEVEL", "debug"),
 	}
 }
 
@@ -64,6 +380,32 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvBool получает логическое значение переменной окружения
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			return boolVal
+		}
+	}
+	return defaultValue
+}
+
+// getEnvList получает список значений, разделенных запятыми
+func getEnvList(key string) []string {
+	return getEnvSplit(key, ",")
+}
+
+// getEnvSplit получает список значений, разделенных sep (для значений, которые сами содержат запятые)
+func getEnvSplit(key, sep string) []string {
+	var result []string
+	for _, item := range strings.Split(os.Getenv(key), sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
 // getEnvDuration получает значение времени из переменной окружения
 func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
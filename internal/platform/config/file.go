@@ -0,0 +1,103 @@
+// internal/platform/config/file.go
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// CONFIG_FILE_ENV переменная окружения с путем к файлу конфигурации в формате .env
+const CONFIG_FILE_ENV = "CLI_APP_CONFIG_FILE"
+
+var (
+	fileMu sync.Mutex
+	// fileEnv значения окружения до применения файла для ключей, заданных файлом
+	// (nil - переменная не была задана); нужны, чтобы вернуть ключ, удаленный из файла
+	fileEnv = make(map[string]*string)
+)
+
+// FilePath возвращает путь к файлу конфигурации (пусто - файл не используется)
+func FilePath() string {
+	return os.Getenv(CONFIG_FILE_ENV)
+}
+
+// LoadFile применяет к окружению процесса переменные из файла в формате .env (KEY=VALUE,
+// строки с # - комментарии). Значения файла имеют приоритет над окружением, поэтому файл
+// можно изменить и перечитать без перезапуска; ключ, удаленный из файла, получает прежнее
+// значение окружения. Пустой path ничего не делает
+func LoadFile(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	values, err := readEnvFile(path)
+	if err != nil {
+		return err
+	}
+
+	fileMu.Lock()
+	defer fileMu.Unlock()
+
+	for key, original := range fileEnv {
+		if _, ok := values[key]; ok {
+			continue
+		}
+		if original == nil {
+			os.Unsetenv(key)
+		} else {
+			os.Setenv(key, *original)
+		}
+		delete(fileEnv, key)
+	}
+
+	for key, value := range values {
+		if _, ok := fileEnv[key]; !ok {
+			if original, set := os.LookupEnv(key); set {
+				fileEnv[key] = &original
+			} else {
+				fileEnv[key] = nil
+			}
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("failed to set %s from %s: %w", key, path, err)
+		}
+	}
+	return nil
+}
+
+// readEnvFile читает пары KEY=VALUE; значения в кавычках используются без кавычек
+func readEnvFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer file.Close()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(strings.TrimPrefix(text, "export "), "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", path, line)
+		}
+
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return values, nil
+}
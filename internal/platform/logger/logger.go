@@ -2,8 +2,11 @@ package logger
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -12,33 +15,116 @@ type Logger struct {
 	*log.Logger
 }
 
+// Level минимальный уровень выводимых сообщений
+type Level int32
+
+const (
+	LevelDebug Level = iota // Все сообщения
+	LevelInfo               // Без отладочных сообщений
+	LevelWarn               // Только предупреждения и ошибки
+	LevelError              // Только ошибки
+)
+
 var defaultLogger *Logger
 
+// minLevel текущий уровень; меняется на лету при перезагрузке конфигурации
+var minLevel atomic.Int32
+
+// color выделять ли уровни сообщений цветом
+var color atomic.Bool
+
+// levelColors коды ANSI цвета уровней сообщений
+var levelColors = map[string]string{
+	"DEBUG":   "\033[2m",
+	"INFO":    "\033[36m",
+	"SUCCESS": "\033[32m",
+	"WARN":    "\033[33m",
+	"ERROR":   "\033[31m",
+	"FATAL":   "\033[1;31m",
+}
+
 func init() {
-	// Инициализируем логгер по умолчанию
+	// Инициализируем логгер по умолчанию. Логи пишутся в stderr, чтобы не смешиваться
+	// с выводом команд (например, export-articles в stdout)
 	defaultLogger = &Logger{
-		Logger: log.New(os.Stdout, "", 0), // Без стандартных флагов, добавим свои
+		Logger: log.New(os.Stderr, "", 0), // Без стандартных флагов, добавим свои
+	}
+	color.Store(ColorTerminal(os.Stderr))
+}
+
+// ColorTerminal проверяет, можно ли выводить в f цветной текст: f - терминал (а не файл
+// или канал), переменная NO_COLOR не задана и TERM не равна dumb
+func ColorTerminal(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// SetColor включает или отключает цветные уровни сообщений (по умолчанию включены,
+// если stderr - терминал)
+func SetColor(enabled bool) {
+	color.Store(enabled)
+}
+
+// SetOutput перенаправляет вывод логгера (например, в io.Discard для --quiet)
+func SetOutput(w io.Writer) {
+	defaultLogger.SetOutput(w)
+}
+
+// ParseLevel разбирает уровень логирования: debug, info, warn или error
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return LevelDebug, fmt.Errorf("unknown log level %q (expected debug, info, warn or error)", s)
 	}
 }
 
+// SetLevel задает минимальный уровень выводимых сообщений
+func SetLevel(level Level) {
+	minLevel.Store(int32(level))
+}
+
+// enabled проверяет, выводятся ли сообщения уровня level
+func enabled(level Level) bool {
+	return int32(level) >= minLevel.Load()
+}
+
 // Info выводит информационное сообщение
 func Info(msg string, args ...interface{}) {
-	defaultLogger.logWithLevel("INFO", msg, args...)
+	if enabled(LevelInfo) {
+		defaultLogger.logWithLevel("INFO", msg, args...)
+	}
 }
 
 // Error выводит сообщение об ошибке
 func Error(msg string, args ...interface{}) {
-	defaultLogger.logWithLevel("ERROR", msg, args...)
+	if enabled(LevelError) {
+		defaultLogger.logWithLevel("ERROR", msg, args...)
+	}
 }
 
 // Debug выводит отладочное сообщение
 func Debug(msg string, args ...interface{}) {
-	defaultLogger.logWithLevel("DEBUG", msg, args...)
+	if enabled(LevelDebug) {
+		defaultLogger.logWithLevel("DEBUG", msg, args...)
+	}
 }
 
 // Warn выводит предупреждение
 func Warn(msg string, args ...interface{}) {
-	defaultLogger.logWithLevel("WARN", msg, args...)
+	if enabled(LevelWarn) {
+		defaultLogger.logWithLevel("WARN", msg, args...)
+	}
 }
 
 // logWithLevel форматирует и выводит сообщение с уровнем логирования
@@ -51,6 +137,10 @@ func (l *Logger) logWithLevel(level, msg string, args ...interface{}) {
 		msg = fmt.Sprintf(msg, args...)
 	}
 
+	if code, ok := levelColors[level]; ok && color.Load() {
+		level = code + level + "\033[0m"
+	}
+
 	// Выводим сообщение в формате: [ВРЕМЯ] УРОВЕНЬ: сообщение
 	l.Logger.Printf("[%s] %s: %s", timestamp, level, msg)
 }
@@ -61,7 +151,9 @@ func Fatal(msg string, args ...interface{}) {
 	os.Exit(1)
 }
 
-// Success выводит сообщение об успешном выполнении операции
+// Success выводит сообщение об успешном выполнении операции (уровень info)
 func Success(msg string, args ...interface{}) {
-	defaultLogger.logWithLevel("SUCCESS", msg, args...)
+	if enabled(LevelInfo) {
+		defaultLogger.logWithLevel("SUCCESS", msg, args...)
+	}
 }
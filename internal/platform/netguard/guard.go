@@ -0,0 +1,153 @@
+// internal/platform/netguard/guard.go
+package netguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress возвращается при попытке соединиться с запрещенным адресом
+var ErrForbiddenAddress = errors.New("address is not allowed")
+
+// blockedPrefixes внутренние, служебные и зарезервированные сети, к которым нельзя
+// обращаться по URL из лент: иначе экземпляр с несколькими пользователями можно
+// использовать для сканирования внутренней сети
+var blockedPrefixes = mustParsePrefixes(
+	"0.0.0.0/8",      // "Эта" сеть
+	"10.0.0.0/8",     // Частная сеть
+	"100.64.0.0/10",  // CGNAT
+	"127.0.0.0/8",    // Loopback
+	"169.254.0.0/16", // Link-local (в том числе метаданные облаков 169.254.169.254)
+	"172.16.0.0/12",  // Частная сеть
+	"192.0.0.0/24",   // Служебные назначения IETF
+	"192.168.0.0/16", // Частная сеть
+	"198.18.0.0/15",  // Тестирование производительности
+	"224.0.0.0/4",    // Multicast
+	"240.0.0.0/4",    // Зарезервировано (включая broadcast)
+	"::/128",         // Неопределенный адрес
+	"::1/128",        // Loopback
+	"64:ff9b:1::/48", // Локальная трансляция NAT64 (позиция IPv4 адреса зависит от сети)
+	"fc00::/7",       // Unique local
+	"fe80::/10",      // Link-local
+	"ff00::/8",       // Multicast
+)
+
+// nat64Prefix общеизвестная сеть NAT64: последние 32 бита адреса - IPv4 адрес, с которым
+// соединяется транслятор (64:ff9b::a9fe:a9fe ведет на 169.254.169.254)
+var nat64Prefix = netip.MustParsePrefix("64:ff9b::/96")
+
+// Guard проверяет адреса исходящих соединений. Проверка выполняется при установке
+// соединения, уже после разрешения DNS, поэтому ее нельзя обойти через DNS rebinding
+// или перенаправление на внутренний адрес. Запросы через прокси проверяются по имени
+// цели, см. SecureTransport
+type Guard struct {
+	blockPrivate bool
+	allowed      []netip.Prefix
+}
+
+// New создает проверку адресов. blockPrivate включает запрет внутренних сетей;
+// allowed - CIDR сети (или отдельные IP), которые разрешены несмотря на запрет
+func New(blockPrivate bool, allowed []string) (*Guard, error) {
+	g := &Guard{blockPrivate: blockPrivate}
+
+	for _, entry := range allowed {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(entry)
+			if addrErr != nil {
+				return nil, fmt.Errorf("invalid allowed network %q: expected CIDR or IP address", entry)
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		g.allowed = append(g.allowed, prefix.Masked())
+	}
+
+	return g, nil
+}
+
+// Check проверяет, что с адресом можно соединяться
+func (g *Guard) Check(addr netip.Addr) error {
+	if g == nil || !g.blockPrivate {
+		return nil
+	}
+
+	addr = addr.Unmap()
+	if g.isAllowed(addr) {
+		return nil
+	}
+
+	// Адрес NAT64 проверяется по встроенному IPv4 адресу
+	target := addr
+	if nat64Prefix.Contains(addr) {
+		b := addr.As16()
+		target = netip.AddrFrom4([4]byte(b[12:]))
+		if g.isAllowed(target) {
+			return nil
+		}
+	}
+
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(target) {
+			if target != addr {
+				return fmt.Errorf("%w: %s (NAT64 for %s) is in internal network %s", ErrForbiddenAddress, addr, target, prefix)
+			}
+			return fmt.Errorf("%w: %s is in internal network %s", ErrForbiddenAddress, addr, prefix)
+		}
+	}
+	return nil
+}
+
+// isAllowed сообщает, входит ли адрес в разрешенные сети
+func (g *Guard) isAllowed(addr netip.Addr) bool {
+	for _, prefix := range g.allowed {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Control подходит для net.Dialer.Control; address - уже разрешенный "ip:port"
+func (g *Guard) Control(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: unexpected address %s", ErrForbiddenAddress, address)
+	}
+	return g.Check(addr)
+}
+
+// DialContext устанавливает TCP соединение только с разрешенными адресами.
+// Подходит для http.Transport.DialContext
+func (g *Guard) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   g.Control,
+	}
+	return dialer.DialContext(ctx, network, address)
+}
+
+// mustParsePrefixes разбирает список сетей, заданный в коде
+func mustParsePrefixes(prefixes ...string) []netip.Prefix {
+	result := make([]netip.Prefix, 0, len(prefixes))
+	for _, p := range prefixes {
+		result = append(result, netip.MustParsePrefix(p))
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+// internal/platform/netguard/guard_test.go
+package netguard_test
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+
+	"rsshub/internal/platform/netguard"
+)
+
+func TestCheck(t *testing.T) {
+	guard, err := netguard.New(true, []string{"10.1.2.0/24", "192.168.7.7"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		addr    string
+		blocked bool
+	}{
+		{"public ipv4", "93.184.216.34", false},
+		{"loopback", "127.0.0.1", true},
+		{"private network", "10.0.0.1", true},
+		{"cloud metadata", "169.254.169.254", true},
+		{"cgnat", "100.64.0.1", true},
+		{"public ipv6", "2606:2800:220:1:248:1893:25c8:1946", false},
+		{"ipv6 loopback", "::1", true},
+		{"unique local ipv6", "fd00::1", true},
+
+		{"ipv4-mapped loopback", "::ffff:127.0.0.1", true},
+		{"ipv4-mapped public", "::ffff:93.184.216.34", false},
+		{"nat64 metadata", "64:ff9b::a9fe:a9fe", true},
+		{"nat64 loopback", "64:ff9b::7f00:1", true},
+		{"nat64 public", "64:ff9b::5db8:d822", false},
+		{"local nat64", "64:ff9b:1::5db8:d822", true},
+
+		{"allowed network", "10.1.2.3", false},
+		{"allowed address", "192.168.7.7", false},
+		{"allowed network through nat64", "64:ff9b::a01:203", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := guard.Check(netip.MustParseAddr(tt.addr))
+			if got := errors.Is(err, netguard.ErrForbiddenAddress); got != tt.blocked {
+				t.Errorf("Check(%s) = %v, want blocked %v", tt.addr, err, tt.blocked)
+			}
+		})
+	}
+}
+
+func TestCheckDisabled(t *testing.T) {
+	guard, err := netguard.New(false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := guard.Check(netip.MustParseAddr("64:ff9b::a9fe:a9fe")); err != nil {
+		t.Errorf("Check with blocking disabled = %v, want nil", err)
+	}
+}
@@ -0,0 +1,112 @@
+// internal/platform/netguard/transport.go
+package netguard
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// proxyPorts порты прокси по умолчанию: с ними http.Transport соединяется с прокси без порта
+var proxyPorts = map[string]string{
+	"http":    "80",
+	"https":   "443",
+	"socks5":  "1080",
+	"socks5h": "1080",
+}
+
+// CheckHost разрешает имя хоста и проверяет все его адреса. Нужна там, где соединение
+// с хостом устанавливает не этот процесс (запрос через прокси)
+func (g *Guard) CheckHost(ctx context.Context, host string) error {
+	if g == nil || !g.blockPrivate {
+		return nil
+	}
+
+	host = strings.TrimSuffix(strings.Trim(host, "[]"), ".")
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return g.Check(addr)
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if err := g.Check(addr); err != nil {
+			return fmt.Errorf("%s: %w", host, err)
+		}
+	}
+	return nil
+}
+
+// SecureTransport настраивает прокси и соединения транспорта. proxy nil - прокси из
+// HTTP(S)_PROXY. Адрес прокси задан администратором и пропускается без проверки, даже если
+// он локальный (socks5://127.0.0.1:1080); остальные соединения проверяются Control.
+// За прокси соединение с целью устанавливает прокси, поэтому цель запроса проверяется
+// перед каждым запросом (и каждым редиректом) по адресам, в которые разрешается ее имя
+func (g *Guard) SecureTransport(t *http.Transport, proxy *url.URL) {
+	trusted := make(map[string]bool)
+	next := http.ProxyFromEnvironment
+	if proxy != nil {
+		next = http.ProxyURL(proxy)
+		trusted[proxyAddr(proxy)] = true
+	} else {
+		// http.ProxyFromEnvironment тоже читает переменные окружения один раз
+		env := httpproxy.FromEnvironment()
+		for _, raw := range []string{env.HTTPProxy, env.HTTPSProxy} {
+			if u := parseEnvProxy(raw); u != nil {
+				trusted[proxyAddr(u)] = true
+			}
+		}
+	}
+
+	t.Proxy = func(req *http.Request) (*url.URL, error) {
+		proxyURL, err := next(req)
+		if err != nil || proxyURL == nil {
+			return proxyURL, err
+		}
+		if err := g.CheckHost(req.Context(), req.URL.Hostname()); err != nil {
+			return nil, err
+		}
+		return proxyURL, nil
+	}
+
+	proxyDialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	t.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		if trusted[address] {
+			return proxyDialer.DialContext(ctx, network, address)
+		}
+		return g.DialContext(ctx, network, address)
+	}
+}
+
+// proxyAddr адрес "host:port", с которым http.Transport соединяется для прокси
+func proxyAddr(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		port = proxyPorts[u.Scheme]
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// parseEnvProxy разбирает прокси из переменной окружения так же, как net/http:
+// адрес без схемы означает HTTP прокси
+func parseEnvProxy(raw string) *url.URL {
+	if raw == "" {
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || proxyPorts[u.Scheme] == "" {
+		if u, err = url.Parse("http://" + raw); err != nil {
+			return nil
+		}
+	}
+	return u
+}
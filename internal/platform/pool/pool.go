@@ -0,0 +1,424 @@
+// internal/platform/pool/pool.go
+
+// Package pool реализует пул воркеров с общей очередью заданий по приоритетам, из которой
+// свободные воркеры сами забирают задания, изменением размера на лету, сроками ожидания
+// и выполнения задания, перехватом паник и хуками для инструментирования
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// Priority приоритет задания; задания с более высоким приоритетом выдаются воркерам первыми
+type Priority int
+
+const (
+	High Priority = iota
+	Normal
+	Low
+
+	numPriorities = 3
+)
+
+// ErrNotRun передается в Job.Done, если задание не выполнялось: пул остановлен
+// (контекст пула отменен) до того, как воркер взялся за задание
+var ErrNotRun = errors.New("job was not run: pool is stopped")
+
+// ErrExpired передается в Job.Done, если ни один воркер не взялся за задание за Job.MaxWait
+var ErrExpired = errors.New("job was not run: no worker was free in time")
+
+// TimeoutError передается в Job.Done, если задание не уложилось в срок
+type TimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("job timed out after %v", e.Timeout)
+}
+
+// PanicError передается в Job.Done, если задание завершилось паникой
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("job panicked: %v", e.Value)
+}
+
+// Job задание пула
+type Job struct {
+	Name     string // Имя для хуков и логов
+	Priority Priority
+	Run      func(ctx context.Context, worker int) error
+
+	// MaxWait сколько задание может ждать свободного воркера в очереди (0 - без ограничения)
+	MaxWait time.Duration
+
+	// Done вызывается ровно один раз: с результатом Run, ErrNotRun, ErrExpired, *TimeoutError
+	// или *PanicError. После таймаута результат Run отбрасывается
+	Done func(err error)
+
+	enqueued time.Time     // Когда задание попало в очередь
+	waited   time.Duration // Сколько задание ждало воркера
+	expiry   *time.Timer   // Снимает задание с очереди по истечении MaxWait
+}
+
+// Waited возвращает, сколько задание ждало в очереди, пока его не взял воркер или
+// не истек MaxWait. Доступно в Run, Done и хуках
+func (j *Job) Waited() time.Duration {
+	return j.waited
+}
+
+// Hooks необязательные обработчики событий пула. Вызываются синхронно из воркеров,
+// поэтому должны быть быстрыми
+type Hooks struct {
+	OnWorkerStart func(worker int)
+	OnWorkerStop  func(worker int)
+	OnJobStart    func(worker int, job *Job) // job.Waited() - время ожидания в очереди
+	OnJobFinish   func(worker int, job *Job, elapsed time.Duration, err error)
+	// OnJobDiscard вызывается, когда задание, отмененное по таймауту, все же завершилось
+	OnJobDiscard func(worker int, job *Job, err error)
+}
+
+// Options параметры пула
+type Options struct {
+	Workers   int           // Количество воркеров (не меньше 1)
+	QueueSize int           // Сколько заданий может ждать в очереди (0 - без ограничения)
+	Timeout   time.Duration // Срок выполнения задания (0 - без ограничения)
+	Hooks     Hooks
+}
+
+// Pool пул воркеров. Задания ждут в общей очереди, а каждый освободившийся воркер забирает
+// из нее самое старое задание самого высокого приоритета, поэтому задания не привязаны
+// к занятым воркерам. Заполненная очередь не отбрасывает задания: Submit ждет, пока воркер
+// заберет одно из них
+type Pool struct {
+	ctx   context.Context
+	hooks Hooks
+	wg    sync.WaitGroup
+
+	queueMu   sync.Mutex
+	jobs      *sync.Cond // Воркерам: появилось задание, пул закрыт или воркер остановлен
+	space     *sync.Cond // Отправителям: в очереди освободилось место или пул закрыт
+	closed    bool
+	lanes     [numPriorities][]*Job // Задания по приоритетам в порядке отправки
+	queueSize int
+
+	mu      sync.Mutex
+	timeout time.Duration
+	workers []chan struct{} // Каналы остановки воркеров; номер воркера - индекс + 1
+}
+
+// New создает пул и запускает воркеров. Отмена ctx отменяет выполняемые задания,
+// а еще не начатые завершаются с ErrNotRun
+func New(ctx context.Context, opts Options) *Pool {
+	p := &Pool{ctx: ctx, hooks: opts.Hooks, timeout: opts.Timeout, queueSize: opts.QueueSize}
+	p.jobs = sync.NewCond(&p.queueMu)
+	p.space = sync.NewCond(&p.queueMu)
+
+	p.mu.Lock()
+	p.grow(max(opts.Workers, 1))
+	p.mu.Unlock()
+	return p
+}
+
+// Size возвращает текущее количество воркеров
+func (p *Pool) Size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.workers)
+}
+
+// Queued возвращает количество заданий, ожидающих свободного воркера
+func (p *Pool) Queued() int {
+	p.queueMu.Lock()
+	defer p.queueMu.Unlock()
+	return p.queued()
+}
+
+// OldestWait возвращает, сколько ждет самое старое задание в очереди (0 - очередь пуста)
+func (p *Pool) OldestWait() time.Duration {
+	p.queueMu.Lock()
+	defer p.queueMu.Unlock()
+
+	var oldest time.Duration
+	for _, lane := range p.lanes {
+		if len(lane) > 0 {
+			oldest = max(oldest, time.Since(lane[0].enqueued))
+		}
+	}
+	return oldest
+}
+
+// Resize меняет количество воркеров. Лишние воркеры завершают текущее задание и
+// останавливаются, не беря новых
+func (p *Pool) Resize(n int) error {
+	if n <= 0 {
+		return fmt.Errorf("workers count must be positive")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if n > len(p.workers) {
+		p.grow(n)
+		return nil
+	}
+
+	// Будим ожидающих воркеров под блокировкой очереди, чтобы остановка не потерялась
+	p.queueMu.Lock()
+	for _, stop := range p.workers[n:] {
+		close(stop)
+	}
+	p.jobs.Broadcast()
+	p.queueMu.Unlock()
+	p.workers = p.workers[:n]
+	return nil
+}
+
+// grow запускает воркеров, пока их не станет n. Вызывается под p.mu
+func (p *Pool) grow(n int) {
+	for len(p.workers) < n {
+		stop := make(chan struct{})
+		p.workers = append(p.workers, stop)
+		p.wg.Add(1)
+		go p.worker(len(p.workers), stop)
+	}
+}
+
+// SetTimeout меняет срок выполнения для заданий, которые начнутся после вызова
+func (p *Pool) SetTimeout(timeout time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.timeout = timeout
+}
+
+// Submit добавляет задание в очередь, ожидая свободного места, если очередь заполнена.
+// Возвращает false, если пул закрыт или ctx отменен
+func (p *Pool) Submit(ctx context.Context, job *Job) bool {
+	p.queueMu.Lock()
+	defer p.queueMu.Unlock()
+
+	if p.queueSize > 0 {
+		// Cond нельзя ждать вместе с ctx, поэтому отмена ctx будит отправителей
+		stop := context.AfterFunc(ctx, func() {
+			p.queueMu.Lock()
+			p.space.Broadcast()
+			p.queueMu.Unlock()
+		})
+		defer stop()
+
+		for !p.closed && ctx.Err() == nil && p.queued() >= p.queueSize {
+			p.space.Wait()
+		}
+	}
+	if p.closed || ctx.Err() != nil {
+		return false
+	}
+
+	p.push(job)
+	return true
+}
+
+// Close прекращает прием заданий. Воркеры выдают оставшиеся в очереди задания и останавливаются
+func (p *Pool) Close() {
+	p.queueMu.Lock()
+	defer p.queueMu.Unlock()
+
+	p.closed = true
+	p.jobs.Broadcast()
+	p.space.Broadcast()
+}
+
+// Wait ожидает остановки всех воркеров (после Close или уменьшения размера)
+func (p *Pool) Wait() {
+	p.wg.Wait()
+}
+
+// priority возвращает приоритет задания; неизвестный приоритет считается обычным
+func priority(job *Job) Priority {
+	if job.Priority < High || job.Priority > Low {
+		return Normal
+	}
+	return job.Priority
+}
+
+// queued возвращает количество заданий в очереди. Вызывается под p.queueMu
+func (p *Pool) queued() int {
+	n := 0
+	for _, lane := range p.lanes {
+		n += len(lane)
+	}
+	return n
+}
+
+// push ставит задание в очередь и будит одного свободного воркера. Вызывается под p.queueMu
+func (p *Pool) push(job *Job) {
+	job.enqueued = time.Now()
+	if job.MaxWait > 0 {
+		job.expiry = time.AfterFunc(job.MaxWait, func() { p.expire(job) })
+	}
+	lane := priority(job)
+	p.lanes[lane] = append(p.lanes[lane], job)
+	p.jobs.Signal()
+}
+
+// pop забирает самое старое задание самого высокого приоритета (nil - очередь пуста).
+// Вызывается под p.queueMu
+func (p *Pool) pop() *Job {
+	for i, lane := range p.lanes {
+		if len(lane) == 0 {
+			continue
+		}
+		job := lane[0]
+		lane[0] = nil
+		p.lanes[i] = lane[1:]
+		p.taken(job)
+		return job
+	}
+	return nil
+}
+
+// remove снимает задание с очереди; возвращает false, если его там уже нет. Вызывается под p.queueMu
+func (p *Pool) remove(job *Job) bool {
+	lane := priority(job)
+	for i, queued := range p.lanes[lane] {
+		if queued == job {
+			p.lanes[lane] = append(p.lanes[lane][:i:i], p.lanes[lane][i+1:]...)
+			p.taken(job)
+			return true
+		}
+	}
+	return false
+}
+
+// taken отмечает, что задание покинуло очередь. Вызывается под p.queueMu
+func (p *Pool) taken(job *Job) {
+	job.waited = time.Since(job.enqueued)
+	if job.expiry != nil {
+		job.expiry.Stop()
+	}
+	// Отправитель, которого разбудили, мог уже уйти из-за отмены своего ctx, поэтому будим всех
+	p.space.Broadcast()
+}
+
+// expire завершает задание с ErrExpired, если за MaxWait его не взял ни один воркер
+func (p *Pool) expire(job *Job) {
+	p.queueMu.Lock()
+	expired := p.remove(job)
+	p.queueMu.Unlock()
+
+	if expired {
+		job.Done(ErrExpired)
+	}
+}
+
+// worker выполняет задания, пока пул не закрыт и воркер не остановлен
+func (p *Pool) worker(id int, stop <-chan struct{}) {
+	defer p.wg.Done()
+
+	if p.hooks.OnWorkerStart != nil {
+		p.hooks.OnWorkerStart(id)
+	}
+	defer func() {
+		if p.hooks.OnWorkerStop != nil {
+			p.hooks.OnWorkerStop(id)
+		}
+	}()
+
+	for {
+		job, ok := p.next(stop)
+		if !ok {
+			return
+		}
+		p.run(id, job)
+	}
+}
+
+// next ждет и забирает следующее задание из очереди. Возвращает false, если воркер
+// остановлен или пул закрыт и очередь пуста
+func (p *Pool) next(stop <-chan struct{}) (*Job, bool) {
+	p.queueMu.Lock()
+	defer p.queueMu.Unlock()
+
+	for {
+		// Остановленный воркер не берет новых заданий, даже если они есть
+		select {
+		case <-stop:
+			return nil, false
+		default:
+		}
+
+		if job := p.pop(); job != nil {
+			return job, true
+		}
+		if p.closed {
+			return nil, false
+		}
+		p.jobs.Wait()
+	}
+}
+
+// run выполняет задание со сроком и перехватом паники и сообщает результат в Job.Done
+func (p *Pool) run(id int, job *Job) {
+	started := time.Now()
+
+	var once sync.Once
+	finish := func(err error) bool {
+		first := false
+		once.Do(func() {
+			first = true
+			if p.hooks.OnJobFinish != nil {
+				p.hooks.OnJobFinish(id, job, time.Since(started), err)
+			}
+			job.Done(err)
+		})
+		return first
+	}
+
+	// После остановки пула оставшиеся задания не выполняются
+	if p.ctx.Err() != nil {
+		finish(ErrNotRun)
+		return
+	}
+
+	if p.hooks.OnJobStart != nil {
+		p.hooks.OnJobStart(id, job)
+	}
+
+	ctx, cancel := context.WithCancel(p.ctx)
+	defer cancel()
+
+	p.mu.Lock()
+	timeout := p.timeout
+	p.mu.Unlock()
+
+	if timeout > 0 {
+		timer := time.AfterFunc(timeout, func() {
+			cancel()
+			finish(&TimeoutError{Timeout: timeout})
+		})
+		defer timer.Stop()
+	}
+
+	err := safeRun(ctx, id, job)
+	if !finish(err) && p.hooks.OnJobDiscard != nil {
+		p.hooks.OnJobDiscard(id, job, err)
+	}
+}
+
+// safeRun выполняет задание, превращая панику в *PanicError
+func safeRun(ctx context.Context, id int, job *Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return job.Run(ctx, id)
+}
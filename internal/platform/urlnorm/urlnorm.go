@@ -0,0 +1,139 @@
+// Package urlnorm приводит URL лент и статей к каноническому виду, чтобы разные записи
+// одного адреса (регистр хоста, порт по умолчанию, порядок параметров, метки аналитики)
+// считались одним адресом
+package urlnorm
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// trackingParams параметры запроса, которые добавляют системы аналитики, рекламы и рассылок.
+// На содержимое страницы они не влияют, поэтому удаляются
+var trackingParams = map[string]bool{
+	"fbclid":      true,
+	"gclid":       true,
+	"dclid":       true,
+	"gbraid":      true,
+	"wbraid":      true,
+	"msclkid":     true,
+	"yclid":       true,
+	"igshid":      true,
+	"mc_cid":      true,
+	"mc_eid":      true,
+	"_hsenc":      true,
+	"_hsmi":       true,
+	"mkt_tok":     true,
+	"oly_anon_id": true,
+	"oly_enc_id":  true,
+	"vero_id":     true,
+	"_ga":         true,
+	"ref_src":     true,
+}
+
+// trackingPrefixes префиксы имен параметров аналитики (utm_source, utm_medium...)
+var trackingPrefixes = []string{"utm_"}
+
+// Normalize возвращает канонический вид HTTP(S) URL: схема и хост в нижнем регистре, без порта
+// по умолчанию и завершающей точки хоста, пустой путь заменен на "/", параметры аналитики удалены,
+// остальные параметры отсортированы по имени (значения и их кодирование не меняются).
+// Фрагмент сохраняется: у разных статей одной страницы ссылки могут отличаться только
+// якорем (#v1.2). Адреса, которые не являются HTTP(S) URL (например, сокращения reddit:r/golang),
+// возвращаются без пробелов по краям и в остальном без изменений
+func Normalize(raw string) string {
+	u, ok := parse(raw)
+	if !ok {
+		return strings.TrimSpace(raw)
+	}
+	return u.String()
+}
+
+// Key возвращает ключ сравнения URL: Normalize без схемы (http и https не различаются),
+// префикса www., фрагмента и завершающего слеша пути. Ключ не является адресом, по нему
+// только сравнивают, например, URL лент
+func Key(raw string) string {
+	u, ok := parse(raw)
+	if !ok {
+		return strings.TrimSpace(raw)
+	}
+
+	key := strings.TrimPrefix(u.Host, "www.") + strings.TrimRight(u.EscapedPath(), "/")
+	if u.RawQuery != "" {
+		key += "?" + u.RawQuery
+	}
+	return key
+}
+
+// parse разбирает HTTP(S) URL и приводит его к каноническому виду
+func parse(raw string) (*url.URL, bool) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return nil, false
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return nil, false
+	}
+	u.Scheme = scheme
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" {
+		return nil, false
+	}
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]" // IPv6
+	}
+	if port := u.Port(); port != "" && !(scheme == "http" && port == "80") && !(scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+	u.Host = host
+
+	if u.Path == "" {
+		u.Path, u.RawPath = "/", ""
+	}
+	u.RawQuery = cleanQuery(u.RawQuery)
+	u.ForceQuery = false
+	return u, true
+}
+
+// cleanQuery удаляет из строки запроса пустые параметры и параметры аналитики и сортирует
+// остальные по имени. Параметры не декодируются, чтобы не менять их кодирование
+func cleanQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+
+	var params []string
+	for _, param := range strings.Split(rawQuery, "&") {
+		if param == "" || isTracking(paramName(param)) {
+			continue
+		}
+		params = append(params, param)
+	}
+	sort.SliceStable(params, func(i, j int) bool { return paramName(params[i]) < paramName(params[j]) })
+	return strings.Join(params, "&")
+}
+
+// paramName возвращает имя параметра запроса в нижнем регистре
+func paramName(param string) string {
+	name, _, _ := strings.Cut(param, "=")
+	if unescaped, err := url.QueryUnescape(name); err == nil {
+		name = unescaped
+	}
+	return strings.ToLower(name)
+}
+
+// isTracking проверяет, что параметр добавлен системой аналитики
+func isTracking(name string) bool {
+	if trackingParams[name] {
+		return true
+	}
+	for _, prefix := range trackingPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
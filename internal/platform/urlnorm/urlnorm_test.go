@@ -0,0 +1,133 @@
+// internal/platform/urlnorm/urlnorm_test.go
+package urlnorm_test
+
+import (
+	"testing"
+
+	"rsshub/internal/core/domain"
+	"rsshub/internal/platform/urlnorm"
+	"rsshub/internal/platform/utils"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"host case folding", "HTTPS://Example.COM/Feed", "https://example.com/Feed"},
+		{"trailing dot in host", "https://example.com./feed", "https://example.com/feed"},
+		{"empty path", "https://example.com", "https://example.com/"},
+		{"surrounding spaces", "  https://example.com/feed  ", "https://example.com/feed"},
+
+		{"default http port", "http://example.com:80/feed", "http://example.com/feed"},
+		{"default https port", "https://example.com:443/feed", "https://example.com/feed"},
+		{"https port on http", "http://example.com:443/feed", "http://example.com:443/feed"},
+		{"custom port", "https://example.com:8443/feed", "https://example.com:8443/feed"},
+
+		{"query sorted by name", "https://example.com/?b=2&a=1&c=3", "https://example.com/?a=1&b=2&c=3"},
+		{"repeated params keep order", "https://example.com/?tag=z&a=1&tag=y", "https://example.com/?a=1&tag=z&tag=y"},
+		{"values not reencoded", "https://example.com/?q=a%20b&p=x+y", "https://example.com/?p=x+y&q=a%20b"},
+		{"empty params dropped", "https://example.com/?&a=1&&", "https://example.com/?a=1"},
+		{"bare question mark", "https://example.com/feed?", "https://example.com/feed"},
+
+		{"utm params", "https://example.com/post?utm_source=rss&utm_medium=feed&id=7", "https://example.com/post?id=7"},
+		{"click ids", "https://example.com/post?fbclid=abc&gclid=def&id=7", "https://example.com/post?id=7"},
+		{"tracking name case", "https://example.com/post?UTM_Source=rss&FBCLID=1", "https://example.com/post"},
+		{"newsletter params", "https://example.com/post?mc_cid=1&mc_eid=2&_hsenc=3", "https://example.com/post"},
+		{"similar names kept", "https://example.com/post?utm=1&ref=2", "https://example.com/post?ref=2&utm=1"},
+
+		{"fragment kept", "https://example.com/changelog#v1.2", "https://example.com/changelog#v1.2"},
+
+		{"ipv6 host", "http://[2001:DB8::1]/feed", "http://[2001:db8::1]/feed"},
+		{"ipv6 default port", "https://[2001:db8::1]:443/feed", "https://[2001:db8::1]/feed"},
+		{"ipv6 custom port", "http://[::1]:8080/feed", "http://[::1]:8080/feed"},
+
+		{"shorthand", " reddit:r/golang ", "reddit:r/golang"},
+		{"ftp scheme", "ftp://Example.com/feed.xml", "ftp://Example.com/feed.xml"},
+		{"mailto", "mailto:news@example.com", "mailto:news@example.com"},
+		{"relative path", "/feed.xml", "/feed.xml"},
+		{"missing host", "https:///feed", "https:///feed"},
+		{"invalid escape", "https://example.com/%zz", "https://example.com/%zz"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := urlnorm.Normalize(tt.raw); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"scheme ignored", "http://example.com/feed", "example.com/feed"},
+		{"www ignored", "https://www.Example.com/feed", "example.com/feed"},
+		{"trailing slash ignored", "https://example.com/feed/", "example.com/feed"},
+		{"root path", "https://example.com", "example.com"},
+		{"fragment ignored", "https://example.com/feed#top", "example.com/feed"},
+		{"query kept sorted", "https://example.com/feed?b=2&a=1&utm_source=x", "example.com/feed?a=1&b=2"},
+		{"port kept", "https://example.com:8443/feed", "example.com:8443/feed"},
+		{"default port dropped", "https://example.com:443/feed", "example.com/feed"},
+		{"ipv6 host", "https://[2001:DB8::1]/feed/", "[2001:db8::1]/feed"},
+		{"not a URL", " reddit:r/golang ", "reddit:r/golang"},
+		{"unsupported scheme", "gopher://example.com/feed", "gopher://example.com/feed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := urlnorm.Key(tt.raw); got != tt.want {
+				t.Errorf("Key(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+
+	// Одна и та же лента, записанная по-разному, дает один ключ
+	same := []string{
+		"http://example.com/feed",
+		"https://www.example.com/feed/",
+		"HTTPS://EXAMPLE.COM:443/feed?utm_source=newsletter#latest",
+	}
+	for _, raw := range same[1:] {
+		if urlnorm.Key(raw) != urlnorm.Key(same[0]) {
+			t.Errorf("Key(%q) = %q, want the same key as %q (%q)", raw, urlnorm.Key(raw), same[0], urlnorm.Key(same[0]))
+		}
+	}
+}
+
+func TestArticleDedupKey(t *testing.T) {
+	feedID, err := utils.ParseUUID("0b7e4b1e-6f0e-4c3a-9d1c-3f1e2a4b5c6d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	link := "https://Example.com:443/post?utm_source=rss&id=7"
+
+	tests := []struct {
+		name string
+		mode domain.DedupMode
+		guid string
+		link string
+		want string
+	}{
+		{"guid item", domain.DedupByGUID, "urn:post:7", link, "guid:" + feedID.String() + ":urn:post:7"},
+		{"guid not normalized", domain.DedupByGUID, "HTTPS://Example.com/?utm_source=x", link, "guid:" + feedID.String() + ":HTTPS://Example.com/?utm_source=x"},
+		{"link item", domain.DedupByGUID, "", link, "link:" + feedID.String() + ":https://example.com/post?id=7"},
+		{"link mode ignores guid", domain.DedupByLink, "urn:post:7", link, "link:" + feedID.String() + ":https://example.com/post?id=7"},
+		{"link fragment kept", domain.DedupByLink, "", "https://example.com/changelog#v1.2", "link:" + feedID.String() + ":https://example.com/changelog#v1.2"},
+		{"link not a URL", domain.DedupByLink, "", " tag:example.com,2024:7 ", "link:" + feedID.String() + ":tag:example.com,2024:7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := domain.ArticleDedupKey(tt.mode, feedID, tt.guid, tt.link); got != tt.want {
+				t.Errorf("ArticleDedupKey(%v, %q, %q) = %q, want %q", tt.mode, tt.guid, tt.link, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// internal/platform/utils/text.go
+package utils
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	// htmlTag тег разметки в описании статьи
+	htmlTag = regexp.MustCompile(`(?s)<[^>]*>`)
+
+	// spaces последовательность пробельных символов
+	spaces = regexp.MustCompile(`\s+`)
+)
+
+// StripHTML превращает HTML описание статьи в простой текст: теги заменяются пробелами,
+// сущности раскрываются, пробельные символы схлопываются в один пробел
+func StripHTML(s string) string {
+	text := html.UnescapeString(htmlTag.ReplaceAllString(s, " "))
+	return strings.TrimSpace(spaces.ReplaceAllString(text, " "))
+}
+
+// Truncate обрезает строку до max символов; обрезанная строка заканчивается многоточием
+func Truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	if max <= 0 {
+		return ""
+	}
+	return string(runes[:max-1]) + "…"
+}
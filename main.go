@@ -1,23 +1,61 @@
 package main
 
 import (
+	"fmt"
+	"io"
 	"os"
+	_ "time/tzdata" // База часовых поясов для --tz: в образе alpine ее нет
 
 	"rsshub/internal/adapter/cli"
 	httpfetcher "rsshub/internal/adapter/fetcher/http"
 	"rsshub/internal/adapter/storage"
 	"rsshub/internal/platform/config"
 	"rsshub/internal/platform/logger"
+	"rsshub/internal/platform/secret"
 )
 
 func main() {
-	// 1. Load configuration
+	os.Exit(run())
+}
+
+// run выполняет команду и возвращает код выхода (см. cli.ExitCode)
+func run() int {
+	// 0. Global flags (--quiet, --json-errors, --tz, --no-color, --config)
+	opts, args := cli.ParseOptions(os.Args)
+	if opts.Quiet {
+		logger.SetOutput(io.Discard)
+	}
+	if opts.NoColor {
+		logger.SetColor(false)
+	}
+
+	// 1. Load configuration (файл CLI_APP_CONFIG_FILE дополняет окружение). --config задает
+	// файл через ту же переменную, чтобы SIGHUP перечитывал именно его
+	if opts.ConfigFile != "" {
+		os.Setenv(config.CONFIG_FILE_ENV, opts.ConfigFile)
+	}
+	if err := config.LoadFile(config.FilePath()); err != nil {
+		logger.Error("Failed to load config file: %v", err)
+		return cli.ExitFailure
+	}
 	cfg := config.Load()
 
 	// 2. Connect to DB
-	db, err := storage.New(cfg.Database.GetDSN())
+	cipher, err := secret.NewCipher(cfg.SecretKey)
+	if err != nil {
+		logger.Error("Failed to initialize secret cipher: %v", err)
+		return cli.ExitFailure
+	}
+
+	// health только читает состояние: не применяет миграции и сообщает о недоступной БД в отчете
+	health := len(args) > 1 && args[1] == "health"
+
+	db, err := storage.New(cfg.Database.GetDSN(), cipher)
 	if err != nil {
-		logger.Fatal("Failed to connect to database: %v", err)
+		if health {
+			return cli.ReportDatabaseDown(err)
+		}
+		return cli.ReportError(cli.DatabaseUnavailable(err), opts)
 	}
 	defer func() {
 		if err := db.Close(); err != nil {
@@ -26,18 +64,27 @@ func main() {
 	}()
 
 	// 3. Run migrations
-	if err := db.RunMigrations(); err != nil {
-		logger.Fatal("Failed to run migrations: %v", err)
+	if !health {
+		if err := db.RunMigrations(); err != nil {
+			return cli.ReportError(fmt.Errorf("failed to run migrations: %w", err), opts)
+		}
 	}
 
-	parser := httpfetcher.NewParser()
+	parser, err := httpfetcher.NewParser(&cfg.Fetcher)
+	if err != nil {
+		logger.Error("Failed to create RSS parser: %v", err)
+		return cli.ExitFailure
+	}
 
 	// 4. Build CLI (composition root: inject repository + config)
 	cliApp := cli.New(db, parser, cfg)
+	if err := cliApp.SetTimezone(opts.Timezone); err != nil {
+		return cli.ReportError(err, opts)
+	}
+	if opts.NoColor {
+		cliApp.DisableColor()
+	}
 
 	// 5. Run CLI
-	if err := cliApp.Run(os.Args); err != nil {
-		logger.Error("Command failed: %v", err)
-		os.Exit(1)
-	}
+	return cli.ReportError(cliApp.Run(args), opts)
 }
@@ -1,2 +1,2 @@
 -- Откат создания таблицы feeds
-DROP TABLE IF EXISTS feeds;
\ No newline at end of file
+DROP TABLE IF EXISTS feeds;
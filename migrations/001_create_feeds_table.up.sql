@@ -2,7 +2,7 @@
 -- UUID расширение для генерации уникальных идентификаторов
 CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 
-CREATE TABLE feeds (
+CREATE TABLE IF NOT EXISTS feeds (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
@@ -11,7 +11,7 @@ CREATE TABLE feeds (
 );
 
 -- Индекс для быстрого поиска по имени
-CREATE INDEX idx_feeds_name ON feeds(name);
+CREATE INDEX IF NOT EXISTS idx_feeds_name ON feeds(name);
 
 -- Индекс для сортировки по времени обновления (для выбора устаревших лент)
-CREATE INDEX idx_feeds_updated_at ON feeds(updated_at);
\ No newline at end of file
+CREATE INDEX IF NOT EXISTS idx_feeds_updated_at ON feeds(updated_at);
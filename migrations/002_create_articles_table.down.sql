@@ -1,2 +1,2 @@
 -- Откат создания таблицы articles
-DROP TABLE IF EXISTS articles;
\ No newline at end of file
+DROP TABLE IF EXISTS articles;
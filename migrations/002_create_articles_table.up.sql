@@ -1,5 +1,5 @@
 -- Создание таблицы для статей
-CREATE TABLE articles (
+CREATE TABLE IF NOT EXISTS articles (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
@@ -17,10 +17,10 @@ CREATE TABLE articles (
 );
 
 -- Индекс для быстрого поиска статей по ленте
-CREATE INDEX idx_articles_feed_id ON articles(feed_id);
+CREATE INDEX IF NOT EXISTS idx_articles_feed_id ON articles(feed_id);
 
 -- Индекс для сортировки по дате публикации
-CREATE INDEX idx_articles_published_at ON articles(published_at DESC);
+CREATE INDEX IF NOT EXISTS idx_articles_published_at ON articles(published_at DESC);
 
 -- Композитный индекс для выборки последних статей конкретной ленты
-CREATE INDEX idx_articles_feed_published ON articles(feed_id, published_at DESC);
\ No newline at end of file
+CREATE INDEX IF NOT EXISTS idx_articles_feed_published ON articles(feed_id, published_at DESC);
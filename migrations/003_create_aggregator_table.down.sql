@@ -1 +1,2 @@
-DROP TABLE IF EXISTS aggregator;
\ No newline at end of file
+-- Откат создания таблицы aggregator
+DROP TABLE IF EXISTS aggregator;
@@ -1,8 +1,15 @@
--- Создание таблицы для настройки агрегатора
-CREATE TABLE aggregator (
-id SERIAL PRIMARY KEY,
-key TEXT UNIQUE NOT NULL,
-value TEXT NOT NULL);
+-- Создание таблицы для настроек агрегатора и блокировок
+CREATE TABLE IF NOT EXISTS aggregator (
+    id SERIAL PRIMARY KEY,
+    key TEXT UNIQUE NOT NULL,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
 
--- Индекс
-CREATE INDEX idx_aggregator_id ON aggregator(id);
\ No newline at end of file
+-- Таблица могла быть создана ранее без временных меток
+ALTER TABLE aggregator ADD COLUMN IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT NOW();
+ALTER TABLE aggregator ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+-- Индекс для поиска настроек по ключу
+CREATE INDEX IF NOT EXISTS idx_aggregator_key ON aggregator(key);
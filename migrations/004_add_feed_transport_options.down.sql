@@ -0,0 +1,2 @@
+ALTER TABLE feeds DROP COLUMN IF EXISTS tls_insecure;
+ALTER TABLE feeds DROP COLUMN IF EXISTS proxy_url;
@@ -0,0 +1,3 @@
+-- Сетевые настройки ленты: индивидуальный прокси и отключение проверки TLS
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS proxy_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS tls_insecure BOOLEAN NOT NULL DEFAULT FALSE;
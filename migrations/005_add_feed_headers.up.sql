@@ -0,0 +1,2 @@
+-- Пользовательские HTTP заголовки для запросов ленты
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS headers JSONB NOT NULL DEFAULT '{}'::jsonb;
@@ -0,0 +1,2 @@
+-- Зашифрованные учетные данные (HTTP Basic или Bearer токен) для приватных лент
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS credentials TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_feeds_priority_updated_at;
+ALTER TABLE feeds DROP COLUMN IF EXISTS priority;
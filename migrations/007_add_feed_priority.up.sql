@@ -0,0 +1,5 @@
+-- Приоритет ленты: 0 - low, 1 - normal, 2 - high
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS priority SMALLINT NOT NULL DEFAULT 1;
+
+-- Индекс для выбора лент по приоритету и времени обновления
+CREATE INDEX IF NOT EXISTS idx_feeds_priority_updated_at ON feeds(priority DESC, updated_at ASC);
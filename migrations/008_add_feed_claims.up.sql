@@ -0,0 +1,3 @@
+-- Резервирование лент экземплярами агрегатора (для нескольких процессов fetch)
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS claimed_by TEXT;                -- Идентификатор экземпляра
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMP;        -- Окончание аренды
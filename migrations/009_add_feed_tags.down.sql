@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_feeds_tags;
+ALTER TABLE feeds DROP COLUMN IF EXISTS tags;
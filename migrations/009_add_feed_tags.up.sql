@@ -0,0 +1,5 @@
+-- Теги ленты для группировки и фильтрации
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+-- Индекс для поиска лент по тегам
+CREATE INDEX IF NOT EXISTS idx_feeds_tags ON feeds USING GIN(tags);
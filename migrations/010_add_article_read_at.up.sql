@@ -0,0 +1,2 @@
+-- Время прочтения статьи (NULL - не прочитана)
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS read_at TIMESTAMP;
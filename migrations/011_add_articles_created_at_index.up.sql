@@ -0,0 +1,2 @@
+-- Индекс для выборки статей, добавленных за период (дайджесты)
+CREATE INDEX IF NOT EXISTS idx_articles_created_at ON articles(created_at);
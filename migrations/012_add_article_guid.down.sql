@@ -0,0 +1,11 @@
+-- Перед откатом удаляем статьи с повторяющимися ссылками, иначе уникальность не восстановить
+DELETE FROM articles a
+USING articles b
+WHERE a.link = b.link AND a.created_at > b.created_at;
+
+DROP INDEX IF EXISTS idx_articles_link;
+ALTER TABLE articles ADD CONSTRAINT articles_link_key UNIQUE (link);
+
+DROP INDEX IF EXISTS idx_articles_dedup_key;
+ALTER TABLE articles DROP COLUMN IF EXISTS dedup_key;
+ALTER TABLE articles DROP COLUMN IF EXISTS guid;
@@ -0,0 +1,14 @@
+-- GUID элемента RSS и ключ уникальности статьи: GUID в пределах ленты, если он есть, иначе ссылка
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS guid TEXT;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS dedup_key TEXT;
+
+-- Существующие статьи сохранялись без GUID, поэтому их ключом становится ссылка
+UPDATE articles SET dedup_key = 'link:' || link WHERE dedup_key IS NULL;
+ALTER TABLE articles ALTER COLUMN dedup_key SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_articles_dedup_key ON articles(dedup_key);
+
+-- Ссылка больше не уникальна (ленты меняют параметры в URL при стабильном GUID),
+-- но по ней по-прежнему ищутся дубликаты
+ALTER TABLE articles DROP CONSTRAINT IF EXISTS articles_link_key;
+CREATE INDEX IF NOT EXISTS idx_articles_link ON articles(link);
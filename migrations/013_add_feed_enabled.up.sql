@@ -0,0 +1,2 @@
+-- Отключенные ленты не получаются агрегатором, но их статьи сохраняются
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS enabled BOOLEAN NOT NULL DEFAULT TRUE;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_feeds_created_id;
+DROP INDEX IF EXISTS idx_articles_feed_published_id;
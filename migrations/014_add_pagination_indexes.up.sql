@@ -0,0 +1,3 @@
+-- Индексы для keyset пагинации статей ленты и списка лент
+CREATE INDEX IF NOT EXISTS idx_articles_feed_published_id ON articles(feed_id, published_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_feeds_created_id ON feeds(created_at DESC, id DESC);
@@ -0,0 +1,13 @@
+-- Ключи доступа к HTTP API (хранится только SHA-256 хеш ключа)
+CREATE TABLE IF NOT EXISTS api_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name TEXT NOT NULL,
+    prefix TEXT NOT NULL,
+    key_hash TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+-- Имя уникально среди действующих ключей; после отзыва его можно выдать снова
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_active_name ON api_keys(name) WHERE revoked_at IS NULL;
@@ -0,0 +1,7 @@
+-- Смарт-ленты: именованные сохраненные поиски по статьям всех лент
+CREATE TABLE IF NOT EXISTS smart_feeds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name TEXT NOT NULL UNIQUE,
+    query TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,2 @@
+-- Индивидуальный таймаут получения ленты в миллисекундах (0 - глобальный CLI_APP_FETCH_TIMEOUT)
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS timeout_ms INTEGER NOT NULL DEFAULT 0;
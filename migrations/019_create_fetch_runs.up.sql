@@ -0,0 +1,14 @@
+-- История циклов получения лент: итоги и ошибки каждого цикла
+CREATE TABLE IF NOT EXISTS fetch_runs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    instance TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP NOT NULL,
+    feeds INTEGER NOT NULL DEFAULT 0,
+    new_articles INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    skipped INTEGER NOT NULL DEFAULT 0,
+    errors JSONB NOT NULL DEFAULT '[]'::jsonb
+);
+
+CREATE INDEX IF NOT EXISTS idx_fetch_runs_started_at ON fetch_runs (started_at DESC);
@@ -0,0 +1 @@
+ALTER TABLE fetch_runs DROP COLUMN IF EXISTS timed_out;
@@ -0,0 +1,2 @@
+-- Количество лент, обработка которых отменена watchdog по таймауту
+ALTER TABLE fetch_runs ADD COLUMN IF NOT EXISTS timed_out INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_articles_modified;
+ALTER TABLE articles DROP COLUMN IF EXISTS modified_at;
+ALTER TABLE articles DROP COLUMN IF EXISTS content_hash;
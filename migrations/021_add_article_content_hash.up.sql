@@ -0,0 +1,6 @@
+-- Хеш заголовка и описания для обнаружения статей, измененных лентой после сохранения.
+-- Пустой хеш у статей, сохраненных раньше: он заполняется при следующем получении ленты
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS content_hash TEXT NOT NULL DEFAULT '';
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS modified_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_articles_modified ON articles (feed_id, published_at DESC, id DESC) WHERE modified_at IS NOT NULL;
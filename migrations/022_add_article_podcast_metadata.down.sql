@@ -0,0 +1,4 @@
+ALTER TABLE articles DROP COLUMN IF EXISTS itunes_episode;
+ALTER TABLE articles DROP COLUMN IF EXISTS itunes_image;
+ALTER TABLE articles DROP COLUMN IF EXISTS itunes_duration;
+ALTER TABLE articles DROP COLUMN IF EXISTS itunes_author;
@@ -0,0 +1,5 @@
+-- Метаданные эпизодов подкастов из пространства имен iTunes (NULL - не подкаст или нет значения)
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS itunes_author TEXT;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS itunes_duration INTEGER; -- Секунды
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS itunes_image TEXT;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS itunes_episode INTEGER;
@@ -0,0 +1,13 @@
+-- Вложения статей из Media RSS (media:content, media:thumbnail) в порядке их появления в ленте
+CREATE TABLE IF NOT EXISTS article_media (
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,  -- Порядковый номер вложения в статье
+    kind TEXT NOT NULL,         -- content или thumbnail
+    url TEXT NOT NULL,
+    type TEXT,                  -- MIME тип
+    medium TEXT,                -- image, video, audio, document, executable
+    width INTEGER,
+    height INTEGER,
+
+    PRIMARY KEY (article_id, position)
+);
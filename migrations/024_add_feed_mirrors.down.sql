@@ -0,0 +1,3 @@
+ALTER TABLE feeds DROP COLUMN IF EXISTS active_url;
+ALTER TABLE feeds DROP COLUMN IF EXISTS fetch_failures;
+ALTER TABLE feeds DROP COLUMN IF EXISTS mirrors;
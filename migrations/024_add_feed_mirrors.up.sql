@@ -0,0 +1,6 @@
+-- Зеркала ленты: запасные URL, которые пробуются после нескольких неудач основного подряд
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS mirrors TEXT[] NOT NULL DEFAULT '{}';
+-- Неудачные попытки получить ленту с основного URL подряд
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS fetch_failures INTEGER NOT NULL DEFAULT 0;
+-- Зеркало, с которого лента получена последний раз (пусто - основной URL)
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS active_url TEXT NOT NULL DEFAULT '';
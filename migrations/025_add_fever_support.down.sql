@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_api_keys_fever_hash;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS fever_hash;
+ALTER TABLE articles DROP COLUMN IF EXISTS saved_at;
+DROP INDEX IF EXISTS idx_articles_seq;
+ALTER TABLE articles DROP COLUMN IF EXISTS seq;
+DROP INDEX IF EXISTS idx_feeds_seq;
+ALTER TABLE feeds DROP COLUMN IF EXISTS seq;
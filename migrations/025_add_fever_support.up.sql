@@ -0,0 +1,12 @@
+-- Целочисленные идентификаторы лент и статей для Fever API (клиенты не понимают UUID)
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS seq BIGSERIAL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_feeds_seq ON feeds(seq);
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS seq BIGSERIAL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_articles_seq ON articles(seq);
+
+-- Время добавления статьи в избранное (NULL - не в избранном)
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS saved_at TIMESTAMP;
+
+-- MD5 строки "имя:ключ", которым Fever клиенты подписывают запросы (NULL - ключ создан до поддержки Fever)
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS fever_hash TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_fever_hash ON api_keys(fever_hash) WHERE fever_hash IS NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE feeds DROP COLUMN IF EXISTS last_error_at;
+ALTER TABLE feeds DROP COLUMN IF EXISTS last_error;
@@ -0,0 +1,3 @@
+-- Последняя ошибка обработки ленты (получение, таймаут или паника) и ее время; пусто - последняя обработка успешна
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS last_error TEXT NOT NULL DEFAULT '';
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS last_error_at TIMESTAMP;
@@ -0,0 +1 @@
+ALTER TABLE articles ALTER COLUMN published_at TYPE TIMESTAMP USING published_at AT TIME ZONE 'UTC';
@@ -0,0 +1,4 @@
+-- Дата публикации хранится с часовым поясом (в UTC): в TIMESTAMP смещение из даты ленты терялось,
+-- и статьи лент из разных часовых поясов сортировались со сдвигом на несколько часов.
+-- Прежние значения считаются временем UTC
+ALTER TABLE articles ALTER COLUMN published_at TYPE TIMESTAMPTZ USING published_at AT TIME ZONE 'UTC';
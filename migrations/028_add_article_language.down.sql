@@ -0,0 +1,3 @@
+ALTER TABLE feeds DROP COLUMN IF EXISTS languages;
+DROP INDEX IF EXISTS idx_articles_lang;
+ALTER TABLE articles DROP COLUMN IF EXISTS lang;
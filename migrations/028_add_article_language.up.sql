@@ -0,0 +1,5 @@
+-- Язык статьи (код ISO 639-1), определенный по заголовку и описанию; пусто - не определен
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS lang TEXT NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_articles_lang ON articles (lang);
+-- Ожидаемые языки ленты: статьи на других языках не сохраняются (пусто - любые)
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS languages TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,11 @@
+-- Иконки лент (favicon сайта или картинка канала) для клиентов API.
+-- Неудачная попытка тоже сохраняется, без данных, чтобы не повторять ее в каждом цикле
+CREATE TABLE IF NOT EXISTS feed_icons (
+    feed_id UUID PRIMARY KEY REFERENCES feeds(id) ON DELETE CASCADE,
+    url TEXT NOT NULL DEFAULT '',
+    content_type TEXT NOT NULL DEFAULT '',
+    data BYTEA,
+    fetched_at TIMESTAMP,
+    checked_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_error TEXT NOT NULL DEFAULT ''
+);
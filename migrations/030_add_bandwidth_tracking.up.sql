@@ -0,0 +1,11 @@
+-- Объем ответов лент по дням (до распаковки, как передано по сети) для статистики и лимита трафика.
+-- При удалении ленты ее трафик остается без ссылки на ленту и продолжает учитываться в лимите
+CREATE TABLE IF NOT EXISTS feed_traffic (
+    feed_id UUID REFERENCES feeds(id) ON DELETE SET NULL,
+    day DATE NOT NULL,
+    bytes BIGINT NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_feed_traffic_feed_day ON feed_traffic (feed_id, day);
+CREATE INDEX IF NOT EXISTS idx_feed_traffic_day ON feed_traffic (day);
+-- Байт, загруженных за цикл получения
+ALTER TABLE fetch_runs ADD COLUMN IF NOT EXISTS bytes BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE feeds DROP COLUMN IF EXISTS next_run_at;
+ALTER TABLE feeds DROP COLUMN IF EXISTS schedule;
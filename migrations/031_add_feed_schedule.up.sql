@@ -0,0 +1,4 @@
+-- Расписание получения ленты в формате cron (пусто - в каждом цикле агрегатора)
+-- и время следующего запуска по расписанию, до которого лента не резервируется
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS schedule TEXT NOT NULL DEFAULT '';
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS next_run_at TIMESTAMPTZ;
@@ -0,0 +1,17 @@
+-- Редакции содержимого статей: каждая различающаяся по хешу версия заголовка и описания
+-- сохраняется один раз, чтобы исправления и отзывы статей лентой можно было просмотреть и сравнить
+CREATE TABLE IF NOT EXISTS article_versions (
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    content_hash TEXT NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(), -- Когда версия впервые получена
+    PRIMARY KEY (article_id, content_hash)
+);
+
+-- Текущее содержимое уже сохраненных статей становится их первой известной версией
+INSERT INTO article_versions (article_id, content_hash, title, description, created_at)
+SELECT id, content_hash, title, COALESCE(description, ''), COALESCE(modified_at, created_at)
+FROM articles
+WHERE content_hash <> ''
+ON CONFLICT DO NOTHING;
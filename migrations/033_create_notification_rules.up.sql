@@ -0,0 +1,8 @@
+-- Правила уведомлений: новые статьи, подходящие под запрос (синтаксис смарт-лент), отправляются в канал
+CREATE TABLE IF NOT EXISTS notification_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name TEXT NOT NULL UNIQUE,
+    query TEXT NOT NULL,
+    channel TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
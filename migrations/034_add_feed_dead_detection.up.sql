@@ -0,0 +1,8 @@
+-- Начало непрерывной серии неудач обработки ленты (NULL - последняя обработка успешна),
+-- по которому агрегатор находит мертвые ленты, и причина их автоматического отключения
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS failing_since TIMESTAMPTZ;
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS disabled_reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMPTZ;
+
+-- Для лент, которые уже завершаются ошибкой, серия неудач отсчитывается от последней ошибки
+UPDATE feeds SET failing_since = last_error_at WHERE last_error <> '' AND failing_since IS NULL;
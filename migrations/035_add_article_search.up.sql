@@ -0,0 +1,27 @@
+-- Словарь полнотекстового поиска по определенному языку статьи; для остальных языков
+-- слова индексируются без стемминга
+CREATE OR REPLACE FUNCTION article_search_config(lang TEXT) RETURNS regconfig
+LANGUAGE sql IMMUTABLE AS $$
+    SELECT CASE lang
+        WHEN 'en' THEN 'english'::regconfig
+        WHEN 'de' THEN 'german'::regconfig
+        WHEN 'fr' THEN 'french'::regconfig
+        WHEN 'es' THEN 'spanish'::regconfig
+        WHEN 'it' THEN 'italian'::regconfig
+        WHEN 'pt' THEN 'portuguese'::regconfig
+        WHEN 'nl' THEN 'dutch'::regconfig
+        WHEN 'ru' THEN 'russian'::regconfig
+        WHEN 'el' THEN 'greek'::regconfig
+        WHEN 'ar' THEN 'arabic'::regconfig
+        ELSE 'simple'::regconfig
+    END
+$$;
+
+-- Полнотекстовый индекс статей для поиска похожих (rsshub related): заголовок с весом A,
+-- описание с весом B
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector(article_search_config(lang), coalesce(title, '')), 'A') ||
+    setweight(to_tsvector(article_search_config(lang), coalesce(description, '')), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_articles_search_vector ON articles USING GIN (search_vector);
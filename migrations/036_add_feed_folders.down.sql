@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_feeds_folder;
+ALTER TABLE feeds DROP COLUMN IF EXISTS folder;
+DROP TABLE IF EXISTS folders;
@@ -0,0 +1,12 @@
+-- Папки лент: иерархия "News/Tech/Go" хранится путями, и у каждой папки есть строки для всех
+-- родительских уровней, поэтому дерево папок видно и там, где лент верхнего уровня нет.
+-- Переименование папки каскадно меняет путь у ее лент, удаление оставляет ленты вне папок
+CREATE TABLE IF NOT EXISTS folders (
+    path TEXT PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS folder TEXT REFERENCES folders(path) ON UPDATE CASCADE ON DELETE SET NULL;
+
+-- Индекс нужен каскадному обновлению и удалению папок
+CREATE INDEX IF NOT EXISTS idx_feeds_folder ON feeds(folder);
@@ -0,0 +1,2 @@
+ALTER TABLE fetch_runs DROP COLUMN IF EXISTS updated;
+ALTER TABLE fetch_runs DROP COLUMN IF EXISTS duplicates;
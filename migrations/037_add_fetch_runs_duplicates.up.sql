@@ -0,0 +1,3 @@
+-- Элементы лент, статьи которых уже были сохранены: пропущенные без изменений и обновленные
+ALTER TABLE fetch_runs ADD COLUMN IF NOT EXISTS duplicates INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE fetch_runs ADD COLUMN IF NOT EXISTS updated INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Индивидуальный интервал получения ленты в миллисекундах (0 - в каждом цикле агрегатора):
+-- лента не резервируется, пока с ее последнего получения не прошел этот интервал
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS interval_ms BIGINT NOT NULL DEFAULT 0;
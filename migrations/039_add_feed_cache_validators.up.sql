@@ -0,0 +1,4 @@
+-- Валидаторы кеша последнего ответа ленты (заголовки ETag и Last-Modified): со следующим запросом
+-- они отправляются в If-None-Match и If-Modified-Since, и неизменившаяся лента отвечает 304
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS etag TEXT NOT NULL DEFAULT '';
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS last_modified TEXT NOT NULL DEFAULT '';
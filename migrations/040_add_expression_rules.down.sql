@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS filter_rules;
+ALTER TABLE notification_rules DROP COLUMN IF EXISTS expression;
@@ -0,0 +1,12 @@
+-- Правила уведомлений могут задаваться выражением вместо запроса смарт-ленты
+ALTER TABLE notification_rules ADD COLUMN IF NOT EXISTS expression TEXT NOT NULL DEFAULT '';
+
+-- Правила фильтров: элементы лент, подходящие под выражение, отбрасываются до сохранения (drop)
+-- или, если есть правила keep, сохраняются только подходящие под одно из них
+CREATE TABLE IF NOT EXISTS filter_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name TEXT NOT NULL UNIQUE,
+    expression TEXT NOT NULL,
+    action TEXT NOT NULL DEFAULT 'drop' CHECK (action IN ('drop', 'keep')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,2 @@
+ALTER TABLE fetch_runs DROP COLUMN IF EXISTS queue_wait_max_ms;
+ALTER TABLE fetch_runs DROP COLUMN IF EXISTS queue_wait_avg_ms;
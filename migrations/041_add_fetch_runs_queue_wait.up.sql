@@ -0,0 +1,3 @@
+-- Сколько ленты цикла ждали свободного воркера в общей очереди: в среднем и дольше всего
+ALTER TABLE fetch_runs ADD COLUMN IF NOT EXISTS queue_wait_avg_ms BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE fetch_runs ADD COLUMN IF NOT EXISTS queue_wait_max_ms BIGINT NOT NULL DEFAULT 0;
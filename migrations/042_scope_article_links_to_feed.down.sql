@@ -0,0 +1,12 @@
+-- Ключ по ссылке снова общий для всех лент. Из статей с одной ссылкой в нескольких лентах общий
+-- ключ получает самая ранняя, у остальных остается ключ ленты, чтобы статьи не удалялись
+UPDATE articles a
+SET dedup_key = 'link:' || substring(a.dedup_key FROM 7 + length(a.feed_id::text))
+WHERE a.dedup_key LIKE 'link:' || a.feed_id || ':%'
+	AND NOT EXISTS (
+		SELECT 1 FROM articles o
+		WHERE o.id <> a.id
+			AND o.dedup_key LIKE 'link:%'
+			AND substring(o.dedup_key FROM 7 + length(o.feed_id::text)) = substring(a.dedup_key FROM 7 + length(a.feed_id::text))
+			AND (o.created_at, o.id) < (a.created_at, a.id)
+	);
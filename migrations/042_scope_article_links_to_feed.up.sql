@@ -0,0 +1,5 @@
+-- Статья уникальна в пределах своей ленты: ключ по ссылке, как и ключ по GUID, включает ID ленты,
+-- и одна ссылка может быть сохранена в нескольких лентах (CLI_APP_CROSS_FEED_DEDUP)
+UPDATE articles
+SET dedup_key = 'link:' || feed_id || ':' || substring(dedup_key FROM 6)
+WHERE dedup_key LIKE 'link:%' AND dedup_key NOT LIKE 'link:' || feed_id || ':%';
@@ -0,0 +1,13 @@
+// Package migrations содержит версионированные SQL миграции схемы базы данных.
+//
+// Каждая миграция состоит из пары файлов NNN_name.up.sql и NNN_name.down.sql,
+// где NNN - номер версии. Миграции применяются по возрастанию версии
+// и откатываются в обратном порядке.
+package migrations
+
+import "embed"
+
+// FS содержит SQL файлы миграций, встроенные в бинарный файл
+//
+//go:embed *.sql
+var FS embed.FS